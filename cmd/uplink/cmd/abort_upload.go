@@ -0,0 +1,83 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"storj.io/common/fpath"
+	"storj.io/uplink"
+)
+
+var (
+	abortUploadListFlag *bool
+)
+
+func init() {
+	abortUploadCmd := addCmd(&cobra.Command{
+		Use:   "abort-upload sj://BUCKET/KEY",
+		Short: "Abort pending multipart uploads of an object, e.g. left by an interrupted cp",
+		RunE:  abortUploadMain,
+		Args:  cobra.ExactArgs(1),
+	}, RootCmd)
+	abortUploadListFlag = abortUploadCmd.Flags().Bool("list", false, "if true, only list pending uploads of the object without aborting them")
+}
+
+func abortUploadMain(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := withTelemetry(cmd)
+
+	dst, err := fpath.New(args[0])
+	if err != nil {
+		return err
+	}
+	if dst.IsLocal() || dst.Path() == "" {
+		return fmt.Errorf("object must be Storj URL with a key: %s", dst)
+	}
+
+	project, err := cfg.getProject(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer closeProject(project)
+
+	uploadIDs, err := pendingUploads(ctx, project, dst)
+	if err != nil {
+		return err
+	}
+	if len(uploadIDs) == 0 {
+		fmt.Printf("No pending uploads of %s\n", dst)
+		return nil
+	}
+
+	for _, uploadID := range uploadIDs {
+		if *abortUploadListFlag {
+			fmt.Printf("Pending upload %s of %s\n", uploadID, dst)
+			continue
+		}
+
+		if err := project.AbortUpload(ctx, dst.Bucket(), dst.Path(), uploadID); err != nil {
+			return fmt.Errorf("failed to abort upload %s of %s: %w", uploadID, dst, err)
+		}
+		fmt.Printf("Aborted upload %s of %s\n", uploadID, dst)
+	}
+
+	return nil
+}
+
+// pendingUploads returns ids of pending multipart uploads of the dst object.
+func pendingUploads(ctx context.Context, project *uplink.Project, dst fpath.FPath) (uploadIDs []string, err error) {
+	uploads := project.ListUploads(ctx, dst.Bucket(), &uplink.ListUploadsOptions{
+		Prefix: dst.Path(),
+	})
+	for uploads.Next() {
+		// the prefix matches also keys which start with it.
+		if upload := uploads.Item(); upload.Key == dst.Path() {
+			uploadIDs = append(uploadIDs, upload.UploadID)
+		}
+	}
+	return uploadIDs, uploads.Err()
+}
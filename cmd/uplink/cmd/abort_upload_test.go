@@ -0,0 +1,105 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd_test
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/private/testplanet"
+	"storj.io/uplink"
+)
+
+func TestAbortUpload(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 4,
+		UplinkCount:      1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkExe := ctx.Compile("storj.io/storj/cmd/uplink")
+
+		// Configure uplink.
+		{
+			access := planet.Uplinks[0].Access[planet.Satellites[0].ID()]
+
+			accessString, err := access.Serialize()
+			require.NoError(t, err)
+
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"import",
+				accessString,
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+		}
+
+		bucketName := "testbucket"
+
+		project, err := planet.Uplinks[0].OpenProject(ctx, planet.Satellites[0])
+		require.NoError(t, err)
+		defer ctx.Check(project.Close)
+
+		_, err = project.CreateBucket(ctx, bucketName)
+		require.NoError(t, err)
+
+		pending, err := project.BeginUpload(ctx, bucketName, "object", nil)
+		require.NoError(t, err)
+		// upload of a key sharing the prefix must be kept.
+		other, err := project.BeginUpload(ctx, bucketName, "object-other", nil)
+		require.NoError(t, err)
+
+		pendingKeys := func() (keys []string) {
+			uploads := project.ListUploads(ctx, bucketName, &uplink.ListUploadsOptions{Recursive: true})
+			for uploads.Next() {
+				keys = append(keys, uploads.Item().Key)
+			}
+			require.NoError(t, uploads.Err())
+			return keys
+		}
+
+		// Only list.
+		{
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"abort-upload", "--list",
+				"sj://"+bucketName+"/object",
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+			require.Equal(t, "Pending upload "+pending.UploadID+" of sj://"+bucketName+"/object\n", string(output))
+			require.ElementsMatch(t, []string{"object", "object-other"}, pendingKeys())
+		}
+
+		// Abort.
+		{
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"abort-upload",
+				"sj://"+bucketName+"/object",
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+			require.Equal(t, "Aborted upload "+pending.UploadID+" of sj://"+bucketName+"/object\n", string(output))
+			require.Equal(t, []string{"object-other"}, pendingKeys())
+		}
+
+		// Nothing left to abort.
+		{
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"abort-upload",
+				"sj://"+bucketName+"/object",
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+			require.Equal(t, "No pending uploads of sj://"+bucketName+"/object\n", string(output))
+		}
+
+		require.NoError(t, project.AbortUpload(ctx, bucketName, "object-other", other.UploadID))
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"os"
 	"time"
 
 	"github.com/btcsuite/btcutil/base58"
@@ -34,10 +35,17 @@ type registerConfig struct {
 	AccessConfig
 }
 
+type exportConfig struct {
+	Output string `help:"path of the file to write the serialized access to" default:"" basic-help:"true"`
+	Force  bool   `help:"if true, overwrite existing output file" default:"false" basic-help:"true"`
+	AccessConfig
+}
+
 var (
 	inspectCfg  AccessConfig
 	listCfg     AccessConfig
 	registerCfg registerConfig
+	exportCfg   exportConfig
 )
 
 func init() {
@@ -69,14 +77,23 @@ func init() {
 		Args:  cobra.MaximumNArgs(1),
 	}
 
+	exportCmd := &cobra.Command{
+		Use:   "export [ACCESS]",
+		Short: "Export serialized access to a file readable only by the current user.",
+		RunE:  accessExport,
+		Args:  cobra.MaximumNArgs(1),
+	}
+
 	RootCmd.AddCommand(accessCmd)
 	accessCmd.AddCommand(inspectCmd)
 	accessCmd.AddCommand(listCmd)
 	accessCmd.AddCommand(registerCmd)
+	accessCmd.AddCommand(exportCmd)
 
 	process.Bind(inspectCmd, &inspectCfg, defaults, cfgstruct.ConfDir(getConfDir()))
 	process.Bind(listCmd, &listCfg, defaults, cfgstruct.ConfDir(getConfDir()))
 	process.Bind(registerCmd, &registerCfg, defaults, cfgstruct.ConfDir(getConfDir()))
+	process.Bind(exportCmd, &exportCfg, defaults, cfgstruct.ConfDir(getConfDir()))
 }
 
 func accessList(cmd *cobra.Command, args []string) (err error) {
@@ -173,6 +190,55 @@ func accessInspect(cmd *cobra.Command, args []string) (err error) {
 	return nil
 }
 
+func accessExport(cmd *cobra.Command, args []string) (err error) {
+	if exportCfg.Output == "" {
+		return errs.New("output file must be specified with --output")
+	}
+
+	access, err := getAccessFromArgZeroOrConfig(exportCfg.AccessConfig, args)
+	if err != nil {
+		return errs.New("no access specified: %w", err)
+	}
+
+	if err := ExportAccess(access, exportCfg.Output, exportCfg.Force); err != nil {
+		return err
+	}
+
+	fmt.Println("Access exported to", exportCfg.Output)
+	return nil
+}
+
+// ExportAccess writes serialized access to the file with permissions allowing only the owner to read it.
+// Existing file is overwritten only when force is set.
+func ExportAccess(access *uplink.Access, path string, force bool) (err error) {
+	serialized, err := access.Serialize()
+	if err != nil {
+		return err
+	}
+
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if force {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+
+	file, err := os.OpenFile(path, flags, 0600)
+	if err != nil {
+		if os.IsExist(err) {
+			return errs.New("file %q already exists, use --force to overwrite it", path)
+		}
+		return err
+	}
+	defer func() { err = errs.Combine(err, file.Close()) }()
+
+	// overwritten file may have been created with less restrictive permissions.
+	if err := file.Chmod(0600); err != nil {
+		return err
+	}
+
+	_, err = file.WriteString(serialized)
+	return err
+}
+
 func parseAccessRaw(access string) (_ *pb.Scope, err error) {
 	data, version, err := base58.CheckDecode(access)
 	if err != nil || version != 0 {
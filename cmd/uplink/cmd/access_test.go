@@ -5,9 +5,12 @@ package cmd_test
 
 import (
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"os/exec"
+	"runtime"
 	"testing"
 	"time"
 
@@ -73,3 +76,70 @@ func TestAccessImport(t *testing.T) {
 	t.Log(string(output))
 	require.NoError(t, err)
 }
+
+func TestAccessExport(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	uplinkExe := ctx.Compile("storj.io/storj/cmd/uplink")
+
+	output, err := exec.Command(uplinkExe, "--config-dir", ctx.Dir("uplink"), "import", testAccess).CombinedOutput()
+	t.Log(string(output))
+	require.NoError(t, err)
+
+	access, err := uplink.ParseAccess(testAccess)
+	require.NoError(t, err)
+	expectedAccess, err := access.Serialize()
+	require.NoError(t, err)
+
+	exportFile := ctx.File("export", "access")
+
+	// Export imported access.
+	{
+		output, err := exec.Command(uplinkExe, "--config-dir", ctx.Dir("uplink"), "access", "export", "--output", exportFile).CombinedOutput()
+		t.Log(string(output))
+		require.NoError(t, err)
+		require.NotContains(t, string(output), testAccess)
+
+		data, err := ioutil.ReadFile(exportFile)
+		require.NoError(t, err)
+		require.Equal(t, expectedAccess, string(data))
+
+		if runtime.GOOS != "windows" {
+			info, err := os.Stat(exportFile)
+			require.NoError(t, err)
+			require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+		}
+	}
+
+	// Refuse to overwrite existing file.
+	{
+		require.NoError(t, ioutil.WriteFile(exportFile, []byte("existing"), 0644))
+
+		output, err := exec.Command(uplinkExe, "--config-dir", ctx.Dir("uplink"), "access", "export", "--output", exportFile).CombinedOutput()
+		t.Log(string(output))
+		require.Error(t, err)
+		require.Contains(t, string(output), "already exists")
+
+		data, err := ioutil.ReadFile(exportFile)
+		require.NoError(t, err)
+		require.Equal(t, "existing", string(data))
+	}
+
+	// Overwrite existing file with force.
+	{
+		output, err := exec.Command(uplinkExe, "--config-dir", ctx.Dir("uplink"), "access", "export", "--output", exportFile, "--force").CombinedOutput()
+		t.Log(string(output))
+		require.NoError(t, err)
+
+		data, err := ioutil.ReadFile(exportFile)
+		require.NoError(t, err)
+		require.Equal(t, expectedAccess, string(data))
+
+		if runtime.GOOS != "windows" {
+			info, err := os.Stat(exportFile)
+			require.NoError(t, err)
+			require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+		}
+	}
+}
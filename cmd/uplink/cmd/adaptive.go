@@ -0,0 +1,155 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// throughputSmoothing is the weight of the last transfer in the average transfer throughput.
+	throughputSmoothing = 0.3
+	// degradedThroughput is the fraction of the average throughput, below which the transfer is considered degraded.
+	degradedThroughput = 0.5
+)
+
+// AdaptiveLimiter limits the number of concurrent transfers, adapting the limit to observed throughput.
+//
+// It starts with a single transfer. The limit is increased after every transfer which was not slower
+// than the average, decreased after a transfer much slower than the average and halved after a failure.
+type AdaptiveLimiter struct {
+	max int
+
+	mu      sync.Mutex
+	changed chan struct{}
+	limit   int
+	active  int
+	average float64
+}
+
+// NewAdaptiveLimiter creates new AdaptiveLimiter, which allows at most max concurrent transfers.
+func NewAdaptiveLimiter(max int) *AdaptiveLimiter {
+	if max < 1 {
+		max = 1
+	}
+	return &AdaptiveLimiter{
+		max:     max,
+		changed: make(chan struct{}),
+		limit:   1,
+	}
+}
+
+// Limit returns the current limit of concurrent transfers.
+func (limiter *AdaptiveLimiter) Limit() int {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	return limiter.limit
+}
+
+// Acquire waits until a transfer may start or ctx is canceled.
+func (limiter *AdaptiveLimiter) Acquire(ctx context.Context) error {
+	for {
+		limiter.mu.Lock()
+		if limiter.active < limiter.limit {
+			limiter.active++
+			limiter.mu.Unlock()
+			return nil
+		}
+		changed := limiter.changed
+		limiter.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Done releases the transfer started by Acquire and adapts the limit to the transfer result.
+func (limiter *AdaptiveLimiter) Done(size int64, elapsed time.Duration, err error) {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	limiter.active--
+
+	switch {
+	case err != nil:
+		limiter.limit = (limiter.limit + 1) / 2
+	case elapsed > 0:
+		throughput := float64(size) / elapsed.Seconds()
+		switch {
+		case limiter.average == 0 || throughput >= limiter.average:
+			if limiter.limit < limiter.max {
+				limiter.limit++
+			}
+		case throughput < limiter.average*degradedThroughput:
+			if limiter.limit > 1 {
+				limiter.limit--
+			}
+		}
+
+		if limiter.average == 0 {
+			limiter.average = throughput
+		} else {
+			limiter.average += throughputSmoothing * (throughput - limiter.average)
+		}
+	}
+
+	// wake up transfers waiting for a slot.
+	close(limiter.changed)
+	limiter.changed = make(chan struct{})
+}
+
+// transferAdaptive runs transfer of every item in the batch, as many at once as the limiter allows.
+// Without continueOnError remaining transfers are canceled after the first failure.
+func transferAdaptive(ctx context.Context, limiter *AdaptiveLimiter, transfers *batch, items []localFile,
+	transfer func(ctx context.Context, item localFile) (size int64, err error)) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var failed error
+
+	for _, item := range items {
+		if err := limiter.Acquire(ctx); err != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(item localFile) {
+			defer wg.Done()
+
+			start := time.Now()
+			var size int64
+			var transferErr error
+			err := transfers.Run(item.path, func() error {
+				size, transferErr = transfer(ctx, item)
+				return transferErr
+			})
+			limiter.Done(size, time.Since(start), transferErr)
+
+			if err != nil {
+				mu.Lock()
+				if failed == nil {
+					failed = err
+				}
+				mu.Unlock()
+				cancel()
+			}
+		}(item)
+	}
+	wg.Wait()
+
+	if failed != nil {
+		return failed
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return transfers.Err()
+}
@@ -0,0 +1,81 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/cmd/uplink/cmd"
+)
+
+// fakeTransport simulates transfers of fixed size with throughput changed by the test.
+type fakeTransport struct {
+	limiter    *cmd.AdaptiveLimiter
+	size       int64
+	throughput int64 // bytes per second
+}
+
+// transfer runs a single simulated transfer and returns the limit afterwards.
+func (transport *fakeTransport) transfer(ctx context.Context, t *testing.T, err error) int {
+	require.NoError(t, transport.limiter.Acquire(ctx))
+	elapsed := time.Duration(transport.size * int64(time.Second) / transport.throughput)
+	transport.limiter.Done(transport.size, elapsed, err)
+	return transport.limiter.Limit()
+}
+
+func TestAdaptiveLimiter(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &fakeTransport{
+		limiter:    cmd.NewAdaptiveLimiter(4),
+		size:       1 << 20,
+		throughput: 1 << 20,
+	}
+	require.Equal(t, 1, transport.limiter.Limit())
+
+	// improving conditions ramp up concurrency until the max.
+	for _, expected := range []int{2, 3, 4, 4} {
+		require.Equal(t, expected, transport.transfer(ctx, t, nil))
+		transport.throughput *= 2
+	}
+
+	// degrading conditions back off.
+	transport.throughput /= 16
+	require.Equal(t, 3, transport.transfer(ctx, t, nil))
+	transport.throughput /= 4
+	require.Equal(t, 2, transport.transfer(ctx, t, nil))
+
+	// errors halve the limit, but keep at least one transfer.
+	require.Equal(t, 1, transport.transfer(ctx, t, errors.New("network unreachable")))
+	require.Equal(t, 1, transport.transfer(ctx, t, errors.New("network unreachable")))
+}
+
+func TestAdaptiveLimiterAcquire(t *testing.T) {
+	limiter := cmd.NewAdaptiveLimiter(2)
+	require.NoError(t, limiter.Acquire(context.Background()))
+
+	// no free slot until the running transfer is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.Error(t, limiter.Acquire(ctx))
+
+	acquired := make(chan error, 1)
+	go func() { acquired <- limiter.Acquire(context.Background()) }()
+
+	limiter.Done(1<<20, time.Second, nil)
+	require.NoError(t, <-acquired)
+	require.Equal(t, 2, limiter.Limit())
+}
+
+func TestNewAdaptiveLimiterMinimum(t *testing.T) {
+	limiter := cmd.NewAdaptiveLimiter(0)
+	require.NoError(t, limiter.Acquire(context.Background()))
+	limiter.Done(1, time.Second, nil)
+	require.Equal(t, 1, limiter.Limit())
+}
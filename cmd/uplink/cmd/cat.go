@@ -42,5 +42,6 @@ func catMain(cmd *cobra.Command, args []string) (err error) {
 		return err
 	}
 
-	return download(ctx, src, dst, false)
+	_, err = download(ctx, src, dst, false)
+	return err
 }
@@ -0,0 +1,259 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/zeebo/errs"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/term"
+
+	"storj.io/uplink"
+)
+
+const (
+	// clientEncryptionMetadataKey is the custom metadata key marking objects encrypted with --client-encrypt,
+	// its value is the encryption scheme.
+	clientEncryptionMetadataKey = "x-uplink-client-encryption"
+	// clientEncryptionSaltMetadataKey is the custom metadata key of the salt the key was derived with.
+	clientEncryptionSaltMetadataKey = "x-uplink-client-encryption-salt"
+	// clientEncryptionNonceMetadataKey is the custom metadata key of the nonce prefix of the chunks.
+	clientEncryptionNonceMetadataKey = "x-uplink-client-encryption-nonce"
+
+	// clientEncryptionScheme is AES-256-GCM of consecutive chunks with key derived from passphrase by scrypt.
+	clientEncryptionScheme = "aes-256-gcm-scrypt-v1"
+
+	// clientPassphraseEnv is the environment variable the passphrase is read from, when not set by flag.
+	clientPassphraseEnv = "UPLINK_CLIENT_PASSPHRASE"
+
+	// clientChunkSize is the size of plaintext sealed together, the last chunk may be shorter.
+	clientChunkSize = 64 * 1024
+	clientSaltSize  = 16
+	// clientNoncePrefixSize leaves 4 bytes of the nonce for the chunk counter and 1 byte for the last chunk flag.
+	clientNoncePrefixSize = 7
+)
+
+// ErrClientDecryption is returned when object encrypted with --client-encrypt can't be decrypted,
+// which means the passphrase is wrong or the object was modified.
+var ErrClientDecryption = errs.Class("client decryption failed")
+
+// clientEncryptPassphrase is the passphrase of --client-encrypt, resolved once per command.
+var clientEncryptPassphrase string
+
+// resolveClientPassphrase returns passphrase of --client-passphrase, UPLINK_CLIENT_PASSPHRASE environment variable
+// or the one entered in terminal, in this order. Entered passphrase has to be repeated when confirm is true.
+func resolveClientPassphrase(confirm bool) (string, error) {
+	if *clientPassphrase != "" {
+		return *clientPassphrase, nil
+	}
+	if passphrase := os.Getenv(clientPassphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", fmt.Errorf("--client-encrypt requires passphrase: use --client-passphrase or %s environment variable", clientPassphraseEnv)
+	}
+
+	fmt.Fprint(os.Stderr, "Enter client encryption passphrase: ")
+	passphrase, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+	if len(passphrase) == 0 {
+		return "", errors.New("client encryption passphrase cannot be empty")
+	}
+
+	if confirm {
+		fmt.Fprint(os.Stderr, "Enter client encryption passphrase again: ")
+		repeated, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", err
+		}
+		if string(repeated) != string(passphrase) {
+			return "", errors.New("client encryption passphrase does not match")
+		}
+	}
+
+	return string(passphrase), nil
+}
+
+// IsClientEncrypted returns true when the object was encrypted with --client-encrypt.
+func IsClientEncrypted(custom uplink.CustomMetadata) bool {
+	_, ok := custom[clientEncryptionMetadataKey]
+	return ok
+}
+
+// ClientEncrypt returns reader encrypting plaintext with key derived from the passphrase and a copy of customMetadata
+// with the encryption marker and parameters needed to decrypt it.
+func ClientEncrypt(plaintext io.Reader, passphrase string, customMetadata uplink.CustomMetadata) (io.Reader, uplink.CustomMetadata, error) {
+	salt := make([]byte, clientSaltSize)
+	noncePrefix := make([]byte, clientNoncePrefixSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, nil, err
+	}
+	if _, err := rand.Read(noncePrefix); err != nil {
+		return nil, nil, err
+	}
+
+	aead, err := newClientAEAD(passphrase, salt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	encrypted := make(uplink.CustomMetadata, len(customMetadata)+3)
+	for key, value := range customMetadata {
+		encrypted[key] = value
+	}
+	encrypted[clientEncryptionMetadataKey] = clientEncryptionScheme
+	encrypted[clientEncryptionSaltMetadataKey] = base64.StdEncoding.EncodeToString(salt)
+	encrypted[clientEncryptionNonceMetadataKey] = base64.StdEncoding.EncodeToString(noncePrefix)
+
+	return &clientChunkReader{
+		source:    bufio.NewReader(plaintext),
+		chunkSize: clientChunkSize,
+		transform: func(dst, chunk []byte, counter uint32, last bool) ([]byte, error) {
+			return aead.Seal(dst, clientNonce(noncePrefix, counter, last), chunk, nil), nil
+		},
+	}, encrypted, nil
+}
+
+// ClientDecrypt returns reader decrypting ciphertext of object with custom metadata encrypted by ClientEncrypt.
+// Reading fails with ErrClientDecryption when the passphrase is wrong or the ciphertext was modified.
+func ClientDecrypt(ciphertext io.Reader, passphrase string, custom uplink.CustomMetadata) (io.Reader, error) {
+	if scheme := custom[clientEncryptionMetadataKey]; scheme != clientEncryptionScheme {
+		return nil, fmt.Errorf("unsupported client encryption scheme %q", scheme)
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(custom[clientEncryptionSaltMetadataKey])
+	if err != nil || len(salt) != clientSaltSize {
+		return nil, fmt.Errorf("invalid client encryption salt %q", custom[clientEncryptionSaltMetadataKey])
+	}
+	noncePrefix, err := base64.StdEncoding.DecodeString(custom[clientEncryptionNonceMetadataKey])
+	if err != nil || len(noncePrefix) != clientNoncePrefixSize {
+		return nil, fmt.Errorf("invalid client encryption nonce %q", custom[clientEncryptionNonceMetadataKey])
+	}
+
+	aead, err := newClientAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &clientChunkReader{
+		source:    bufio.NewReader(ciphertext),
+		chunkSize: clientChunkSize + aead.Overhead(),
+		transform: func(dst, chunk []byte, counter uint32, last bool) ([]byte, error) {
+			plaintext, err := aead.Open(dst, clientNonce(noncePrefix, counter, last), chunk, nil)
+			if err != nil {
+				if counter == 0 {
+					return nil, ErrClientDecryption.New("wrong passphrase or the object was modified")
+				}
+				return nil, ErrClientDecryption.New("chunk %d was modified or the object was truncated", counter)
+			}
+			return plaintext, nil
+		},
+	}, nil
+}
+
+// newClientAEAD returns AES-256-GCM with key derived from the passphrase and salt.
+func newClientAEAD(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// clientNonce returns nonce of the chunk, the last chunk flag prevents truncating the object at chunk boundary.
+func clientNonce(prefix []byte, counter uint32, last bool) []byte {
+	nonce := make([]byte, 0, clientNoncePrefixSize+5)
+	nonce = append(nonce, prefix...)
+	nonce = append(nonce, 0, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(nonce[clientNoncePrefixSize:], counter)
+	if last {
+		nonce[len(nonce)-1] = 1
+	}
+	return nonce
+}
+
+// clientChunkReader transforms source by chunks of chunkSize, transform either encrypts or decrypts a chunk.
+// There is always at least one chunk, so even empty plaintext is authenticated.
+type clientChunkReader struct {
+	source    *bufio.Reader
+	chunkSize int
+	transform func(dst, chunk []byte, counter uint32, last bool) ([]byte, error)
+
+	counter uint32
+	chunk   []byte
+	buffer  []byte
+	output  []byte
+	done    bool
+}
+
+// Read implements io.Reader.
+func (reader *clientChunkReader) Read(p []byte) (int, error) {
+	for len(reader.output) == 0 {
+		if reader.done {
+			return 0, io.EOF
+		}
+		if err := reader.next(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, reader.output)
+	reader.output = reader.output[n:]
+	return n, nil
+}
+
+// next transforms the following chunk of source into output.
+func (reader *clientChunkReader) next() error {
+	if reader.chunk == nil {
+		reader.chunk = make([]byte, reader.chunkSize)
+	}
+
+	n, err := io.ReadFull(reader.source, reader.chunk)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return err
+	}
+
+	last := n < reader.chunkSize
+	if !last {
+		// full chunk is the last one, when nothing follows it.
+		if _, err := reader.source.Peek(1); err != nil {
+			if !errors.Is(err, io.EOF) {
+				return err
+			}
+			last = true
+		}
+	}
+
+	output, err := reader.transform(reader.buffer[:0], reader.chunk[:n], reader.counter, last)
+	if err != nil {
+		return err
+	}
+
+	reader.buffer = output
+	reader.output = output
+	reader.counter++
+	reader.done = last
+	return nil
+}
@@ -5,6 +5,11 @@ package cmd
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
 
 	"github.com/spf13/cobra"
 
@@ -35,3 +40,57 @@ func enableTracing(config map[string]interface{}) {
 	config["tracing.enabled"] = true
 	config["tracing.sample"] = 1
 }
+
+// batch runs operation on multiple items, either stopping at the first failure
+// or collecting failures of all items when continueOnError is set.
+// Items may run concurrently.
+type batch struct {
+	continueOnError bool
+
+	mu       sync.Mutex
+	total    int
+	failures []batchFailure
+}
+
+// batchFailure is a failed batch item.
+type batchFailure struct {
+	item string
+	err  error
+}
+
+// Run runs fn for the item. The error is returned only when the batch fails fast,
+// otherwise it's reported and remembered for the summary.
+func (batch *batch) Run(item string, fn func() error) error {
+	batch.mu.Lock()
+	batch.total++
+	batch.mu.Unlock()
+
+	err := fn()
+	if err == nil || !batch.continueOnError {
+		return err
+	}
+
+	batch.mu.Lock()
+	defer batch.mu.Unlock()
+
+	fmt.Fprintf(os.Stderr, "failed %s: %v\n", item, err)
+	batch.failures = append(batch.failures, batchFailure{item: item, err: err})
+	return nil
+}
+
+// Err returns summary of all failed items or nil when all items succeeded.
+func (batch *batch) Err() error {
+	batch.mu.Lock()
+	defer batch.mu.Unlock()
+
+	if len(batch.failures) == 0 {
+		return nil
+	}
+
+	var summary strings.Builder
+	fmt.Fprintf(&summary, "%d of %d items failed:", len(batch.failures), batch.total)
+	for _, failure := range batch.failures {
+		fmt.Fprintf(&summary, "\n\t%s: %v", failure.item, failure.err)
+	}
+	return errors.New(summary.String())
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/uplink"
+)
+
+// contentTypes contains content types inferred from file extensions of uploaded files,
+// it's loaded from --content-type-map and nil when the flag isn't set.
+var contentTypes ContentTypeMap
+
+// ContentTypeMap maps lowercase file extensions, including the leading dot, to content types.
+type ContentTypeMap map[string]string
+
+// LoadContentTypeMap reads content type map from JSON file with an object of extension to content type.
+// Extensions are matched case insensitively and the leading dot is optional.
+func LoadContentTypeMap(path string) (_ ContentTypeMap, err error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries map[string]string
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("invalid content type map %s: %w", path, err)
+	}
+
+	extensions := make([]string, 0, len(entries))
+	for extension := range entries {
+		extensions = append(extensions, extension)
+	}
+	sort.Strings(extensions)
+
+	var group errs.Group
+	contentTypes := make(ContentTypeMap, len(entries))
+	for _, extension := range extensions {
+		contentType := entries[extension]
+
+		normalized := strings.ToLower(extension)
+		if !strings.HasPrefix(normalized, ".") {
+			normalized = "." + normalized
+		}
+		if len(normalized) == 1 || strings.ContainsAny(normalized[1:], "./\\") {
+			group.Add(fmt.Errorf("invalid extension %q", extension))
+			continue
+		}
+		if _, _, err := mime.ParseMediaType(contentType); err != nil {
+			group.Add(fmt.Errorf("invalid content type %q of extension %q: %w", contentType, extension, err))
+			continue
+		}
+		if _, ok := contentTypes[normalized]; ok {
+			group.Add(fmt.Errorf("extension %q is mapped more than once", extension))
+			continue
+		}
+
+		contentTypes[normalized] = contentType
+	}
+
+	if err := group.Err(); err != nil {
+		return nil, fmt.Errorf("invalid content type map %s: %w", path, err)
+	}
+
+	return contentTypes, nil
+}
+
+// TypeByExtension returns content type of the file name based on its extension.
+// Extensions missing in the map fall back to the built-in types, empty is returned when the type is unknown.
+func (contentTypes ContentTypeMap) TypeByExtension(name string) string {
+	extension := strings.ToLower(filepath.Ext(name))
+	if extension == "" {
+		return ""
+	}
+
+	if contentType, ok := contentTypes[extension]; ok {
+		return contentType
+	}
+
+	return mime.TypeByExtension(extension)
+}
+
+// withInferredContentType returns a copy of customMetadata with content type inferred from the name,
+// when the content type isn't already set and it can be inferred.
+func withInferredContentType(customMetadata uplink.CustomMetadata, contentTypes ContentTypeMap, name string) uplink.CustomMetadata {
+	if hasContentType(customMetadata) {
+		return customMetadata
+	}
+
+	contentType := contentTypes.TypeByExtension(name)
+	if contentType == "" {
+		return customMetadata
+	}
+
+	inferred := make(uplink.CustomMetadata, len(customMetadata)+1)
+	for key, value := range customMetadata {
+		inferred[key] = value
+	}
+	inferred["content-type"] = contentType
+
+	return inferred
+}
@@ -4,12 +4,20 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
+	"io/ioutil"
+	"mime"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -18,19 +26,59 @@ import (
 	"github.com/zeebo/errs"
 
 	"storj.io/common/fpath"
+	"storj.io/common/memory"
 	"storj.io/uplink"
 )
 
 var (
-	progress *bool
-	expires  *string
-	metadata *string
+	progress            *bool
+	expires             *string
+	metadata            *string
+	dstAccess           *string
+	reportPath          *string
+	contentTypeMap      *string
+	clientPassphrase    *string
+	clientEncrypt       *bool
+	inferExt            *bool
+	checksum            *bool
+	continueOnError     *bool
+	recursive           *bool
+	followSymlinks      *bool
+	metaSidecar         *bool
+	preserveMtime       *bool
+	adaptive            *bool
+	maxParallelism      *int
+	downloadParallelism *int
+	resume              *bool
+	untar               *bool
+	public              *bool
+	ifNewer             *bool
+	publicAuthService   *string
+	publicBaseURL       *string
+	pipeTo              *string
+	partSize            memory.Size
+	maxTotalSize        memory.Size
+)
+
+const (
+	// minPartSize is the minimum size of all parts of multipart upload except the last one.
+	minPartSize = 5 * memory.MiB
+	// maxPartSize is the maximum size of a single part of multipart upload.
+	maxPartSize = 5 * memory.GiB
+	// maxPartCount is the maximum number of parts of multipart upload.
+	maxPartCount = 10000
+
+	// checksumMetadataKey is the custom metadata key of SHA-256 checksum of uploaded data.
+	checksumMetadataKey = "x-uplink-sha256"
+
+	// metadataSidecarSuffix is appended to local file path to get path of its metadata sidecar file.
+	metadataSidecarSuffix = ".meta.json"
 )
 
 func init() {
 	cpCmd := addCmd(&cobra.Command{
 		Use:   "cp SOURCE DESTINATION",
-		Short: "Copies a local file or Storj object to another location locally or in Storj",
+		Short: "Copies a local file, HTTP(S) URL or Storj object to another location locally or in Storj",
 		RunE:  copyMain,
 		Args:  cobra.ExactArgs(2),
 	}, RootCmd)
@@ -38,6 +86,30 @@ func init() {
 	progress = cpCmd.Flags().Bool("progress", true, "if true, show progress")
 	expires = cpCmd.Flags().String("expires", "", "optional expiration date of an object. Please use format (yyyy-mm-ddThh:mm:ssZhh:mm)")
 	metadata = cpCmd.Flags().String("metadata", "", "optional metadata for the object. Please use a single level JSON object of string to string only")
+	cpCmd.Flags().Var(&partSize, "part-size", "if set, upload the object in parts of this size (5MiB-5GiB). Larger parts need more memory, smaller parts make more requests")
+	resume = cpCmd.Flags().Bool("resume", false, "if true, continue pending multipart upload of the destination object, uploading only the data following the already uploaded parts; requires --part-size, the pending upload is kept when the upload fails")
+	checksum = cpCmd.Flags().Bool("checksum", false, "if true, store SHA-256 checksum of uploaded data in object metadata under "+checksumMetadataKey)
+	inferExt = cpCmd.Flags().Bool("infer-extension", false, "if true, append file extension based on object content-type when downloading into a directory an object without extension")
+	continueOnError = cpCmd.Flags().Bool("continue-on-error", false, "if true, keep copying remaining files matching the pattern when a file fails and report all failures at the end")
+	recursive = cpCmd.Flags().Bool("recursive", false, "if true, upload all files of the local source directory, keeping their paths relative to it")
+	followSymlinks = cpCmd.Flags().Bool("follow-symlinks", false, "if true, upload targets of symbolic links found by --recursive, otherwise symbolic links are skipped")
+	metaSidecar = cpCmd.Flags().Bool("metadata-sidecar", false, "if true, read content type and metadata of every uploaded file from JSON file next to it with "+metadataSidecarSuffix+" suffix, when it exists; sidecar files themselves are not uploaded by --recursive and patterns")
+	adaptive = cpCmd.Flags().Bool("adaptive", false, "if true, upload multiple files matching the pattern or found by --recursive at once, starting with one upload and adapting the number of concurrent uploads to observed throughput; progress is not shown")
+	maxParallelism = cpCmd.Flags().Int("max-parallelism", 8, "maximum number of concurrent uploads with --adaptive")
+	downloadParallelism = cpCmd.Flags().Int("download-parallelism", 1, "number of ranges of a single object downloaded at once into the destination file; values above 1 need a seekable destination and don't apply to stdout")
+	ifNewer = cpCmd.Flags().Bool("if-newer", false, "if true, download the object only when the local destination file doesn't exist or was modified before the object was created; use with --preserve-mtime to mirror objects")
+	preserveMtime = cpCmd.Flags().Bool("preserve-mtime", false, "if true, set modification time of downloaded files to the time the object was created instead of the download time")
+	cpCmd.Flags().Var(&maxTotalSize, "max-total-size", "if set, stop with an error when files matching the pattern or found by --recursive would upload more than this size in total, e.g. 10GiB; the total is estimated from local file sizes before the upload starts")
+	reportPath = cpCmd.Flags().String("report", "", "if set, write JSON report of all transferred items with their status and a summary to this file, also when the copy fails")
+	contentTypeMap = cpCmd.Flags().String("content-type-map", "", "if set, infer content type of uploaded files without one from their extension using JSON file of extension to content type, e.g. {\".log\": \"text/plain\"}; extensions missing in the file use the built-in types")
+	clientEncrypt = cpCmd.Flags().Bool("client-encrypt", false, "if true, encrypt uploaded data with AES-256-GCM before it's passed to uplink and decrypt downloaded data, with key derived from passphrase separate from the access")
+	clientPassphrase = cpCmd.Flags().String("client-passphrase", "", "passphrase of --client-encrypt; when empty, it's read from "+clientPassphraseEnv+" environment variable or entered in terminal")
+	untar = cpCmd.Flags().Bool("untar", false, "if true, upload every file of the local tar archive, or tar stream from stdin, as a separate object under the destination prefix, keeping its path in the archive; gzip compressed archives are supported, directories and links are skipped")
+	public = cpCmd.Flags().Bool("public", false, "if true, register public read-only access to every uploaded object with the auth service and print its linksharing URL; anyone with the URL can download the object until it's deleted, the link can't be revoked otherwise")
+	publicAuthService = cpCmd.Flags().String("public-auth-service", "https://auth.us1.storjshare.io", "url of the auth service public access of --public is registered with")
+	publicBaseURL = cpCmd.Flags().String("public-base-url", "https://link.us1.storjshare.io", "base url of linksharing URLs printed by --public")
+	pipeTo = cpCmd.Flags().String("pipe-to", "", "if set, start this command and stream the downloaded object to its standard input instead of stdout, e.g. 'wc -c'; destination must be -, arguments are split at white space without shell interpretation, cp fails reporting the exit code when the command fails")
+	dstAccess = cpCmd.Flags().String("dst-access", "", "access name or serialized access used for the destination when copying between Storj locations, e.g. on another satellite")
 
 	setBasicFlags(cpCmd.Flags(), "progress", "expires", "metadata")
 }
@@ -52,15 +124,17 @@ func upload(ctx context.Context, src fpath.FPath, dst fpath.FPath, showProgress
 		return fmt.Errorf("destination must be Storj URL: %s", dst)
 	}
 
-	var expiration time.Time
-	if *expires != "" {
-		expiration, err = time.Parse(time.RFC3339, *expires)
-		if err != nil {
-			return err
-		}
-		if expiration.Before(time.Now()) {
-			return fmt.Errorf("invalid expiration date: (%s) has already passed", *expires)
-		}
+	if err := validatePartSize(partSize); err != nil {
+		return err
+	}
+
+	if *resume && partSize == 0 {
+		return fmt.Errorf("--resume requires --part-size")
+	}
+
+	expiration, err := parseExpiration()
+	if err != nil {
+		return err
 	}
 
 	// if object name not specified, default to filename
@@ -88,93 +162,399 @@ func upload(ctx context.Context, src fpath.FPath, dst fpath.FPath, showProgress
 		return fmt.Errorf("source cannot be a directory: %s", src)
 	}
 
+	if partSize > 0 && fileInfo.Mode().IsRegular() && fileInfo.Size() > maxPartCount*partSize.Int64() {
+		return fmt.Errorf("part size %s is too small for %s file: upload can't have more than %d parts", partSize, memory.Size(fileInfo.Size()), maxPartCount)
+	}
+
 	project, err := cfg.getProject(ctx, false)
 	if err != nil {
 		return err
 	}
 	defer closeProject(project)
 
-	reader := io.Reader(file)
+	var resumed resumeState
+	if *resume {
+		resumed, err = findResumableUpload(ctx, project, dst)
+		if err != nil {
+			return err
+		}
+	}
+
+	customMetadata, err := parseMetadata()
+	if err != nil {
+		return err
+	}
+
+	if *metaSidecar && src.Base() != "-" {
+		customMetadata, err = readMetadataSidecar(src.Path()+metadataSidecarSuffix, customMetadata)
+		if err != nil {
+			return err
+		}
+	}
+
+	if contentTypes != nil && src.Base() != "-" {
+		customMetadata = withInferredContentType(customMetadata, contentTypes, src.Base())
+	}
+
+	return uploadReader(ctx, project, dst, file, fileInfo.Size(), expiration, customMetadata, resumed, showProgress)
+}
+
+// uploadReader uploads data of reader as object dst, applying --checksum, --client-encrypt, --part-size and --public.
+// The size is used for the progress only, -1 means it's not known. Resumed upload skips data of the already uploaded parts.
+func uploadReader(ctx context.Context, project *uplink.Project, dst fpath.FPath, reader io.Reader, size int64, expiration time.Time, customMetadata uplink.CustomMetadata, resumed resumeState, showProgress bool) (err error) {
+	// checksum is calculated while streaming, so it's added to metadata after all data is uploaded.
+	var hasher hash.Hash
+	if *checksum {
+		hasher = sha256.New()
+	}
+
+	// data of the already uploaded parts is skipped, but it's still part of the checksum.
+	if resumed.offset > 0 {
+		skipped := ioutil.Discard
+		if hasher != nil {
+			skipped = hasher
+		}
+		if _, err := io.CopyN(skipped, reader, resumed.offset); err != nil {
+			// uploaded parts don't belong to this file, so the upload can't be resumed.
+			err = fmt.Errorf("failed to skip %d bytes of already uploaded parts: %w", resumed.offset, err)
+			return errs.Combine(err, project.AbortUpload(ctx, dst.Bucket(), dst.Path(), resumed.uploadID))
+		}
+	}
+
 	var bar *progressbar.ProgressBar
 	if showProgress {
-		bar = progressbar.New64(fileInfo.Size())
+		bar = progressbar.New64(size)
+		bar.SetCurrent(resumed.offset)
 		reader = bar.NewProxyReader(reader)
 		bar.Start()
 	}
 
-	var customMetadata uplink.CustomMetadata
-	if *metadata != "" {
-		err := json.Unmarshal([]byte(*metadata), &customMetadata)
+	if hasher != nil {
+		reader = io.TeeReader(reader, hasher)
+	}
+
+	if *clientEncrypt {
+		reader, customMetadata, err = ClientEncrypt(reader, clientEncryptPassphrase, customMetadata)
 		if err != nil {
 			return err
 		}
+	}
 
-		if err := customMetadata.Verify(); err != nil {
+	if partSize > 0 {
+		err = uploadMultipart(ctx, project, dst, reader, expiration, customMetadata, hasher, resumed)
+		if err != nil {
+			return err
+		}
+	} else {
+		upload, err := project.UploadObject(ctx, dst.Bucket(), dst.Path(), &uplink.UploadOptions{
+			Expires: expiration,
+		})
+		if err != nil {
+			return err
+		}
+
+		if _, err := io.Copy(upload, reader); err != nil {
+			return errs.Combine(err, upload.Abort())
+		}
+
+		if err := upload.SetCustomMetadata(ctx, withChecksum(customMetadata, hasher)); err != nil {
+			return errs.Combine(err, upload.Abort())
+		}
+
+		if err := upload.Commit(); err != nil {
 			return err
 		}
 	}
 
-	upload, err := project.UploadObject(ctx, dst.Bucket(), dst.Path(), &uplink.UploadOptions{
-		Expires: expiration,
-	})
+	if bar != nil {
+		bar.Finish()
+	}
+
+	fmt.Printf("Created %s\n", dst.String())
+
+	if *public {
+		return sharePublicly(ctx, dst)
+	}
+
+	return nil
+}
+
+// parseExpiration returns expiration of uploaded objects set by --expires, zero time when it's not set.
+func parseExpiration() (time.Time, error) {
+	if *expires == "" {
+		return time.Time{}, nil
+	}
+
+	expiration, err := time.Parse(time.RFC3339, *expires)
 	if err != nil {
-		return err
+		return time.Time{}, err
 	}
+	if expiration.Before(time.Now()) {
+		return time.Time{}, fmt.Errorf("invalid expiration date: (%s) has already passed", *expires)
+	}
+	return expiration, nil
+}
+
+// parseMetadata returns custom metadata of uploaded objects set by --metadata, nil when it's not set.
+func parseMetadata() (uplink.CustomMetadata, error) {
+	if *metadata == "" {
+		return nil, nil
+	}
+
+	var customMetadata uplink.CustomMetadata
+	if err := json.Unmarshal([]byte(*metadata), &customMetadata); err != nil {
+		return nil, err
+	}
+	if err := customMetadata.Verify(); err != nil {
+		return nil, err
+	}
+	return customMetadata, nil
+}
 
-	err = upload.SetCustomMetadata(ctx, customMetadata)
+// metadataSidecar is the schema of metadata sidecar file.
+type metadataSidecar struct {
+	ContentType string            `json:"contentType"`
+	Metadata    map[string]string `json:"metadata"`
+}
+
+// readMetadataSidecar reads the metadata sidecar file and merges it into a copy of customMetadata,
+// sidecar values take precedence. Missing sidecar is ignored.
+func readMetadataSidecar(path string, customMetadata uplink.CustomMetadata) (_ uplink.CustomMetadata, err error) {
+	data, err := ioutil.ReadFile(path)
 	if err != nil {
-		abortErr := upload.Abort()
-		err = errs.Combine(err, abortErr)
-		return err
+		if errors.Is(err, os.ErrNotExist) {
+			return customMetadata, nil
+		}
+		return nil, err
+	}
+
+	var sidecar metadataSidecar
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&sidecar); err != nil {
+		return nil, fmt.Errorf("invalid metadata sidecar %s: %w", path, err)
+	}
+
+	merged := make(uplink.CustomMetadata, len(customMetadata)+len(sidecar.Metadata)+1)
+	for key, value := range customMetadata {
+		merged[key] = value
+	}
+	for key, value := range sidecar.Metadata {
+		merged[key] = value
+	}
+	if sidecar.ContentType != "" {
+		for key := range merged {
+			if strings.EqualFold(key, "content-type") {
+				delete(merged, key)
+			}
+		}
+		merged["content-type"] = sidecar.ContentType
 	}
 
-	_, err = io.Copy(upload, reader)
+	if err := merged.Verify(); err != nil {
+		return nil, fmt.Errorf("invalid metadata sidecar %s: %w", path, err)
+	}
+
+	return merged, nil
+}
+
+// isMetadataSidecar returns true if the file is a metadata sidecar, which should not be uploaded on its own.
+func isMetadataSidecar(path string) bool {
+	return *metaSidecar && strings.HasSuffix(path, metadataSidecarSuffix)
+}
+
+// uploadMultipart uploads data from reader to dst in parts of partSize.
+// When hasher is not nil, checksum of all read data is added to metadata on commit.
+// Resumed upload continues with the part following the uploaded ones, reader must start after their data.
+func uploadMultipart(ctx context.Context, project *uplink.Project, dst fpath.FPath, reader io.Reader, expiration time.Time, customMetadata uplink.CustomMetadata, hasher hash.Hash, resumed resumeState) (err error) {
+	uploadID, nextPart := resumed.uploadID, resumed.nextPart
+	if uploadID == "" {
+		info, err := project.BeginUpload(ctx, dst.Bucket(), dst.Path(), &uplink.UploadOptions{
+			Expires: expiration,
+		})
+		if err != nil {
+			return err
+		}
+		uploadID, nextPart = info.UploadID, 1
+	}
+	// uploaded parts are kept to be resumed later, unless resuming can't succeed either.
+	var fatal bool
+	defer func() {
+		if err != nil && (!*resume || fatal) {
+			err = errs.Combine(err, project.AbortUpload(ctx, dst.Bucket(), dst.Path(), uploadID))
+		}
+	}()
+
+	for partNumber := nextPart; ; partNumber++ {
+		if partNumber > maxPartCount {
+			fatal = true
+			return fmt.Errorf("upload can't have more than %d parts, use bigger part size", maxPartCount)
+		}
+
+		part, err := project.UploadPart(ctx, dst.Bucket(), dst.Path(), uploadID, partNumber)
+		if err != nil {
+			return err
+		}
+
+		n, err := io.CopyN(part, reader, partSize.Int64())
+		if err != nil && !errors.Is(err, io.EOF) {
+			return errs.Combine(err, part.Abort())
+		}
+
+		if n == 0 && partNumber > 1 {
+			if err := part.Abort(); err != nil {
+				return err
+			}
+			break
+		}
+
+		if err := part.Commit(); err != nil {
+			return err
+		}
+
+		if n < partSize.Int64() {
+			break
+		}
+	}
+
+	_, err = project.CommitUpload(ctx, dst.Bucket(), dst.Path(), uploadID, &uplink.CommitUploadOptions{
+		CustomMetadata: withChecksum(customMetadata, hasher),
+	})
+	return err
+}
+
+// resumeState describes pending multipart upload to continue, zero value means a new upload.
+type resumeState struct {
+	uploadID string
+	nextPart uint32
+	// offset is the number of bytes in the already uploaded parts.
+	offset int64
+}
+
+// findResumableUpload finds pending multipart upload of dst and its uploaded parts.
+// When there is no pending upload, zero resumeState is returned.
+func findResumableUpload(ctx context.Context, project *uplink.Project, dst fpath.FPath) (_ resumeState, err error) {
+	uploadIDs, err := pendingUploads(ctx, project, dst)
 	if err != nil {
-		abortErr := upload.Abort()
-		err = errs.Combine(err, abortErr)
-		return err
+		return resumeState{}, err
 	}
 
-	if err := upload.Commit(); err != nil {
-		return err
+	switch len(uploadIDs) {
+	case 0:
+		return resumeState{}, nil
+	case 1:
+	default:
+		return resumeState{}, fmt.Errorf("%d pending uploads of %s found, can't choose which one to resume, use abort-upload to abort them", len(uploadIDs), dst)
 	}
 
-	if bar != nil {
-		bar.Finish()
+	var uploaded []uplink.Part
+	parts := project.ListUploadParts(ctx, dst.Bucket(), dst.Path(), uploadIDs[0], nil)
+	for parts.Next() {
+		uploaded = append(uploaded, *parts.Item())
+	}
+	if err := parts.Err(); err != nil {
+		return resumeState{}, err
 	}
+
+	offset, nextPart, err := ResumeOffset(uploaded)
 	if err != nil {
-		return err
+		return resumeState{}, fmt.Errorf("can't resume upload of %s: %w", dst, err)
 	}
 
-	fmt.Printf("Created %s\n", dst.String())
+	return resumeState{
+		uploadID: uploadIDs[0],
+		nextPart: nextPart,
+		offset:   offset,
+	}, nil
+}
+
+// ResumeOffset returns the number of bytes in the uploaded parts and the number of the next part to upload.
+// Sizes of the parts may differ, e.g. when the upload was started with another part size,
+// but the parts must be numbered consecutively from 1, so that the data following them is known.
+func ResumeOffset(parts []uplink.Part) (offset int64, nextPart uint32, err error) {
+	sorted := append([]uplink.Part(nil), parts...)
+	sort.Slice(sorted, func(i, k int) bool {
+		return sorted[i].PartNumber < sorted[k].PartNumber
+	})
+
+	nextPart = 1
+	for _, part := range sorted {
+		if part.PartNumber != nextPart {
+			return 0, 0, fmt.Errorf("part %d is missing", nextPart)
+		}
+		offset += part.Size
+		nextPart++
+	}
+
+	return offset, nextPart, nil
+}
+
+// withChecksum returns copy of metadata with hex encoded checksum of hasher, or unchanged metadata when hasher is nil.
+func withChecksum(customMetadata uplink.CustomMetadata, hasher hash.Hash) uplink.CustomMetadata {
+	if hasher == nil {
+		return customMetadata
+	}
 
+	withChecksum := customMetadata.Clone()
+	if withChecksum == nil {
+		withChecksum = uplink.CustomMetadata{}
+	}
+	withChecksum[checksumMetadataKey] = hex.EncodeToString(hasher.Sum(nil))
+	return withChecksum
+}
+
+// validatePartSize checks that part size is within multipart upload limits.
+// Zero part size means that multipart upload is not used.
+func validatePartSize(size memory.Size) error {
+	if size == 0 {
+		return nil
+	}
+	if size < minPartSize || size > maxPartSize {
+		return fmt.Errorf("invalid part size %s: must be between %s and %s", size, minPartSize, maxPartSize)
+	}
 	return nil
 }
 
-// download transfers s3 compatible object src to dst on local machine.
-func download(ctx context.Context, src fpath.FPath, dst fpath.FPath, showProgress bool) (err error) {
+// download transfers s3 compatible object src to dst on local machine and returns the number of downloaded bytes.
+func download(ctx context.Context, src fpath.FPath, dst fpath.FPath, showProgress bool) (_ int64, err error) {
 	if src.IsLocal() {
-		return fmt.Errorf("source must be Storj URL: %s", src)
+		return 0, fmt.Errorf("source must be Storj URL: %s", src)
 	}
 
 	if !dst.IsLocal() {
-		return fmt.Errorf("destination must be local path: %s", dst)
+		return 0, fmt.Errorf("destination must be local path: %s", dst)
+	}
+
+	if *downloadParallelism < 1 {
+		return 0, fmt.Errorf("--download-parallelism must be at least 1: %d", *downloadParallelism)
 	}
 
 	project, err := cfg.getProject(ctx, false)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer closeProject(project)
 
+	if *downloadParallelism > 1 && dst.Base() != "-" {
+		return downloadParallel(ctx, project, src, dst, showProgress)
+	}
+
 	download, err := project.DownloadObject(ctx, src.Bucket(), src.Path(), nil)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer func() { err = errs.Combine(err, download.Close()) }()
 
+	encrypted := IsClientEncrypted(download.Info().Custom)
+	if encrypted && !*clientEncrypt {
+		return 0, fmt.Errorf("object %s is encrypted with --client-encrypt, use it to download the object", src)
+	}
+	if !encrypted && *clientEncrypt {
+		return 0, fmt.Errorf("object %s is not encrypted with --client-encrypt", src)
+	}
+
 	var bar *progressbar.ProgressBar
-	var reader io.ReadCloser
+	var reader io.Reader
 	if showProgress {
 		info := download.Info()
 		bar = progressbar.New64(info.System.ContentLength)
@@ -184,17 +564,22 @@ func download(ctx context.Context, src fpath.FPath, dst fpath.FPath, showProgres
 		reader = download
 	}
 
-	if fileInfo, err := os.Stat(dst.Path()); err == nil && fileInfo.IsDir() {
-		dst = dst.Join(src.Base())
+	if encrypted {
+		reader, err = ClientDecrypt(reader, clientEncryptPassphrase, download.Info().Custom)
+		if err != nil {
+			return 0, err
+		}
 	}
 
+	dst = downloadDestination(src, dst, download.Info())
+
 	var file *os.File
 	if dst.Base() == "-" {
 		file = os.Stdout
 	} else {
 		file, err = os.Create(dst.Path())
 		if err != nil {
-			return err
+			return 0, err
 		}
 		defer func() {
 			if err := file.Close(); err != nil {
@@ -203,40 +588,278 @@ func download(ctx context.Context, src fpath.FPath, dst fpath.FPath, showProgres
 		}()
 	}
 
-	_, err = io.Copy(file, reader)
+	var downloaded int64
+	if pipeCommand != nil {
+		downloaded, err = PipeTo(ctx, pipeCommand, reader, os.Stdout, os.Stderr)
+	} else {
+		downloaded, err = io.Copy(file, reader)
+	}
 	if bar != nil {
 		bar.Finish()
 	}
 	if err != nil {
-		return err
+		return downloaded, err
 	}
 
 	if dst.Base() != "-" {
+		if *preserveMtime {
+			if err := preserveModTime(dst.Path(), download.Info()); err != nil {
+				return downloaded, err
+			}
+		}
+
 		fmt.Printf("Downloaded %s to %s\n", src.String(), dst.String())
 	}
 
+	return downloaded, nil
+}
+
+// downloadIfNewer downloads src only when the local destination is older than the object,
+// and prints the number of downloaded and skipped objects.
+func downloadIfNewer(ctx context.Context, src fpath.FPath, dst fpath.FPath, report *TransferReport) error {
+	current, resolved, err := localCopyCurrent(ctx, src, dst)
+	if err != nil {
+		report.Add(ReportItem{Source: src.String(), Destination: dst.String(), Status: ReportStatusFailed, Error: err.Error()})
+		return err
+	}
+
+	if current {
+		report.Skip(src.String(), resolved.String())
+		fmt.Printf("Skipped %s: %s is up to date\n", src.String(), resolved.String())
+		fmt.Println("Downloaded 0, skipped 1")
+		return nil
+	}
+
+	_, err = report.Run(src.String(), dst.String(), func() (int64, error) {
+		return download(ctx, src, dst, *progress)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Downloaded 1, skipped 0")
 	return nil
 }
 
-// copy copies s3 compatible object src to s3 compatible object dst.
-func copyObject(ctx context.Context, src fpath.FPath, dst fpath.FPath) (err error) {
+// downloadParallel downloads src into local file dst in --download-parallelism ranges at once.
+func downloadParallel(ctx context.Context, project *uplink.Project, src fpath.FPath, dst fpath.FPath, showProgress bool) (_ int64, err error) {
+	object, err := project.StatObject(ctx, src.Bucket(), src.Path())
+	if err != nil {
+		return 0, err
+	}
+	size := object.System.ContentLength
+
+	dst = downloadDestination(src, dst, object)
+
+	file, err := os.Create(dst.Path())
+	if err != nil {
+		return 0, err
+	}
+	defer func() { err = errs.Combine(err, file.Close()) }()
+
+	var bar *progressbar.ProgressBar
+	if showProgress {
+		bar = progressbar.New64(size)
+		bar.Start()
+	}
+
+	downloaded, err := DownloadRanges(ctx, file, size, *downloadParallelism, func(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+		download, err := project.DownloadObject(ctx, src.Bucket(), src.Path(), &uplink.DownloadOptions{
+			Offset: offset,
+			Length: length,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if bar != nil {
+			return bar.NewProxyReader(download), nil
+		}
+		return download, nil
+	})
+	if bar != nil {
+		bar.Finish()
+	}
+	if err != nil {
+		return downloaded, err
+	}
+
+	// ranges are written at their offsets, so the file size confirms nothing was written past the object end.
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return downloaded, err
+	}
+	if fileInfo.Size() != size {
+		return downloaded, fmt.Errorf("downloaded file %s has %d bytes, expected %d", dst, fileInfo.Size(), size)
+	}
+
+	if *preserveMtime {
+		if err := preserveModTime(dst.Path(), object); err != nil {
+			return downloaded, err
+		}
+	}
+
+	fmt.Printf("Downloaded %s to %s\n", src.String(), dst.String())
+
+	return downloaded, nil
+}
+
+// downloadDestination returns path of the file object src is downloaded to, when dst is a directory.
+func downloadDestination(src fpath.FPath, dst fpath.FPath, object *uplink.Object) fpath.FPath {
+	if fileInfo, err := os.Stat(dst.Path()); err == nil && fileInfo.IsDir() {
+		name := src.Base()
+		if *inferExt {
+			name = InferExtension(name, contentType(object.Custom))
+		}
+		return dst.Join(name)
+	}
+	return dst
+}
+
+// localCopyCurrent returns true when the local destination of src is not older than the object,
+// so the download can be skipped with --if-newer. The resolved destination is returned too.
+func localCopyCurrent(ctx context.Context, src fpath.FPath, dst fpath.FPath) (_ bool, _ fpath.FPath, err error) {
+	project, err := cfg.getProject(ctx, false)
+	if err != nil {
+		return false, dst, err
+	}
+	defer closeProject(project)
+
+	object, err := project.StatObject(ctx, src.Bucket(), src.Path())
+	if err != nil {
+		return false, dst, err
+	}
+
+	dst = downloadDestination(src, dst, object)
+	current, err := LocalCopyCurrent(dst.Path(), object.System.Created)
+	return current, dst, err
+}
+
+// LocalCopyCurrent returns true when the local file exists and wasn't modified before the object was created.
+// Times are compared with one second precision, since not all file systems keep fractions of a second.
+// Objects without creation time are never current.
+func LocalCopyCurrent(path string, created time.Time) (bool, error) {
+	if created.IsZero() {
+		return false, nil
+	}
+
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	if !fileInfo.Mode().IsRegular() {
+		return false, nil
+	}
+
+	return !fileInfo.ModTime().Truncate(time.Second).Before(created.Truncate(time.Second)), nil
+}
+
+// preserveModTime sets modification time of the downloaded file to the object creation time.
+// Objects without creation time, e.g. from satellites not reporting it, keep the download time.
+func preserveModTime(path string, object *uplink.Object) error {
+	created := object.System.Created
+	if created.IsZero() {
+		fmt.Fprintf(os.Stderr, "Object %s has no creation time, keeping download time of %s\n", object.Key, path)
+		return nil
+	}
+
+	return os.Chtimes(path, created, created)
+}
+
+// preferredExtensions contains extensions for common content types,
+// which have several possible extensions or aren't known on every platform.
+var preferredExtensions = map[string]string{
+	"application/gzip":   ".gz",
+	"application/json":   ".json",
+	"application/pdf":    ".pdf",
+	"application/x-gzip": ".gz",
+	"application/x-tar":  ".tar",
+	"application/zip":    ".zip",
+	"image/gif":          ".gif",
+	"image/jpeg":         ".jpg",
+	"image/png":          ".png",
+	"text/csv":           ".csv",
+	"text/html":          ".html",
+	"text/plain":         ".txt",
+	"video/mp4":          ".mp4",
+}
+
+// InferExtension appends extension matching content type to the name without extension.
+// Name is returned unchanged when it has an extension or content type is unknown.
+func InferExtension(name, contentType string) string {
+	if contentType == "" || filepath.Ext(name) != "" {
+		return name
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return name
+	}
+
+	if ext, ok := preferredExtensions[mediaType]; ok {
+		return name + ext
+	}
+
+	extensions, err := mime.ExtensionsByType(mediaType)
+	if err != nil || len(extensions) == 0 {
+		return name
+	}
+
+	return name + extensions[0]
+}
+
+// contentType returns content type stored in object custom metadata.
+func contentType(custom uplink.CustomMetadata) string {
+	for key, value := range custom {
+		if strings.EqualFold(key, "content-type") {
+			return value
+		}
+	}
+	return ""
+}
+
+// copy copies s3 compatible object src to s3 compatible object dst and returns the number of copied bytes.
+func copyObject(ctx context.Context, src fpath.FPath, dst fpath.FPath) (_ int64, err error) {
 	if src.IsLocal() {
-		return fmt.Errorf("source must be Storj URL: %s", src)
+		return 0, fmt.Errorf("source must be Storj URL: %s", src)
 	}
 
 	if dst.IsLocal() {
-		return fmt.Errorf("destination must be Storj URL: %s", dst)
+		return 0, fmt.Errorf("destination must be Storj URL: %s", dst)
 	}
 
-	project, err := cfg.getProject(ctx, false)
+	srcAccess, err := cfg.GetAccess()
 	if err != nil {
-		return err
+		return 0, err
+	}
+
+	project, err := cfg.openProject(ctx, srcAccess, false)
+	if err != nil {
+		return 0, err
 	}
 	defer closeProject(project)
 
+	dstProject := project
+	if *dstAccess != "" {
+		access, err := getAccessByNameOrValue(*dstAccess)
+		if err != nil {
+			return 0, err
+		}
+
+		// objects can't be copied between satellites on the server side,
+		// so the data is streamed through this client from one project to another.
+		dstProject, err = cfg.openProject(ctx, access, false)
+		if err != nil {
+			return 0, err
+		}
+		defer closeProject(dstProject)
+	}
+
 	download, err := project.DownloadObject(ctx, src.Bucket(), src.Path(), nil)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer func() { err = errs.Combine(err, download.Close()) }()
 
@@ -257,37 +880,256 @@ func copyObject(ctx context.Context, src fpath.FPath, dst fpath.FPath) (err erro
 		dst = dst.Join(src.Base())
 	}
 
-	upload, err := project.UploadObject(ctx, dst.Bucket(), dst.Path(), &uplink.UploadOptions{
+	upload, err := dstProject.UploadObject(ctx, dst.Bucket(), dst.Path(), &uplink.UploadOptions{
 		Expires: downloadInfo.System.Expires,
 	})
+	if err != nil {
+		return 0, err
+	}
 
-	_, err = io.Copy(upload, reader)
+	copied, err := io.Copy(upload, reader)
 	if err != nil {
 		abortErr := upload.Abort()
-		return errs.Combine(err, abortErr)
+		return 0, errs.Combine(err, abortErr)
 	}
 
 	err = upload.SetCustomMetadata(ctx, downloadInfo.Custom)
 	if err != nil {
 		abortErr := upload.Abort()
-		return errs.Combine(err, abortErr)
+		return 0, errs.Combine(err, abortErr)
 	}
 
 	err = upload.Commit()
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	if bar != nil {
 		bar.Finish()
 	}
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	fmt.Printf("%s copied to %s\n", src.String(), dst.String())
 
-	return nil
+	return copied, nil
+}
+
+// isGlobPattern returns true if path contains glob meta characters and doesn't name an existing file.
+func isGlobPattern(path string) bool {
+	if !strings.ContainsAny(path, "*?[") {
+		return false
+	}
+	_, err := os.Stat(path)
+	return os.IsNotExist(err)
+}
+
+// uploadGlob uploads every file matching src pattern into dst prefix.
+func uploadGlob(ctx context.Context, src fpath.FPath, dst fpath.FPath, showProgress bool, report *TransferReport) (err error) {
+	matches, err := filepath.Glob(src.Path())
+	if err != nil {
+		return fmt.Errorf("invalid pattern %q: %w", src.Path(), err)
+	}
+
+	uploads := batch{continueOnError: *continueOnError}
+
+	var files []string
+	for _, match := range matches {
+		match := match
+		err := uploads.Run(match, func() error {
+			fileInfo, err := os.Stat(match)
+			if err != nil {
+				report.Add(ReportItem{
+					Source:      match,
+					Destination: dst.Join(filepath.Base(match)).String(),
+					Status:      ReportStatusFailed,
+					Error:       err.Error(),
+				})
+				return err
+			}
+			if !fileInfo.IsDir() && !isMetadataSidecar(match) {
+				files = append(files, match)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(files) == 0 && uploads.Err() == nil {
+		return fmt.Errorf("no files match pattern %q", src.Path())
+	}
+
+	localFiles := make([]localFile, 0, len(files))
+	for _, file := range files {
+		localFiles = append(localFiles, localFile{path: file, key: filepath.Base(file)})
+	}
+
+	return uploadFiles(ctx, &uploads, localFiles, dst, showProgress, report)
+}
+
+// localFile is a file found in the uploaded directory.
+type localFile struct {
+	path string
+	// key is the path relative to the uploaded directory, using "/" as separator.
+	key string
+}
+
+// uploadRecursive uploads all files of the local directory under the destination prefix.
+func uploadRecursive(ctx context.Context, src fpath.FPath, dst fpath.FPath, showProgress bool, report *TransferReport) (err error) {
+	files, err := collectFiles(src.Path(), *followSymlinks)
+	if err != nil {
+		return err
+	}
+
+	uploads := batch{continueOnError: *continueOnError}
+	return uploadFiles(ctx, &uploads, files, dst, showProgress, report)
+}
+
+// uploadFiles uploads local files under the destination prefix one by one,
+// or concurrently with --adaptive, when progress is not shown.
+func uploadFiles(ctx context.Context, uploads *batch, files []localFile, dst fpath.FPath, showProgress bool, report *TransferReport) error {
+	quota := NewTransferQuota(maxTotalSize.Int64())
+	if err := quota.Estimate(localFileSizes(files)); err != nil {
+		return err
+	}
+
+	uploadFile := func(ctx context.Context, file localFile, showProgress bool) (size int64, err error) {
+		fileDst := dst.Join(file.key)
+		return report.Run(file.path, fileDst.String(), func() (int64, error) {
+			fileSrc, err := fpath.New(file.path)
+			if err != nil {
+				return 0, err
+			}
+
+			fileInfo, err := os.Stat(file.path)
+			if err != nil {
+				return 0, err
+			}
+
+			// files may grow after the estimation, so the quota is checked again.
+			if err := quota.Reserve(file.path, fileInfo.Size()); err != nil {
+				return 0, err
+			}
+
+			err = upload(ctx, fileSrc, fileDst, showProgress)
+			if err != nil {
+				quota.Release(fileInfo.Size())
+			}
+			return fileInfo.Size(), err
+		})
+	}
+
+	if *adaptive {
+		err := transferAdaptive(ctx, NewAdaptiveLimiter(*maxParallelism), uploads, files, func(ctx context.Context, file localFile) (int64, error) {
+			return uploadFile(ctx, file, false)
+		})
+		if quotaErr := quota.Err(); quotaErr != nil {
+			return quotaErr
+		}
+		return err
+	}
+
+	for _, file := range files {
+		file := file
+		err := uploads.Run(file.path, func() error {
+			_, err := uploadFile(ctx, file, showProgress)
+			return err
+		})
+		// exceeded quota stops the upload even with --continue-on-error.
+		if quotaErr := quota.Err(); quotaErr != nil {
+			return quotaErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return uploads.Err()
+}
+
+// localFileSizes returns current sizes of the local files, files which can't be read are
+// skipped, their upload fails later.
+func localFileSizes(files []localFile) []int64 {
+	sizes := make([]int64, 0, len(files))
+	for _, file := range files {
+		fileInfo, err := os.Stat(file.path)
+		if err != nil {
+			continue
+		}
+		sizes = append(sizes, fileInfo.Size())
+	}
+	return sizes
+}
+
+// collectFiles returns regular files in the directory and its subdirectories.
+// Symbolic links are skipped unless followSymlinks is set. Every directory is visited
+// at most once, which guards against symbolic link loops.
+func collectFiles(root string, followSymlinks bool) (files []localFile, err error) {
+	visited := make(map[string]bool)
+
+	var walk func(dir, prefix string) error
+	walk = func(dir, prefix string) error {
+		realDir, err := filepath.EvalSymlinks(dir)
+		if err != nil {
+			return err
+		}
+		if visited[realDir] {
+			fmt.Fprintf(os.Stderr, "skipping %s: directory was already visited, possibly a symbolic link loop\n", dir)
+			return nil
+		}
+		visited[realDir] = true
+
+		entries, err := ioutil.ReadDir(dir)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			path := filepath.Join(dir, entry.Name())
+			key := prefix + entry.Name()
+
+			if entry.Mode()&os.ModeSymlink != 0 {
+				if !followSymlinks {
+					fmt.Fprintf(os.Stderr, "skipping symbolic link %s, use --follow-symlinks to upload its target\n", path)
+					continue
+				}
+
+				entry, err = os.Stat(path)
+				if err != nil {
+					return err
+				}
+			}
+
+			switch {
+			case entry.IsDir():
+				if err := walk(path, key+"/"); err != nil {
+					return err
+				}
+			case entry.Mode().IsRegular() && !isMetadataSidecar(path):
+				files = append(files, localFile{path: path, key: key})
+			}
+		}
+
+		return nil
+	}
+
+	return files, walk(root, "")
+}
+
+// getAccessByNameOrValue returns named access from configuration or parses the value as serialized access.
+func getAccessByNameOrValue(value string) (*uplink.Access, error) {
+	access, err := cfg.GetNamedAccess(value)
+	if err != nil {
+		return nil, err
+	}
+	if access != nil {
+		return access, nil
+	}
+
+	return uplink.ParseAccess(value)
 }
 
 // copyMain is the function executed when cpCmd is called.
@@ -301,12 +1143,36 @@ func copyMain(cmd *cobra.Command, args []string) (err error) {
 
 	ctx, _ := withTelemetry(cmd)
 
-	src, err := fpath.New(args[0])
+	dst, err := fpath.New(args[1])
 	if err != nil {
 		return err
 	}
 
-	dst, err := fpath.New(args[1])
+	var report *TransferReport
+	if *reportPath != "" {
+		report = NewTransferReport()
+		defer func() {
+			if writeErr := report.Write(*reportPath); writeErr != nil {
+				err = errs.Combine(err, fmt.Errorf("failed to write report: %w", writeErr))
+			}
+		}()
+	}
+
+	// if uploading from HTTP URL
+	if isHTTPURL(args[0]) {
+		if flag := httpIncompatibleFlag(); flag != "" {
+			return fmt.Errorf("--%s can't be used when uploading from HTTP URL", flag)
+		}
+		if *public && dst.IsLocal() {
+			return errors.New("--public can be used only when uploading")
+		}
+		_, err = report.Run(args[0], dst.String(), func() (int64, error) {
+			return uploadHTTP(ctx, args[0], dst, *progress)
+		})
+		return err
+	}
+
+	src, err := fpath.New(args[0])
 	if err != nil {
 		return err
 	}
@@ -316,16 +1182,107 @@ func copyMain(cmd *cobra.Command, args []string) (err error) {
 		return errors.New("at least one of the source or the destination must be a Storj URL")
 	}
 
+	if *dstAccess != "" && (src.IsLocal() || dst.IsLocal()) {
+		return errors.New("--dst-access can be used only when copying between Storj locations")
+	}
+
+	if *recursive && !src.IsLocal() {
+		return errors.New("--recursive can be used only when uploading a local directory")
+	}
+
+	if *ifNewer && (src.IsLocal() || !dst.IsLocal() || dst.Base() == "-") {
+		return errors.New("--if-newer can be used only when downloading into a local file")
+	}
+
+	if *pipeTo != "" {
+		if src.IsLocal() || !dst.IsLocal() || dst.Base() != "-" {
+			return errors.New("--pipe-to can be used only when downloading to -")
+		}
+		pipeCommand, err = ParsePipeCommand(*pipeTo)
+		if err != nil {
+			return err
+		}
+	}
+
+	if *public {
+		if dst.IsLocal() || !src.IsLocal() {
+			return errors.New("--public can be used only when uploading")
+		}
+		if *clientEncrypt {
+			return errors.New("--public can't be used with --client-encrypt, shared objects couldn't be decrypted")
+		}
+	}
+
+	if *untar {
+		if !src.IsLocal() || dst.IsLocal() {
+			return errors.New("--untar can be used only when uploading a local tar archive")
+		}
+		if *recursive || *resume || *metaSidecar || *adaptive {
+			return errors.New("--untar can't be used with --recursive, --resume, --metadata-sidecar and --adaptive")
+		}
+	}
+
+	if *clientEncrypt {
+		if !src.IsLocal() && !dst.IsLocal() {
+			return errors.New("--client-encrypt can be used only when uploading or downloading")
+		}
+		if *resume {
+			return errors.New("--client-encrypt can't be used with --resume")
+		}
+		if dst.IsLocal() && *downloadParallelism > 1 {
+			return errors.New("--client-encrypt can't be used with --download-parallelism")
+		}
+
+		// uploaded data can't be decrypted with a mistyped passphrase, so it has to be entered twice.
+		clientEncryptPassphrase, err = resolveClientPassphrase(src.IsLocal())
+		if err != nil {
+			return err
+		}
+	}
+
 	// if uploading
 	if src.IsLocal() {
-		return upload(ctx, src, dst, *progress)
+		if *contentTypeMap != "" {
+			contentTypes, err = LoadContentTypeMap(*contentTypeMap)
+			if err != nil {
+				return err
+			}
+		}
+
+		if *untar {
+			return uploadTar(ctx, src, dst, *progress, report)
+		}
+		if *recursive {
+			return uploadRecursive(ctx, src, dst, *progress, report)
+		}
+		if isGlobPattern(src.Path()) {
+			return uploadGlob(ctx, src, dst, *progress, report)
+		}
+		_, err = report.Run(src.String(), dst.String(), func() (int64, error) {
+			fileInfo, err := os.Stat(src.Path())
+			if err != nil {
+				return 0, err
+			}
+			return fileInfo.Size(), upload(ctx, src, dst, *progress)
+		})
+		return err
 	}
 
 	// if downloading
 	if dst.IsLocal() {
-		return download(ctx, src, dst, *progress)
+		if *ifNewer {
+			return downloadIfNewer(ctx, src, dst, report)
+		}
+
+		_, err = report.Run(src.String(), dst.String(), func() (int64, error) {
+			return download(ctx, src, dst, *progress)
+		})
+		return err
 	}
 
 	// if copying from one remote location to another
-	return copyObject(ctx, src, dst)
+	_, err = report.Run(src.String(), dst.String(), func() (int64, error) {
+		return copyObject(ctx, src, dst)
+	})
+	return err
 }
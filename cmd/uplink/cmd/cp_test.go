@@ -0,0 +1,1949 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/memory"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/cmd/uplink/cmd"
+	"storj.io/storj/private/testplanet"
+	"storj.io/uplink"
+)
+
+func TestCpPartSize(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 4,
+		UplinkCount:      1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkExe := ctx.Compile("storj.io/storj/cmd/uplink")
+
+		// Configure uplink.
+		{
+			access := planet.Uplinks[0].Access[planet.Satellites[0].ID()]
+
+			accessString, err := access.Serialize()
+			require.NoError(t, err)
+
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"import",
+				accessString,
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+		}
+
+		bucketName := testrand.BucketName()
+		err := planet.Uplinks[0].CreateBucket(ctx, planet.Satellites[0], bucketName)
+		require.NoError(t, err)
+
+		expectedData := testrand.Bytes(11 * memory.MiB)
+		localFile := ctx.File("multipart", "object")
+		writeFile(t, localFile, expectedData)
+
+		// Upload in parts.
+		{
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"cp", "--progress=false",
+				"--part-size", "5MiB",
+				localFile, "sj://"+bucketName+"/multipart",
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+
+			data, err := planet.Uplinks[0].Download(ctx, planet.Satellites[0], bucketName, "multipart")
+			require.NoError(t, err)
+			require.Equal(t, expectedData, data)
+
+			segments, err := planet.Satellites[0].Metainfo.Metabase.TestingAllSegments(ctx)
+			require.NoError(t, err)
+
+			parts := make(map[uint32]struct{})
+			for _, segment := range segments {
+				parts[segment.Position.Part] = struct{}{}
+			}
+			require.Len(t, parts, 3)
+		}
+
+		// Upload with invalid part sizes.
+		for _, size := range []string{"1MiB", "6GiB"} {
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"cp", "--progress=false",
+				"--part-size", size,
+				localFile, "sj://"+bucketName+"/invalid-part-size",
+			).CombinedOutput()
+			t.Log(string(output))
+			require.Error(t, err)
+			require.Contains(t, string(output), "invalid part size")
+		}
+	})
+}
+
+func TestCpBetweenSatellites(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   2,
+		StorageNodeCount: 4,
+		UplinkCount:      1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkExe := ctx.Compile("storj.io/storj/cmd/uplink")
+
+		srcSatellite, dstSatellite := planet.Satellites[0], planet.Satellites[1]
+
+		// Configure uplink with the source satellite access.
+		{
+			access := planet.Uplinks[0].Access[srcSatellite.ID()]
+
+			accessString, err := access.Serialize()
+			require.NoError(t, err)
+
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"import",
+				accessString,
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+		}
+
+		dstAccess, err := planet.Uplinks[0].Access[dstSatellite.ID()].Serialize()
+		require.NoError(t, err)
+
+		srcBucket, dstBucket := testrand.BucketName(), testrand.BucketName()
+		require.NoError(t, planet.Uplinks[0].CreateBucket(ctx, srcSatellite, srcBucket))
+		require.NoError(t, planet.Uplinks[0].CreateBucket(ctx, dstSatellite, dstBucket))
+
+		expectedData := testrand.Bytes(6 * memory.MiB)
+		require.NoError(t, planet.Uplinks[0].Upload(ctx, srcSatellite, srcBucket, "object", expectedData))
+
+		// Copy object to the bucket on another satellite.
+		{
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"cp", "--progress=false",
+				"--dst-access", dstAccess,
+				"sj://"+srcBucket+"/object", "sj://"+dstBucket+"/copy",
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+
+			data, err := planet.Uplinks[0].Download(ctx, dstSatellite, dstBucket, "copy")
+			require.NoError(t, err)
+			require.Equal(t, expectedData, data)
+
+			data, err = planet.Uplinks[0].Download(ctx, srcSatellite, srcBucket, "object")
+			require.NoError(t, err)
+			require.Equal(t, expectedData, data)
+		}
+
+		// Destination access can't be used for local destination.
+		{
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"cp", "--progress=false",
+				"--dst-access", dstAccess,
+				"sj://"+srcBucket+"/object", ctx.File("local"),
+			).CombinedOutput()
+			t.Log(string(output))
+			require.Error(t, err)
+			require.Contains(t, string(output), "--dst-access can be used only")
+		}
+	})
+}
+
+func TestCpGlob(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 4,
+		UplinkCount:      1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkExe := ctx.Compile("storj.io/storj/cmd/uplink")
+
+		// Configure uplink.
+		{
+			access := planet.Uplinks[0].Access[planet.Satellites[0].ID()]
+
+			accessString, err := access.Serialize()
+			require.NoError(t, err)
+
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"import",
+				accessString,
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+		}
+
+		bucketName := testrand.BucketName()
+		err := planet.Uplinks[0].CreateBucket(ctx, planet.Satellites[0], bucketName)
+		require.NoError(t, err)
+
+		expected := map[string][]byte{
+			"a.gz": testrand.Bytes(memory.KiB),
+			"b.gz": testrand.Bytes(memory.KiB),
+		}
+		for name, data := range expected {
+			writeFile(t, ctx.File("logs", name), data)
+		}
+		writeFile(t, ctx.File("logs", "c.txt"), testrand.Bytes(memory.KiB))
+
+		// Upload files matching the pattern.
+		{
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"cp", "--progress=false",
+				filepath.Join(ctx.Dir("logs"), "*.gz"), "sj://"+bucketName+"/",
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+
+			for name, data := range expected {
+				downloaded, err := planet.Uplinks[0].Download(ctx, planet.Satellites[0], bucketName, name)
+				require.NoError(t, err)
+				require.Equal(t, data, downloaded)
+			}
+
+			objects, err := planet.Uplinks[0].ListObjects(ctx, planet.Satellites[0], bucketName)
+			require.NoError(t, err)
+			require.Len(t, objects, len(expected))
+		}
+
+		// Pattern matching nothing.
+		{
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"cp", "--progress=false",
+				filepath.Join(ctx.Dir("logs"), "*.zip"), "sj://"+bucketName+"/logs/",
+			).CombinedOutput()
+			t.Log(string(output))
+			require.Error(t, err)
+			require.Contains(t, string(output), "no files match pattern")
+		}
+	})
+}
+
+func TestCpContinueOnError(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 4,
+		UplinkCount:      1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkExe := ctx.Compile("storj.io/storj/cmd/uplink")
+
+		// Configure uplink.
+		{
+			access := planet.Uplinks[0].Access[planet.Satellites[0].ID()]
+
+			accessString, err := access.Serialize()
+			require.NoError(t, err)
+
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"import",
+				accessString,
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+		}
+
+		bucketName := testrand.BucketName()
+		err := planet.Uplinks[0].CreateBucket(ctx, planet.Satellites[0], bucketName)
+		require.NoError(t, err)
+
+		expected := map[string][]byte{
+			"a.gz": testrand.Bytes(memory.KiB),
+			"c.gz": testrand.Bytes(memory.KiB),
+		}
+		for name, data := range expected {
+			writeFile(t, ctx.File("logs", name), data)
+		}
+		// broken symlink matches the pattern, but can't be read.
+		require.NoError(t, os.Symlink(ctx.File("missing"), ctx.File("logs", "b.gz")))
+
+		// Fail fast by default.
+		{
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"cp", "--progress=false",
+				filepath.Join(ctx.Dir("logs"), "*.gz"), "sj://"+bucketName+"/",
+			).CombinedOutput()
+			t.Log(string(output))
+			require.Error(t, err)
+
+			objects, err := planet.Uplinks[0].ListObjects(ctx, planet.Satellites[0], bucketName)
+			require.NoError(t, err)
+			require.Empty(t, objects)
+		}
+
+		// Attempt all files and summarize failures.
+		{
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"cp", "--progress=false", "--continue-on-error",
+				filepath.Join(ctx.Dir("logs"), "*.gz"), "sj://"+bucketName+"/",
+			).CombinedOutput()
+			t.Log(string(output))
+			require.Error(t, err)
+			require.Contains(t, string(output), "1 of 3 items failed")
+			require.Contains(t, string(output), filepath.Join(ctx.Dir("logs"), "b.gz"))
+
+			for name, data := range expected {
+				downloaded, err := planet.Uplinks[0].Download(ctx, planet.Satellites[0], bucketName, name)
+				require.NoError(t, err)
+				require.Equal(t, data, downloaded)
+			}
+		}
+	})
+}
+
+func TestCpChecksum(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 4,
+		UplinkCount:      1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkExe := ctx.Compile("storj.io/storj/cmd/uplink")
+
+		// Configure uplink.
+		{
+			access := planet.Uplinks[0].Access[planet.Satellites[0].ID()]
+
+			accessString, err := access.Serialize()
+			require.NoError(t, err)
+
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"import",
+				accessString,
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+		}
+
+		bucketName := testrand.BucketName()
+		err := planet.Uplinks[0].CreateBucket(ctx, planet.Satellites[0], bucketName)
+		require.NoError(t, err)
+
+		project, err := planet.Uplinks[0].GetProject(ctx, planet.Satellites[0])
+		require.NoError(t, err)
+		defer ctx.Check(project.Close)
+
+		expectedData := testrand.Bytes(11 * memory.MiB)
+		localFile := ctx.File("checksum", "object")
+		writeFile(t, localFile, expectedData)
+
+		expectedChecksum := sha256.Sum256(expectedData)
+
+		for _, tt := range []struct {
+			key  string
+			args []string
+		}{
+			{"single", nil},
+			{"multipart", []string{"--part-size", "5MiB"}},
+		} {
+			args := append([]string{"--config-dir", ctx.Dir("uplink"), "cp", "--progress=false", "--checksum", "--metadata", `{"owner":"test"}`}, tt.args...)
+			output, err := exec.Command(uplinkExe, append(args, localFile, "sj://"+bucketName+"/"+tt.key)...).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+
+			object, err := project.StatObject(ctx, bucketName, tt.key)
+			require.NoError(t, err)
+			require.Equal(t, hex.EncodeToString(expectedChecksum[:]), object.Custom["x-uplink-sha256"], tt.key)
+			require.Equal(t, "test", object.Custom["owner"], tt.key)
+		}
+
+		// Checksum isn't stored without the flag.
+		{
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"cp", "--progress=false",
+				localFile, "sj://"+bucketName+"/plain",
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+
+			object, err := project.StatObject(ctx, bucketName, "plain")
+			require.NoError(t, err)
+			require.NotContains(t, object.Custom, "x-uplink-sha256")
+		}
+	})
+}
+
+func TestCpPreserveMtime(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 4,
+		UplinkCount:      1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkExe := ctx.Compile("storj.io/storj/cmd/uplink")
+
+		// Configure uplink.
+		{
+			access := planet.Uplinks[0].Access[planet.Satellites[0].ID()]
+
+			accessString, err := access.Serialize()
+			require.NoError(t, err)
+
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"import",
+				accessString,
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+		}
+
+		bucketName := testrand.BucketName()
+		err := planet.Uplinks[0].Upload(ctx, planet.Satellites[0], bucketName, "object", testrand.Bytes(memory.KiB))
+		require.NoError(t, err)
+
+		project, err := planet.Uplinks[0].GetProject(ctx, planet.Satellites[0])
+		require.NoError(t, err)
+		defer ctx.Check(project.Close)
+
+		object, err := project.StatObject(ctx, bucketName, "object")
+		require.NoError(t, err)
+		created := object.System.Created
+		require.False(t, created.IsZero())
+
+		download := func(name string, args ...string) time.Time {
+			localFile := ctx.File("mtime", name)
+
+			args = append([]string{"--config-dir", ctx.Dir("uplink"), "cp", "--progress=false"}, args...)
+			output, err := exec.Command(uplinkExe, append(args, "sj://"+bucketName+"/object", localFile)...).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+
+			info, err := os.Stat(localFile)
+			require.NoError(t, err)
+			return info.ModTime()
+		}
+
+		require.WithinDuration(t, created, download("preserved", "--preserve-mtime"), time.Millisecond)
+		require.False(t, download("default").Equal(created))
+	})
+}
+
+func TestInferExtension(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		contentType string
+		expected    string
+	}{
+		{"report", "application/pdf", "report.pdf"},
+		{"photo", "image/jpeg", "photo.jpg"},
+		{"image", "image/png", "image.png"},
+		{"notes", "text/plain; charset=utf-8", "notes.txt"},
+		{"data", "application/json", "data.json"},
+		{"backup", "application/gzip", "backup.gz"},
+		{"photo.jpg", "image/jpeg", "photo.jpg"},
+		{"archive.tar", "application/zip", "archive.tar"},
+		{"unknown", "application/x-unknown-type", "unknown"},
+		{"empty", "", "empty"},
+		{"invalid", "not a content type;;", "invalid"},
+	} {
+		require.Equal(t, tt.expected, cmd.InferExtension(tt.name, tt.contentType), tt.name)
+	}
+}
+
+func writeFile(t *testing.T, path string, data []byte) {
+	require.NoError(t, ioutil.WriteFile(path, data, 0644))
+}
+
+func TestCpRecursiveSymlinks(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 4,
+		UplinkCount:      1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkExe := ctx.Compile("storj.io/storj/cmd/uplink")
+
+		// Configure uplink.
+		{
+			access := planet.Uplinks[0].Access[planet.Satellites[0].ID()]
+
+			accessString, err := access.Serialize()
+			require.NoError(t, err)
+
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"import",
+				accessString,
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+		}
+
+		fileData := testrand.Bytes(memory.KiB)
+		nestedData := testrand.Bytes(memory.KiB)
+		writeFile(t, ctx.File("dir", "file"), fileData)
+		writeFile(t, ctx.File("dir", "nested", "file"), nestedData)
+		require.NoError(t, os.Symlink(ctx.File("dir", "file"), ctx.File("dir", "link")))
+		require.NoError(t, os.Symlink(ctx.Dir("dir"), ctx.File("dir", "nested", "loop")))
+
+		listKeys := func(bucket string) []string {
+			objects, err := planet.Uplinks[0].ListObjects(ctx, planet.Satellites[0], bucket)
+			require.NoError(t, err)
+
+			var keys []string
+			for _, object := range objects {
+				keys = append(keys, object.Key)
+			}
+			return keys
+		}
+
+		// Symbolic links are skipped by default.
+		{
+			bucketName := testrand.BucketName()
+			require.NoError(t, planet.Uplinks[0].CreateBucket(ctx, planet.Satellites[0], bucketName))
+
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"cp", "--progress=false", "--recursive",
+				ctx.Dir("dir"), "sj://"+bucketName+"/",
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+			require.Contains(t, string(output), "skipping symbolic link "+ctx.File("dir", "link"))
+			require.Contains(t, string(output), "skipping symbolic link "+ctx.File("dir", "nested", "loop"))
+
+			require.ElementsMatch(t, []string{"file", "nested/"}, listKeys(bucketName))
+
+			downloaded, err := planet.Uplinks[0].Download(ctx, planet.Satellites[0], bucketName, "nested/file")
+			require.NoError(t, err)
+			require.Equal(t, nestedData, downloaded)
+		}
+
+		// Symbolic links are followed, the loop is visited only once.
+		{
+			bucketName := testrand.BucketName()
+			require.NoError(t, planet.Uplinks[0].CreateBucket(ctx, planet.Satellites[0], bucketName))
+
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"cp", "--progress=false", "--recursive", "--follow-symlinks",
+				ctx.Dir("dir"), "sj://"+bucketName+"/",
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+			require.Contains(t, string(output), "possibly a symbolic link loop")
+
+			for key, data := range map[string][]byte{
+				"file":        fileData,
+				"link":        fileData,
+				"nested/file": nestedData,
+			} {
+				downloaded, err := planet.Uplinks[0].Download(ctx, planet.Satellites[0], bucketName, key)
+				require.NoError(t, err)
+				require.Equal(t, data, downloaded)
+			}
+		}
+	})
+}
+
+func TestCpMetadataSidecar(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 4,
+		UplinkCount:      1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkExe := ctx.Compile("storj.io/storj/cmd/uplink")
+
+		// Configure uplink.
+		{
+			access := planet.Uplinks[0].Access[planet.Satellites[0].ID()]
+
+			accessString, err := access.Serialize()
+			require.NoError(t, err)
+
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"import",
+				accessString,
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+		}
+
+		bucketName := testrand.BucketName()
+		err := planet.Uplinks[0].CreateBucket(ctx, planet.Satellites[0], bucketName)
+		require.NoError(t, err)
+
+		project, err := planet.Uplinks[0].GetProject(ctx, planet.Satellites[0])
+		require.NoError(t, err)
+		defer ctx.Check(project.Close)
+
+		writeFile(t, ctx.File("files", "with-sidecar.txt"), testrand.Bytes(memory.KiB))
+		writeFile(t, ctx.File("files", "with-sidecar.txt.meta.json"), []byte(`{"contentType": "text/plain", "metadata": {"owner": "sidecar"}}`))
+		writeFile(t, ctx.File("files", "without-sidecar.txt"), testrand.Bytes(memory.KiB))
+		writeFile(t, ctx.File("invalid", "invalid.txt"), testrand.Bytes(memory.KiB))
+		writeFile(t, ctx.File("invalid", "invalid.txt.meta.json"), []byte(`{"metadata": {"owner": 1}}`))
+
+		// Sidecar is applied when present, --metadata is overridden by it.
+		{
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"cp", "--progress=false", "--metadata-sidecar",
+				"--metadata", `{"owner": "flag", "team": "flag"}`,
+				filepath.Join(ctx.Dir("files"), "*.txt*"), "sj://"+bucketName+"/",
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+
+			object, err := project.StatObject(ctx, bucketName, "with-sidecar.txt")
+			require.NoError(t, err)
+			require.Equal(t, "text/plain", object.Custom["content-type"])
+			require.Equal(t, "sidecar", object.Custom["owner"])
+			require.Equal(t, "flag", object.Custom["team"])
+
+			object, err = project.StatObject(ctx, bucketName, "without-sidecar.txt")
+			require.NoError(t, err)
+			require.NotContains(t, object.Custom, "content-type")
+			require.Equal(t, "flag", object.Custom["owner"])
+
+			// sidecar itself is not uploaded.
+			_, err = project.StatObject(ctx, bucketName, "with-sidecar.txt.meta.json")
+			require.Error(t, err)
+		}
+
+		// Invalid sidecar is reported with the file path.
+		{
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"cp", "--progress=false", "--metadata-sidecar",
+				ctx.File("invalid", "invalid.txt"), "sj://"+bucketName+"/",
+			).CombinedOutput()
+			t.Log(string(output))
+			require.Error(t, err)
+			require.Contains(t, string(output), "invalid metadata sidecar "+ctx.File("invalid", "invalid.txt.meta.json"))
+		}
+	})
+}
+
+func TestCpResume(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 4,
+		UplinkCount:      1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkExe := ctx.Compile("storj.io/storj/cmd/uplink")
+
+		// Configure uplink.
+		{
+			access := planet.Uplinks[0].Access[planet.Satellites[0].ID()]
+
+			accessString, err := access.Serialize()
+			require.NoError(t, err)
+
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"import",
+				accessString,
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+		}
+
+		bucketName := testrand.BucketName()
+		err := planet.Uplinks[0].CreateBucket(ctx, planet.Satellites[0], bucketName)
+		require.NoError(t, err)
+
+		expectedData := testrand.Bytes(18 * memory.MiB)
+		localFile := ctx.File("resume", "object")
+		writeFile(t, localFile, expectedData)
+
+		project, err := planet.Uplinks[0].OpenProject(ctx, planet.Satellites[0])
+		require.NoError(t, err)
+		defer ctx.Check(project.Close)
+
+		// interrupted upload with parts of different sizes.
+		info, err := project.BeginUpload(ctx, bucketName, "resumed", nil)
+		require.NoError(t, err)
+
+		uploaded := 0
+		for i, size := range []memory.Size{6 * memory.MiB, 5 * memory.MiB} {
+			part, err := project.UploadPart(ctx, bucketName, "resumed", info.UploadID, uint32(i+1))
+			require.NoError(t, err)
+			_, err = part.Write(expectedData[uploaded : uploaded+size.Int()])
+			require.NoError(t, err)
+			require.NoError(t, part.Commit())
+			uploaded += size.Int()
+		}
+
+		output, err := exec.Command(uplinkExe,
+			"--config-dir", ctx.Dir("uplink"),
+			"cp", "--progress=false",
+			"--part-size", "5MiB", "--resume", "--checksum",
+			localFile, "sj://"+bucketName+"/resumed",
+		).CombinedOutput()
+		t.Log(string(output))
+		require.NoError(t, err)
+
+		data, err := planet.Uplinks[0].Download(ctx, planet.Satellites[0], bucketName, "resumed")
+		require.NoError(t, err)
+		require.Equal(t, expectedData, data)
+
+		// checksum includes data of the resumed parts.
+		object, err := project.StatObject(ctx, bucketName, "resumed")
+		require.NoError(t, err)
+		expectedChecksum := sha256.Sum256(expectedData)
+		require.Equal(t, hex.EncodeToString(expectedChecksum[:]), object.Custom["x-uplink-sha256"])
+
+		// without pending upload, a new one is started.
+		output, err = exec.Command(uplinkExe,
+			"--config-dir", ctx.Dir("uplink"),
+			"cp", "--progress=false",
+			"--part-size", "5MiB", "--resume",
+			localFile, "sj://"+bucketName+"/new",
+		).CombinedOutput()
+		t.Log(string(output))
+		require.NoError(t, err)
+
+		data, err = planet.Uplinks[0].Download(ctx, planet.Satellites[0], bucketName, "new")
+		require.NoError(t, err)
+		require.Equal(t, expectedData, data)
+	})
+}
+
+func TestResumeOffset(t *testing.T) {
+	// progress of the resumed upload starts from the offset.
+	offset, nextPart, err := cmd.ResumeOffset([]uplink.Part{
+		{PartNumber: 2, Size: 5 * memory.MiB.Int64()},
+		{PartNumber: 1, Size: 6 * memory.MiB.Int64()},
+		{PartNumber: 3, Size: 8 * memory.MiB.Int64()},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 19*memory.MiB.Int64(), offset)
+	require.EqualValues(t, 4, nextPart)
+
+	offset, nextPart, err = cmd.ResumeOffset(nil)
+	require.NoError(t, err)
+	require.Zero(t, offset)
+	require.EqualValues(t, 1, nextPart)
+
+	_, _, err = cmd.ResumeOffset([]uplink.Part{
+		{PartNumber: 1, Size: 5 * memory.MiB.Int64()},
+		{PartNumber: 3, Size: 5 * memory.MiB.Int64()},
+	})
+	require.Error(t, err)
+}
+
+func TestCpMaxTotalSize(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 4,
+		UplinkCount:      1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkExe := ctx.Compile("storj.io/storj/cmd/uplink")
+
+		// Configure uplink.
+		{
+			access := planet.Uplinks[0].Access[planet.Satellites[0].ID()]
+
+			accessString, err := access.Serialize()
+			require.NoError(t, err)
+
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"import",
+				accessString,
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+		}
+
+		writeFile(t, ctx.File("dir", "first"), testrand.Bytes(10*memory.KiB))
+		writeFile(t, ctx.File("dir", "nested", "second"), testrand.Bytes(10*memory.KiB))
+
+		// Total size within the limit is uploaded.
+		{
+			bucketName := testrand.BucketName()
+			require.NoError(t, planet.Uplinks[0].CreateBucket(ctx, planet.Satellites[0], bucketName))
+
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"cp", "--progress=false", "--recursive", "--max-total-size", "20KiB",
+				ctx.Dir("dir"), "sj://"+bucketName+"/",
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+
+			objects, err := planet.Uplinks[0].ListObjects(ctx, planet.Satellites[0], bucketName)
+			require.NoError(t, err)
+			require.Len(t, objects, 2)
+		}
+
+		// Total size over the limit is refused before anything is uploaded.
+		{
+			bucketName := testrand.BucketName()
+			require.NoError(t, planet.Uplinks[0].CreateBucket(ctx, planet.Satellites[0], bucketName))
+
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"cp", "--progress=false", "--recursive", "--continue-on-error", "--max-total-size", "15KiB",
+				ctx.Dir("dir"), "sj://"+bucketName+"/",
+			).CombinedOutput()
+			t.Log(string(output))
+			require.Error(t, err)
+			require.Contains(t, string(output), "exceeds --max-total-size")
+
+			objects, err := planet.Uplinks[0].ListObjects(ctx, planet.Satellites[0], bucketName)
+			require.NoError(t, err)
+			require.Empty(t, objects)
+		}
+	})
+}
+
+func TestTransferQuota(t *testing.T) {
+	quota := cmd.NewTransferQuota(100)
+	require.NoError(t, quota.Estimate([]int64{40, 60}))
+	require.Error(t, quota.Estimate([]int64{40, 61}))
+
+	require.NoError(t, quota.Reserve("first", 40))
+
+	// failed transfers don't count.
+	require.NoError(t, quota.Reserve("failed", 50))
+	quota.Release(50)
+
+	require.NoError(t, quota.Reserve("second", 60))
+	require.NoError(t, quota.Err())
+
+	err := quota.Reserve("third", 1)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "stopped after transferring 2 files")
+	require.Equal(t, err, quota.Err())
+
+	// once exceeded, no more transfers are allowed.
+	require.Error(t, quota.Reserve("empty", 0))
+
+	unlimited := cmd.NewTransferQuota(0)
+	require.NoError(t, unlimited.Estimate([]int64{1 << 40}))
+	require.NoError(t, unlimited.Reserve("huge", 1<<40))
+}
+
+func TestCpReport(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 4,
+		UplinkCount:      1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkExe := ctx.Compile("storj.io/storj/cmd/uplink")
+
+		// Configure uplink.
+		{
+			access := planet.Uplinks[0].Access[planet.Satellites[0].ID()]
+
+			accessString, err := access.Serialize()
+			require.NoError(t, err)
+
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"import",
+				accessString,
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+		}
+
+		bucketName := testrand.BucketName()
+		require.NoError(t, planet.Uplinks[0].CreateBucket(ctx, planet.Satellites[0], bucketName))
+
+		writeFile(t, ctx.File("logs", "a.gz"), testrand.Bytes(memory.KiB))
+		writeFile(t, ctx.File("logs", "c.gz"), testrand.Bytes(2*memory.KiB))
+		// broken symlink matches the pattern, but can't be read.
+		require.NoError(t, os.Symlink(ctx.File("missing"), ctx.File("logs", "b.gz")))
+
+		reportPath := ctx.File("report.json")
+		output, err := exec.Command(uplinkExe,
+			"--config-dir", ctx.Dir("uplink"),
+			"cp", "--progress=false", "--continue-on-error", "--report", reportPath,
+			filepath.Join(ctx.Dir("logs"), "*.gz"), "sj://"+bucketName+"/",
+		).CombinedOutput()
+		t.Log(string(output))
+		require.Error(t, err)
+
+		// report is written also when the copy fails.
+		data, err := ioutil.ReadFile(reportPath)
+		require.NoError(t, err)
+
+		var report cmd.TransferReportFile
+		require.NoError(t, json.Unmarshal(data, &report))
+
+		require.Equal(t, 3, report.Summary.Items)
+		require.Equal(t, 2, report.Summary.Succeeded)
+		require.Equal(t, 1, report.Summary.Failed)
+		require.Equal(t, 3*memory.KiB.Int64(), report.Summary.Bytes)
+
+		items := make(map[string]cmd.ReportItem)
+		for _, item := range report.Items {
+			items[filepath.Base(item.Source)] = item
+		}
+		require.Len(t, items, 3)
+
+		require.Equal(t, cmd.ReportStatusSucceeded, items["a.gz"].Status)
+		require.Equal(t, "sj://"+bucketName+"/a.gz", items["a.gz"].Destination)
+		require.Equal(t, memory.KiB.Int64(), items["a.gz"].Bytes)
+		require.Empty(t, items["a.gz"].Error)
+
+		require.Equal(t, cmd.ReportStatusSucceeded, items["c.gz"].Status)
+		require.Equal(t, 2*memory.KiB.Int64(), items["c.gz"].Bytes)
+
+		require.Equal(t, cmd.ReportStatusFailed, items["b.gz"].Status)
+		require.NotEmpty(t, items["b.gz"].Error)
+	})
+}
+
+func TestTransferReport(t *testing.T) {
+	report := cmd.NewTransferReport()
+
+	bytes, err := report.Run("first", "sj://bucket/first", func() (int64, error) { return 100, nil })
+	require.NoError(t, err)
+	require.EqualValues(t, 100, bytes)
+
+	_, err = report.Run("second", "sj://bucket/second", func() (int64, error) { return 0, errors.New("upload failed") })
+	require.Error(t, err)
+
+	report.Skip("sj://bucket/third", "third")
+
+	file := report.File()
+	require.Equal(t, cmd.ReportSummary{Items: 3, Succeeded: 1, Failed: 1, Skipped: 1, Bytes: 100, Duration: file.Summary.Duration}, file.Summary)
+	require.Equal(t, cmd.ReportStatusSkipped, file.Items[2].Status)
+	require.Equal(t, "upload failed", file.Items[1].Error)
+	require.Equal(t, cmd.ReportStatusFailed, file.Items[1].Status)
+
+	// nil report only runs the transfer.
+	var disabled *cmd.TransferReport
+	bytes, err = disabled.Run("first", "sj://bucket/first", func() (int64, error) { return 100, nil })
+	require.NoError(t, err)
+	require.EqualValues(t, 100, bytes)
+	require.NoError(t, disabled.Write("unused"))
+}
+
+func TestCpFromHTTP(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 4,
+		UplinkCount:      1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkExe := ctx.Compile("storj.io/storj/cmd/uplink")
+
+		// Configure uplink.
+		{
+			access := planet.Uplinks[0].Access[planet.Satellites[0].ID()]
+
+			accessString, err := access.Serialize()
+			require.NoError(t, err)
+
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"import",
+				accessString,
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+		}
+
+		data := testrand.Bytes(10 * memory.KiB)
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/data/file.txt", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write(data)
+		})
+		mux.Handle("/moved", http.RedirectHandler("/data/file.txt", http.StatusFound))
+		mux.HandleFunc("/missing", http.NotFound)
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		bucketName := testrand.BucketName()
+		require.NoError(t, planet.Uplinks[0].CreateBucket(ctx, planet.Satellites[0], bucketName))
+
+		// Object key defaults to the last element of the URL path.
+		{
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"cp", "--progress=false",
+				server.URL+"/data/file.txt", "sj://"+bucketName+"/",
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+
+			downloaded, err := planet.Uplinks[0].Download(ctx, planet.Satellites[0], bucketName, "file.txt")
+			require.NoError(t, err)
+			require.Equal(t, data, downloaded)
+
+			project, err := planet.Uplinks[0].OpenProject(ctx, planet.Satellites[0])
+			require.NoError(t, err)
+			defer ctx.Check(project.Close)
+
+			object, err := project.StatObject(ctx, bucketName, "file.txt")
+			require.NoError(t, err)
+			require.Equal(t, "text/plain", object.Custom["content-type"])
+		}
+
+		// Redirects are followed and content type can be overridden.
+		{
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"cp", "--progress=false", "--metadata", `{"content-type":"application/octet-stream"}`,
+				server.URL+"/moved", "sj://"+bucketName+"/moved",
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+
+			downloaded, err := planet.Uplinks[0].Download(ctx, planet.Satellites[0], bucketName, "moved")
+			require.NoError(t, err)
+			require.Equal(t, data, downloaded)
+
+			project, err := planet.Uplinks[0].OpenProject(ctx, planet.Satellites[0])
+			require.NoError(t, err)
+			defer ctx.Check(project.Close)
+
+			object, err := project.StatObject(ctx, bucketName, "moved")
+			require.NoError(t, err)
+			require.Equal(t, "application/octet-stream", object.Custom["content-type"])
+		}
+
+		// HTTP error status fails the copy without creating the object.
+		{
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"cp", "--progress=false",
+				server.URL+"/missing", "sj://"+bucketName+"/missing",
+			).CombinedOutput()
+			t.Log(string(output))
+			require.Error(t, err)
+			require.Contains(t, string(output), "404 Not Found")
+
+			_, err = planet.Uplinks[0].Download(ctx, planet.Satellites[0], bucketName, "missing")
+			require.True(t, errors.Is(err, uplink.ErrObjectNotFound))
+		}
+
+		// Flags which don't apply to HTTP URL are refused instead of being ignored.
+		for _, flag := range []string{"--recursive", "--dst-access=other", "--adaptive", "--max-total-size=1MiB", "--preserve-mtime", "--download-parallelism=2", "--content-type-map=types.json", "--client-encrypt", "--untar", "--if-newer", "--pipe-to=cat"} {
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"cp", "--progress=false", flag,
+				server.URL+"/data/file.txt", "sj://"+bucketName+"/refused",
+			).CombinedOutput()
+			t.Log(string(output))
+			require.Error(t, err, flag)
+			require.Contains(t, string(output), "can't be used when uploading from HTTP URL", flag)
+		}
+	})
+}
+
+func TestCpDownloadParallelism(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 4,
+		UplinkCount:      1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkExe := ctx.Compile("storj.io/storj/cmd/uplink")
+
+		// Configure uplink.
+		{
+			access := planet.Uplinks[0].Access[planet.Satellites[0].ID()]
+
+			accessString, err := access.Serialize()
+			require.NoError(t, err)
+
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"import",
+				accessString,
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+		}
+
+		bucketName := testrand.BucketName()
+		// size not divisible by parallelism, spanning multiple segments.
+		data := testrand.Bytes(5*memory.MiB + 3)
+		require.NoError(t, planet.Uplinks[0].Upload(ctx, planet.Satellites[0], bucketName, "large", data))
+
+		for _, parallelism := range []string{"1", "4", "7"} {
+			dst := ctx.File("download", "large-"+parallelism)
+
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"cp", "--progress=false", "--download-parallelism", parallelism,
+				"sj://"+bucketName+"/large", dst,
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+
+			downloaded, err := ioutil.ReadFile(dst)
+			require.NoError(t, err)
+			require.Equal(t, data, downloaded, parallelism)
+		}
+
+		output, err := exec.Command(uplinkExe,
+			"--config-dir", ctx.Dir("uplink"),
+			"cp", "--progress=false", "--download-parallelism", "0",
+			"sj://"+bucketName+"/large", ctx.File("download", "invalid"),
+		).CombinedOutput()
+		t.Log(string(output))
+		require.Error(t, err)
+	})
+}
+
+func TestSplitRanges(t *testing.T) {
+	require.Empty(t, cmd.SplitRanges(0, 4))
+	require.Equal(t, []cmd.ByteRange{{Offset: 0, Length: 10}}, cmd.SplitRanges(10, 1))
+	require.Equal(t, []cmd.ByteRange{{Offset: 0, Length: 10}}, cmd.SplitRanges(10, 0))
+	require.Equal(t, []cmd.ByteRange{
+		{Offset: 0, Length: 4},
+		{Offset: 4, Length: 3},
+		{Offset: 7, Length: 3},
+	}, cmd.SplitRanges(10, 3))
+
+	// parts are never empty.
+	require.Len(t, cmd.SplitRanges(2, 5), 2)
+}
+
+// bufferWriterAt is an in-memory io.WriterAt of fixed size.
+type bufferWriterAt struct {
+	mu   sync.Mutex
+	data []byte
+}
+
+func (buffer *bufferWriterAt) WriteAt(p []byte, offset int64) (int, error) {
+	buffer.mu.Lock()
+	defer buffer.mu.Unlock()
+
+	if offset+int64(len(p)) > int64(len(buffer.data)) {
+		return 0, errors.New("write past the end")
+	}
+	return copy(buffer.data[offset:], p), nil
+}
+
+func TestDownloadRanges(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	object := testrand.Bytes(100*memory.KiB + 7)
+	open := func(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(object[offset : offset+length])), nil
+	}
+
+	for _, parallelism := range []int{1, 3, 8, 16} {
+		dst := &bufferWriterAt{data: make([]byte, len(object))}
+		downloaded, err := cmd.DownloadRanges(ctx, dst, int64(len(object)), parallelism, open)
+		require.NoError(t, err)
+		require.EqualValues(t, len(object), downloaded)
+		require.Equal(t, object, dst.data, parallelism)
+	}
+
+	// short range fails the download.
+	short := func(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+		if offset > 0 {
+			length--
+		}
+		return ioutil.NopCloser(bytes.NewReader(object[offset : offset+length])), nil
+	}
+	dst := &bufferWriterAt{data: make([]byte, len(object))}
+	_, err := cmd.DownloadRanges(ctx, dst, int64(len(object)), 4, short)
+	require.Error(t, err)
+
+	// failing range fails the download.
+	failing := func(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+		if offset > 0 {
+			return nil, errors.New("segment not found")
+		}
+		return open(ctx, offset, length)
+	}
+	_, err = cmd.DownloadRanges(ctx, dst, int64(len(object)), 4, failing)
+	require.Error(t, err)
+}
+
+func TestCpContentTypeMap(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 4,
+		UplinkCount:      1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkExe := ctx.Compile("storj.io/storj/cmd/uplink")
+
+		// Configure uplink.
+		{
+			access := planet.Uplinks[0].Access[planet.Satellites[0].ID()]
+
+			accessString, err := access.Serialize()
+			require.NoError(t, err)
+
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"import",
+				accessString,
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+		}
+
+		bucketName := testrand.BucketName()
+		err := planet.Uplinks[0].CreateBucket(ctx, planet.Satellites[0], bucketName)
+		require.NoError(t, err)
+
+		project, err := planet.Uplinks[0].GetProject(ctx, planet.Satellites[0])
+		require.NoError(t, err)
+		defer ctx.Check(project.Close)
+
+		mapPath := ctx.File("map.json")
+		writeFile(t, mapPath, []byte(`{".json": "application/vnd.custom+json", "log": "text/x-log"}`))
+		writeFile(t, ctx.File("files", "data.json"), testrand.Bytes(memory.KiB))
+		writeFile(t, ctx.File("files", "server.log"), testrand.Bytes(memory.KiB))
+		writeFile(t, ctx.File("files", "page.html"), testrand.Bytes(memory.KiB))
+		writeFile(t, ctx.File("files", "noextension"), testrand.Bytes(memory.KiB))
+
+		output, err := exec.Command(uplinkExe,
+			"--config-dir", ctx.Dir("uplink"),
+			"cp", "--progress=false", "--recursive", "--content-type-map", mapPath,
+			ctx.Dir("files"), "sj://"+bucketName+"/",
+		).CombinedOutput()
+		t.Log(string(output))
+		require.NoError(t, err)
+
+		for key, expected := range map[string]string{
+			"data.json":  "application/vnd.custom+json",
+			"server.log": "text/x-log",
+			"page.html":  "text/html; charset=utf-8",
+		} {
+			object, err := project.StatObject(ctx, bucketName, key)
+			require.NoError(t, err)
+			require.Equal(t, expected, object.Custom["content-type"], key)
+		}
+
+		object, err := project.StatObject(ctx, bucketName, "noextension")
+		require.NoError(t, err)
+		require.NotContains(t, object.Custom, "content-type")
+
+		// invalid map fails before anything is uploaded.
+		invalidPath := ctx.File("invalid.json")
+		writeFile(t, invalidPath, []byte(`{".bin": "not a content type"}`))
+
+		output, err = exec.Command(uplinkExe,
+			"--config-dir", ctx.Dir("uplink"),
+			"cp", "--progress=false", "--content-type-map", invalidPath,
+			ctx.File("files", "data.json"), "sj://"+bucketName+"/invalid.json",
+		).CombinedOutput()
+		t.Log(string(output))
+		require.Error(t, err)
+
+		_, err = project.StatObject(ctx, bucketName, "invalid.json")
+		require.Error(t, err)
+	})
+}
+
+func TestLoadContentTypeMap(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	path := ctx.File("map.json")
+	writeFile(t, path, []byte(`{".JSON": "application/vnd.custom+json", "log": "text/x-log"}`))
+
+	contentTypes, err := cmd.LoadContentTypeMap(path)
+	require.NoError(t, err)
+	require.Equal(t, cmd.ContentTypeMap{
+		".json": "application/vnd.custom+json",
+		".log":  "text/x-log",
+	}, contentTypes)
+
+	// mapped extensions override the built-in types, case insensitively.
+	require.Equal(t, "application/vnd.custom+json", contentTypes.TypeByExtension("data.Json"))
+	require.Equal(t, "text/x-log", contentTypes.TypeByExtension("dir/server.log"))
+	// unmapped extensions fall back to the built-in types.
+	require.Equal(t, "image/png", contentTypes.TypeByExtension("image.png"))
+	require.Equal(t, "", contentTypes.TypeByExtension("noextension"))
+	require.Equal(t, "", contentTypes.TypeByExtension("file.unknown-extension"))
+
+	for _, invalid := range []string{
+		`not json`,
+		`{".log": 1}`,
+		`{".log": "not a content type"}`,
+		`{"": "text/plain"}`,
+		`{".": "text/plain"}`,
+		`{".tar.gz": "application/gzip"}`,
+		`{".log": "text/plain", "LOG": "text/x-log"}`,
+	} {
+		writeFile(t, path, []byte(invalid))
+		_, err := cmd.LoadContentTypeMap(path)
+		require.Error(t, err, invalid)
+	}
+
+	_, err = cmd.LoadContentTypeMap(ctx.File("missing.json"))
+	require.Error(t, err)
+}
+
+func TestCpClientEncrypt(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 4,
+		UplinkCount:      1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkExe := ctx.Compile("storj.io/storj/cmd/uplink")
+
+		// Configure uplink.
+		{
+			access := planet.Uplinks[0].Access[planet.Satellites[0].ID()]
+
+			accessString, err := access.Serialize()
+			require.NoError(t, err)
+
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"import",
+				accessString,
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+		}
+
+		bucketName := testrand.BucketName()
+		err := planet.Uplinks[0].CreateBucket(ctx, planet.Satellites[0], bucketName)
+		require.NoError(t, err)
+
+		expectedData := testrand.Bytes(200 * memory.KiB)
+		srcPath := ctx.File("src", "secret.bin")
+		writeFile(t, srcPath, expectedData)
+
+		output, err := exec.Command(uplinkExe,
+			"--config-dir", ctx.Dir("uplink"),
+			"cp", "--progress=false", "--client-encrypt", "--client-passphrase", "correct horse",
+			srcPath, "sj://"+bucketName+"/secret.bin",
+		).CombinedOutput()
+		t.Log(string(output))
+		require.NoError(t, err)
+
+		// stored data is encrypted and marked in metadata.
+		stored, err := planet.Uplinks[0].Download(ctx, planet.Satellites[0], bucketName, "secret.bin")
+		require.NoError(t, err)
+		require.NotEqual(t, expectedData, stored)
+		require.NotContains(t, string(stored), string(expectedData[:memory.KiB]))
+
+		project, err := planet.Uplinks[0].GetProject(ctx, planet.Satellites[0])
+		require.NoError(t, err)
+		defer ctx.Check(project.Close)
+
+		object, err := project.StatObject(ctx, bucketName, "secret.bin")
+		require.NoError(t, err)
+		require.True(t, cmd.IsClientEncrypted(object.Custom))
+
+		// passphrase from the environment decrypts the object.
+		dstPath := ctx.File("dst", "secret.bin")
+		download := exec.Command(uplinkExe,
+			"--config-dir", ctx.Dir("uplink"),
+			"cp", "--progress=false", "--client-encrypt",
+			"sj://"+bucketName+"/secret.bin", dstPath,
+		)
+		download.Env = append(os.Environ(), "UPLINK_CLIENT_PASSPHRASE=correct horse")
+		output, err = download.CombinedOutput()
+		t.Log(string(output))
+		require.NoError(t, err)
+
+		actualData, err := ioutil.ReadFile(dstPath)
+		require.NoError(t, err)
+		require.Equal(t, expectedData, actualData)
+
+		// wrong passphrase fails with a clear error.
+		output, err = exec.Command(uplinkExe,
+			"--config-dir", ctx.Dir("uplink"),
+			"cp", "--progress=false", "--client-encrypt", "--client-passphrase", "wrong horse",
+			"sj://"+bucketName+"/secret.bin", ctx.File("dst", "wrong.bin"),
+		).CombinedOutput()
+		t.Log(string(output))
+		require.Error(t, err)
+		require.Contains(t, string(output), "wrong passphrase")
+
+		// encrypted object isn't downloaded without --client-encrypt.
+		output, err = exec.Command(uplinkExe,
+			"--config-dir", ctx.Dir("uplink"),
+			"cp", "--progress=false",
+			"sj://"+bucketName+"/secret.bin", ctx.File("dst", "raw.bin"),
+		).CombinedOutput()
+		t.Log(string(output))
+		require.Error(t, err)
+	})
+}
+
+func TestClientEncryptRoundTrip(t *testing.T) {
+	const chunkSize = 64 * 1024
+
+	for _, size := range []int{0, 1, chunkSize - 1, chunkSize, chunkSize + 1, 3*chunkSize + 17} {
+		plaintext := testrand.BytesInt(size)
+
+		encrypting, custom, err := cmd.ClientEncrypt(bytes.NewReader(plaintext), "passphrase", uplink.CustomMetadata{"owner": "test"})
+		require.NoError(t, err)
+		require.True(t, cmd.IsClientEncrypted(custom))
+		require.Equal(t, "test", custom["owner"])
+
+		ciphertext, err := ioutil.ReadAll(encrypting)
+		require.NoError(t, err)
+		require.Greater(t, len(ciphertext), size, size)
+
+		decrypting, err := cmd.ClientDecrypt(bytes.NewReader(ciphertext), "passphrase", custom)
+		require.NoError(t, err)
+		decrypted, err := ioutil.ReadAll(decrypting)
+		require.NoError(t, err, size)
+		require.Equal(t, len(plaintext), len(decrypted), size)
+		require.Equal(t, plaintext, decrypted, size)
+	}
+}
+
+func TestClientDecryptWrongPassphrase(t *testing.T) {
+	const chunkSize = 64 * 1024
+
+	for _, size := range []int{0, 2*chunkSize + 1} {
+		encrypting, custom, err := cmd.ClientEncrypt(bytes.NewReader(testrand.BytesInt(size)), "passphrase", nil)
+		require.NoError(t, err)
+		ciphertext, err := ioutil.ReadAll(encrypting)
+		require.NoError(t, err)
+
+		decrypting, err := cmd.ClientDecrypt(bytes.NewReader(ciphertext), "wrong", custom)
+		require.NoError(t, err)
+		_, err = ioutil.ReadAll(decrypting)
+		require.Error(t, err)
+		require.True(t, cmd.ErrClientDecryption.Has(err))
+		require.Contains(t, err.Error(), "wrong passphrase")
+
+		if size > chunkSize {
+			// truncating the object at chunk boundary is detected.
+			decrypting, err = cmd.ClientDecrypt(bytes.NewReader(ciphertext[:chunkSize+16]), "passphrase", custom)
+			require.NoError(t, err)
+			_, err = ioutil.ReadAll(decrypting)
+			require.True(t, cmd.ErrClientDecryption.Has(err))
+		}
+	}
+
+	// objects without client encryption metadata can't be decrypted.
+	_, err := cmd.ClientDecrypt(bytes.NewReader(nil), "passphrase", uplink.CustomMetadata{})
+	require.Error(t, err)
+}
+
+func TestCpUntar(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 4,
+		UplinkCount:      1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkExe := ctx.Compile("storj.io/storj/cmd/uplink")
+
+		// Configure uplink.
+		{
+			access := planet.Uplinks[0].Access[planet.Satellites[0].ID()]
+
+			accessString, err := access.Serialize()
+			require.NoError(t, err)
+
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"import",
+				accessString,
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+		}
+
+		files := map[string][]byte{
+			"readme.txt":           testrand.Bytes(100),
+			"docs/guide.txt":       testrand.Bytes(memory.KiB),
+			"docs/nested/empty":    {},
+			"docs/nested/data.bin": testrand.Bytes(2 * memory.KiB),
+		}
+
+		var archive bytes.Buffer
+		writer := tar.NewWriter(&archive)
+		require.NoError(t, writer.WriteHeader(&tar.Header{Typeflag: tar.TypeDir, Name: "./docs/", Mode: 0755}))
+		require.NoError(t, writer.WriteHeader(&tar.Header{Typeflag: tar.TypeDir, Name: "./docs/nested/", Mode: 0755}))
+		for _, name := range []string{"readme.txt", "docs/guide.txt", "docs/nested/empty", "docs/nested/data.bin"} {
+			require.NoError(t, writer.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: "./" + name, Mode: 0644, Size: int64(len(files[name]))}))
+			_, err := writer.Write(files[name])
+			require.NoError(t, err)
+		}
+		require.NoError(t, writer.WriteHeader(&tar.Header{Typeflag: tar.TypeSymlink, Name: "./docs/link", Linkname: "guide.txt"}))
+		require.NoError(t, writer.Close())
+
+		tarPath := ctx.File("archive.tar")
+		writeFile(t, tarPath, archive.Bytes())
+
+		checkObjects := func(bucketName, prefix string) {
+			objects, err := planet.Uplinks[0].ListObjects(ctx, planet.Satellites[0], bucketName)
+			require.NoError(t, err)
+
+			var keys []string
+			for _, object := range objects {
+				keys = append(keys, object.Key)
+			}
+			if prefix == "" {
+				require.ElementsMatch(t, []string{"readme.txt", "docs/"}, keys)
+			} else {
+				require.ElementsMatch(t, []string{prefix}, keys)
+			}
+
+			for name, data := range files {
+				downloaded, err := planet.Uplinks[0].Download(ctx, planet.Satellites[0], bucketName, prefix+name)
+				require.NoError(t, err)
+				require.Equal(t, data, downloaded, name)
+			}
+
+			_, err = planet.Uplinks[0].Download(ctx, planet.Satellites[0], bucketName, prefix+"docs/link")
+			require.Error(t, err)
+		}
+
+		// Every file of the archive becomes an object, directories and links are skipped.
+		{
+			bucketName := testrand.BucketName()
+			require.NoError(t, planet.Uplinks[0].CreateBucket(ctx, planet.Satellites[0], bucketName))
+
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"cp", "--progress=false", "--untar",
+				tarPath, "sj://"+bucketName+"/",
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+			require.Contains(t, string(output), "skipping link "+tarPath+":./docs/link to guide.txt")
+
+			checkObjects(bucketName, "")
+		}
+
+		// Gzip compressed tar stream from stdin is uploaded under the destination prefix.
+		{
+			bucketName := testrand.BucketName()
+			require.NoError(t, planet.Uplinks[0].CreateBucket(ctx, planet.Satellites[0], bucketName))
+
+			var compressed bytes.Buffer
+			gzipWriter := gzip.NewWriter(&compressed)
+			_, err := gzipWriter.Write(archive.Bytes())
+			require.NoError(t, err)
+			require.NoError(t, gzipWriter.Close())
+
+			cmd := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"cp", "--progress=false", "--untar",
+				"-", "sj://"+bucketName+"/backup",
+			)
+			cmd.Stdin = &compressed
+			output, err := cmd.CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+
+			checkObjects(bucketName, "backup/")
+		}
+
+		// Only local tar archive can be expanded.
+		{
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"cp", "--progress=false", "--untar",
+				"sj://bucket/archive.tar", ctx.Dir("untarred"),
+			).CombinedOutput()
+			t.Log(string(output))
+			require.Error(t, err)
+			require.Contains(t, string(output), "--untar can be used only when uploading a local tar archive")
+		}
+	})
+}
+
+func TestTarEntryKey(t *testing.T) {
+	for name, expected := range map[string]string{
+		"file":           "file",
+		"./dir/file":     "dir/file",
+		"/absolute/file": "absolute/file",
+		"dir//file":      "dir/file",
+		"..file":         "..file",
+	} {
+		key, err := cmd.TarEntryKey(name)
+		require.NoError(t, err, name)
+		require.Equal(t, expected, key, name)
+	}
+
+	for _, name := range []string{"../file", "dir/../../file", "dir/..", "./", "/"} {
+		_, err := cmd.TarEntryKey(name)
+		require.Error(t, err, name)
+	}
+}
+
+func TestCpPublic(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 4,
+		UplinkCount:      1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkExe := ctx.Compile("storj.io/storj/cmd/uplink")
+
+		// Configure uplink.
+		{
+			access := planet.Uplinks[0].Access[planet.Satellites[0].ID()]
+
+			accessString, err := access.Serialize()
+			require.NoError(t, err)
+
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"import",
+				accessString,
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+		}
+
+		// stub auth service remembering registered accesses.
+		var mu sync.Mutex
+		var registered []map[string]interface{}
+		authService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost || r.URL.Path != "/v1/access" {
+				http.NotFound(w, r)
+				return
+			}
+
+			var request map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			mu.Lock()
+			registered = append(registered, request)
+			mu.Unlock()
+
+			_, _ = w.Write([]byte(`{"access_key_id":"publickey", "secret_key":"secret", "endpoint":"https://gateway.example.test"}`))
+		}))
+		defer authService.Close()
+
+		bucketName := testrand.BucketName()
+		require.NoError(t, planet.Uplinks[0].CreateBucket(ctx, planet.Satellites[0], bucketName))
+
+		data := testrand.Bytes(memory.KiB)
+		writeFile(t, ctx.File("report 2021.txt"), data)
+
+		output, err := exec.Command(uplinkExe,
+			"--config-dir", ctx.Dir("uplink"),
+			"cp", "--progress=false", "--public",
+			"--public-auth-service", authService.URL,
+			"--public-base-url", "https://link.example.test/",
+			ctx.File("report 2021.txt"), "sj://"+bucketName+"/shared/",
+		).CombinedOutput()
+		t.Log(string(output))
+		require.NoError(t, err)
+		require.Contains(t, string(output), "Public URL https://link.example.test/s/publickey/"+bucketName+"/shared/report%202021.txt")
+
+		mu.Lock()
+		require.Len(t, registered, 1)
+		require.Equal(t, true, registered[0]["public"])
+		grant, ok := registered[0]["access_grant"].(string)
+		mu.Unlock()
+		require.True(t, ok)
+
+		// registered access can download only the uploaded object.
+		access, err := uplink.ParseAccess(grant)
+		require.NoError(t, err)
+
+		project, err := uplink.OpenProject(ctx, access)
+		require.NoError(t, err)
+		defer ctx.Check(project.Close)
+
+		download, err := project.DownloadObject(ctx, bucketName, "shared/report 2021.txt", nil)
+		require.NoError(t, err)
+		downloaded, err := ioutil.ReadAll(download)
+		require.NoError(t, err)
+		require.NoError(t, download.Close())
+		require.Equal(t, data, downloaded)
+
+		upload, err := project.UploadObject(ctx, bucketName, "shared/other.txt", nil)
+		if err == nil {
+			err = upload.Commit()
+		}
+		require.Error(t, err)
+
+		// downloads can't be shared.
+		output, err = exec.Command(uplinkExe,
+			"--config-dir", ctx.Dir("uplink"),
+			"cp", "--progress=false", "--public",
+			"sj://"+bucketName+"/shared/report 2021.txt", ctx.File("downloaded.txt"),
+		).CombinedOutput()
+		t.Log(string(output))
+		require.Error(t, err)
+		require.Contains(t, string(output), "--public can be used only when uploading")
+	})
+}
+
+func TestPublicObjectURL(t *testing.T) {
+	require.Equal(t,
+		"https://link.example.test/s/key/bucket/dir/file%20name%3F.txt",
+		cmd.PublicObjectURL("https://link.example.test/", "key", "bucket", "dir/file name?.txt"))
+	require.Equal(t,
+		"https://link.example.test/s/key/bucket/file",
+		cmd.PublicObjectURL("https://link.example.test", "key", "bucket", "file"))
+}
+
+func TestCpIfNewer(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 4,
+		UplinkCount:      1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkExe := ctx.Compile("storj.io/storj/cmd/uplink")
+
+		// Configure uplink.
+		{
+			access := planet.Uplinks[0].Access[planet.Satellites[0].ID()]
+
+			accessString, err := access.Serialize()
+			require.NoError(t, err)
+
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"import",
+				accessString,
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+		}
+
+		bucketName := testrand.BucketName()
+		data := testrand.Bytes(memory.KiB)
+		require.NoError(t, planet.Uplinks[0].Upload(ctx, planet.Satellites[0], bucketName, "object", data))
+
+		project, err := planet.Uplinks[0].GetProject(ctx, planet.Satellites[0])
+		require.NoError(t, err)
+		defer ctx.Check(project.Close)
+
+		object, err := project.StatObject(ctx, bucketName, "object")
+		require.NoError(t, err)
+
+		dst := ctx.File("mirror", "object")
+		copyIfNewer := func() string {
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"cp", "--progress=false", "--if-newer", "--preserve-mtime",
+				"sj://"+bucketName+"/object", ctx.Dir("mirror"),
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+			return string(output)
+		}
+
+		// missing local file is downloaded.
+		output := copyIfNewer()
+		require.Contains(t, output, "Downloaded 1, skipped 0")
+		downloaded, err := ioutil.ReadFile(dst)
+		require.NoError(t, err)
+		require.Equal(t, data, downloaded)
+
+		// current local file is skipped, even when it differs.
+		writeFile(t, dst, []byte("local changes"))
+		require.NoError(t, os.Chtimes(dst, object.System.Created, object.System.Created))
+
+		output = copyIfNewer()
+		require.Contains(t, output, "Downloaded 0, skipped 1")
+		downloaded, err = ioutil.ReadFile(dst)
+		require.NoError(t, err)
+		require.Equal(t, []byte("local changes"), downloaded)
+
+		// local file older than the object is replaced.
+		older := object.System.Created.Add(-time.Hour)
+		require.NoError(t, os.Chtimes(dst, older, older))
+
+		output = copyIfNewer()
+		require.Contains(t, output, "Downloaded 1, skipped 0")
+		downloaded, err = ioutil.ReadFile(dst)
+		require.NoError(t, err)
+		require.Equal(t, data, downloaded)
+
+		// skipped downloads are counted in the report.
+		reportPath := ctx.File("report.json")
+		reportOutput, err := exec.Command(uplinkExe,
+			"--config-dir", ctx.Dir("uplink"),
+			"cp", "--progress=false", "--if-newer", "--report", reportPath,
+			"sj://"+bucketName+"/object", dst,
+		).CombinedOutput()
+		t.Log(string(reportOutput))
+		require.NoError(t, err)
+
+		reportData, err := ioutil.ReadFile(reportPath)
+		require.NoError(t, err)
+		var report cmd.TransferReportFile
+		require.NoError(t, json.Unmarshal(reportData, &report))
+		require.Equal(t, 1, report.Summary.Skipped)
+		require.Zero(t, report.Summary.Succeeded)
+	})
+}
+
+func TestLocalCopyCurrent(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	created := time.Date(2021, 5, 10, 12, 30, 15, 500000000, time.UTC)
+
+	current, err := cmd.LocalCopyCurrent(ctx.File("missing"), created)
+	require.NoError(t, err)
+	require.False(t, current)
+
+	path := ctx.File("file")
+	writeFile(t, path, []byte("data"))
+
+	for _, test := range []struct {
+		modified time.Time
+		current  bool
+	}{
+		{modified: created, current: true},
+		{modified: created.Add(time.Hour), current: true},
+		// fractions of a second are ignored.
+		{modified: created.Truncate(time.Second), current: true},
+		{modified: created.Add(-time.Second), current: false},
+		{modified: created.Add(-time.Hour), current: false},
+	} {
+		require.NoError(t, os.Chtimes(path, test.modified, test.modified))
+		current, err := cmd.LocalCopyCurrent(path, created)
+		require.NoError(t, err)
+		require.Equal(t, test.current, current, test.modified)
+	}
+
+	// objects without creation time are always downloaded.
+	current, err = cmd.LocalCopyCurrent(path, time.Time{})
+	require.NoError(t, err)
+	require.False(t, current)
+
+	// directory isn't a copy of the object.
+	current, err = cmd.LocalCopyCurrent(ctx.Dir("directory"), created)
+	require.NoError(t, err)
+	require.False(t, current)
+}
+
+func TestCpPipeTo(t *testing.T) {
+	if _, err := exec.LookPath("wc"); err != nil {
+		t.Skip("wc is not available")
+	}
+
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 4,
+		UplinkCount:      1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkExe := ctx.Compile("storj.io/storj/cmd/uplink")
+
+		// Configure uplink.
+		{
+			access := planet.Uplinks[0].Access[planet.Satellites[0].ID()]
+
+			accessString, err := access.Serialize()
+			require.NoError(t, err)
+
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"import",
+				accessString,
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+		}
+
+		bucketName := testrand.BucketName()
+		data := testrand.Bytes(100*memory.KiB + 13)
+		require.NoError(t, planet.Uplinks[0].Upload(ctx, planet.Satellites[0], bucketName, "object", data))
+
+		output, err := exec.Command(uplinkExe,
+			"--config-dir", ctx.Dir("uplink"),
+			"cp", "--progress=false", "--pipe-to", "wc -c",
+			"sj://"+bucketName+"/object", "-",
+		).Output()
+		require.NoError(t, err)
+		require.Equal(t, strconv.Itoa(len(data)), strings.TrimSpace(string(output)))
+
+		// failing command fails the download.
+		output, err = exec.Command(uplinkExe,
+			"--config-dir", ctx.Dir("uplink"),
+			"cp", "--progress=false", "--pipe-to", "false",
+			"sj://"+bucketName+"/object", "-",
+		).CombinedOutput()
+		t.Log(string(output))
+		require.Error(t, err)
+		require.Contains(t, string(output), "exited with code 1")
+
+		// the object is piped only instead of downloading to stdout.
+		output, err = exec.Command(uplinkExe,
+			"--config-dir", ctx.Dir("uplink"),
+			"cp", "--progress=false", "--pipe-to", "wc -c",
+			"sj://"+bucketName+"/object", ctx.File("download", "object"),
+		).CombinedOutput()
+		t.Log(string(output))
+		require.Error(t, err)
+	})
+}
+
+func TestPipeTo(t *testing.T) {
+	for _, command := range []string{"wc", "sh"} {
+		if _, err := exec.LookPath(command); err != nil {
+			t.Skipf("%s is not available", command)
+		}
+	}
+
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	data := testrand.Bytes(256*memory.KiB + 1)
+
+	command, err := cmd.ParsePipeCommand("  wc   -c ")
+	require.NoError(t, err)
+	require.Equal(t, []string{"wc", "-c"}, command)
+
+	var stdout bytes.Buffer
+	written, err := cmd.PipeTo(ctx, command, bytes.NewReader(data), &stdout, nil)
+	require.NoError(t, err)
+	require.EqualValues(t, len(data), written)
+	require.Equal(t, strconv.Itoa(len(data)), strings.TrimSpace(stdout.String()))
+
+	// command failing mid-stream reports its exit code.
+	_, err = cmd.PipeTo(ctx, []string{"sh", "-c", "head -c 10 > /dev/null; exit 3"}, bytes.NewReader(data), nil, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "exited with code 3")
+
+	// command which stops reading early fails the download, even when it succeeds.
+	_, err = cmd.PipeTo(ctx, []string{"sh", "-c", "exec 0<&-; sleep 1"}, bytes.NewReader(data), nil, nil)
+	require.Error(t, err)
+
+	// failing read kills the command.
+	failing := io.MultiReader(bytes.NewReader(data[:10]), failingReader{errors.New("connection reset")})
+	_, err = cmd.PipeTo(ctx, []string{"sh", "-c", "cat > /dev/null; sleep 10"}, failing, nil, nil)
+	require.EqualError(t, err, "connection reset")
+
+	_, err = cmd.PipeTo(ctx, []string{"uplink-missing-command"}, bytes.NewReader(data), nil, nil)
+	require.Error(t, err)
+
+	_, err = cmd.ParsePipeCommand("   ")
+	require.Error(t, err)
+}
+
+// failingReader fails every read with err.
+type failingReader struct{ err error }
+
+func (reader failingReader) Read([]byte) (int, error) { return 0, reader.err }
@@ -0,0 +1,159 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/fpath"
+	"storj.io/uplink"
+)
+
+// isHTTPURL returns true if the source is a HTTP or HTTPS URL, which is uploaded by streaming the response body.
+func isHTTPURL(source string) bool {
+	lower := strings.ToLower(source)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
+}
+
+// httpIncompatibleFlag returns name of the first set flag which does not apply when uploading from HTTP URL,
+// or empty string when there is none.
+func httpIncompatibleFlag() string {
+	flags := []struct {
+		name string
+		set  bool
+	}{
+		{"recursive", *recursive},
+		{"follow-symlinks", *followSymlinks},
+		{"dst-access", *dstAccess != ""},
+		{"metadata-sidecar", *metaSidecar},
+		{"adaptive", *adaptive},
+		{"max-total-size", maxTotalSize > 0},
+		{"continue-on-error", *continueOnError},
+		{"infer-extension", *inferExt},
+		{"preserve-mtime", *preserveMtime},
+		{"download-parallelism", *downloadParallelism > 1},
+		{"content-type-map", *contentTypeMap != ""},
+		{"client-encrypt", *clientEncrypt},
+		{"untar", *untar},
+		{"if-newer", *ifNewer},
+		{"pipe-to", *pipeTo != ""},
+	}
+
+	for _, flag := range flags {
+		if flag.set {
+			return flag.name
+		}
+	}
+	return ""
+}
+
+// uploadHTTP streams the response body of GET request of src into Storj object dst.
+// Redirects are followed and the content type of the response is stored in metadata,
+// unless it's set by --metadata.
+func uploadHTTP(ctx context.Context, src string, dst fpath.FPath, showProgress bool) (_ int64, err error) {
+	if dst.IsLocal() {
+		return 0, fmt.Errorf("destination must be Storj URL: %s", dst)
+	}
+
+	if *resume {
+		return 0, errors.New("--resume can't be used when uploading from HTTP URL")
+	}
+
+	if err := validatePartSize(partSize); err != nil {
+		return 0, err
+	}
+
+	expiration, err := parseExpiration()
+	if err != nil {
+		return 0, err
+	}
+
+	srcURL, err := url.Parse(src)
+	if err != nil {
+		return 0, fmt.Errorf("invalid source URL %q: %w", src, err)
+	}
+
+	// if object name not specified, default to the last element of the URL path
+	if strings.HasSuffix(dst.String(), "/") || dst.Path() == "" {
+		name := path.Base(srcURL.Path)
+		if name == "." || name == "/" {
+			return 0, fmt.Errorf("destination must contain object key, source URL %s has no file name", src)
+		}
+		dst = dst.Join(name)
+	}
+
+	customMetadata := uplink.CustomMetadata{}
+	if *metadata != "" {
+		if err := json.Unmarshal([]byte(*metadata), &customMetadata); err != nil {
+			return 0, err
+		}
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, srcURL.String(), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	// the default client follows up to 10 redirects.
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get %s: %w", src, err)
+	}
+	defer func() { err = errs.Combine(err, response.Body.Close()) }()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return 0, fmt.Errorf("failed to get %s: HTTP status %s", src, response.Status)
+	}
+
+	if contentType := response.Header.Get("Content-Type"); contentType != "" && !hasContentType(customMetadata) {
+		customMetadata["content-type"] = contentType
+	}
+
+	if err := customMetadata.Verify(); err != nil {
+		return 0, err
+	}
+
+	project, err := cfg.getProject(ctx, false)
+	if err != nil {
+		return 0, err
+	}
+	defer closeProject(project)
+
+	counter := &countingReader{reader: response.Body}
+	err = uploadReader(ctx, project, dst, counter, response.ContentLength, expiration, customMetadata, resumeState{}, showProgress)
+	return counter.read, err
+}
+
+// hasContentType returns true if metadata contain content type with any casing of the key.
+func hasContentType(customMetadata uplink.CustomMetadata) bool {
+	for key := range customMetadata {
+		if strings.EqualFold(key, "content-type") {
+			return true
+		}
+	}
+	return false
+}
+
+// countingReader counts bytes read from reader.
+type countingReader struct {
+	reader io.Reader
+	read   int64
+}
+
+// Read implements io.Reader.
+func (counter *countingReader) Read(p []byte) (int, error) {
+	n, err := counter.reader.Read(p)
+	counter.read += int64(n)
+	return n, err
+}
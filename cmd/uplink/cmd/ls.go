@@ -7,8 +7,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"sort"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/spf13/cobra"
 
@@ -20,8 +23,18 @@ var (
 	lsRecursiveFlag *bool
 	lsEncryptedFlag *bool
 	lsPendingFlag   *bool
+	lsDelimiterFlag *string
+	lsFilterFlag    *string
+	lsSortFlag      *string
+	lsReverseFlag   *bool
+	lsAfterFlag     *string
+	lsLimitFlag     *int
+	lsSummaryFlag   *bool
 )
 
+// lsSortWarnThreshold is the number of buffered entries after which ls warns about sorting large listing.
+const lsSortWarnThreshold = 100000
+
 func init() {
 	lsCmd := addCmd(&cobra.Command{
 		Use:   "ls [sj://BUCKET[/PREFIX]]",
@@ -32,6 +45,14 @@ func init() {
 	lsRecursiveFlag = lsCmd.Flags().Bool("recursive", false, "if true, list recursively")
 	lsEncryptedFlag = lsCmd.Flags().Bool("encrypted", false, "if true, show paths as base64-encoded encrypted paths")
 	lsPendingFlag = lsCmd.Flags().Bool("pending", false, "if true, list pending objects")
+	lsDelimiterFlag = lsCmd.Flags().String("delimiter", "/", "single character used to group object keys into prefixes")
+	lsSortFlag = lsCmd.Flags().String("sort", "", "sort objects by name, size or mtime; requires fetching the whole listing before printing")
+	lsReverseFlag = lsCmd.Flags().Bool("reverse", false, "if true, reverse the sort order")
+	lsFilterFlag = lsCmd.Flags().String("filter-prefix", "", "list only object keys starting with this raw prefix, not treated as a directory; with --recursive all matching keys are listed, otherwise they are grouped by the delimiter following the prefix")
+
+	lsAfterFlag = lsCmd.Flags().String("after", "", "list only objects following this key, as printed by the previous paged listing")
+	lsLimitFlag = lsCmd.Flags().Int("limit", 0, "maximum number of objects and prefixes to list, prints the --after value to resume the listing with to stderr when more are available")
+	lsSummaryFlag = lsCmd.Flags().Bool("summary", false, "if true, print number of listed objects and prefixes and total size of the objects after the listing as TOT objects=N prefixes=N bytes=N")
 
 	setBasicFlags(lsCmd.Flags(), "recursive", "encrypted", "pending")
 }
@@ -39,6 +60,28 @@ func init() {
 func list(cmd *cobra.Command, args []string) error {
 	ctx, _ := withTelemetry(cmd)
 
+	if utf8.RuneCountInString(*lsDelimiterFlag) != 1 {
+		return fmt.Errorf("delimiter must be a single character: %q", *lsDelimiterFlag)
+	}
+	if *lsFilterFlag != "" && *lsPendingFlag {
+		return fmt.Errorf("--filter-prefix cannot be used with --pending")
+	}
+	switch *lsSortFlag {
+	case "", "name", "size", "mtime":
+	default:
+		return fmt.Errorf("invalid sort key %q: must be one of name, size, mtime", *lsSortFlag)
+	}
+
+	paged := *lsAfterFlag != "" || *lsLimitFlag != 0
+	if *lsLimitFlag < 0 {
+		return fmt.Errorf("limit must not be negative: %d", *lsLimitFlag)
+	}
+	if paged && (len(args) == 0 || *lsPendingFlag || *lsSortFlag != "" || *lsFilterFlag != "" || *lsDelimiterFlag != "/") {
+		return fmt.Errorf("--after and --limit can be used only when listing a bucket without --pending, --sort, --filter-prefix and --delimiter")
+	}
+
+	out := newListOutput(*lsSortFlag, *lsReverseFlag)
+
 	project, err := cfg.getProject(ctx, *lsEncryptedFlag)
 	if err != nil {
 		return err
@@ -56,14 +99,23 @@ func list(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("no bucket specified, use format sj://bucket/")
 		}
 
-		if !strings.HasSuffix(args[0], "/") && src.Path() != "" {
-			err = listObject(ctx, project, src.Bucket(), src.Path())
+		// the object itself would be repeated on every page
+		if !paged && !strings.HasSuffix(args[0], "/") && !strings.HasSuffix(args[0], *lsDelimiterFlag) && src.Path() != "" {
+			err = listObject(ctx, project, out, src.Bucket(), src.Path())
 			if err != nil && !errors.Is(err, uplink.ErrObjectNotFound) {
 				return convertError(err, src)
 			}
 		}
-		err = listObjects(ctx, project, src.Bucket(), src.Path(), false)
-		return convertError(err, src)
+		err = listObjects(ctx, project, out, src.Bucket(), src.Path(), false)
+		out.Flush()
+		if err != nil {
+			return convertError(err, src)
+		}
+
+		if *lsSummaryFlag {
+			out.PrintSummary()
+		}
+		return nil
 	}
 	noBuckets := true
 
@@ -75,7 +127,9 @@ func list(cmd *cobra.Command, args []string) error {
 			fmt.Println("BKT", formatTime(bucket.Created), bucket.Name)
 		}
 		if *lsRecursiveFlag {
-			if err := listObjectsFromBucket(ctx, project, bucket.Name); err != nil {
+			err := listObjectsFromBucket(ctx, project, out, bucket.Name)
+			out.Flush()
+			if err != nil {
 				return err
 			}
 		}
@@ -88,53 +142,74 @@ func list(cmd *cobra.Command, args []string) error {
 	if noBuckets {
 		fmt.Println("No buckets")
 	}
+	if *lsSummaryFlag && *lsRecursiveFlag {
+		out.PrintSummary()
+	}
 	return nil
 }
 
-func listObjectsFromBucket(ctx context.Context, project *uplink.Project, bucket string) error {
-	return listObjects(ctx, project, bucket, "", true)
+func listObjectsFromBucket(ctx context.Context, project *uplink.Project, out *listOutput, bucket string) error {
+	return listObjects(ctx, project, out, bucket, "", true)
 }
 
-func listObject(ctx context.Context, project *uplink.Project, bucket, path string) error {
+func listObject(ctx context.Context, project *uplink.Project, out *listOutput, bucket, path string) error {
 	if *lsPendingFlag {
-		return listPendingObject(ctx, project, bucket, path)
+		return listPendingObject(ctx, project, out, bucket, path)
 	}
 	object, err := project.StatObject(ctx, bucket, path)
 	if err != nil {
 		return err
 	}
-	fmt.Printf("%v %v %12v %v\n", "OBJ", formatTime(object.System.Created), object.System.ContentLength, path)
+	out.Add(listEntry{Path: path, Created: object.System.Created, Size: object.System.ContentLength})
 	return nil
 }
 
-func listObjects(ctx context.Context, project *uplink.Project, bucket, prefix string, prependBucket bool) error {
+func listObjects(ctx context.Context, project *uplink.Project, out *listOutput, bucket, prefix string, prependBucket bool) error {
 	// TODO force adding slash at the end because fpath is removing it,
 	// most probably should be fixed in storj/common
-	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+	// Prefix ending with custom delimiter is already a prefix of keys grouped by it.
+	if prefix != "" && !strings.HasSuffix(prefix, "/") && !strings.HasSuffix(prefix, *lsDelimiterFlag) {
 		prefix += "/"
 	}
+	prefix += *lsFilterFlag
 
 	var objects *uplink.ObjectIterator
 	if *lsPendingFlag {
-		return listPendingObjects(ctx, project, bucket, prefix, prependBucket)
+		return listPendingObjects(ctx, project, out, bucket, prefix, prependBucket)
+	}
+
+	// uplink accepts only prefixes ending with "/", so raw prefixes are filtered here
+	if *lsDelimiterFlag != "/" || *lsFilterFlag != "" {
+		return listObjectsWithDelimiter(ctx, project, out, bucket, prefix, *lsDelimiterFlag, prependBucket)
+	}
+
+	// uplink cursor is relative to the listed prefix
+	if *lsAfterFlag != "" && !strings.HasPrefix(*lsAfterFlag, prefix) {
+		return fmt.Errorf("--after key %q must start with the listed prefix %q", *lsAfterFlag, prefix)
 	}
 
 	objects = project.ListObjects(ctx, bucket, &uplink.ListObjectsOptions{
 		Prefix:    prefix,
+		Cursor:    strings.TrimPrefix(*lsAfterFlag, prefix),
 		Recursive: *lsRecursiveFlag,
 		System:    true,
 	})
+
+	listed, last := 0, ""
 	for objects.Next() {
 		object := objects.Item()
+		if *lsLimitFlag > 0 && listed >= *lsLimitFlag {
+			// the cursor goes to stderr, so stdout contains listed objects only.
+			fmt.Fprintln(os.Stderr, "NEXT", last)
+			break
+		}
+
 		path := object.Key
 		if prependBucket {
 			path = fmt.Sprintf("%s/%s", bucket, path)
 		}
-		if object.IsPrefix {
-			fmt.Println("PRE", path)
-		} else {
-			fmt.Printf("%v %v %12v %v\n", "OBJ", formatTime(object.System.Created), object.System.ContentLength, path)
-		}
+		out.Add(listEntry{IsPrefix: object.IsPrefix, Path: path, Created: object.System.Created, Size: object.System.ContentLength})
+		listed, last = listed+1, object.Key
 	}
 	if objects.Err() != nil {
 		return objects.Err()
@@ -143,7 +218,56 @@ func listObjects(ctx context.Context, project *uplink.Project, bucket, prefix st
 	return nil
 }
 
-func listPendingObject(ctx context.Context, project *uplink.Project, bucket, path string) error {
+// listObjectsWithDelimiter lists objects grouping them into prefixes by custom delimiter.
+// Uplink groups prefixes only by "/", so objects are always listed recursively and grouped here.
+// The prefix may end in the middle of a path component, keys not matching it are skipped.
+func listObjectsWithDelimiter(ctx context.Context, project *uplink.Project, out *listOutput, bucket, prefix, delimiter string, prependBucket bool) error {
+	objects := project.ListObjects(ctx, bucket, &uplink.ListObjectsOptions{
+		Prefix:    prefix[:strings.LastIndex(prefix, "/")+1],
+		Recursive: true,
+		System:    true,
+	})
+
+	listedPrefixes := make(map[string]bool)
+	for objects.Next() {
+		object := objects.Item()
+		if !strings.HasPrefix(object.Key, prefix) {
+			continue
+		}
+
+		key, isPrefix := object.Key, false
+		if !*lsRecursiveFlag {
+			key, isPrefix = delimitKey(object.Key, prefix, delimiter)
+		}
+		if isPrefix && listedPrefixes[key] {
+			continue
+		}
+
+		path := key
+		if prependBucket {
+			path = fmt.Sprintf("%s/%s", bucket, path)
+		}
+		if isPrefix {
+			listedPrefixes[key] = true
+		}
+		out.Add(listEntry{IsPrefix: isPrefix, Path: path, Created: object.System.Created, Size: object.System.ContentLength})
+	}
+
+	return objects.Err()
+}
+
+// delimitKey cuts the key after the first delimiter following the prefix.
+// It reports whether the key was cut, i.e. whether it belongs to a nested prefix.
+func delimitKey(key, prefix, delimiter string) (_ string, isPrefix bool) {
+	index := strings.Index(key[len(prefix):], delimiter)
+	if index < 0 {
+		return key, false
+	}
+
+	return key[:len(prefix)+index+len(delimiter)], true
+}
+
+func listPendingObject(ctx context.Context, project *uplink.Project, out *listOutput, bucket, path string) error {
 	uploads := project.ListUploads(ctx, bucket, &uplink.ListUploadsOptions{
 		Prefix: path,
 		System: true,
@@ -152,13 +276,12 @@ func listPendingObject(ctx context.Context, project *uplink.Project, bucket, pat
 
 	for uploads.Next() {
 		object := uploads.Item()
-		path := object.Key
-		fmt.Printf("%v %v %12v %v\n", "OBJ", formatTime(object.System.Created), object.System.ContentLength, path)
+		out.Add(listEntry{Path: object.Key, Created: object.System.Created, Size: object.System.ContentLength})
 	}
 	return uploads.Err()
 }
 
-func listPendingObjects(ctx context.Context, project *uplink.Project, bucket, prefix string, prependBucket bool) error {
+func listPendingObjects(ctx context.Context, project *uplink.Project, out *listOutput, bucket, prefix string, prependBucket bool) error {
 	// TODO force adding slash at the end because fpath is removing it,
 	// most probably should be fixed in storj/common
 	if prefix != "" && !strings.HasSuffix(prefix, "/") {
@@ -180,16 +303,107 @@ func listPendingObjects(ctx context.Context, project *uplink.Project, bucket, pr
 		if prependBucket {
 			path = fmt.Sprintf("%s/%s", bucket, path)
 		}
-		if object.IsPrefix {
-			fmt.Println("PRE", path)
-		} else {
-			fmt.Printf("%v %v %12v %v\n", "OBJ", formatTime(object.System.Created), object.System.ContentLength, path)
-		}
+		out.Add(listEntry{IsPrefix: object.IsPrefix, Path: path, Created: object.System.Created, Size: object.System.ContentLength})
 	}
 
 	return objects.Err()
 }
 
+// listEntry is a single listed object or prefix.
+type listEntry struct {
+	IsPrefix bool
+	Path     string
+	Created  time.Time
+	Size     int64
+}
+
+// listOutput prints listed entries, buffering them when they need to be sorted.
+type listOutput struct {
+	sortBy  string
+	reverse bool
+
+	warned  bool
+	entries []listEntry
+
+	// totals of all added entries.
+	objects  int64
+	prefixes int64
+	size     int64
+}
+
+// newListOutput creates new instance of listOutput, empty sortBy means entries are printed in listing order.
+func newListOutput(sortBy string, reverse bool) *listOutput {
+	return &listOutput{
+		sortBy:  sortBy,
+		reverse: reverse,
+	}
+}
+
+// Add prints the entry or buffers it until Flush when sorting is enabled.
+func (out *listOutput) Add(entry listEntry) {
+	if entry.IsPrefix {
+		out.prefixes++
+	} else {
+		out.objects++
+		out.size += entry.Size
+	}
+
+	if out.sortBy == "" {
+		printListEntry(entry)
+		return
+	}
+
+	out.entries = append(out.entries, entry)
+	if len(out.entries) >= lsSortWarnThreshold && !out.warned {
+		out.warned = true
+		fmt.Fprintf(os.Stderr, "warning: sorting requires fetching the whole listing, already buffered %d entries\n", len(out.entries))
+	}
+}
+
+// Flush sorts and prints buffered entries.
+func (out *listOutput) Flush() {
+	sortListEntries(out.entries, out.sortBy, out.reverse)
+	for _, entry := range out.entries {
+		printListEntry(entry)
+	}
+	out.entries = nil
+}
+
+// PrintSummary prints totals of all entries added so far.
+func (out *listOutput) PrintSummary() {
+	fmt.Printf("TOT objects=%d prefixes=%d bytes=%d\n", out.objects, out.prefixes, out.size)
+}
+
+// sortListEntries sorts entries by name, size or mtime, entries with equal keys are ordered by name.
+func sortListEntries(entries []listEntry, sortBy string, reverse bool) {
+	sort.SliceStable(entries, func(i, k int) bool {
+		a, b := entries[i], entries[k]
+		if reverse {
+			a, b = b, a
+		}
+
+		switch sortBy {
+		case "size":
+			if a.Size != b.Size {
+				return a.Size < b.Size
+			}
+		case "mtime":
+			if !a.Created.Equal(b.Created) {
+				return a.Created.Before(b.Created)
+			}
+		}
+		return a.Path < b.Path
+	})
+}
+
+func printListEntry(entry listEntry) {
+	if entry.IsPrefix {
+		fmt.Println("PRE", entry.Path)
+	} else {
+		fmt.Printf("%v %v %12v %v\n", "OBJ", formatTime(entry.Created), entry.Size, entry.Path)
+	}
+}
+
 func formatTime(t time.Time) string {
 	return t.Local().Format("2006-01-02 15:04:05")
 }
@@ -4,9 +4,12 @@
 package cmd_test
 
 import (
+	"bytes"
+	"fmt"
 	"os/exec"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -175,6 +178,464 @@ func TestLsPending(t *testing.T) {
 	})
 }
 
+func TestLsDelimiter(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 4,
+		UplinkCount:      1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkExe := ctx.Compile("storj.io/storj/cmd/uplink")
+
+		// Configure uplink.
+		{
+			access := planet.Uplinks[0].Access[planet.Satellites[0].ID()]
+
+			accessString, err := access.Serialize()
+			require.NoError(t, err)
+
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"import",
+				accessString,
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+		}
+
+		bucketName := "testbucket"
+
+		err := planet.Uplinks[0].CreateBucket(ctx, planet.Satellites[0], bucketName)
+		require.NoError(t, err)
+
+		for _, key := range []string{"2020-01-report", "2020-02-report", "2021-01-report", "summary", "logs/app-1", "logs/app-2", "logs/db"} {
+			err = planet.Uplinks[0].Upload(ctx, planet.Satellites[0], bucketName, key, testrand.Bytes(memory.KiB))
+			require.NoError(t, err)
+		}
+
+		// List bucket grouping by custom delimiter.
+		{
+			cmd := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"ls",
+				"--delimiter", "-",
+				"sj://"+bucketName,
+			)
+			t.Log(cmd)
+
+			output, err := cmd.Output()
+			require.NoError(t, err)
+
+			checkOutput(t, output,
+				"PRE 2020-",
+				"PRE 2021-",
+				"summary",
+				"PRE logs/app-",
+				"logs/db",
+			)
+			require.Equal(t, 1, strings.Count(string(output), "PRE 2020-"))
+		}
+
+		// List nested prefix grouping by custom delimiter.
+		{
+			cmd := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"ls",
+				"--delimiter", "-",
+				"sj://"+bucketName+"/2020-",
+			)
+			t.Log(cmd)
+
+			output, err := cmd.Output()
+			require.NoError(t, err)
+
+			checkOutput(t, output,
+				"PRE 2020-01-",
+				"PRE 2020-02-",
+			)
+		}
+
+		// List directory grouping its keys by custom delimiter, "/" removed by fpath is kept.
+		for _, path := range []string{"sj://" + bucketName + "/logs/", "sj://" + bucketName + "/logs"} {
+			cmd := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"ls",
+				"--delimiter", "-",
+				path,
+			)
+			t.Log(cmd)
+
+			output, err := cmd.Output()
+			require.NoError(t, err)
+
+			checkOutput(t, output,
+				"PRE logs/app-",
+				"logs/db",
+			)
+			require.Equal(t, 1, strings.Count(string(output), "PRE logs/app-"), path)
+		}
+
+		// Delimiter must be a single character.
+		{
+			cmd := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"ls",
+				"--delimiter", "--",
+				"sj://"+bucketName,
+			)
+			t.Log(cmd)
+
+			output, err := cmd.CombinedOutput()
+			require.Error(t, err)
+			require.Contains(t, string(output), "delimiter must be a single character")
+		}
+	})
+}
+
+func TestLsFilterPrefix(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 4,
+		UplinkCount:      1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkExe := ctx.Compile("storj.io/storj/cmd/uplink")
+
+		// Configure uplink.
+		{
+			access := planet.Uplinks[0].Access[planet.Satellites[0].ID()]
+
+			accessString, err := access.Serialize()
+			require.NoError(t, err)
+
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"import",
+				accessString,
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+		}
+
+		bucketName := "testbucket"
+
+		err := planet.Uplinks[0].CreateBucket(ctx, planet.Satellites[0], bucketName)
+		require.NoError(t, err)
+
+		for _, key := range []string{"logs/2020/01/a", "logs/2021/01/b", "logs/2021/02/c", "logs/2021-summary", "other/2021/d"} {
+			err = planet.Uplinks[0].Upload(ctx, planet.Satellites[0], bucketName, key, testrand.Bytes(memory.KiB))
+			require.NoError(t, err)
+		}
+
+		// List without recursion groups keys by delimiter following the prefix.
+		{
+			cmd := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"ls",
+				"--filter-prefix", "logs/2021",
+				"sj://"+bucketName,
+			)
+			t.Log(cmd)
+
+			output, err := cmd.Output()
+			require.NoError(t, err)
+
+			checkOutput(t, output,
+				"PRE logs/2021/",
+				"logs/2021-summary",
+			)
+			require.Equal(t, 1, strings.Count(string(output), "PRE logs/2021/"))
+		}
+
+		// List recursively all keys matching the prefix.
+		{
+			cmd := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"ls",
+				"--recursive",
+				"--filter-prefix", "logs/2021",
+				"sj://"+bucketName,
+			)
+			t.Log(cmd)
+
+			output, err := cmd.Output()
+			require.NoError(t, err)
+
+			checkOutput(t, output,
+				"logs/2021/01/b",
+				"logs/2021/02/c",
+				"logs/2021-summary",
+			)
+		}
+
+		// Filter prefix is relative to the listed prefix.
+		{
+			cmd := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"ls",
+				"--recursive",
+				"--filter-prefix", "2021/0",
+				"sj://"+bucketName+"/logs/",
+			)
+			t.Log(cmd)
+
+			output, err := cmd.Output()
+			require.NoError(t, err)
+
+			checkOutput(t, output,
+				"logs/2021/01/b",
+				"logs/2021/02/c",
+			)
+		}
+	})
+}
+
+func TestLsSort(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 4,
+		UplinkCount:      1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkExe := ctx.Compile("storj.io/storj/cmd/uplink")
+
+		// Configure uplink.
+		{
+			access := planet.Uplinks[0].Access[planet.Satellites[0].ID()]
+
+			accessString, err := access.Serialize()
+			require.NoError(t, err)
+
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"import",
+				accessString,
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+		}
+
+		bucketName := "testbucket"
+
+		err := planet.Uplinks[0].CreateBucket(ctx, planet.Satellites[0], bucketName)
+		require.NoError(t, err)
+
+		// objects are uploaded in order of their modification time.
+		for _, object := range []struct {
+			key  string
+			size memory.Size
+		}{
+			{"b", 3 * memory.KiB},
+			{"c", 1 * memory.KiB},
+			{"a", 2 * memory.KiB},
+		} {
+			err = planet.Uplinks[0].Upload(ctx, planet.Satellites[0], bucketName, object.key, testrand.Bytes(object.size))
+			require.NoError(t, err)
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		for _, tt := range []struct {
+			args     []string
+			expected []string
+		}{
+			{[]string{"--sort", "name"}, []string{"a", "b", "c"}},
+			{[]string{"--sort", "size"}, []string{"c", "a", "b"}},
+			{[]string{"--sort", "mtime"}, []string{"b", "c", "a"}},
+			{[]string{"--sort", "name", "--reverse"}, []string{"c", "b", "a"}},
+			{[]string{"--sort", "size", "--reverse"}, []string{"b", "a", "c"}},
+			{[]string{"--sort", "mtime", "--reverse"}, []string{"a", "c", "b"}},
+		} {
+			args := append([]string{"--config-dir", ctx.Dir("uplink"), "ls"}, tt.args...)
+			cmd := exec.Command(uplinkExe, append(args, "sj://"+bucketName)...)
+			t.Log(cmd)
+
+			output, err := cmd.Output()
+			require.NoError(t, err)
+
+			var keys []string
+			for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+				fields := strings.Fields(line)
+				keys = append(keys, fields[len(fields)-1])
+			}
+			require.Equal(t, tt.expected, keys, tt.args)
+		}
+
+		// Unknown sort key.
+		{
+			cmd := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"ls",
+				"--sort", "owner",
+				"sj://"+bucketName,
+			)
+			t.Log(cmd)
+
+			output, err := cmd.CombinedOutput()
+			require.Error(t, err)
+			require.Contains(t, string(output), "invalid sort key")
+		}
+	})
+}
+
+func TestLsPaging(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 4,
+		UplinkCount:      1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkExe := ctx.Compile("storj.io/storj/cmd/uplink")
+
+		// Configure uplink.
+		{
+			access := planet.Uplinks[0].Access[planet.Satellites[0].ID()]
+
+			accessString, err := access.Serialize()
+			require.NoError(t, err)
+
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"import",
+				accessString,
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+		}
+
+		bucketName := "testbucket"
+
+		err := planet.Uplinks[0].CreateBucket(ctx, planet.Satellites[0], bucketName)
+		require.NoError(t, err)
+
+		objectKeys := []string{"dir/a", "dir/b", "dir/c", "dir/d", "dir/e"}
+		for _, key := range objectKeys {
+			err = planet.Uplinks[0].Upload(ctx, planet.Satellites[0], bucketName, key, testrand.Bytes(memory.KiB))
+			require.NoError(t, err)
+		}
+
+		listPage := func(after string) (keys []string, next string) {
+			args := []string{"--config-dir", ctx.Dir("uplink"), "ls", "--limit", "3"}
+			if after != "" {
+				args = append(args, "--after", after)
+			}
+			cmd := exec.Command(uplinkExe, append(args, "sj://"+bucketName+"/dir/")...)
+			t.Log(cmd)
+
+			var stderr bytes.Buffer
+			cmd.Stderr = &stderr
+
+			output, err := cmd.Output()
+			require.NoError(t, err)
+
+			for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+				fields := strings.Fields(line)
+				keys = append(keys, fields[len(fields)-1])
+			}
+			for _, line := range strings.Split(stderr.String(), "\n") {
+				if fields := strings.Fields(line); len(fields) == 2 && fields[0] == "NEXT" {
+					next = fields[1]
+				}
+			}
+			return keys, next
+		}
+
+		first, next := listPage("")
+		require.Len(t, first, 3)
+		require.Equal(t, first[2], next)
+
+		second, next := listPage(next)
+		require.Len(t, second, 2)
+		require.Empty(t, next)
+
+		require.ElementsMatch(t, objectKeys, append(first, second...))
+
+		// After key outside of the listed prefix.
+		{
+			cmd := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"ls",
+				"--after", "other/a",
+				"sj://"+bucketName+"/dir/",
+			)
+			t.Log(cmd)
+
+			output, err := cmd.CombinedOutput()
+			require.Error(t, err)
+			require.Contains(t, string(output), "must start with the listed prefix")
+		}
+	})
+}
+
+func TestLsSummary(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 4,
+		UplinkCount:      1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkExe := ctx.Compile("storj.io/storj/cmd/uplink")
+
+		// Configure uplink.
+		{
+			access := planet.Uplinks[0].Access[planet.Satellites[0].ID()]
+
+			accessString, err := access.Serialize()
+			require.NoError(t, err)
+
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"import",
+				accessString,
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+		}
+
+		bucketName := "testbucket"
+
+		err := planet.Uplinks[0].CreateBucket(ctx, planet.Satellites[0], bucketName)
+		require.NoError(t, err)
+
+		for key, size := range map[string]memory.Size{
+			"a":         1 * memory.KiB,
+			"b":         2 * memory.KiB,
+			"dir/c":     3 * memory.KiB,
+			"dir/sub/d": 4 * memory.KiB,
+		} {
+			err = planet.Uplinks[0].Upload(ctx, planet.Satellites[0], bucketName, key, testrand.Bytes(size))
+			require.NoError(t, err)
+		}
+
+		for _, tt := range []struct {
+			args    []string
+			summary string
+		}{
+			{[]string{"sj://" + bucketName}, "TOT objects=2 prefixes=1 bytes=3072"},
+			{[]string{"sj://" + bucketName, "--recursive"}, "TOT objects=4 prefixes=0 bytes=10240"},
+			{[]string{"sj://" + bucketName + "/dir/"}, "TOT objects=1 prefixes=1 bytes=3072"},
+			{[]string{"--recursive"}, "TOT objects=4 prefixes=0 bytes=10240"},
+			{[]string{"sj://" + bucketName, "--sort", "size"}, "TOT objects=2 prefixes=1 bytes=3072"},
+		} {
+			args := append([]string{"--config-dir", ctx.Dir("uplink"), "ls", "--summary"}, tt.args...)
+			lsCmd := exec.Command(uplinkExe, args...)
+			t.Log(lsCmd)
+
+			output, err := lsCmd.Output()
+			require.NoError(t, err)
+
+			lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+			require.Equal(t, tt.summary, lines[len(lines)-1], tt.args)
+
+			// footer counts match the listed entries.
+			var objects, prefixes int
+			for _, line := range lines[:len(lines)-1] {
+				switch {
+				case strings.HasPrefix(line, "OBJ"):
+					objects++
+				case strings.HasPrefix(line, "PRE"):
+					prefixes++
+				}
+			}
+			require.Equal(t, fmt.Sprintf("TOT objects=%d prefixes=%d", objects, prefixes), tt.summary[:strings.LastIndex(tt.summary, " ")], tt.args)
+		}
+	})
+}
+
 func checkOutput(t *testing.T, output []byte, objectKeys ...string) {
 	lines := strings.Split(string(output), "\n")
 
@@ -0,0 +1,104 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/zeebo/errs"
+	"golang.org/x/sync/errgroup"
+)
+
+// ByteRange is a range of object data downloaded by a single parallel download.
+type ByteRange struct {
+	Offset int64
+	Length int64
+}
+
+// SplitRanges splits size bytes into at most parts ranges of about the same length.
+func SplitRanges(size int64, parts int) []ByteRange {
+	if size <= 0 {
+		return nil
+	}
+	if parts < 1 {
+		parts = 1
+	}
+	if int64(parts) > size {
+		parts = int(size)
+	}
+
+	ranges := make([]ByteRange, 0, parts)
+	length, remainder := size/int64(parts), size%int64(parts)
+
+	var offset int64
+	for i := 0; i < parts; i++ {
+		rangeLength := length
+		// the remainder is spread over the first ranges.
+		if int64(i) < remainder {
+			rangeLength++
+		}
+		ranges = append(ranges, ByteRange{Offset: offset, Length: rangeLength})
+		offset += rangeLength
+	}
+
+	return ranges
+}
+
+// RangeOpener opens reader of length bytes of the object starting at offset.
+type RangeOpener func(ctx context.Context, offset, length int64) (io.ReadCloser, error)
+
+// DownloadRanges downloads size bytes with open in parallel ranges and writes them into dst at their offsets.
+// Every range has to contain exactly its length, otherwise the download fails.
+func DownloadRanges(ctx context.Context, dst io.WriterAt, size int64, parallelism int, open RangeOpener) (int64, error) {
+	ranges := SplitRanges(size, parallelism)
+
+	downloaded := make([]int64, len(ranges))
+	group, ctx := errgroup.WithContext(ctx)
+	for i, byteRange := range ranges {
+		i, byteRange := i, byteRange
+		group.Go(func() (err error) {
+			reader, err := open(ctx, byteRange.Offset, byteRange.Length)
+			if err != nil {
+				return err
+			}
+			defer func() { err = errs.Combine(err, reader.Close()) }()
+
+			writer := &offsetWriter{dst: dst, offset: byteRange.Offset}
+			downloaded[i], err = io.CopyN(writer, reader, byteRange.Length)
+			if err != nil {
+				return fmt.Errorf("failed to download range %d-%d: %w", byteRange.Offset, byteRange.Offset+byteRange.Length-1, err)
+			}
+			return nil
+		})
+	}
+	err := group.Wait()
+
+	var total int64
+	for _, n := range downloaded {
+		total += n
+	}
+	if err != nil {
+		return total, err
+	}
+
+	if total != size {
+		return total, fmt.Errorf("downloaded %d bytes, expected %d", total, size)
+	}
+	return total, nil
+}
+
+// offsetWriter writes sequentially into dst starting at offset.
+type offsetWriter struct {
+	dst    io.WriterAt
+	offset int64
+}
+
+// Write implements io.Writer.
+func (writer *offsetWriter) Write(p []byte) (int, error) {
+	n, err := writer.dst.WriteAt(p, writer.offset)
+	writer.offset += int64(n)
+	return n, err
+}
@@ -0,0 +1,91 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+// pipeCommand is the command with arguments downloaded data is written to with --pipe-to.
+var pipeCommand []string
+
+// ParsePipeCommand splits --pipe-to value into the command and its arguments at white space.
+// The command is executed directly, so shell syntax like quotes, redirections or pipes is not supported.
+func ParsePipeCommand(value string) ([]string, error) {
+	command := strings.Fields(value)
+	if len(command) == 0 {
+		return nil, errors.New("--pipe-to command is empty")
+	}
+	return command, nil
+}
+
+// PipeTo starts command and streams data from reader to its standard input, returning the number of bytes
+// written to it. Output of the command goes to stdout and stderr. It fails when the command exits with a non-zero
+// code, reporting the code, or stops reading before the end of data. When reading fails, the command is killed,
+// so it doesn't process incomplete data as if it were complete.
+func PipeTo(ctx context.Context, command []string, reader io.Reader, stdout, stderr io.Writer) (_ int64, err error) {
+	if len(command) == 0 {
+		return 0, errors.New("pipe command is empty")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	process := exec.CommandContext(ctx, command[0], command[1:]...)
+	process.Stdout = stdout
+	process.Stderr = stderr
+
+	stdin, err := process.StdinPipe()
+	if err != nil {
+		return 0, err
+	}
+	if err := process.Start(); err != nil {
+		return 0, fmt.Errorf("failed to start command %q: %w", command[0], err)
+	}
+
+	source := &readErrorRecorder{reader: reader}
+	written, copyErr := io.Copy(stdin, source)
+	if source.err != nil {
+		// the command is killed, so its exit status only reflects that.
+		cancel()
+		_ = process.Wait()
+		return written, source.err
+	}
+
+	closeErr := stdin.Close()
+	if err := process.Wait(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return written, fmt.Errorf("command %q exited with code %d after receiving %d bytes", command[0], exitErr.ExitCode(), written)
+		}
+		return written, err
+	}
+
+	if copyErr != nil {
+		return written, fmt.Errorf("command %q stopped reading after receiving %d bytes: %w", command[0], written, copyErr)
+	}
+
+	return written, closeErr
+}
+
+// readErrorRecorder remembers the error of reading from reader, other than io.EOF,
+// so it can be told apart from errors of writing the data.
+type readErrorRecorder struct {
+	reader io.Reader
+	err    error
+}
+
+// Read implements io.Reader.
+func (recorder *readErrorRecorder) Read(p []byte) (int, error) {
+	n, err := recorder.reader.Read(p)
+	if err != nil && !errors.Is(err, io.EOF) {
+		recorder.err = err
+	}
+	return n, err
+}
@@ -0,0 +1,55 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"storj.io/common/fpath"
+	"storj.io/uplink"
+)
+
+// PublicObjectURL returns linksharing URL of the object shared with the registered access key.
+func PublicObjectURL(baseURL, accessKey, bucket, key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return fmt.Sprintf("%s/s/%s/%s/%s", strings.TrimSuffix(baseURL, "/"), url.PathEscape(accessKey), url.PathEscape(bucket), strings.Join(segments, "/"))
+}
+
+// sharePublicly registers read-only access to the uploaded object dst as public access with the auth service
+// of --public-auth-service and prints its linksharing URL.
+//
+// Anyone who gets the URL can download the object until it's deleted. The access is stored by the auth service
+// as public access, so the auth service and linksharing service are able to decrypt the object. The access allows
+// only downloads of objects under the object key, it can't list or read other objects of the bucket.
+// The access grant itself isn't printed, so the link can't be revoked other than by deleting the object.
+func sharePublicly(ctx context.Context, dst fpath.FPath) error {
+	access, err := cfg.GetAccess()
+	if err != nil {
+		return err
+	}
+
+	shared, err := access.Share(uplink.Permission{AllowDownload: true}, uplink.SharePrefix{
+		Bucket: dst.Bucket(),
+		Prefix: dst.Path(),
+	})
+	if err != nil {
+		return err
+	}
+
+	accessKey, _, _, err := RegisterAccess(ctx, shared, *publicAuthService, true, defaultAccessRegisterTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to register public access to %s: %w", dst, err)
+	}
+
+	fmt.Printf("Public URL %s\n", PublicObjectURL(*publicBaseURL, accessKey, dst.Bucket(), dst.Path()))
+
+	return nil
+}
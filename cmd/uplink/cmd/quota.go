@@ -0,0 +1,82 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"fmt"
+	"sync"
+
+	"storj.io/common/memory"
+)
+
+// TransferQuota limits the cumulative size of transfers started by a single command.
+//
+// Once a transfer doesn't fit into the quota, the quota is exceeded and no further
+// transfers are allowed, even smaller ones, so the command stops instead of skipping files.
+type TransferQuota struct {
+	limit int64
+
+	mu          sync.Mutex
+	reserved    int64
+	transferred int
+	exceeded    error
+}
+
+// NewTransferQuota creates a quota of limit bytes, zero or negative limit means unlimited.
+func NewTransferQuota(limit int64) *TransferQuota {
+	return &TransferQuota{limit: limit}
+}
+
+// Estimate checks whether transfers of the given sizes fit into the quota, before any of them is started.
+func (quota *TransferQuota) Estimate(sizes []int64) error {
+	if quota.limit <= 0 {
+		return nil
+	}
+
+	var total int64
+	for _, size := range sizes {
+		total += size
+	}
+	if total > quota.limit {
+		return fmt.Errorf("estimated total size %s of %d files exceeds --max-total-size %s, nothing was transferred",
+			memory.Size(total), len(sizes), memory.Size(quota.limit))
+	}
+	return nil
+}
+
+// Reserve reserves size bytes for a transfer of item, failing when the quota would be exceeded.
+func (quota *TransferQuota) Reserve(item string, size int64) error {
+	quota.mu.Lock()
+	defer quota.mu.Unlock()
+
+	if quota.exceeded != nil {
+		return quota.exceeded
+	}
+	if quota.limit > 0 && quota.reserved+size > quota.limit {
+		quota.exceeded = fmt.Errorf("transferring %s (%s) would exceed --max-total-size %s, stopped after transferring %d files (%s)",
+			item, memory.Size(size), memory.Size(quota.limit), quota.transferred, memory.Size(quota.reserved))
+		return quota.exceeded
+	}
+
+	quota.reserved += size
+	quota.transferred++
+	return nil
+}
+
+// Release returns size bytes of a failed transfer back to the quota.
+func (quota *TransferQuota) Release(size int64) {
+	quota.mu.Lock()
+	defer quota.mu.Unlock()
+
+	quota.reserved -= size
+	quota.transferred--
+}
+
+// Err returns the error of the transfer which exceeded the quota, if any.
+func (quota *TransferQuota) Err() error {
+	quota.mu.Lock()
+	defer quota.mu.Unlock()
+
+	return quota.exceeded
+}
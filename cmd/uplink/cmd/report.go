@@ -0,0 +1,146 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"sync"
+	"time"
+)
+
+const (
+	// ReportStatusSucceeded is the status of a successfully transferred item.
+	ReportStatusSucceeded = "succeeded"
+	// ReportStatusFailed is the status of a failed item.
+	ReportStatusFailed = "failed"
+	// ReportStatusSkipped is the status of an item which didn't need to be transferred.
+	ReportStatusSkipped = "skipped"
+)
+
+// ReportItem is a single transferred item of the transfer report.
+type ReportItem struct {
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+	Bytes       int64  `json:"bytes"`
+	// Duration of the transfer in seconds.
+	Duration float64 `json:"duration"`
+	Status   string  `json:"status"`
+	Error    string  `json:"error,omitempty"`
+}
+
+// ReportSummary summarizes all items of the transfer report.
+type ReportSummary struct {
+	Items     int `json:"items"`
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+	Skipped   int `json:"skipped"`
+	// Bytes is the sum of bytes of succeeded items.
+	Bytes int64 `json:"bytes"`
+	// Duration of the whole command in seconds.
+	Duration float64 `json:"duration"`
+}
+
+// TransferReportFile is the content of the report file written by cp --report.
+type TransferReportFile struct {
+	Items   []ReportItem  `json:"items"`
+	Summary ReportSummary `json:"summary"`
+}
+
+// TransferReport collects results of transfers for the report file.
+// All methods can be called on nil report, which doesn't record anything.
+type TransferReport struct {
+	started time.Time
+
+	mu    sync.Mutex
+	items []ReportItem
+}
+
+// NewTransferReport creates an empty report, which measures duration from now.
+func NewTransferReport() *TransferReport {
+	return &TransferReport{started: time.Now()}
+}
+
+// Run runs transfer of source to destination and records its result.
+func (report *TransferReport) Run(source, destination string, transfer func() (bytes int64, err error)) (int64, error) {
+	start := time.Now()
+	bytes, err := transfer()
+
+	item := ReportItem{
+		Source:      source,
+		Destination: destination,
+		Bytes:       bytes,
+		Duration:    time.Since(start).Seconds(),
+		Status:      ReportStatusSucceeded,
+	}
+	if err != nil {
+		item.Status = ReportStatusFailed
+		item.Error = err.Error()
+	}
+	report.Add(item)
+
+	return bytes, err
+}
+
+// Add records a finished item.
+func (report *TransferReport) Add(item ReportItem) {
+	if report == nil {
+		return
+	}
+
+	report.mu.Lock()
+	defer report.mu.Unlock()
+
+	report.items = append(report.items, item)
+}
+
+// Skip records an item which didn't need to be transferred.
+func (report *TransferReport) Skip(source, destination string) {
+	report.Add(ReportItem{
+		Source:      source,
+		Destination: destination,
+		Status:      ReportStatusSkipped,
+	})
+}
+
+// File returns the report content with summary of all items recorded so far.
+func (report *TransferReport) File() TransferReportFile {
+	report.mu.Lock()
+	defer report.mu.Unlock()
+
+	file := TransferReportFile{
+		Items: append([]ReportItem{}, report.items...),
+		Summary: ReportSummary{
+			Items:    len(report.items),
+			Duration: time.Since(report.started).Seconds(),
+		},
+	}
+	for _, item := range report.items {
+		switch item.Status {
+		case ReportStatusFailed:
+			file.Summary.Failed++
+		case ReportStatusSkipped:
+			file.Summary.Skipped++
+		default:
+			file.Summary.Succeeded++
+			file.Summary.Bytes += item.Bytes
+		}
+	}
+
+	return file
+}
+
+// Write writes the report as JSON to path.
+func (report *TransferReport) Write(path string) error {
+	if report == nil {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(report.File(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, data, 0644)
+}
@@ -4,6 +4,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -13,45 +14,62 @@ import (
 )
 
 var (
-	rmEncryptedFlag *bool
-	rmPendingFlag   *bool
+	rmEncryptedFlag       *bool
+	rmPendingFlag         *bool
+	rmContinueOnErrorFlag *bool
 )
 
 func init() {
 	rmCmd := addCmd(&cobra.Command{
-		Use:   "rm sj://BUCKET/KEY",
-		Short: "Delete an object",
-		RunE:  deleteObject,
-		Args:  cobra.ExactArgs(1),
+		Use:   "rm sj://BUCKET/KEY [sj://BUCKET/KEY...]",
+		Short: "Delete objects",
+		RunE:  deleteObjects,
+		Args:  cobra.MinimumNArgs(1),
 	}, RootCmd)
 	rmEncryptedFlag = rmCmd.Flags().Bool("encrypted", false, "if true, treat paths as base64-encoded encrypted paths")
 	rmPendingFlag = rmCmd.Flags().Bool("pending", false, "if true, delete a pending object")
+	rmContinueOnErrorFlag = rmCmd.Flags().Bool("continue-on-error", false, "if true, keep deleting remaining objects when one fails and report all failures at the end")
 
 	setBasicFlags(rmCmd.Flags(), "pending")
 	setBasicFlags(rmCmd.Flags(), "encrypted")
 }
 
-func deleteObject(cmd *cobra.Command, args []string) error {
+func deleteObjects(cmd *cobra.Command, args []string) error {
 	ctx, _ := withTelemetry(cmd)
 
 	if len(args) == 0 {
 		return fmt.Errorf("no object specified for deletion")
 	}
 
-	dst, err := fpath.New(args[0])
+	project, err := cfg.getProject(ctx, *rmEncryptedFlag)
 	if err != nil {
 		return err
 	}
+	defer closeProject(project)
 
-	if dst.IsLocal() {
-		return fmt.Errorf("no bucket specified, use format sj://bucket/")
+	deletes := batch{continueOnError: *rmContinueOnErrorFlag}
+	for _, arg := range args {
+		arg := arg
+		err := deletes.Run(arg, func() error {
+			return deleteObject(ctx, project, arg)
+		})
+		if err != nil {
+			return err
+		}
 	}
 
-	project, err := cfg.getProject(ctx, *rmEncryptedFlag)
+	return deletes.Err()
+}
+
+func deleteObject(ctx context.Context, project *uplink.Project, path string) (err error) {
+	dst, err := fpath.New(path)
 	if err != nil {
 		return err
 	}
-	defer closeProject(project)
+
+	if dst.IsLocal() {
+		return fmt.Errorf("no bucket specified, use format sj://bucket/")
+	}
 
 	if *rmPendingFlag {
 		// TODO we may need a dedicated endpoint for deleting pending object streams
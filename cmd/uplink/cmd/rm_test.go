@@ -162,3 +162,91 @@ func committedObjectExists(ctx context.Context, satellite *testplanet.Satellite,
 	_, err := project.StatObject(ctx, bucketName, objectKey)
 	return err == nil
 }
+
+func TestRmContinueOnError(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 4,
+		UplinkCount:      1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		uplinkExe := ctx.Compile("storj.io/storj/cmd/uplink")
+
+		uplinkPeer := planet.Uplinks[0]
+		satellite := planet.Satellites[0]
+		project, err := uplinkPeer.GetProject(ctx, satellite)
+		require.NoError(t, err)
+		defer ctx.Check(project.Close)
+
+		// Configure uplink.
+		{
+			access := planet.Uplinks[0].Access[planet.Satellites[0].ID()]
+
+			accessString, err := access.Serialize()
+			require.NoError(t, err)
+
+			output, err := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"import",
+				accessString,
+			).CombinedOutput()
+			t.Log(string(output))
+			require.NoError(t, err)
+		}
+
+		bucketName := "testbucket"
+
+		err = uplinkPeer.CreateBucket(ctx, satellite, bucketName)
+		require.NoError(t, err)
+
+		for _, key := range []string{"first", "second", "third", "fourth"} {
+			err = uplinkPeer.Upload(ctx, satellite, bucketName, key, testrand.Bytes(memory.KiB))
+			require.NoError(t, err)
+		}
+
+		// Fail fast by default.
+		{
+			cmd := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"rm",
+				"sj://"+bucketName+"/first",
+				"local-path",
+				"sj://"+bucketName+"/second",
+			)
+			t.Log(cmd)
+
+			output, err := cmd.CombinedOutput()
+			t.Log(string(output))
+			require.Error(t, err)
+			require.Contains(t, string(output), "no bucket specified")
+
+			require.False(t, committedObjectExists(ctx, satellite, project, bucketName, "first"))
+			require.True(t, committedObjectExists(ctx, satellite, project, bucketName, "second"))
+		}
+
+		// Attempt all objects and summarize failures.
+		{
+			cmd := exec.Command(uplinkExe,
+				"--config-dir", ctx.Dir("uplink"),
+				"rm",
+				"--continue-on-error",
+				"sj://"+bucketName+"/second",
+				"local-path",
+				"sj://"+bucketName+"/third",
+				"other-local-path",
+				"sj://"+bucketName+"/fourth",
+			)
+			t.Log(cmd)
+
+			output, err := cmd.CombinedOutput()
+			t.Log(string(output))
+			require.Error(t, err)
+			require.Contains(t, string(output), "2 of 5 items failed")
+			require.Contains(t, string(output), "local-path: no bucket specified")
+			require.Contains(t, string(output), "other-local-path: no bucket specified")
+
+			for _, key := range []string{"second", "third", "fourth"} {
+				require.False(t, committedObjectExists(ctx, satellite, project, bucketName, key), key)
+			}
+		}
+	})
+}
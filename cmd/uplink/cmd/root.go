@@ -91,6 +91,11 @@ func (cliCfg *UplinkFlags) getProject(ctx context.Context, encryptionBypass bool
 		return nil, err
 	}
 
+	return cliCfg.openProject(ctx, access, encryptionBypass)
+}
+
+// openProject opens project using provided access and client configuration.
+func (cliCfg *UplinkFlags) openProject(ctx context.Context, access *uplink.Access, encryptionBypass bool) (_ *uplink.Project, err error) {
 	uplinkCfg := uplink.Config{}
 	uplinkCfg.UserAgent = cliCfg.Client.UserAgent
 	uplinkCfg.DialTimeout = cliCfg.Client.DialTimeout
@@ -0,0 +1,160 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/fpath"
+)
+
+// gzipMagic are the first bytes of gzip compressed data.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// TarEntryKey returns object key of the tar entry relative to the destination prefix.
+// Leading "/" and "./" are removed, entries with ".." are rejected, since they may escape the archive root.
+func TarEntryKey(name string) (string, error) {
+	if strings.Contains("/"+name+"/", "/../") {
+		return "", fmt.Errorf("tar entry %q contains \"..\"", name)
+	}
+
+	key := strings.TrimPrefix(path.Clean("/"+name), "/")
+	if key == "" {
+		return "", fmt.Errorf("tar entry %q has empty name", name)
+	}
+	return key, nil
+}
+
+// uploadTar uploads every regular file of the local tar archive, or tar stream from stdin, as a separate object
+// under the destination prefix, keeping its path in the archive. Gzip compressed archives are decompressed.
+// Directory entries are skipped, since prefixes don't need to be created. Symbolic links, hard links and
+// other special entries are skipped too, their targets are uploaded when the archive contains them.
+func uploadTar(ctx context.Context, src fpath.FPath, dst fpath.FPath, showProgress bool, report *TransferReport) (err error) {
+	if !strings.HasSuffix(dst.String(), "/") && dst.Path() != "" {
+		dst, err = fpath.New(dst.String() + "/")
+		if err != nil {
+			return err
+		}
+	}
+
+	expiration, err := parseExpiration()
+	if err != nil {
+		return err
+	}
+
+	customMetadata, err := parseMetadata()
+	if err != nil {
+		return err
+	}
+
+	var file *os.File
+	if src.Base() == "-" {
+		file = os.Stdin
+	} else {
+		file, err = os.Open(src.Path())
+		if err != nil {
+			return err
+		}
+		defer func() { err = errs.Combine(err, file.Close()) }()
+	}
+
+	source := bufio.NewReader(file)
+	archive := io.Reader(source)
+	if isGzip(source) {
+		decompressed, gzipErr := gzip.NewReader(source)
+		if gzipErr != nil {
+			return fmt.Errorf("invalid gzip compressed tar %s: %w", src, gzipErr)
+		}
+		defer func() { err = errs.Combine(err, decompressed.Close()) }()
+		archive = decompressed
+	}
+
+	project, err := cfg.getProject(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer closeProject(project)
+
+	quota := NewTransferQuota(maxTotalSize.Int64())
+	uploads := batch{continueOnError: *continueOnError}
+
+	reader := tar.NewReader(archive)
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read tar %s: %w", src, err)
+		}
+
+		entry := src.String() + ":" + header.Name
+
+		switch header.Typeflag {
+		case tar.TypeReg, tar.TypeRegA:
+		case tar.TypeDir:
+			continue
+		case tar.TypeSymlink, tar.TypeLink:
+			fmt.Fprintf(os.Stderr, "skipping link %s to %s\n", entry, header.Linkname)
+			continue
+		default:
+			fmt.Fprintf(os.Stderr, "skipping %s: not a regular file\n", entry)
+			continue
+		}
+
+		err = uploads.Run(entry, func() error {
+			key, err := TarEntryKey(header.Name)
+			if err != nil {
+				report.Add(ReportItem{Source: entry, Status: ReportStatusFailed, Error: err.Error()})
+				return err
+			}
+
+			entryDst := dst.Join(key)
+			_, err = report.Run(entry, entryDst.String(), func() (int64, error) {
+				if err := quota.Reserve(entry, header.Size); err != nil {
+					return 0, err
+				}
+
+				entryMetadata := customMetadata
+				if contentTypes != nil {
+					entryMetadata = withInferredContentType(entryMetadata, contentTypes, key)
+				}
+
+				err := uploadReader(ctx, project, entryDst, reader, header.Size, expiration, entryMetadata, resumeState{}, showProgress)
+				if err != nil {
+					quota.Release(header.Size)
+				}
+				return header.Size, err
+			})
+			return err
+		})
+		// exceeded quota stops the upload even with --continue-on-error.
+		if quotaErr := quota.Err(); quotaErr != nil {
+			return quotaErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return uploads.Err()
+}
+
+// isGzip returns true when the buffered data starts with gzip magic bytes.
+func isGzip(source *bufio.Reader) bool {
+	magic, err := source.Peek(len(gzipMagic))
+	return err == nil && bytes.Equal(magic, gzipMagic)
+}
@@ -30,5 +30,9 @@ func (c *cmdCp) Setup(a clingy.Arguments, f clingy.Flags) {
 }
 
 func (c *cmdCp) Execute(ctx clingy.Context) error {
+	if dryRun(ctx, "copy %s to %s", c.source, c.dest) {
+		return nil
+	}
+
 	return nil
 }
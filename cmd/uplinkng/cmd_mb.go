@@ -20,5 +20,9 @@ func (c *cmdMb) Setup(a clingy.Arguments, f clingy.Flags) {
 }
 
 func (c *cmdMb) Execute(ctx clingy.Context) error {
+	if dryRun(ctx, "create bucket %s", c.name) {
+		return nil
+	}
+
 	return nil
 }
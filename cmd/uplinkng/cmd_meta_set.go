@@ -0,0 +1,118 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/zeebo/clingy"
+	"github.com/zeebo/errs"
+
+	"storj.io/uplink"
+)
+
+type cmdMetaSet struct {
+	projectProvider
+
+	metadata []string
+	remove   []string
+
+	path string
+}
+
+func (c *cmdMetaSet) Setup(a clingy.Arguments, f clingy.Flags) {
+	c.projectProvider.Setup(a, f)
+
+	c.metadata = f.New("metadata", "Metadata entry to add or overwrite (key=value)", nil,
+		clingy.Repeated,
+	).([]string)
+	c.remove = f.New("remove", "Metadata entry to remove", nil,
+		clingy.Repeated,
+	).([]string)
+
+	c.path = a.New("path", "Path to object (sj://BUCKET/KEY)").(string)
+}
+
+func (c *cmdMetaSet) Execute(ctx clingy.Context) error {
+	bucket, key, err := parseObjectPath(c.path)
+	if err != nil {
+		return err
+	}
+
+	set, err := parseMetadataEntries(c.metadata)
+	if err != nil {
+		return err
+	}
+
+	if dryRun(ctx, "set metadata of %s", c.path) {
+		return nil
+	}
+
+	project, err := c.OpenProject(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = project.Close() }()
+
+	object, err := project.StatObject(ctx, bucket, key)
+	if errors.Is(err, uplink.ErrObjectNotFound) {
+		return errs.New("object %s not found", c.path)
+	} else if err != nil {
+		return err
+	}
+
+	metadata := updateMetadata(object.Custom, set, c.remove)
+
+	// uplink has no way to update the metadata of a committed object,
+	// so the object is copied in place with the updated metadata.
+	download, err := project.DownloadObject(ctx, bucket, key, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = download.Close() }()
+
+	upload, err := project.UploadObject(ctx, bucket, key, &uplink.UploadOptions{
+		Expires: object.System.Expires,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = upload.Abort() }()
+
+	if err := upload.SetCustomMetadata(ctx, metadata); err != nil {
+		return err
+	}
+	if _, err := io.Copy(upload, download); err != nil {
+		return err
+	}
+	return upload.Commit()
+}
+
+// parseMetadataEntries parses key=value metadata entries.
+func parseMetadataEntries(entries []string) (map[string]string, error) {
+	metadata := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		idx := strings.IndexByte(entry, '=')
+		if idx <= 0 {
+			return nil, errs.New("invalid metadata entry %q: must be key=value", entry)
+		}
+		metadata[entry[:idx]] = entry[idx+1:]
+	}
+	return metadata, nil
+}
+
+// updateMetadata returns a copy of the existing metadata with the entries set and removed.
+// Entries are removed after being set, so removal wins when a key is in both.
+func updateMetadata(existing uplink.CustomMetadata, set map[string]string, remove []string) uplink.CustomMetadata {
+	metadata := existing.Clone()
+	for key, value := range set {
+		metadata[key] = value
+	}
+	for _, key := range remove {
+		delete(metadata, key)
+	}
+	return metadata
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/uplink"
+)
+
+func TestUpdateMetadata(t *testing.T) {
+	existing := uplink.CustomMetadata{"color": "red", "size": "large"}
+
+	for _, tt := range []struct {
+		name     string
+		set      []string
+		remove   []string
+		expected uplink.CustomMetadata
+	}{
+		{
+			name:     "add",
+			set:      []string{"shape=round", "empty="},
+			expected: uplink.CustomMetadata{"color": "red", "size": "large", "shape": "round", "empty": ""},
+		},
+		{
+			name:     "overwrite",
+			set:      []string{"color=blue", "formula=a=b"},
+			expected: uplink.CustomMetadata{"color": "blue", "size": "large", "formula": "a=b"},
+		},
+		{
+			name:     "remove",
+			remove:   []string{"size", "missing"},
+			expected: uplink.CustomMetadata{"color": "red"},
+		},
+		{
+			name:     "remove wins over set",
+			set:      []string{"size=small"},
+			remove:   []string{"size"},
+			expected: uplink.CustomMetadata{"color": "red"},
+		},
+	} {
+		set, err := parseMetadataEntries(tt.set)
+		require.NoError(t, err, tt.name)
+
+		require.Equal(t, tt.expected, updateMetadata(existing, set, tt.remove), tt.name)
+	}
+
+	// existing metadata is not modified.
+	require.Equal(t, uplink.CustomMetadata{"color": "red", "size": "large"}, existing)
+}
+
+func TestParseMetadataEntriesInvalid(t *testing.T) {
+	for _, entry := range []string{"", "novalue", "=value"} {
+		_, err := parseMetadataEntries([]string{entry})
+		require.Error(t, err, entry)
+	}
+}
+
+func TestParseObjectPath(t *testing.T) {
+	bucket, key, err := parseObjectPath("sj://bucket/dir/key")
+	require.NoError(t, err)
+	require.Equal(t, "bucket", bucket)
+	require.Equal(t, "dir/key", key)
+
+	for _, path := range []string{"bucket/key", "sj://bucket", "sj://bucket/", "sj:///key"} {
+		_, _, err := parseObjectPath(path)
+		require.Error(t, err, path)
+	}
+}
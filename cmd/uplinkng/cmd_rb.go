@@ -28,5 +28,9 @@ func (c *cmdRb) Setup(a clingy.Arguments, f clingy.Flags) {
 }
 
 func (c *cmdRb) Execute(ctx clingy.Context) error {
+	if dryRun(ctx, "remove bucket %s", c.name) {
+		return nil
+	}
+
 	return nil
 }
@@ -33,5 +33,9 @@ func (c *cmdRm) Setup(a clingy.Arguments, f clingy.Flags) {
 }
 
 func (c *cmdRm) Execute(ctx clingy.Context) error {
+	if dryRun(ctx, "remove %s", c.path) {
+		return nil
+	}
+
 	return nil
 }
@@ -17,6 +17,7 @@ import (
 
 type globalFlags struct {
 	interactive  bool
+	dryRun       bool
 	configDir    string
 	oldConfigDir string
 
@@ -42,6 +43,11 @@ func (g *globalFlags) Setup(f clingy.Flags) {
 		clingy.Advanced,
 	).(bool)
 
+	g.dryRun = f.New(
+		"dry-run", "Print the actions mutating commands would perform without performing them", false,
+		clingy.Transform(strconv.ParseBool),
+	).(bool)
+
 	g.configDir = f.New(
 		"config-dir", "Directory that stores the configuration", appDir(false, "storj", "uplink"),
 	).(string)
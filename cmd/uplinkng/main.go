@@ -45,6 +45,7 @@ func main() {
 		c.New("rm", "Remove an object", new(cmdRm))
 		c.Group("meta", "Object metadata related commands", func() {
 			c.New("get", "Get an object's metadata", new(cmdMetaGet))
+			c.New("set", "Add, overwrite or remove an object's metadata entries", new(cmdMetaSet))
 		})
 		c.New("version", "Prints version information", new(cmdVersion))
 	})
@@ -4,10 +4,14 @@
 package main
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
+
+	"github.com/zeebo/clingy"
+	"github.com/zeebo/errs"
 )
 
 // appDir returns best base directory for the currently running operating system. It
@@ -55,3 +59,27 @@ func appDir(legacy bool, subdir ...string) string {
 	}
 	return filepath.Join(append([]string{appdir}, subdir...)...)
 }
+
+// dryRun reports if the --dry-run global flag is set. If it is, the action is printed
+// and the calling command must return without opening a project or mutating anything.
+func dryRun(ctx clingy.Context, format string, args ...interface{}) bool {
+	if !gf.dryRun {
+		return false
+	}
+	fmt.Fprintf(ctx, "(dry-run) "+format+"\n", args...)
+	return true
+}
+
+// parseObjectPath splits a sj://BUCKET/KEY path into the bucket and the key.
+func parseObjectPath(path string) (bucket, key string, err error) {
+	trimmed := strings.TrimPrefix(path, "sj://")
+	if trimmed == path {
+		return "", "", errs.New("invalid object path %q: must start with sj://", path)
+	}
+
+	idx := strings.IndexByte(trimmed, '/')
+	if idx <= 0 || idx == len(trimmed)-1 {
+		return "", "", errs.New("invalid object path %q: must be sj://BUCKET/KEY", path)
+	}
+	return trimmed[:idx], trimmed[idx+1:], nil
+}
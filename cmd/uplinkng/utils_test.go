@@ -0,0 +1,64 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zeebo/clingy"
+
+	"storj.io/uplink"
+)
+
+// testContext is a clingy.Context capturing the command output.
+type testContext struct {
+	context.Context
+
+	stdout bytes.Buffer
+	stderr bytes.Buffer
+}
+
+func (c *testContext) WithContext(ctx context.Context) clingy.Context {
+	c.Context = ctx
+	return c
+}
+
+func (c *testContext) Read(p []byte) (int, error)  { return 0, io.EOF }
+func (c *testContext) Write(p []byte) (int, error) { return c.stdout.Write(p) }
+
+func (c *testContext) Stdin() io.Reader  { return strings.NewReader("") }
+func (c *testContext) Stdout() io.Writer { return &c.stdout }
+func (c *testContext) Stderr() io.Writer { return &c.stderr }
+
+func TestDryRun(t *testing.T) {
+	defer func(dryRun bool) { gf.dryRun = dryRun }(gf.dryRun)
+	gf.dryRun = true
+
+	// any access to the project could mutate it, so dry-run must not open one.
+	provider := projectProvider{
+		openProject: func(ctx context.Context) (*uplink.Project, error) {
+			t.Fatal("project opened during dry-run")
+			return nil, nil
+		},
+	}
+
+	for _, tt := range []struct {
+		cmd    clingy.Cmd
+		output string
+	}{
+		{&cmdCp{projectProvider: provider, source: "file", dest: "sj://bucket/key"}, "(dry-run) copy file to sj://bucket/key\n"},
+		{&cmdRm{projectProvider: provider, path: "sj://bucket/key"}, "(dry-run) remove sj://bucket/key\n"},
+		{&cmdMb{projectProvider: provider, name: "sj://bucket"}, "(dry-run) create bucket sj://bucket\n"},
+		{&cmdRb{projectProvider: provider, name: "sj://bucket"}, "(dry-run) remove bucket sj://bucket\n"},
+	} {
+		ctx := &testContext{Context: context.Background()}
+		require.NoError(t, tt.cmd.Execute(ctx))
+		require.Equal(t, tt.output, ctx.stdout.String())
+	}
+}
@@ -11,7 +11,6 @@ import (
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 
-	"storj.io/common/storj"
 	"storj.io/storj/multinode/payouts"
 )
 
@@ -53,6 +52,25 @@ func (controller *Payouts) GetAllNodesTotalEarned(w http.ResponseWriter, r *http
 	}
 }
 
+// Metrics handles fleet payout metrics in Prometheus exposition format, served from the last snapshot.
+func (controller *Payouts) Metrics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Add("Content-Type", "text/plain; version=0.0.4")
+
+	if err = controller.service.WriteMetrics(w); err != nil {
+		if payouts.ErrSnapshotNotReady.Has(err) {
+			controller.serveError(w, http.StatusServiceUnavailable, ErrPayouts.Wrap(err))
+			return
+		}
+
+		controller.log.Error("failed to write payouts metrics", zap.Error(err))
+		return
+	}
+}
+
 // SatelliteEstimations handles nodes estimated earnings from satellite.
 func (controller *Payouts) SatelliteEstimations(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -68,7 +86,7 @@ func (controller *Payouts) SatelliteEstimations(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	satelliteID, err := storj.NodeIDFromString(id)
+	satelliteID, err := controller.service.ResolveSatellite(id)
 	if err != nil {
 		controller.serveError(w, http.StatusBadRequest, ErrPayouts.Wrap(err))
 		return
@@ -174,7 +192,7 @@ func (controller *Payouts) SatellitePeriodSummary(w http.ResponseWriter, r *http
 		return
 	}
 
-	satelliteID, err := storj.NodeIDFromString(id)
+	satelliteID, err := controller.service.ResolveSatellite(id)
 	if err != nil {
 		controller.serveError(w, http.StatusBadRequest, ErrPayouts.Wrap(err))
 		return
@@ -207,7 +225,7 @@ func (controller *Payouts) SatelliteSummary(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	satelliteID, err := storj.NodeIDFromString(id)
+	satelliteID, err := controller.service.ResolveSatellite(id)
 	if err != nil {
 		controller.serveError(w, http.StatusBadRequest, ErrPayouts.Wrap(err))
 		return
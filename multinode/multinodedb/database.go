@@ -91,6 +91,7 @@ func Open(ctx context.Context, log *zap.Logger, databaseURL string) (*DB, error)
 func (db *DB) Nodes() nodes.DB {
 	return &nodesdb{
 		methods: db,
+		db:      db.DB,
 	}
 }
 
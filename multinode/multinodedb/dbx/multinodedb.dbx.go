@@ -275,7 +275,13 @@ func newpgx(db *DB) *pgxDB {
 }
 
 func (obj *pgxDB) Schema() string {
-	return `CREATE TABLE nodes (
+	return `CREATE TABLE node_annotations (
+	node_id bytea NOT NULL,
+	note text NOT NULL,
+	updated_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( node_id )
+);
+CREATE TABLE nodes (
 	id bytea NOT NULL,
 	name text NOT NULL,
 	public_address text NOT NULL,
@@ -345,7 +351,13 @@ func newsqlite3(db *DB) *sqlite3DB {
 }
 
 func (obj *sqlite3DB) Schema() string {
-	return `CREATE TABLE nodes (
+	return `CREATE TABLE node_annotations (
+	node_id BLOB NOT NULL,
+	note TEXT NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( node_id )
+);
+CREATE TABLE nodes (
 	id BLOB NOT NULL,
 	name TEXT NOT NULL,
 	public_address TEXT NOT NULL,
@@ -503,6 +515,75 @@ func (f Node_ApiSecret_Field) value() interface{} {
 
 func (Node_ApiSecret_Field) _Column() string { return "api_secret" }
 
+type NodeAnnotation struct {
+	NodeId    []byte
+	Note      string
+	UpdatedAt time.Time
+}
+
+func (NodeAnnotation) _Table() string { return "node_annotations" }
+
+type NodeAnnotation_Update_Fields struct {
+	Note NodeAnnotation_Note_Field
+}
+
+type NodeAnnotation_NodeId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func NodeAnnotation_NodeId(v []byte) NodeAnnotation_NodeId_Field {
+	return NodeAnnotation_NodeId_Field{_set: true, _value: v}
+}
+
+func (f NodeAnnotation_NodeId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (NodeAnnotation_NodeId_Field) _Column() string { return "node_id" }
+
+type NodeAnnotation_Note_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func NodeAnnotation_Note(v string) NodeAnnotation_Note_Field {
+	return NodeAnnotation_Note_Field{_set: true, _value: v}
+}
+
+func (f NodeAnnotation_Note_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (NodeAnnotation_Note_Field) _Column() string { return "note" }
+
+type NodeAnnotation_UpdatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func NodeAnnotation_UpdatedAt(v time.Time) NodeAnnotation_UpdatedAt_Field {
+	return NodeAnnotation_UpdatedAt_Field{_set: true, _value: v}
+}
+
+func (f NodeAnnotation_UpdatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (NodeAnnotation_UpdatedAt_Field) _Column() string { return "updated_at" }
+
 func toUTC(t time.Time) time.Time {
 	return t.UTC()
 }
@@ -952,6 +1033,33 @@ func (obj *pgxImpl) Create_Node(ctx context.Context,
 
 }
 
+func (obj *pgxImpl) ReplaceNoReturn_NodeAnnotation(ctx context.Context,
+	node_annotation_node_id NodeAnnotation_NodeId_Field,
+	node_annotation_note NodeAnnotation_Note_Field) (
+	err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	__now := obj.db.Hooks.Now().UTC()
+	__node_id_val := node_annotation_node_id.value()
+	__note_val := node_annotation_note.value()
+	__updated_at_val := __now
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO node_annotations ( node_id, note, updated_at ) VALUES ( ?, ?, ? ) ON CONFLICT ( node_id ) DO UPDATE SET node_id = EXCLUDED.node_id, note = EXCLUDED.note, updated_at = EXCLUDED.updated_at")
+
+	var __values []interface{}
+	__values = append(__values, __node_id_val, __note_val, __updated_at_val)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	_, err = obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return obj.makeErr(err)
+	}
+	return nil
+
+}
+
 func (obj *pgxImpl) Get_Node_By_Id(ctx context.Context,
 	node_id Node_Id_Field) (
 	node *Node, err error) {
@@ -1006,6 +1114,38 @@ func (obj *pgxImpl) All_Node(ctx context.Context) (
 
 }
 
+func (obj *pgxImpl) All_NodeAnnotation(ctx context.Context) (
+	rows []*NodeAnnotation, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT node_annotations.node_id, node_annotations.note, node_annotations.updated_at FROM node_annotations")
+
+	var __values []interface{}
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	for __rows.Next() {
+		node_annotation := &NodeAnnotation{}
+		err = __rows.Scan(&node_annotation.NodeId, &node_annotation.Note, &node_annotation.UpdatedAt)
+		if err != nil {
+			return nil, obj.makeErr(err)
+		}
+		rows = append(rows, node_annotation)
+	}
+	if err := __rows.Err(); err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return rows, nil
+
+}
+
 func (obj *pgxImpl) Update_Node_By_Id(ctx context.Context,
 	node_id Node_Id_Field,
 	update Node_Update_Fields) (
@@ -1111,6 +1251,33 @@ func (obj *pgxImpl) Delete_Node_By_Id(ctx context.Context,
 
 }
 
+func (obj *pgxImpl) Delete_NodeAnnotation_By_NodeId(ctx context.Context,
+	node_annotation_node_id NodeAnnotation_NodeId_Field) (
+	deleted bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("DELETE FROM node_annotations WHERE node_annotations.node_id = ?")
+
+	var __values []interface{}
+	__values = append(__values, node_annotation_node_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__res, err := obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+
+	__count, err := __res.RowsAffected()
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+
+	return __count > 0, nil
+
+}
+
 func (impl pgxImpl) isConstraintError(err error) (
 	constraint string, ok bool) {
 	if e, ok := err.(*pgconn.PgError); ok {
@@ -1130,6 +1297,16 @@ func (obj *pgxImpl) deleteAll(ctx context.Context) (count int64, err error) {
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM node_annotations;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -1172,6 +1349,33 @@ func (obj *sqlite3Impl) Create_Node(ctx context.Context,
 
 }
 
+func (obj *sqlite3Impl) ReplaceNoReturn_NodeAnnotation(ctx context.Context,
+	node_annotation_node_id NodeAnnotation_NodeId_Field,
+	node_annotation_note NodeAnnotation_Note_Field) (
+	err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	__now := obj.db.Hooks.Now().UTC()
+	__node_id_val := node_annotation_node_id.value()
+	__note_val := node_annotation_note.value()
+	__updated_at_val := __now
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT OR REPLACE INTO node_annotations ( node_id, note, updated_at ) VALUES ( ?, ?, ? )")
+
+	var __values []interface{}
+	__values = append(__values, __node_id_val, __note_val, __updated_at_val)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	_, err = obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return obj.makeErr(err)
+	}
+	return nil
+
+}
+
 func (obj *sqlite3Impl) Get_Node_By_Id(ctx context.Context,
 	node_id Node_Id_Field) (
 	node *Node, err error) {
@@ -1226,6 +1430,38 @@ func (obj *sqlite3Impl) All_Node(ctx context.Context) (
 
 }
 
+func (obj *sqlite3Impl) All_NodeAnnotation(ctx context.Context) (
+	rows []*NodeAnnotation, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT node_annotations.node_id, node_annotations.note, node_annotations.updated_at FROM node_annotations")
+
+	var __values []interface{}
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.QueryContext(ctx, __stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	for __rows.Next() {
+		node_annotation := &NodeAnnotation{}
+		err = __rows.Scan(&node_annotation.NodeId, &node_annotation.Note, &node_annotation.UpdatedAt)
+		if err != nil {
+			return nil, obj.makeErr(err)
+		}
+		rows = append(rows, node_annotation)
+	}
+	if err := __rows.Err(); err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return rows, nil
+
+}
+
 func (obj *sqlite3Impl) Update_Node_By_Id(ctx context.Context,
 	node_id Node_Id_Field,
 	update Node_Update_Fields) (
@@ -1341,6 +1577,33 @@ func (obj *sqlite3Impl) Delete_Node_By_Id(ctx context.Context,
 
 }
 
+func (obj *sqlite3Impl) Delete_NodeAnnotation_By_NodeId(ctx context.Context,
+	node_annotation_node_id NodeAnnotation_NodeId_Field) (
+	deleted bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("DELETE FROM node_annotations WHERE node_annotations.node_id = ?")
+
+	var __values []interface{}
+	__values = append(__values, node_annotation_node_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__res, err := obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+
+	__count, err := __res.RowsAffected()
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+
+	return __count > 0, nil
+
+}
+
 func (obj *sqlite3Impl) getLastNode(ctx context.Context,
 	pk int64) (
 	node *Node, err error) {
@@ -1384,6 +1647,16 @@ func (obj *sqlite3Impl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM node_annotations;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -1445,6 +1718,15 @@ func (rx *Rx) All_Node(ctx context.Context) (
 	return tx.All_Node(ctx)
 }
 
+func (rx *Rx) All_NodeAnnotation(ctx context.Context) (
+	rows []*NodeAnnotation, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.All_NodeAnnotation(ctx)
+}
+
 func (rx *Rx) Create_Node(ctx context.Context,
 	node_id Node_Id_Field,
 	node_name Node_Name_Field,
@@ -1459,6 +1741,16 @@ func (rx *Rx) Create_Node(ctx context.Context,
 
 }
 
+func (rx *Rx) Delete_NodeAnnotation_By_NodeId(ctx context.Context,
+	node_annotation_node_id NodeAnnotation_NodeId_Field) (
+	deleted bool, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Delete_NodeAnnotation_By_NodeId(ctx, node_annotation_node_id)
+}
+
 func (rx *Rx) Delete_Node_By_Id(ctx context.Context,
 	node_id Node_Id_Field) (
 	deleted bool, err error) {
@@ -1479,6 +1771,18 @@ func (rx *Rx) Get_Node_By_Id(ctx context.Context,
 	return tx.Get_Node_By_Id(ctx, node_id)
 }
 
+func (rx *Rx) ReplaceNoReturn_NodeAnnotation(ctx context.Context,
+	node_annotation_node_id NodeAnnotation_NodeId_Field,
+	node_annotation_note NodeAnnotation_Note_Field) (
+	err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.ReplaceNoReturn_NodeAnnotation(ctx, node_annotation_node_id, node_annotation_note)
+
+}
+
 func (rx *Rx) UpdateNoReturn_Node_By_Id(ctx context.Context,
 	node_id Node_Id_Field,
 	update Node_Update_Fields) (
@@ -1505,6 +1809,9 @@ type Methods interface {
 	All_Node(ctx context.Context) (
 		rows []*Node, err error)
 
+	All_NodeAnnotation(ctx context.Context) (
+		rows []*NodeAnnotation, err error)
+
 	Create_Node(ctx context.Context,
 		node_id Node_Id_Field,
 		node_name Node_Name_Field,
@@ -1512,6 +1819,10 @@ type Methods interface {
 		node_api_secret Node_ApiSecret_Field) (
 		node *Node, err error)
 
+	Delete_NodeAnnotation_By_NodeId(ctx context.Context,
+		node_annotation_node_id NodeAnnotation_NodeId_Field) (
+		deleted bool, err error)
+
 	Delete_Node_By_Id(ctx context.Context,
 		node_id Node_Id_Field) (
 		deleted bool, err error)
@@ -1520,6 +1831,11 @@ type Methods interface {
 		node_id Node_Id_Field) (
 		node *Node, err error)
 
+	ReplaceNoReturn_NodeAnnotation(ctx context.Context,
+		node_annotation_node_id NodeAnnotation_NodeId_Field,
+		node_annotation_note NodeAnnotation_Note_Field) (
+		err error)
+
 	UpdateNoReturn_Node_By_Id(ctx context.Context,
 		node_id Node_Id_Field,
 		update Node_Update_Fields) (
@@ -1,5 +1,11 @@
 -- AUTOGENERATED BY storj.io/dbx
 -- DO NOT EDIT
+CREATE TABLE node_annotations (
+	node_id bytea NOT NULL,
+	note text NOT NULL,
+	updated_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( node_id )
+);
 CREATE TABLE nodes (
 	id bytea NOT NULL,
 	name text NOT NULL,
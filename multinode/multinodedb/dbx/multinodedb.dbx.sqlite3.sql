@@ -1,5 +1,11 @@
 -- AUTOGENERATED BY storj.io/dbx
 -- DO NOT EDIT
+CREATE TABLE node_annotations (
+	node_id BLOB NOT NULL,
+	note TEXT NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( node_id )
+);
 CREATE TABLE nodes (
 	id BLOB NOT NULL,
 	name TEXT NOT NULL,
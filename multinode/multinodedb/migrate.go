@@ -26,6 +26,19 @@ func (db *DB) SQLite3Migration() *migrate.Migration {
 					); `,
 				},
 			},
+			{
+				DB:          &db.migrationDB,
+				Description: "Add node annotations",
+				Version:     1,
+				Action: migrate.SQL{
+					`CREATE TABLE node_annotations (
+						node_id BLOB NOT NULL,
+						note TEXT NOT NULL,
+						updated_at TIMESTAMP NOT NULL,
+						PRIMARY KEY ( node_id )
+					);`,
+				},
+			},
 		},
 	}
 }
@@ -49,6 +62,19 @@ func (db *DB) PostgresMigration() *migrate.Migration {
 					);`,
 				},
 			},
+			{
+				DB:          &db.migrationDB,
+				Description: "Add node annotations",
+				Version:     1,
+				Action: migrate.SQL{
+					`CREATE TABLE node_annotations (
+						node_id bytea NOT NULL,
+						note text NOT NULL,
+						updated_at timestamp with time zone NOT NULL,
+						PRIMARY KEY ( node_id )
+					);`,
+				},
+			},
 		},
 	}
 }
@@ -7,6 +7,8 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/zeebo/errs"
 
@@ -27,6 +29,7 @@ var _ nodes.DB = (*nodesdb)(nil)
 // architecture: Database
 type nodesdb struct {
 	methods dbx.Methods
+	db      *dbx.DB
 }
 
 // List returns all connected nodes.
@@ -53,6 +56,108 @@ func (n *nodesdb) List(ctx context.Context) (allNodes []nodes.Node, err error) {
 	return allNodes, ErrNodesDB.Wrap(err)
 }
 
+// ListFiltered returns connected nodes matching the filter, empty list when there are none.
+func (n *nodesdb) ListFiltered(ctx context.Context, filter nodes.ListFilter) (_ []nodes.Node, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	conditions, args := filterConditions(filter)
+
+	query := `SELECT id, name, public_address, api_secret FROM nodes`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+
+	filtered, err := n.queryNodes(ctx, query, args...)
+	if err != nil {
+		return nil, ErrNodesDB.Wrap(err)
+	}
+
+	return filtered, nil
+}
+
+// ListPaged returns a page of connected nodes matching the filter, ordered by id.
+func (n *nodesdb) ListPaged(ctx context.Context, filter nodes.ListFilter, cursor nodes.Cursor) (_ nodes.Page, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if cursor.Limit <= 0 {
+		return nodes.Page{}, ErrNodesDB.New("invalid page limit %d: must be positive", cursor.Limit)
+	}
+
+	conditions, args := filterConditions(filter)
+	if !cursor.After.IsZero() {
+		conditions = append(conditions, "id > ?")
+		args = append(args, cursor.After.Bytes())
+	}
+
+	query := `SELECT id, name, public_address, api_secret FROM nodes`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	// one more node than the limit tells whether there is a following page.
+	query += ` ORDER BY id LIMIT ?`
+	args = append(args, cursor.Limit+1)
+
+	list, err := n.queryNodes(ctx, query, args...)
+	if err != nil {
+		return nodes.Page{}, ErrNodesDB.Wrap(err)
+	}
+
+	page := nodes.Page{Nodes: list}
+	if len(list) > cursor.Limit {
+		page.Nodes = list[:cursor.Limit]
+		page.Next = nodes.Cursor{After: page.Nodes[cursor.Limit-1].ID, Limit: cursor.Limit}
+		page.More = true
+	}
+
+	return page, nil
+}
+
+// filterConditions returns SQL conditions and their arguments matching nodes of the filter.
+func filterConditions(filter nodes.ListFilter) (conditions []string, args []interface{}) {
+	if len(filter.IDs) > 0 {
+		placeholders := make([]string, len(filter.IDs))
+		for i, id := range filter.IDs {
+			placeholders[i] = "?"
+			args = append(args, id.Bytes())
+		}
+		conditions = append(conditions, "id IN ("+strings.Join(placeholders, ", ")+")")
+	}
+
+	if filter.NamePrefix != "" {
+		// comparing substring instead of LIKE keeps the match case sensitive on all databases.
+		conditions = append(conditions, "substr(name, 1, ?) = ?")
+		args = append(args, utf8.RuneCountInString(filter.NamePrefix), filter.NamePrefix)
+	}
+
+	return conditions, args
+}
+
+// queryNodes returns nodes selected by the query, which has to select id, name, public_address and api_secret.
+func (n *nodesdb) queryNodes(ctx context.Context, query string, args ...interface{}) (_ []nodes.Node, err error) {
+	rows, err := n.db.QueryContext(ctx, n.db.Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	list := []nodes.Node{}
+	for rows.Next() {
+		var dbxNode dbx.Node
+		if err := rows.Scan(&dbxNode.Id, &dbxNode.Name, &dbxNode.PublicAddress, &dbxNode.ApiSecret); err != nil {
+			return nil, err
+		}
+
+		node, err := fromDBXNode(ctx, &dbxNode)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, node)
+	}
+
+	return list, rows.Err()
+}
+
 // Get return node from NodesDB by its id.
 func (n *nodesdb) Get(ctx context.Context, id storj.NodeID) (_ nodes.Node, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -89,6 +194,11 @@ func (n *nodesdb) Add(ctx context.Context, id storj.NodeID, apiSecret []byte, pu
 func (n *nodesdb) Remove(ctx context.Context, id storj.NodeID) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	_, err = n.methods.Delete_NodeAnnotation_By_NodeId(ctx, dbx.NodeAnnotation_NodeId(id.Bytes()))
+	if err != nil {
+		return ErrNodesDB.Wrap(err)
+	}
+
 	_, err = n.methods.Delete_Node_By_Id(ctx, dbx.Node_Id(id.Bytes()))
 
 	return ErrNodesDB.Wrap(err)
@@ -105,6 +215,50 @@ func (n *nodesdb) UpdateName(ctx context.Context, id storj.NodeID, name string)
 	return ErrNodesDB.Wrap(err)
 }
 
+// SetAnnotation sets operator note of the specified node, empty note removes it.
+func (n *nodesdb) SetAnnotation(ctx context.Context, id storj.NodeID, note string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if _, err := n.Get(ctx, id); err != nil {
+		return err
+	}
+
+	if note == "" {
+		_, err = n.methods.Delete_NodeAnnotation_By_NodeId(ctx, dbx.NodeAnnotation_NodeId(id.Bytes()))
+		return ErrNodesDB.Wrap(err)
+	}
+
+	err = n.methods.ReplaceNoReturn_NodeAnnotation(ctx, dbx.NodeAnnotation_NodeId(id.Bytes()), dbx.NodeAnnotation_Note(note))
+
+	return ErrNodesDB.Wrap(err)
+}
+
+// ListAnnotations returns notes of all annotated nodes by node id.
+func (n *nodesdb) ListAnnotations(ctx context.Context) (_ map[storj.NodeID]nodes.Annotation, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	dbxAnnotations, err := n.methods.All_NodeAnnotation(ctx)
+	if err != nil {
+		return nil, ErrNodesDB.Wrap(err)
+	}
+
+	annotations := make(map[storj.NodeID]nodes.Annotation, len(dbxAnnotations))
+	for _, dbxAnnotation := range dbxAnnotations {
+		id, err := storj.NodeIDFromBytes(dbxAnnotation.NodeId)
+		if err != nil {
+			return nil, ErrNodesDB.Wrap(err)
+		}
+
+		annotations[id] = nodes.Annotation{
+			NodeID:    id,
+			Note:      dbxAnnotation.Note,
+			UpdatedAt: dbxAnnotation.UpdatedAt.UTC(),
+		}
+	}
+
+	return annotations, nil
+}
+
 // fromDBXNode converts dbx.Node to console.Node.
 func fromDBXNode(ctx context.Context, node *dbx.Node) (_ nodes.Node, err error) {
 	defer mon.Task()(&ctx)(&err)
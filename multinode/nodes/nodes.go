@@ -5,6 +5,7 @@ package nodes
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/zeebo/errs"
@@ -20,12 +21,20 @@ type DB interface {
 	Get(ctx context.Context, id storj.NodeID) (Node, error)
 	// List returns all connected nodes.
 	List(ctx context.Context) ([]Node, error)
+	// ListFiltered returns connected nodes matching the filter, empty list when there are none.
+	ListFiltered(ctx context.Context, filter ListFilter) ([]Node, error)
+	// ListPaged returns a page of connected nodes matching the filter, ordered by id.
+	ListPaged(ctx context.Context, filter ListFilter, cursor Cursor) (Page, error)
 	// Add creates new node in NodesDB.
 	Add(ctx context.Context, id storj.NodeID, apiSecret []byte, publicAddress string) error
 	// Remove removed node from NodesDB.
 	Remove(ctx context.Context, id storj.NodeID) error
 	// UpdateName will update name of the specified node in database.
 	UpdateName(ctx context.Context, id storj.NodeID, name string) error
+	// SetAnnotation sets operator note of the specified node, empty note removes it.
+	SetAnnotation(ctx context.Context, id storj.NodeID, note string) error
+	// ListAnnotations returns notes of all annotated nodes by node id.
+	ListAnnotations(ctx context.Context) (map[storj.NodeID]Annotation, error)
 }
 
 // ErrNoNode is a special error type that indicates about absence of node in NodesDB.
@@ -40,6 +49,178 @@ type Node struct {
 	Name          string `json:"name"`
 }
 
+// Annotation is a note the operator attached to the node, e.g. "maintenance until Friday".
+type Annotation struct {
+	NodeID    storj.NodeID `json:"nodeId"`
+	Note      string       `json:"note"`
+	UpdatedAt time.Time    `json:"updatedAt"`
+}
+
+// ListFilter scopes listed nodes, empty fields match all nodes.
+type ListFilter struct {
+	// IDs limits nodes to the ones with given ids.
+	IDs storj.NodeIDList
+	// NamePrefix limits nodes to the ones whose name starts with the prefix, case sensitive.
+	NamePrefix string
+}
+
+// IsEmpty returns true when filter matches all nodes.
+func (filter ListFilter) IsEmpty() bool {
+	return len(filter.IDs) == 0 && filter.NamePrefix == ""
+}
+
+// Match returns true when node matches the filter.
+func (filter ListFilter) Match(node Node) bool {
+	if len(filter.IDs) > 0 {
+		found := false
+		for _, id := range filter.IDs {
+			if id == node.ID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return strings.HasPrefix(node.Name, filter.NamePrefix)
+}
+
+// Cursor points to a page of nodes ordered by id.
+type Cursor struct {
+	// After is the id of the last node of the previous page, zero id points to the first page.
+	After storj.NodeID
+	// Limit is the maximum number of nodes in the page.
+	Limit int
+}
+
+// Page contains nodes ordered by id.
+type Page struct {
+	Nodes []Node
+	// Next points to the following page, it's valid only when More is true.
+	Next Cursor
+	More bool
+}
+
+// Iterate calls fn for every connected node matching the filter, loading at most pageSize nodes at once.
+// Nodes are ordered by id. Iteration stops at the first error returned by fn.
+func Iterate(ctx context.Context, db DB, filter ListFilter, pageSize int, fn func(Node) error) error {
+	cursor := Cursor{Limit: pageSize}
+	for {
+		page, err := db.ListPaged(ctx, filter, cursor)
+		if err != nil {
+			return err
+		}
+
+		for _, node := range page.Nodes {
+			if err := fn(node); err != nil {
+				return err
+			}
+		}
+
+		if !page.More {
+			return nil
+		}
+		cursor = page.Next
+	}
+}
+
+// FindDuplicateIDs returns ids of nodes which are present in the list more than once.
+func FindDuplicateIDs(list []Node) storj.NodeIDList {
+	seen := make(map[storj.NodeID]int, len(list))
+	var duplicates storj.NodeIDList
+	for _, node := range list {
+		seen[node.ID]++
+		if seen[node.ID] == 2 {
+			duplicates = append(duplicates, node.ID)
+		}
+	}
+
+	return duplicates
+}
+
+// RemoveDuplicates returns list of nodes keeping only the first node with the same id.
+func RemoveDuplicates(list []Node) []Node {
+	seen := make(map[storj.NodeID]bool, len(list))
+	unique := make([]Node, 0, len(list))
+	for _, node := range list {
+		if seen[node.ID] {
+			continue
+		}
+
+		seen[node.ID] = true
+		unique = append(unique, node)
+	}
+
+	return unique
+}
+
+// GroupByAddress groups nodes with the same public address, which are served by the same process.
+// Groups and nodes within them are in the order of the first appearance.
+func GroupByAddress(list []Node) [][]Node {
+	var groups [][]Node
+	indexes := make(map[string]int, len(list))
+	for _, node := range list {
+		index, ok := indexes[node.PublicAddress]
+		if !ok {
+			index = len(groups)
+			indexes[node.PublicAddress] = index
+			groups = append(groups, nil)
+		}
+		groups[index] = append(groups[index], node)
+	}
+
+	return groups
+}
+
+// DuplicateIdentity is a node identity shared by node entries with different public addresses,
+// e.g. when an operator runs the same identity twice, which satellites penalize.
+type DuplicateIdentity struct {
+	ID        storj.NodeID `json:"id"`
+	Names     []string     `json:"names"`
+	Addresses []string     `json:"addresses"`
+}
+
+// FindDuplicateIdentities returns identities of nodes which are present in the list with different public addresses.
+// Entries with the same id and address are plain duplicates and are not reported.
+func FindDuplicateIdentities(list []Node) []DuplicateIdentity {
+	var identities []DuplicateIdentity
+	indexes := make(map[storj.NodeID]int, len(list))
+	for _, node := range list {
+		index, ok := indexes[node.ID]
+		if !ok {
+			index = len(identities)
+			indexes[node.ID] = index
+			identities = append(identities, DuplicateIdentity{ID: node.ID})
+		}
+
+		identity := &identities[index]
+		identity.Names = append(identity.Names, node.Name)
+		if !containsString(identity.Addresses, node.PublicAddress) {
+			identity.Addresses = append(identity.Addresses, node.PublicAddress)
+		}
+	}
+
+	var duplicates []DuplicateIdentity
+	for _, identity := range identities {
+		if len(identity.Addresses) > 1 {
+			duplicates = append(duplicates, identity)
+		}
+	}
+
+	return duplicates
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
 // NodeInfo contains basic node internal state.
 type NodeInfo struct {
 	ID            storj.NodeID `json:"id"`
@@ -63,3 +244,23 @@ type NodeInfoSatellite struct {
 	SuspensionScore float64      `json:"suspensionScore"`
 	TotalEarned     int64        `json:"totalEarned"`
 }
+
+// CredentialsStatus describes whether node accepted the api secret.
+type CredentialsStatus string
+
+const (
+	// CredentialsValid indicates that node accepted the api secret.
+	CredentialsValid CredentialsStatus = "valid"
+	// CredentialsInvalid indicates that node rejected the api secret.
+	CredentialsInvalid CredentialsStatus = "invalid"
+	// CredentialsUnreachable indicates that node could not be reached or failed to respond, so the api secret was not checked.
+	CredentialsUnreachable CredentialsStatus = "unreachable"
+)
+
+// NodeCredentials contains the result of the api secret check for a node.
+type NodeCredentials struct {
+	ID     storj.NodeID      `json:"id"`
+	Name   string            `json:"name"`
+	Status CredentialsStatus `json:"status"`
+	Error  string            `json:"error,omitempty"`
+}
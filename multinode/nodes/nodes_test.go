@@ -4,10 +4,14 @@
 package nodes_test
 
 import (
+	"context"
+	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/zeebo/errs"
 
+	"storj.io/common/storj"
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
 	"storj.io/storj/multinode"
@@ -59,3 +63,265 @@ func TestNodesDB(t *testing.T) {
 		assert.True(t, nodes.ErrNoNode.Has(err))
 	})
 }
+
+func TestNodesDBAnnotations(t *testing.T) {
+	multinodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db multinode.DB) {
+		nodesRepository := db.Nodes()
+
+		annotated, plain := testrand.NodeID(), testrand.NodeID()
+		assert.NoError(t, nodesRepository.Add(ctx, annotated, []byte("secret"), "127.0.0.1:8081"))
+		assert.NoError(t, nodesRepository.Add(ctx, plain, []byte("secret"), "127.0.0.1:8082"))
+
+		annotations, err := nodesRepository.ListAnnotations(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, annotations)
+
+		assert.NoError(t, nodesRepository.SetAnnotation(ctx, annotated, "maintenance until Friday"))
+		annotations, err = nodesRepository.ListAnnotations(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, annotations, 1)
+		assert.Equal(t, annotated, annotations[annotated].NodeID)
+		assert.Equal(t, "maintenance until Friday", annotations[annotated].Note)
+		assert.False(t, annotations[annotated].UpdatedAt.IsZero())
+
+		// note is replaced by the following one.
+		assert.NoError(t, nodesRepository.SetAnnotation(ctx, annotated, "disk replaced"))
+		annotations, err = nodesRepository.ListAnnotations(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, annotations, 1)
+		assert.Equal(t, "disk replaced", annotations[annotated].Note)
+
+		// unknown node can't be annotated.
+		err = nodesRepository.SetAnnotation(ctx, testrand.NodeID(), "note")
+		assert.Error(t, err)
+		assert.True(t, nodes.ErrNoNode.Has(err))
+
+		// empty note removes the annotation.
+		assert.NoError(t, nodesRepository.SetAnnotation(ctx, annotated, ""))
+		annotations, err = nodesRepository.ListAnnotations(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, annotations)
+
+		// removed node loses its annotation.
+		assert.NoError(t, nodesRepository.SetAnnotation(ctx, plain, "to be removed"))
+		assert.NoError(t, nodesRepository.Remove(ctx, plain))
+		annotations, err = nodesRepository.ListAnnotations(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, annotations)
+	})
+}
+
+func TestNodesDBListFiltered(t *testing.T) {
+	multinodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db multinode.DB) {
+		nodesRepository := db.Nodes()
+
+		euFirst, euSecond, us := testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+		for id, name := range map[storj.NodeID]string{
+			euFirst:  "eu-first",
+			euSecond: "eu-second",
+			us:       "us-first",
+		} {
+			assert.NoError(t, nodesRepository.Add(ctx, id, []byte("secret"), "127.0.0.1:8081"))
+			assert.NoError(t, nodesRepository.UpdateName(ctx, id, name))
+		}
+
+		listIDs := func(filter nodes.ListFilter) storj.NodeIDList {
+			list, err := nodesRepository.ListFiltered(ctx, filter)
+			assert.NoError(t, err)
+
+			var ids storj.NodeIDList
+			for _, node := range list {
+				assert.True(t, filter.Match(node))
+				ids = append(ids, node.ID)
+			}
+			return ids
+		}
+
+		assert.ElementsMatch(t, storj.NodeIDList{euFirst, euSecond, us}, listIDs(nodes.ListFilter{}))
+		assert.ElementsMatch(t, storj.NodeIDList{euFirst, euSecond}, listIDs(nodes.ListFilter{NamePrefix: "eu-"}))
+		assert.ElementsMatch(t, storj.NodeIDList{euSecond, us}, listIDs(nodes.ListFilter{IDs: storj.NodeIDList{euSecond, us}}))
+		assert.ElementsMatch(t, storj.NodeIDList{euSecond}, listIDs(nodes.ListFilter{IDs: storj.NodeIDList{euSecond, us}, NamePrefix: "eu-"}))
+
+		// prefix is case sensitive and nothing matching is not an error.
+		assert.Empty(t, listIDs(nodes.ListFilter{NamePrefix: "EU-"}))
+		assert.Empty(t, listIDs(nodes.ListFilter{IDs: storj.NodeIDList{testrand.NodeID()}}))
+	})
+}
+
+func TestNodesDBListPaged(t *testing.T) {
+	multinodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db multinode.DB) {
+		nodesRepository := db.Nodes()
+
+		var ids storj.NodeIDList
+		for i := 0; i < 5; i++ {
+			id := testrand.NodeID()
+			ids = append(ids, id)
+			assert.NoError(t, nodesRepository.Add(ctx, id, []byte("secret"), "127.0.0.1:8081"))
+			assert.NoError(t, nodesRepository.UpdateName(ctx, id, "eu-"+id.String()))
+		}
+		other := testrand.NodeID()
+		assert.NoError(t, nodesRepository.Add(ctx, other, []byte("secret"), "127.0.0.1:8081"))
+		assert.NoError(t, nodesRepository.UpdateName(ctx, other, "us"))
+		sort.Sort(ids)
+
+		var listed storj.NodeIDList
+		cursor := nodes.Cursor{Limit: 2}
+		for pages := 1; ; pages++ {
+			page, err := nodesRepository.ListPaged(ctx, nodes.ListFilter{NamePrefix: "eu-"}, cursor)
+			assert.NoError(t, err)
+			assert.LessOrEqual(t, len(page.Nodes), 2)
+			for _, node := range page.Nodes {
+				listed = append(listed, node.ID)
+			}
+			if !page.More {
+				assert.Equal(t, 3, pages)
+				break
+			}
+			cursor = page.Next
+		}
+		assert.Equal(t, ids, listed)
+
+		_, err := nodesRepository.ListPaged(ctx, nodes.ListFilter{}, nodes.Cursor{})
+		assert.Error(t, err)
+	})
+}
+
+// pagedNodesDB is a nodes.DB returning pages of a list of nodes ordered by id.
+type pagedNodesDB struct {
+	nodes.DB
+	list  []nodes.Node
+	pages int
+}
+
+func (db *pagedNodesDB) ListPaged(ctx context.Context, filter nodes.ListFilter, cursor nodes.Cursor) (nodes.Page, error) {
+	db.pages++
+
+	var page nodes.Page
+	for _, node := range db.list {
+		if !cursor.After.IsZero() && !cursor.After.Less(node.ID) {
+			continue
+		}
+		if len(page.Nodes) == cursor.Limit {
+			page.Next = nodes.Cursor{After: page.Nodes[len(page.Nodes)-1].ID, Limit: cursor.Limit}
+			page.More = true
+			break
+		}
+		page.Nodes = append(page.Nodes, node)
+	}
+	return page, nil
+}
+
+func TestIterate(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	var list []nodes.Node
+	for i := 0; i < 10; i++ {
+		list = append(list, nodes.Node{ID: testrand.NodeID()})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ID.Less(list[j].ID)
+	})
+
+	db := &pagedNodesDB{list: list}
+	var iterated []nodes.Node
+	err := nodes.Iterate(ctx, db, nodes.ListFilter{}, 3, func(node nodes.Node) error {
+		iterated = append(iterated, node)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, list, iterated)
+	assert.Equal(t, 4, db.pages)
+
+	// error returned by fn stops the iteration.
+	db = &pagedNodesDB{list: list}
+	calls := 0
+	err = nodes.Iterate(ctx, db, nodes.ListFilter{}, 3, func(node nodes.Node) error {
+		calls++
+		if calls == 4 {
+			return errs.New("stop")
+		}
+		return nil
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 4, calls)
+	assert.Equal(t, 2, db.pages)
+}
+
+func TestListFilterMatch(t *testing.T) {
+	node := nodes.Node{ID: testrand.NodeID(), Name: "eu-first"}
+
+	assert.True(t, nodes.ListFilter{}.IsEmpty())
+	assert.True(t, nodes.ListFilter{}.Match(node))
+	assert.True(t, nodes.ListFilter{NamePrefix: "eu-"}.Match(node))
+	assert.False(t, nodes.ListFilter{NamePrefix: "us-"}.Match(node))
+	assert.True(t, nodes.ListFilter{IDs: storj.NodeIDList{testrand.NodeID(), node.ID}}.Match(node))
+	assert.False(t, nodes.ListFilter{IDs: storj.NodeIDList{testrand.NodeID()}, NamePrefix: "eu-"}.Match(node))
+}
+
+func TestFindDuplicateIDs(t *testing.T) {
+	first, second, third := testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+
+	list := []nodes.Node{
+		{ID: first, Name: "first"},
+		{ID: second, Name: "second"},
+		{ID: first, Name: "first duplicate"},
+		{ID: third, Name: "third"},
+		{ID: first, Name: "first another duplicate"},
+	}
+
+	duplicates := nodes.FindDuplicateIDs(list)
+	assert.Equal(t, storj.NodeIDList{first}, duplicates)
+
+	unique := nodes.RemoveDuplicates(list)
+	assert.Len(t, unique, 3)
+	assert.Equal(t, "first", unique[0].Name)
+	assert.Equal(t, "second", unique[1].Name)
+	assert.Equal(t, "third", unique[2].Name)
+
+	assert.Empty(t, nodes.FindDuplicateIDs(unique))
+}
+
+func TestFindDuplicateIdentities(t *testing.T) {
+	shared, copied, unique := testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+
+	list := []nodes.Node{
+		{ID: shared, Name: "home", PublicAddress: "home.example.com:28967"},
+		{ID: unique, Name: "unique", PublicAddress: "unique.example.com:28967"},
+		// same identity on a different address.
+		{ID: shared, Name: "office", PublicAddress: "office.example.com:28967"},
+		// plain duplicate of the same node.
+		{ID: copied, Name: "copied", PublicAddress: "copied.example.com:28967"},
+		{ID: copied, Name: "copied again", PublicAddress: "copied.example.com:28967"},
+	}
+
+	assert.Equal(t, []nodes.DuplicateIdentity{
+		{
+			ID:        shared,
+			Names:     []string{"home", "office"},
+			Addresses: []string{"home.example.com:28967", "office.example.com:28967"},
+		},
+	}, nodes.FindDuplicateIdentities(list))
+
+	assert.Empty(t, nodes.FindDuplicateIdentities(nodes.RemoveDuplicates(list)))
+	assert.Empty(t, nodes.FindDuplicateIdentities(nil))
+}
+
+func TestGroupByAddress(t *testing.T) {
+	list := []nodes.Node{
+		{ID: testrand.NodeID(), Name: "first", PublicAddress: "10.0.0.1:28967"},
+		{ID: testrand.NodeID(), Name: "alone", PublicAddress: "10.0.0.2:28967"},
+		{ID: testrand.NodeID(), Name: "second", PublicAddress: "10.0.0.1:28967"},
+		// same host on another port is another process.
+		{ID: testrand.NodeID(), Name: "other port", PublicAddress: "10.0.0.1:28968"},
+	}
+
+	groups := nodes.GroupByAddress(list)
+	assert.Equal(t, [][]nodes.Node{
+		{list[0], list[2]},
+		{list[1]},
+		{list[3]},
+	}, groups)
+
+	assert.Empty(t, nodes.GroupByAddress(nil))
+}
@@ -12,6 +12,7 @@ import (
 	"go.uber.org/zap"
 
 	"storj.io/common/rpc"
+	"storj.io/common/rpc/rpcstatus"
 	"storj.io/common/storj"
 	"storj.io/storj/private/multinodepb"
 )
@@ -53,6 +54,12 @@ func (service *Service) UpdateName(ctx context.Context, id storj.NodeID, name st
 	return Error.Wrap(service.nodes.UpdateName(ctx, id, name))
 }
 
+// Annotate sets operator note of the node shown alongside its payouts, empty note removes it.
+func (service *Service) Annotate(ctx context.Context, id storj.NodeID, note string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return Error.Wrap(service.nodes.SetAnnotation(ctx, id, note))
+}
+
 // Get retrieves node by id.
 func (service *Service) Get(ctx context.Context, id storj.NodeID) (_ Node, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -235,6 +242,105 @@ func (service *Service) ListInfosSatellite(ctx context.Context, satelliteID stor
 	return infos, nil
 }
 
+// DetectDuplicateIdentities returns node identities used by multiple nodes with different public addresses,
+// which indicate a configuration error or the same identity running twice.
+func (service *Service) DetectDuplicateIdentities(ctx context.Context) (_ []DuplicateIdentity, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	nodes, err := service.nodes.List(ctx)
+	if err != nil {
+		if ErrNoNode.Has(err) {
+			return []DuplicateIdentity{}, nil
+		}
+		return nil, Error.Wrap(err)
+	}
+
+	duplicates := FindDuplicateIdentities(nodes)
+	for _, duplicate := range duplicates {
+		service.log.Warn("node identity is used with different addresses",
+			zap.Stringer("node", duplicate.ID),
+			zap.Strings("addresses", duplicate.Addresses),
+		)
+	}
+
+	return duplicates, nil
+}
+
+// CheckCredentials checks api secret of every node by performing a lightweight authenticated rpc.
+// Nodes that reject the secret are reported separately from the nodes that could not be reached.
+func (service *Service) CheckCredentials(ctx context.Context) (_ []NodeCredentials, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	nodes, err := service.nodes.List(ctx)
+	if err != nil {
+		if ErrNoNode.Has(err) {
+			return []NodeCredentials{}, nil
+		}
+		return nil, Error.Wrap(err)
+	}
+
+	credentials := make([]NodeCredentials, 0, len(nodes))
+	for _, node := range nodes {
+		err := service.checkCredentials(ctx, node)
+
+		credentials = append(credentials, NodeCredentials{
+			ID:     node.ID,
+			Name:   node.Name,
+			Status: credentialsStatus(err),
+			Error:  errorMessage(err),
+		})
+	}
+
+	return credentials, nil
+}
+
+// checkCredentials dials the node and calls its version endpoint to verify the api secret.
+func (service *Service) checkCredentials(ctx context.Context, node Node) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	conn, err := service.dialer.DialNodeURL(ctx, storj.NodeURL{
+		ID:      node.ID,
+		Address: node.PublicAddress,
+	})
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	defer func() {
+		err = errs.Combine(err, conn.Close())
+	}()
+
+	nodeClient := multinodepb.NewDRPCNodeClient(conn)
+
+	header := &multinodepb.RequestHeader{
+		ApiKey: node.APISecret,
+	}
+
+	_, err = nodeClient.Version(ctx, &multinodepb.VersionRequest{Header: header})
+	return Error.Wrap(err)
+}
+
+// credentialsStatus converts the result of the api secret check into credentials status.
+func credentialsStatus(err error) CredentialsStatus {
+	switch {
+	case err == nil:
+		return CredentialsValid
+	case rpcstatus.Code(err) == rpcstatus.Unauthenticated:
+		return CredentialsInvalid
+	default:
+		return CredentialsUnreachable
+	}
+}
+
+// errorMessage returns error message or empty string if there is no error.
+func errorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	return err.Error()
+}
+
 // TrustedSatellites returns list of unique trusted satellites node urls.
 func (service *Service) TrustedSatellites(ctx context.Context) (_ storj.NodeURLs, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -0,0 +1,93 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package nodes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/rpc"
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+)
+
+func TestCredentialsStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status CredentialsStatus
+	}{
+		{
+			name:   "valid",
+			err:    nil,
+			status: CredentialsValid,
+		},
+		{
+			name:   "invalid",
+			err:    Error.Wrap(rpcstatus.Wrap(rpcstatus.Unauthenticated, errs.New("api key not found"))),
+			status: CredentialsInvalid,
+		},
+		{
+			name:   "unreachable",
+			err:    Error.Wrap(rpc.Error.New("dial tcp 127.0.0.1:28967: connect: connection refused")),
+			status: CredentialsUnreachable,
+		},
+		{
+			name:   "internal error",
+			err:    Error.Wrap(rpcstatus.Wrap(rpcstatus.Internal, errs.New("database is locked"))),
+			status: CredentialsUnreachable,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.status, credentialsStatus(test.err))
+		})
+	}
+}
+
+// listedNodesDB is a DB returning a fixed list of nodes, which may contain duplicated ids.
+type listedNodesDB struct {
+	DB
+	list []Node
+}
+
+func (db *listedNodesDB) List(ctx context.Context) ([]Node, error) {
+	if len(db.list) == 0 {
+		return nil, ErrNoNode.New("no nodes")
+	}
+	return db.list, nil
+}
+
+func TestDetectDuplicateIdentities(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	id := testrand.NodeID()
+	db := &listedNodesDB{}
+	service := NewService(zaptest.NewLogger(t), rpc.Dialer{}, db)
+
+	duplicates, err := service.DetectDuplicateIdentities(ctx)
+	require.NoError(t, err)
+	require.Empty(t, duplicates)
+
+	db.list = []Node{
+		{ID: id, Name: "first", PublicAddress: "10.0.0.1:28967"},
+		{ID: testrand.NodeID(), Name: "other", PublicAddress: "10.0.0.3:28967"},
+		{ID: id, Name: "second", PublicAddress: "10.0.0.2:28967"},
+	}
+
+	duplicates, err = service.DetectDuplicateIdentities(ctx)
+	require.NoError(t, err)
+	require.Len(t, duplicates, 1)
+	require.Equal(t, id, duplicates[0].ID)
+	require.Equal(t, []string{"first", "second"}, duplicates[0].Names)
+	require.Equal(t, []string{"10.0.0.1:28967", "10.0.0.2:28967"}, duplicates[0].Addresses)
+}
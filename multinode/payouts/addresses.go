@@ -0,0 +1,83 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts
+
+import (
+	"context"
+	"net"
+)
+
+// AddressFamily is the address family preferred when dialing nodes.
+// Empty value leaves the choice to the system.
+type AddressFamily string
+
+const (
+	// AddressFamilyIPv4 prefers IPv4 node addresses.
+	AddressFamilyIPv4 = AddressFamily("ip4")
+	// AddressFamilyIPv6 prefers IPv6 node addresses.
+	AddressFamilyIPv6 = AddressFamily("ip6")
+)
+
+// String returns the address family.
+func (family AddressFamily) String() string {
+	return string(family)
+}
+
+// Set implements pflag.Value by parsing ip4, ip6 or empty value.
+func (family *AddressFamily) Set(value string) error {
+	switch AddressFamily(value) {
+	case "", AddressFamilyIPv4, AddressFamilyIPv6:
+		*family = AddressFamily(value)
+		return nil
+	default:
+		return Error.New("invalid address family %q: must be ip4, ip6 or empty", value)
+	}
+}
+
+// Type returns the type of the pflag.Value.
+func (family AddressFamily) Type() string {
+	return "address-family"
+}
+
+// matches returns whether ip belongs to the address family.
+func (family AddressFamily) matches(ip net.IP) bool {
+	if family == AddressFamilyIPv4 {
+		return ip.To4() != nil
+	}
+	return ip.To4() == nil
+}
+
+// hostResolver resolves host names, implemented by net.Resolver.
+type hostResolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// preferAddress resolves host of the node address and returns the address with the first ip of the
+// preferred family. The address is returned unchanged when there's no preference, it contains an ip already
+// or no ip of the preferred family was resolved, so that the system picks one when dialing.
+func preferAddress(ctx context.Context, resolver hostResolver, family AddressFamily, address string) (string, error) {
+	if family == "" {
+		return address, nil
+	}
+
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return "", Error.Wrap(err)
+	}
+	if net.ParseIP(host) != nil {
+		return address, nil
+	}
+
+	ips, err := resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return "", Error.Wrap(err)
+	}
+
+	for _, ip := range ips {
+		if family.matches(ip.IP) {
+			return net.JoinHostPort(ip.IP.String(), port), nil
+		}
+	}
+	return address, nil
+}
@@ -0,0 +1,128 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/identity/testidentity"
+	"storj.io/common/peertls/tlsopts"
+	"storj.io/common/rpc"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/multinode/nodes"
+)
+
+// fakeResolver resolves host names to predefined ips.
+type fakeResolver struct {
+	hosts map[string][]string
+}
+
+func (resolver *fakeResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	ips, ok := resolver.hosts[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+
+	var addrs []net.IPAddr
+	for _, ip := range ips {
+		addrs = append(addrs, net.IPAddr{IP: net.ParseIP(ip)})
+	}
+	return addrs, nil
+}
+
+func TestPreferAddress(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	resolver := &fakeResolver{hosts: map[string][]string{
+		"dual.example.test": {"2001:db8::1", "192.0.2.1", "192.0.2.2"},
+		"ip6.example.test":  {"2001:db8::2"},
+	}}
+
+	for _, tt := range []struct {
+		family   AddressFamily
+		address  string
+		expected string
+	}{
+		{"", "dual.example.test:28967", "dual.example.test:28967"},
+		{AddressFamilyIPv4, "dual.example.test:28967", "192.0.2.1:28967"},
+		{AddressFamilyIPv6, "dual.example.test:28967", "[2001:db8::1]:28967"},
+		// no address of the preferred family, system picks one.
+		{AddressFamilyIPv4, "ip6.example.test:28967", "ip6.example.test:28967"},
+		// ip addresses are dialed as they are.
+		{AddressFamilyIPv4, "[2001:db8::3]:28967", "[2001:db8::3]:28967"},
+	} {
+		address, err := preferAddress(ctx, resolver, tt.family, tt.address)
+		require.NoError(t, err)
+		require.Equal(t, tt.expected, address, "%q %s", tt.family, tt.address)
+	}
+
+	_, err := preferAddress(ctx, resolver, AddressFamilyIPv4, "unknown.example.test:28967")
+	require.Error(t, err)
+
+	_, err = preferAddress(ctx, resolver, AddressFamilyIPv4, "missing-port.example.test")
+	require.Error(t, err)
+}
+
+func TestAddressFamilySet(t *testing.T) {
+	var family AddressFamily
+	require.NoError(t, family.Set("ip4"))
+	require.Equal(t, AddressFamilyIPv4, family)
+	require.NoError(t, family.Set(""))
+	require.Equal(t, AddressFamily(""), family)
+	require.Error(t, family.Set("ipv4"))
+}
+
+// recordingConnector fails every dial, recording the dialed addresses.
+type recordingConnector struct {
+	dialed []string
+}
+
+func (connector *recordingConnector) DialContext(ctx context.Context, tlsconfig *tls.Config, address string) (rpc.ConnectorConn, error) {
+	connector.dialed = append(connector.dialed, address)
+	return nil, errors.New("unreachable")
+}
+
+func TestDialPreferredAddress(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	ident, err := testidentity.PregeneratedIdentity(0, storj.LatestIDVersion())
+	require.NoError(t, err)
+	tlsOptions, err := tlsopts.NewOptions(ident, tlsopts.Config{}, nil)
+	require.NoError(t, err)
+
+	resolver := &fakeResolver{hosts: map[string][]string{
+		"dual.example.test": {"2001:db8::1", "192.0.2.1"},
+	}}
+
+	for _, tt := range []struct {
+		family   AddressFamily
+		expected string
+	}{
+		{"", "dual.example.test:28967"},
+		{AddressFamilyIPv4, "192.0.2.1:28967"},
+		{AddressFamilyIPv6, "[2001:db8::1]:28967"},
+	} {
+		connector := &recordingConnector{}
+		dialer := rpc.NewDefaultDialer(tlsOptions)
+		dialer.Connector = connector
+
+		service := NewService(zaptest.NewLogger(t), dialer, &nodesDB{}, Config{AddressFamily: tt.family})
+		service.resolver = resolver
+
+		_, err := service.dial(ctx, nodes.Node{ID: testrand.NodeID(), PublicAddress: "dual.example.test:28967"})
+		require.Error(t, err)
+		require.Equal(t, []string{tt.expected}, connector.dialed, tt.family)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts
+
+import (
+	"sort"
+	"strings"
+
+	"storj.io/common/storj"
+	"storj.io/storj/private/multinodepb"
+)
+
+// SatelliteAliases maps legacy ids of renamed satellites to their canonical id, so that
+// payouts of the legacy and the new id are merged into a single satellite.
+type SatelliteAliases map[storj.NodeID]storj.NodeID
+
+// String formats the satellite aliases sorted by legacy id.
+func (aliases SatelliteAliases) String() string {
+	legacy := make(storj.NodeIDList, 0, len(aliases))
+	for id := range aliases {
+		legacy = append(legacy, id)
+	}
+	sort.Sort(legacy)
+
+	s := make([]string, 0, len(legacy))
+	for _, id := range legacy {
+		s = append(s, id.String()+"="+aliases[id].String())
+	}
+	return strings.Join(s, ",")
+}
+
+// Set implements pflag.Value by parsing a comma separated list of legacy id and canonical id pairs.
+func (aliases *SatelliteAliases) Set(value string) error {
+	var entries []string
+	if value != "" {
+		entries = strings.Split(value, ",")
+	}
+
+	toSet := make(SatelliteAliases, len(entries))
+	for _, entry := range entries {
+		idx := strings.IndexByte(entry, '=')
+		if idx < 0 {
+			return Error.New("invalid satellite alias %q: must be legacy-id=canonical-id", entry)
+		}
+
+		legacy, err := storj.NodeIDFromString(strings.TrimSpace(entry[:idx]))
+		if err != nil {
+			return Error.New("invalid legacy satellite id %q: %w", entry[:idx], err)
+		}
+		canonical, err := storj.NodeIDFromString(strings.TrimSpace(entry[idx+1:]))
+		if err != nil {
+			return Error.New("invalid canonical satellite id %q: %w", entry[idx+1:], err)
+		}
+		if legacy == canonical {
+			return Error.New("satellite %s can't be alias of itself", legacy)
+		}
+
+		toSet[legacy] = canonical
+	}
+
+	for legacy, canonical := range toSet {
+		if _, ok := toSet[canonical]; ok {
+			return Error.New("canonical satellite %s of %s is an alias itself", canonical, legacy)
+		}
+	}
+
+	*aliases = toSet
+	return nil
+}
+
+// Type returns the type of the pflag.Value.
+func (aliases SatelliteAliases) Type() string {
+	return "satellite-aliases"
+}
+
+// Canonical returns canonical id of the satellite, which is the id itself when it's not an alias.
+func (aliases SatelliteAliases) Canonical(satelliteID storj.NodeID) storj.NodeID {
+	if canonical, ok := aliases[satelliteID]; ok {
+		return canonical
+	}
+	return satelliteID
+}
+
+// IDs returns canonical id of the satellite followed by all its legacy ids, sorted.
+func (aliases SatelliteAliases) IDs(satelliteID storj.NodeID) storj.NodeIDList {
+	canonical := aliases.Canonical(satelliteID)
+
+	var legacy storj.NodeIDList
+	for id, target := range aliases {
+		if target == canonical {
+			legacy = append(legacy, id)
+		}
+	}
+	sort.Sort(legacy)
+
+	return append(storj.NodeIDList{canonical}, legacy...)
+}
+
+// MergeSummaries merges summaries of legacy ids into summaries of their canonical id and denomination.
+// Satellites are returned in order of the first appearance.
+func (aliases SatelliteAliases) MergeSummaries(summaries []SatelliteSummary) []SatelliteSummary {
+	type key struct {
+		satelliteID storj.NodeID
+		currency    string
+	}
+
+	var merged []SatelliteSummary
+	indexes := make(map[key]int)
+
+	for _, summary := range summaries {
+		summary.SatelliteID = aliases.Canonical(summary.SatelliteID)
+
+		k := key{satelliteID: summary.SatelliteID, currency: summary.Currency}
+		index, ok := indexes[k]
+		if !ok {
+			indexes[k] = len(merged)
+			merged = append(merged, summary)
+			continue
+		}
+
+		merged[index].Earned += summary.Earned
+		merged[index].Net += summary.Net
+	}
+
+	return merged
+}
+
+// CanonicalUndistributed returns copy of undistributed satellites with legacy ids replaced by canonical ids.
+func (aliases SatelliteAliases) CanonicalUndistributed(satellites []*multinodepb.UndistributedSatellite) []*multinodepb.UndistributedSatellite {
+	canonical := make([]*multinodepb.UndistributedSatellite, 0, len(satellites))
+	for _, satellite := range satellites {
+		canonical = append(canonical, &multinodepb.UndistributedSatellite{
+			Total:       satellite.Total,
+			SatelliteId: aliases.Canonical(satellite.SatelliteId),
+		})
+	}
+	return canonical
+}
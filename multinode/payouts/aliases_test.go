@@ -0,0 +1,89 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/storj"
+	"storj.io/common/testrand"
+	"storj.io/storj/multinode/payouts"
+	"storj.io/storj/private/multinodepb"
+)
+
+func TestSatelliteAliasesSet(t *testing.T) {
+	legacy, canonical := testrand.NodeID(), testrand.NodeID()
+
+	var aliases payouts.SatelliteAliases
+	require.NoError(t, aliases.Set(legacy.String()+" = "+canonical.String()))
+	require.Equal(t, payouts.SatelliteAliases{legacy: canonical}, aliases)
+
+	var parsed payouts.SatelliteAliases
+	require.NoError(t, parsed.Set(aliases.String()))
+	require.Equal(t, aliases, parsed)
+
+	require.NoError(t, aliases.Set(""))
+	require.Empty(t, aliases)
+
+	other := testrand.NodeID()
+	for _, invalid := range []string{
+		legacy.String(),
+		legacy.String() + "=satellite",
+		legacy.String() + "=" + legacy.String(),
+		// chained aliases are ambiguous.
+		legacy.String() + "=" + canonical.String() + "," + canonical.String() + "=" + other.String(),
+	} {
+		require.Error(t, aliases.Set(invalid), invalid)
+	}
+}
+
+func TestSatelliteAliasesIDs(t *testing.T) {
+	legacy, canonical, other := testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+	aliases := payouts.SatelliteAliases{legacy: canonical}
+
+	require.Equal(t, canonical, aliases.Canonical(legacy))
+	require.Equal(t, canonical, aliases.Canonical(canonical))
+	require.Equal(t, other, aliases.Canonical(other))
+
+	// both legacy and canonical id refer to the same satellite.
+	require.Equal(t, storj.NodeIDList{canonical, legacy}, aliases.IDs(legacy))
+	require.Equal(t, storj.NodeIDList{canonical, legacy}, aliases.IDs(canonical))
+	require.Equal(t, storj.NodeIDList{other}, aliases.IDs(other))
+	require.Equal(t, storj.NodeIDList{other}, payouts.SatelliteAliases(nil).IDs(other))
+}
+
+func TestSatelliteAliasesMerge(t *testing.T) {
+	legacy, canonical, other := testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+	aliases := payouts.SatelliteAliases{legacy: canonical}
+
+	summaries := payouts.GroupEarnedBySatellite([]*multinodepb.EarnedPerSatelliteResponse{
+		{EarnedSatellite: []*multinodepb.EarnedSatellite{
+			{SatelliteId: legacy, Total: 100, Net: 80},
+			{SatelliteId: other, Total: 50, Net: 50},
+		}},
+		{EarnedSatellite: []*multinodepb.EarnedSatellite{
+			{SatelliteId: canonical, Total: 200, Net: 150},
+		}},
+	})
+	require.Len(t, summaries, 3)
+
+	require.Equal(t, []payouts.SatelliteSummary{
+		{SatelliteID: canonical, Earned: 300, Net: 230, Currency: "USD"},
+		{SatelliteID: other, Earned: 50, Net: 50, Currency: "USD"},
+	}, aliases.MergeSummaries(summaries))
+
+	undistributed := []*multinodepb.UndistributedSatellite{
+		{SatelliteId: legacy, Total: 100},
+		{SatelliteId: canonical, Total: 200},
+	}
+	pending := payouts.PendingPayouts(aliases.CanonicalUndistributed(undistributed), 0)
+	require.Equal(t, []payouts.SatelliteUndistributed{
+		{SatelliteID: canonical, Undistributed: 300},
+	}, pending)
+
+	// the original list is not modified.
+	require.Equal(t, legacy, undistributed[0].SatelliteId)
+}
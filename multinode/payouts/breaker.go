@@ -0,0 +1,105 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts
+
+import (
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+)
+
+// ErrCircuitOpen is an error class for nodes which are not dialed because of repeated failures.
+var ErrCircuitOpen = errs.Class("circuit open")
+
+// CircuitBreakerConfig contains configurable values for node circuit breaker.
+type CircuitBreakerConfig struct {
+	FailureThreshold int           `help:"number of consecutive dial failures after which node is not dialed during cooldown, zero disables circuit breaker" default:"3"`
+	Cooldown         time.Duration `help:"how long node with open circuit is not dialed before it is probed again" default:"10m"`
+}
+
+// circuitBreaker stops dialing nodes which failed too many times in a row.
+// Once cooldown passes a single probe dial is allowed, its success closes the circuit and failure opens it again.
+type circuitBreaker struct {
+	config CircuitBreakerConfig
+	now    func() time.Time
+
+	mu       sync.Mutex
+	circuits map[storj.NodeID]*circuit
+}
+
+// circuit contains failure state of a single node.
+type circuit struct {
+	failures int
+	openedAt time.Time
+}
+
+// newCircuitBreaker creates new instance of circuitBreaker.
+func newCircuitBreaker(config CircuitBreakerConfig) *circuitBreaker {
+	return &circuitBreaker{
+		config:   config,
+		now:      time.Now,
+		circuits: make(map[storj.NodeID]*circuit),
+	}
+}
+
+// Allow returns true if node may be dialed. When cooldown of open circuit has passed,
+// the caller becomes the probe and other callers are rejected until the cooldown passes again.
+func (breaker *circuitBreaker) Allow(nodeID storj.NodeID) bool {
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+
+	circuit, ok := breaker.circuits[nodeID]
+	if !ok || !breaker.isOpen(circuit) {
+		return true
+	}
+
+	now := breaker.now()
+	if now.Sub(circuit.openedAt) < breaker.config.Cooldown {
+		return false
+	}
+
+	circuit.openedAt = now
+	return true
+}
+
+// Success closes circuit of the node.
+func (breaker *circuitBreaker) Success(nodeID storj.NodeID) {
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+
+	delete(breaker.circuits, nodeID)
+}
+
+// Failure records failed dial of the node, opening its circuit when threshold is reached.
+func (breaker *circuitBreaker) Failure(nodeID storj.NodeID) {
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+
+	c, ok := breaker.circuits[nodeID]
+	if !ok {
+		c = &circuit{}
+		breaker.circuits[nodeID] = c
+	}
+
+	c.failures++
+	if breaker.isOpen(c) {
+		c.openedAt = breaker.now()
+	}
+}
+
+// IsOpen returns true if node circuit is open.
+func (breaker *circuitBreaker) IsOpen(nodeID storj.NodeID) bool {
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+
+	circuit, ok := breaker.circuits[nodeID]
+	return ok && breaker.isOpen(circuit)
+}
+
+func (breaker *circuitBreaker) isOpen(circuit *circuit) bool {
+	return breaker.config.FailureThreshold > 0 && circuit.failures >= breaker.config.FailureThreshold
+}
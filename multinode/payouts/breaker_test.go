@@ -0,0 +1,73 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testrand"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	breaker := newCircuitBreaker(CircuitBreakerConfig{
+		FailureThreshold: 2,
+		Cooldown:         time.Minute,
+	})
+	breaker.now = func() time.Time { return now }
+
+	nodeID := testrand.NodeID()
+
+	// failures below threshold keep circuit closed.
+	breaker.Failure(nodeID)
+	require.False(t, breaker.IsOpen(nodeID))
+	require.True(t, breaker.Allow(nodeID))
+
+	// reaching threshold opens circuit.
+	breaker.Failure(nodeID)
+	require.True(t, breaker.IsOpen(nodeID))
+	require.False(t, breaker.Allow(nodeID))
+
+	// circuit stays open during cooldown.
+	now = now.Add(30 * time.Second)
+	require.False(t, breaker.Allow(nodeID))
+
+	// after cooldown only a single probe is allowed.
+	now = now.Add(time.Minute)
+	require.True(t, breaker.Allow(nodeID))
+	require.False(t, breaker.Allow(nodeID))
+
+	// failed probe opens circuit for another cooldown.
+	breaker.Failure(nodeID)
+	require.True(t, breaker.IsOpen(nodeID))
+	now = now.Add(30 * time.Second)
+	require.False(t, breaker.Allow(nodeID))
+
+	// successful probe closes circuit.
+	now = now.Add(time.Minute)
+	require.True(t, breaker.Allow(nodeID))
+	breaker.Success(nodeID)
+	require.False(t, breaker.IsOpen(nodeID))
+	require.True(t, breaker.Allow(nodeID))
+	require.True(t, breaker.Allow(nodeID))
+
+	// circuit is reopened only after threshold is reached again.
+	breaker.Failure(nodeID)
+	require.False(t, breaker.IsOpen(nodeID))
+}
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	breaker := newCircuitBreaker(CircuitBreakerConfig{})
+	nodeID := testrand.NodeID()
+
+	for i := 0; i < 10; i++ {
+		breaker.Failure(nodeID)
+	}
+
+	require.False(t, breaker.IsOpen(nodeID))
+	require.True(t, breaker.Allow(nodeID))
+}
@@ -0,0 +1,113 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts
+
+import (
+	"sync"
+	"time"
+
+	"storj.io/common/storj"
+)
+
+// SummaryCacheConfig contains configurable values for the cache of summary results.
+type SummaryCacheConfig struct {
+	TTL           time.Duration `help:"how long summaries of the current period and all time summaries are reused, zero disables the cache" default:"1m"`
+	HistoricalTTL time.Duration `help:"how long summaries of completed periods are reused, zero disables caching them" default:"1h"`
+}
+
+// summaryCacheKey identifies the summary method and its arguments.
+type summaryCacheKey struct {
+	method      string
+	period      string
+	satelliteID storj.NodeID
+}
+
+// summaryCacheEntry is a cached summary with its expiration.
+type summaryCacheEntry struct {
+	summary Summary
+	expires time.Time
+}
+
+// summaryCache reuses summaries requested repeatedly within their ttl. Completed periods don't change
+// anymore, so their summaries are kept longer than summaries of the current period.
+type summaryCache struct {
+	config SummaryCacheConfig
+	now    func() time.Time
+
+	mu      sync.Mutex
+	entries map[summaryCacheKey]summaryCacheEntry
+}
+
+// newSummaryCache creates new instance of summaryCache.
+func newSummaryCache(config SummaryCacheConfig) *summaryCache {
+	return &summaryCache{
+		config:  config,
+		now:     time.Now,
+		entries: make(map[summaryCacheKey]summaryCacheEntry),
+	}
+}
+
+// Get returns copy of the summary cached for the key, unless it has expired.
+func (cache *summaryCache) Get(key summaryCacheKey) (Summary, bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	entry, ok := cache.entries[key]
+	if !ok {
+		return Summary{}, false
+	}
+	if !cache.now().Before(entry.expires) {
+		delete(cache.entries, key)
+		return Summary{}, false
+	}
+
+	return copySummary(entry.summary), true
+}
+
+// Put caches copy of the summary for the key. Summaries with nodes which were not dialed are incomplete
+// and are not cached.
+func (cache *summaryCache) Put(key summaryCacheKey, summary Summary) {
+	ttl := cache.ttl(key.period)
+	if ttl <= 0 {
+		return
+	}
+	for _, node := range summary.NodeSummary {
+		if node.CircuitOpen {
+			return
+		}
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	now := cache.now()
+	// expired entries are dropped on insert, so keys which are never requested again don't accumulate.
+	for key, entry := range cache.entries {
+		if !now.Before(entry.expires) {
+			delete(cache.entries, key)
+		}
+	}
+
+	cache.entries[key] = summaryCacheEntry{
+		summary: copySummary(summary),
+		expires: now.Add(ttl),
+	}
+}
+
+// ttl returns ttl of summary of the period in YYYY-MM format, empty period is all time summary.
+func (cache *summaryCache) ttl(period string) time.Duration {
+	if cache.config.TTL <= 0 {
+		return 0
+	}
+	if period != "" && period < cache.now().UTC().Format("2006-01") {
+		return cache.config.HistoricalTTL
+	}
+	return cache.config.TTL
+}
+
+// copySummary returns summary with its own copy of node summaries.
+func copySummary(summary Summary) Summary {
+	summary.NodeSummary = append([]NodeSummary(nil), summary.NodeSummary...)
+	return summary
+}
@@ -0,0 +1,123 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/rpc"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+)
+
+func TestSummaryCacheHitMiss(t *testing.T) {
+	now := time.Date(2021, 7, 15, 12, 0, 0, 0, time.UTC)
+	cache := newSummaryCache(SummaryCacheConfig{TTL: time.Minute, HistoricalTTL: time.Hour})
+	cache.now = func() time.Time { return now }
+
+	satelliteID := testrand.NodeID()
+	key := summaryCacheKey{method: "NodesSatellitePeriodSummary", period: "2021-07", satelliteID: satelliteID}
+
+	_, ok := cache.Get(key)
+	require.False(t, ok)
+
+	summary := Summary{TotalPaid: 100}
+	summary.Add(10, 100, testrand.NodeID(), "node")
+	cache.Put(key, summary)
+
+	cached, ok := cache.Get(key)
+	require.True(t, ok)
+	require.Equal(t, summary, cached)
+
+	// cached summary is not shared with callers.
+	cached.NodeSummary[0].Paid = 0
+	cached, ok = cache.Get(key)
+	require.True(t, ok)
+	require.EqualValues(t, 100, cached.NodeSummary[0].Paid)
+
+	// arguments are part of the key.
+	_, ok = cache.Get(summaryCacheKey{method: "NodesSatellitePeriodSummary", period: "2021-06", satelliteID: satelliteID})
+	require.False(t, ok)
+	_, ok = cache.Get(summaryCacheKey{method: "NodesSatellitePeriodSummary", period: "2021-07", satelliteID: testrand.NodeID()})
+	require.False(t, ok)
+	_, ok = cache.Get(summaryCacheKey{method: "NodesPeriodSummary", period: "2021-07"})
+	require.False(t, ok)
+
+	// summary with nodes which were not dialed is not cached.
+	incomplete := summaryCacheKey{method: "NodesPeriodSummary", period: "2021-07"}
+	var partial Summary
+	partial.AddCircuitOpen(testrand.NodeID(), "failing")
+	cache.Put(incomplete, partial)
+	_, ok = cache.Get(incomplete)
+	require.False(t, ok)
+}
+
+func TestSummaryCacheTTL(t *testing.T) {
+	now := time.Date(2021, 7, 15, 12, 0, 0, 0, time.UTC)
+	cache := newSummaryCache(SummaryCacheConfig{TTL: time.Minute, HistoricalTTL: time.Hour})
+	cache.now = func() time.Time { return now }
+
+	current := summaryCacheKey{method: "NodesPeriodSummary", period: "2021-07"}
+	past := summaryCacheKey{method: "NodesPeriodSummary", period: "2021-06"}
+	allTime := summaryCacheKey{method: "NodesSummary"}
+
+	require.Equal(t, time.Minute, cache.ttl(current.period))
+	require.Equal(t, time.Hour, cache.ttl(past.period))
+	require.Equal(t, time.Minute, cache.ttl(allTime.period))
+
+	for _, key := range []summaryCacheKey{current, past, allTime} {
+		cache.Put(key, Summary{TotalPaid: 1})
+	}
+
+	// current period and all time summaries expire first.
+	now = now.Add(time.Minute)
+	_, ok := cache.Get(current)
+	require.False(t, ok)
+	_, ok = cache.Get(allTime)
+	require.False(t, ok)
+	_, ok = cache.Get(past)
+	require.True(t, ok)
+
+	now = now.Add(time.Hour)
+	_, ok = cache.Get(past)
+	require.False(t, ok)
+
+	// zero ttl disables the cache.
+	disabled := newSummaryCache(SummaryCacheConfig{})
+	disabled.Put(past, Summary{TotalPaid: 1})
+	_, ok = disabled.Get(past)
+	require.False(t, ok)
+}
+
+func TestServiceCachedSummary(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	service := NewService(zaptest.NewLogger(t), rpc.Dialer{}, &nodesDB{}, Config{
+		SummaryCache: SummaryCacheConfig{TTL: time.Minute, HistoricalTTL: time.Hour},
+	})
+
+	var collected int
+	summarize := func() (Summary, error) {
+		collected++
+		return Summary{TotalPaid: int64(collected)}, nil
+	}
+
+	key := summaryCacheKey{method: "NodesSatellitePeriodSummary", period: "2021-05", satelliteID: testrand.NodeID()}
+	for i := 0; i < 3; i++ {
+		summary, err := service.cachedSummary(ctx, key, summarize)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, summary.TotalPaid)
+	}
+	require.Equal(t, 1, collected)
+
+	// other arguments miss the cache.
+	summary, err := service.cachedSummary(ctx, summaryCacheKey{method: "NodesSatellitePeriodSummary", period: "2021-06"}, summarize)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, summary.TotalPaid)
+}
@@ -0,0 +1,63 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts
+
+import (
+	"context"
+	"sync"
+
+	"storj.io/common/rpc"
+)
+
+// connectionLimiter bounds amount of simultaneously open node connections.
+// Zero capacity means connections are not limited.
+type connectionLimiter struct {
+	slots chan struct{}
+}
+
+// newConnectionLimiter creates new instance of connectionLimiter.
+func newConnectionLimiter(capacity int) *connectionLimiter {
+	limiter := &connectionLimiter{}
+	if capacity > 0 {
+		limiter.slots = make(chan struct{}, capacity)
+	}
+	return limiter
+}
+
+// Acquire waits for a free connection slot or until ctx is canceled.
+func (limiter *connectionLimiter) Acquire(ctx context.Context) error {
+	if limiter.slots == nil {
+		return nil
+	}
+
+	select {
+	case limiter.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Release frees connection slot.
+func (limiter *connectionLimiter) Release() {
+	if limiter.slots == nil {
+		return
+	}
+	<-limiter.slots
+}
+
+// limitedConn is a node connection which frees its limiter slot on close.
+type limitedConn struct {
+	*rpc.Conn
+
+	once    sync.Once
+	limiter *connectionLimiter
+}
+
+// Close closes the connection and releases its slot.
+func (conn *limitedConn) Close() (err error) {
+	err = conn.Conn.Close()
+	conn.once.Do(conn.limiter.Release)
+	return err
+}
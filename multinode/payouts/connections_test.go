@@ -0,0 +1,79 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+)
+
+func TestConnectionLimiter(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	const capacity = 3
+	limiter := newConnectionLimiter(capacity)
+
+	var open, maxOpen int64
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := limiter.Acquire(ctx); err != nil {
+				t.Error(err)
+				return
+			}
+			defer limiter.Release()
+
+			current := atomic.AddInt64(&open, 1)
+			for {
+				max := atomic.LoadInt64(&maxOpen)
+				if current <= max || atomic.CompareAndSwapInt64(&maxOpen, max, current) {
+					break
+				}
+			}
+
+			time.Sleep(time.Millisecond)
+			atomic.AddInt64(&open, -1)
+		}()
+	}
+	wg.Wait()
+
+	require.LessOrEqual(t, maxOpen, int64(capacity))
+	require.Positive(t, maxOpen)
+}
+
+func TestConnectionLimiterCanceled(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	limiter := newConnectionLimiter(1)
+	require.NoError(t, limiter.Acquire(ctx))
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	require.ErrorIs(t, limiter.Acquire(canceled), context.Canceled)
+
+	limiter.Release()
+	require.NoError(t, limiter.Acquire(ctx))
+}
+
+func TestConnectionLimiterUnlimited(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	limiter := newConnectionLimiter(0)
+	for i := 0; i < 100; i++ {
+		require.NoError(t, limiter.Acquire(ctx))
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts
+
+import (
+	"storj.io/common/rpc"
+	"storj.io/common/storj"
+	"storj.io/storj/multinode/nodes"
+)
+
+// Dialers returns dialer overriding the shared dialer for the node, e.g. for node reached via a relay,
+// which requires different TLS options or identity. ok is false when the node uses the shared dialer.
+type Dialers func(node nodes.Node) (dialer rpc.Dialer, ok bool)
+
+// DialerMap returns Dialers overriding the shared dialer of nodes in the map.
+func DialerMap(dialers map[storj.NodeID]rpc.Dialer) Dialers {
+	return func(node nodes.Node) (rpc.Dialer, bool) {
+		dialer, ok := dialers[node.ID]
+		return dialer, ok
+	}
+}
+
+// SetDialers sets per node dialer overrides, nodes without override use the shared dialer.
+// It has to be called before the service is used.
+func (service *Service) SetDialers(dialers Dialers) {
+	service.dialers = dialers
+}
+
+// nodeDialer returns dialer of the node, which is the override when set or the shared dialer.
+func (service *Service) nodeDialer(node nodes.Node) rpc.Dialer {
+	if service.dialers != nil {
+		if dialer, ok := service.dialers(node); ok {
+			return dialer
+		}
+	}
+	return service.dialer
+}
@@ -0,0 +1,76 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/identity/testidentity"
+	"storj.io/common/peertls/tlsopts"
+	"storj.io/common/rpc"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/multinode/nodes"
+)
+
+func TestDialOverrideDialer(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	newDialer := func(identityIndex int, connector rpc.Connector) rpc.Dialer {
+		tlsOptions, err := tlsopts.NewOptions(testidentity.MustPregeneratedIdentity(identityIndex, storj.LatestIDVersion()), tlsopts.Config{}, nil)
+		require.NoError(t, err)
+
+		dialer := rpc.NewDefaultDialer(tlsOptions)
+		dialer.Connector = connector
+		return dialer
+	}
+
+	shared, relay := &recordingConnector{}, &recordingConnector{}
+
+	relayed := nodes.Node{ID: testrand.NodeID(), PublicAddress: "192.0.2.1:28967"}
+	direct := []nodes.Node{
+		{ID: testrand.NodeID(), PublicAddress: "192.0.2.2:28967"},
+		{ID: testrand.NodeID(), PublicAddress: "192.0.2.3:28967"},
+	}
+
+	service := NewService(zaptest.NewLogger(t), newDialer(0, shared), &nodesDB{}, Config{})
+	service.SetDialers(DialerMap(map[storj.NodeID]rpc.Dialer{
+		relayed.ID: newDialer(1, relay),
+	}))
+
+	for _, node := range append([]nodes.Node{relayed}, direct...) {
+		_, err := service.dial(ctx, node)
+		require.Error(t, err)
+	}
+
+	require.Equal(t, []string{"192.0.2.1:28967"}, relay.dialed)
+	require.Equal(t, []string{"192.0.2.2:28967", "192.0.2.3:28967"}, shared.dialed)
+}
+
+func TestDialSharedDialerWithoutOverrides(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	tlsOptions, err := tlsopts.NewOptions(testidentity.MustPregeneratedIdentity(0, storj.LatestIDVersion()), tlsopts.Config{}, nil)
+	require.NoError(t, err)
+
+	connector := &recordingConnector{}
+	dialer := rpc.NewDefaultDialer(tlsOptions)
+	dialer.Connector = connector
+
+	service := NewService(zaptest.NewLogger(t), dialer, &nodesDB{}, Config{})
+	// resolver without override of the node falls back to the shared dialer.
+	service.SetDialers(func(node nodes.Node) (rpc.Dialer, bool) {
+		return rpc.Dialer{}, false
+	})
+
+	_, err = service.dial(ctx, nodes.Node{ID: testrand.NodeID(), PublicAddress: "192.0.2.1:28967"})
+	require.Error(t, err)
+	require.Equal(t, []string{"192.0.2.1:28967"}, connector.dialed)
+}
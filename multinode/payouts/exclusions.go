@@ -0,0 +1,91 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts
+
+import (
+	"strings"
+
+	"storj.io/common/storj"
+	"storj.io/storj/private/multinodepb"
+)
+
+// SatelliteIDs is used to hold a list of satellite ids, typically for the excluded satellites.
+type SatelliteIDs []storj.NodeID
+
+// String formats the satellite ids.
+func (ids SatelliteIDs) String() string {
+	s := make([]string, 0, len(ids))
+	for _, id := range ids {
+		s = append(s, id.String())
+	}
+	return strings.Join(s, ",")
+}
+
+// Set implements pflag.Value by parsing a comma separated list of satellite ids.
+func (ids *SatelliteIDs) Set(value string) error {
+	var entries []string
+	if value != "" {
+		entries = strings.Split(value, ",")
+	}
+
+	var toSet []storj.NodeID
+	for _, entry := range entries {
+		id, err := storj.NodeIDFromString(strings.TrimSpace(entry))
+		if err != nil {
+			return Error.New("invalid satellite id %q: %w", entry, err)
+		}
+		toSet = append(toSet, id)
+	}
+
+	*ids = toSet
+	return nil
+}
+
+// Type returns the type of the pflag.Value.
+func (ids SatelliteIDs) Type() string {
+	return "satellite-ids"
+}
+
+// Contains returns true if ids contain the satellite id.
+func (ids SatelliteIDs) Contains(satelliteID storj.NodeID) bool {
+	for _, id := range ids {
+		if id == satelliteID {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterEarned returns earned satellites, which are not contained in ids.
+func (ids SatelliteIDs) FilterEarned(satellites []*multinodepb.EarnedSatellite) []*multinodepb.EarnedSatellite {
+	var included []*multinodepb.EarnedSatellite
+	for _, satellite := range satellites {
+		if !ids.Contains(satellite.SatelliteId) {
+			included = append(included, satellite)
+		}
+	}
+	return included
+}
+
+// FilterUndistributed returns undistributed satellites, which are not contained in ids.
+func (ids SatelliteIDs) FilterUndistributed(satellites []*multinodepb.UndistributedSatellite) []*multinodepb.UndistributedSatellite {
+	var included []*multinodepb.UndistributedSatellite
+	for _, satellite := range satellites {
+		if !ids.Contains(satellite.SatelliteId) {
+			included = append(included, satellite)
+		}
+	}
+	return included
+}
+
+// FilterHeldRates returns held rates of satellites, which are not contained in ids.
+func (ids SatelliteIDs) FilterHeldRates(rates []*multinodepb.HeldRatesResponse_HeldRate) []*multinodepb.HeldRatesResponse_HeldRate {
+	var included []*multinodepb.HeldRatesResponse_HeldRate
+	for _, rate := range rates {
+		if !ids.Contains(rate.SatelliteId) {
+			included = append(included, rate)
+		}
+	}
+	return included
+}
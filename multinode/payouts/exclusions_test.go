@@ -0,0 +1,91 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/rpc"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/multinode/payouts"
+	"storj.io/storj/private/multinodepb"
+)
+
+func TestSatelliteIDsFlag(t *testing.T) {
+	first, second := testrand.NodeID(), testrand.NodeID()
+
+	var ids payouts.SatelliteIDs
+	require.NoError(t, ids.Set(first.String()+", "+second.String()))
+	require.Equal(t, payouts.SatelliteIDs{first, second}, ids)
+	require.Equal(t, first.String()+","+second.String(), ids.String())
+
+	require.NoError(t, ids.Set(""))
+	require.Empty(t, ids)
+
+	require.Error(t, ids.Set("invalid"))
+}
+
+func TestExcludedSatellitesFiltered(t *testing.T) {
+	included, excluded := testrand.NodeID(), testrand.NodeID()
+	ids := payouts.SatelliteIDs{excluded}
+
+	earned := ids.FilterEarned([]*multinodepb.EarnedSatellite{
+		{SatelliteId: excluded, Total: 100},
+		{SatelliteId: included, Total: 50},
+		{SatelliteId: excluded, Total: 10},
+	})
+	require.Len(t, earned, 1)
+	require.Equal(t, included, earned[0].SatelliteId)
+
+	summaries := payouts.GroupEarnedBySatellite([]*multinodepb.EarnedPerSatelliteResponse{{EarnedSatellite: earned}})
+	require.Len(t, summaries, 1)
+	require.Equal(t, included, summaries[0].SatelliteID)
+
+	undistributed := ids.FilterUndistributed([]*multinodepb.UndistributedSatellite{
+		{SatelliteId: included, Total: 100},
+		{SatelliteId: excluded, Total: 200},
+	})
+	pending := payouts.PendingPayouts(undistributed, 0)
+	require.Len(t, pending, 1)
+	require.Equal(t, included, pending[0].SatelliteID)
+}
+
+func TestExcludedSatelliteSummary(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	excluded := testrand.NodeID()
+	service := payouts.NewService(zaptest.NewLogger(t), rpc.Dialer{}, nil, payouts.Config{
+		ExcludedSatellites: payouts.SatelliteIDs{excluded},
+	})
+
+	// excluded satellite takes precedence over requested one, nodes are not even listed.
+	summary, err := service.NodesSatelliteSummary(ctx, excluded)
+	require.NoError(t, err)
+	require.Empty(t, summary.NodeSummary)
+
+	summary, err = service.NodesSatellitePeriodSummary(ctx, excluded, "2021-05")
+	require.NoError(t, err)
+	require.Empty(t, summary.NodeSummary)
+
+	estimation, err := service.NodesSatelliteEstimations(ctx, excluded)
+	require.NoError(t, err)
+	require.Zero(t, estimation.EstimatedEarnings)
+	require.Empty(t, estimation.NodeErrors)
+
+	// legacy id of the excluded satellite is excluded as well.
+	legacy := testrand.NodeID()
+	service = payouts.NewService(zaptest.NewLogger(t), rpc.Dialer{}, nil, payouts.Config{
+		ExcludedSatellites: payouts.SatelliteIDs{excluded},
+		SatelliteAliases:   payouts.SatelliteAliases{legacy: excluded},
+	})
+
+	summary, err = service.NodesSatelliteSummary(ctx, legacy)
+	require.NoError(t, err)
+	require.Empty(t, summary.NodeSummary)
+}
@@ -0,0 +1,260 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts
+
+import (
+	"context"
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	_ "modernc.org/sqlite" // used by WriteSQLite, pure Go driver doesn't need cgo.
+
+	"storj.io/common/storj"
+	"storj.io/storj/multinode/nodes"
+)
+
+// ExportMode defines what happens with an existing export database.
+type ExportMode string
+
+const (
+	// ExportOverwrite replaces the existing database with the new export.
+	ExportOverwrite = ExportMode("overwrite")
+	// ExportAppend adds the new export to the existing database, rows of every export are told apart by export id.
+	ExportAppend = ExportMode("append")
+)
+
+// String returns the export mode.
+func (mode ExportMode) String() string {
+	return string(mode)
+}
+
+// Set implements pflag.Value by parsing overwrite or append.
+func (mode *ExportMode) Set(value string) error {
+	switch ExportMode(value) {
+	case ExportOverwrite, ExportAppend:
+		*mode = ExportMode(value)
+		return nil
+	default:
+		return Error.New("invalid export mode %q: must be overwrite or append", value)
+	}
+}
+
+// Type returns the type of the pflag.Value.
+func (mode ExportMode) Type() string {
+	return "export-mode"
+}
+
+// PeriodSummary contains node payouts in a single period.
+type PeriodSummary struct {
+	NodeID storj.NodeID `json:"nodeId"`
+	Period string       `json:"period"`
+	Held   int64        `json:"held"`
+	Paid   int64        `json:"paid"`
+}
+
+// ExportData contains payout data written to the export database.
+type ExportData struct {
+	ExportedAt time.Time
+	Nodes      []NodeSummary
+	Satellites []SatelliteSummary
+	Periods    []PeriodSummary
+}
+
+// exportSchema creates tables of the export database, unless they exist already.
+var exportSchema = []string{
+	`CREATE TABLE IF NOT EXISTS exports (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		exported_at TIMESTAMP NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS nodes (
+		export_id INTEGER NOT NULL REFERENCES exports( id ),
+		node_id TEXT NOT NULL,
+		name TEXT NOT NULL,
+		held INTEGER NOT NULL,
+		paid INTEGER NOT NULL,
+		circuit_open BOOLEAN NOT NULL,
+		annotation TEXT NOT NULL,
+		PRIMARY KEY ( export_id, node_id )
+	)`,
+	`CREATE TABLE IF NOT EXISTS satellites (
+		export_id INTEGER NOT NULL REFERENCES exports( id ),
+		satellite_id TEXT NOT NULL,
+		currency TEXT NOT NULL,
+		earned INTEGER NOT NULL,
+		net INTEGER NOT NULL,
+		PRIMARY KEY ( export_id, satellite_id, currency )
+	)`,
+	`CREATE TABLE IF NOT EXISTS period_summaries (
+		export_id INTEGER NOT NULL REFERENCES exports( id ),
+		node_id TEXT NOT NULL,
+		period TEXT NOT NULL,
+		held INTEGER NOT NULL,
+		paid INTEGER NOT NULL,
+		PRIMARY KEY ( export_id, node_id, period )
+	)`,
+}
+
+// WriteSQLite writes the data into SQLite database at path as a single export, returning its id.
+// Database is created when it doesn't exist, existing one is replaced or appended to depending on the mode.
+// Replaced database is kept until the new export succeeds. Node and satellite ids are stored as strings,
+// so that they can be queried easily.
+func WriteSQLite(ctx context.Context, path string, mode ExportMode, data ExportData) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	switch mode {
+	case ExportOverwrite:
+		return overwriteSQLite(ctx, path, data)
+	case ExportAppend:
+		return writeSQLite(ctx, path, data)
+	default:
+		return 0, Error.New("invalid export mode %q", mode)
+	}
+}
+
+// overwriteSQLite writes the data into a new database next to the existing one at path,
+// which replaces the existing one only when the export succeeds.
+func overwriteSQLite(ctx context.Context, path string, data ExportData) (_ int64, err error) {
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+	defer func() {
+		if err != nil {
+			err = errs.Combine(err, Error.Wrap(os.Remove(tmp.Name())))
+		}
+	}()
+
+	if err := tmp.Close(); err != nil {
+		return 0, Error.Wrap(err)
+	}
+
+	exportID, err := writeSQLite(ctx, tmp.Name(), data)
+	if err != nil {
+		return 0, err
+	}
+
+	return exportID, Error.Wrap(os.Rename(tmp.Name(), path))
+}
+
+// writeSQLite adds the data into SQLite database at path as a single export in one transaction.
+func writeSQLite(ctx context.Context, path string, data ExportData) (_ int64, err error) {
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=foreign_keys(1)")
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, Error.Wrap(db.Close())) }()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+	defer func() {
+		if err != nil {
+			err = errs.Combine(err, Error.Wrap(tx.Rollback()))
+		}
+	}()
+
+	for _, statement := range exportSchema {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			return 0, Error.Wrap(err)
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, `INSERT INTO exports ( exported_at ) VALUES ( ? )`, data.ExportedAt.UTC())
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+	exportID, err := result.LastInsertId()
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+
+	for _, node := range data.Nodes {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO nodes ( export_id, node_id, name, held, paid, circuit_open, annotation )
+			VALUES ( ?, ?, ?, ?, ?, ?, ? )`,
+			exportID, node.NodeID.String(), node.NodeName, node.Held, node.Paid, node.CircuitOpen, node.Annotation)
+		if err != nil {
+			return 0, Error.Wrap(err)
+		}
+	}
+
+	for _, satellite := range data.Satellites {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO satellites ( export_id, satellite_id, currency, earned, net )
+			VALUES ( ?, ?, ?, ?, ? )`,
+			exportID, satellite.SatelliteID.String(), satellite.Currency, satellite.Earned, satellite.Net)
+		if err != nil {
+			return 0, Error.Wrap(err)
+		}
+	}
+
+	for _, period := range data.Periods {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO period_summaries ( export_id, node_id, period, held, paid )
+			VALUES ( ?, ?, ?, ?, ? )`,
+			exportID, period.NodeID.String(), period.Period, period.Held, period.Paid)
+		if err != nil {
+			return 0, Error.Wrap(err)
+		}
+	}
+
+	return exportID, Error.Wrap(tx.Commit())
+}
+
+// ExportSQLite writes all time summary of every node, earnings per satellite and summaries of every period
+// nodes have payouts data for into SQLite database at path for offline analysis. Existing database is replaced
+// or appended to depending on the mode. Period summaries of nodes which fail to respond are skipped.
+func (service *Service) ExportSQLite(ctx context.Context, path string, mode ExportMode) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	data := ExportData{ExportedAt: time.Now()}
+
+	summary, err := service.NodesSummary(ctx)
+	if err != nil {
+		return err
+	}
+	data.Nodes = summary.NodeSummary
+
+	data.Satellites, err = service.GetAllNodesEarnedOnSatellite(ctx, 0)
+	if err != nil {
+		return err
+	}
+
+	err = service.iterateNodes(ctx, func(node nodes.Node) {
+		summaries, err := service.nodeFilteredPeriodSummaries(ctx, node, func(string) bool { return true })
+		if err != nil {
+			service.log.Error("failed to get node period summaries", zap.Stringer("node", node.ID), zap.Error(err))
+			return
+		}
+		service.contacted(node.ID)
+
+		periods := make([]string, 0, len(summaries))
+		for period := range summaries {
+			periods = append(periods, period)
+		}
+		sort.Strings(periods)
+
+		for _, period := range periods {
+			data.Periods = append(data.Periods, PeriodSummary{
+				NodeID: node.ID,
+				Period: period,
+				Held:   summaries[period].Held,
+				Paid:   summaries[period].Paid,
+			})
+		}
+	})
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	_, err = WriteSQLite(ctx, path, mode, data)
+	return err
+}
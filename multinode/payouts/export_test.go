@@ -0,0 +1,128 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts
+
+import (
+	"database/sql"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+)
+
+func TestWriteSQLite(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	path := ctx.File("payouts.db")
+
+	node1, node2 := testrand.NodeID(), testrand.NodeID()
+	satellite := testrand.NodeID()
+
+	data := ExportData{
+		ExportedAt: time.Now(),
+		Nodes: []NodeSummary{
+			{NodeID: node1, NodeName: "first", Held: 10, Paid: 100, Annotation: "rack 1"},
+			{NodeID: node2, NodeName: "second", Held: 20, Paid: 200, CircuitOpen: true},
+		},
+		Satellites: []SatelliteSummary{
+			{SatelliteID: satellite, Earned: 300, Net: 270, Currency: defaultCurrency},
+		},
+		Periods: []PeriodSummary{
+			{NodeID: node1, Period: "2021-01", Held: 4, Paid: 40},
+			{NodeID: node1, Period: "2021-02", Held: 6, Paid: 60},
+			{NodeID: node2, Period: "2021-02", Held: 20, Paid: 200},
+		},
+	}
+
+	first, err := WriteSQLite(ctx, path, ExportOverwrite, data)
+	require.NoError(t, err)
+
+	db, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+	defer func() { ctx.Check(db.Close) }()
+
+	var name string
+	var held, paid int64
+	err = db.QueryRowContext(ctx, `SELECT name, held, paid FROM nodes WHERE export_id = ? AND node_id = ?`, first, node2.String()).Scan(&name, &held, &paid)
+	require.NoError(t, err)
+	require.Equal(t, "second", name)
+	require.EqualValues(t, 20, held)
+	require.EqualValues(t, 200, paid)
+
+	var earned, net int64
+	err = db.QueryRowContext(ctx, `SELECT earned, net FROM satellites WHERE satellite_id = ?`, satellite.String()).Scan(&earned, &net)
+	require.NoError(t, err)
+	require.EqualValues(t, 300, earned)
+	require.EqualValues(t, 270, net)
+
+	var periodPaid int64
+	err = db.QueryRowContext(ctx, `SELECT SUM(paid) FROM period_summaries WHERE node_id = ?`, node1.String()).Scan(&periodPaid)
+	require.NoError(t, err)
+	require.EqualValues(t, 100, periodPaid)
+
+	countExports := func() (count int) {
+		require.NoError(t, db.QueryRowContext(ctx, `SELECT COUNT(*) FROM exports`).Scan(&count))
+		return count
+	}
+
+	t.Run("append", func(t *testing.T) {
+		second, err := WriteSQLite(ctx, path, ExportAppend, data)
+		require.NoError(t, err)
+		require.NotEqual(t, first, second)
+		require.Equal(t, 2, countExports())
+
+		var count int
+		err = db.QueryRowContext(ctx, `SELECT COUNT(*) FROM period_summaries WHERE export_id = ?`, second).Scan(&count)
+		require.NoError(t, err)
+		require.Equal(t, len(data.Periods), count)
+	})
+
+	t.Run("failed overwrite keeps database", func(t *testing.T) {
+		duplicated := data
+		duplicated.Nodes = append([]NodeSummary{}, data.Nodes...)
+		duplicated.Nodes = append(duplicated.Nodes, data.Nodes[0])
+
+		before, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+
+		_, err = WriteSQLite(ctx, path, ExportOverwrite, duplicated)
+		require.Error(t, err)
+		require.Equal(t, 2, countExports())
+
+		after, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, before, after)
+
+		temporary, err := filepath.Glob(path + ".*.tmp")
+		require.NoError(t, err)
+		require.Empty(t, temporary)
+	})
+
+	t.Run("overwrite", func(t *testing.T) {
+		require.NoError(t, db.Close())
+
+		_, err := WriteSQLite(ctx, path, ExportOverwrite, data)
+		require.NoError(t, err)
+
+		db, err = sql.Open("sqlite", path)
+		require.NoError(t, err)
+		require.Equal(t, 1, countExports())
+	})
+
+	t.Run("invalid mode", func(t *testing.T) {
+		_, err := WriteSQLite(ctx, path, ExportMode("replace"), data)
+		require.Error(t, err)
+
+		var mode ExportMode
+		require.Error(t, mode.Set("replace"))
+		require.NoError(t, mode.Set("append"))
+		require.Equal(t, ExportAppend, mode)
+	})
+}
@@ -0,0 +1,244 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts
+
+import (
+	"context"
+	"sort"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap/zaptest"
+	"golang.org/x/sync/errgroup"
+
+	"storj.io/common/identity/testidentity"
+	"storj.io/common/peertls/extensions"
+	"storj.io/common/peertls/tlsopts"
+	"storj.io/common/rpc"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/storj/multinode/nodes"
+	"storj.io/storj/private/multinodepb"
+	"storj.io/storj/private/server"
+)
+
+// fakeNodeTLSConfig accepts test identities of any version without revocation checks.
+var fakeNodeTLSConfig = tlsopts.Config{
+	PeerIDVersions: "*",
+	Extensions: extensions.Config{
+		Revocation:          false,
+		WhitelistSignedLeaf: false,
+	},
+}
+
+// newFakeNodeDialer creates dialer of the multinode dashboard, which dials fake nodes.
+func newFakeNodeDialer(t *testing.T) rpc.Dialer {
+	tlsOptions, err := tlsopts.NewOptions(testidentity.MustPregeneratedIdentity(0, storj.LatestIDVersion()), fakeNodeTLSConfig, nil)
+	require.NoError(t, err)
+	return rpc.NewDefaultDialer(tlsOptions)
+}
+
+// startFakeNode starts a node serving multinode endpoints, identity index must be unique within the test and above 0.
+// The node is stopped when ctx is cleaned up.
+func startFakeNode(t *testing.T, ctx *testcontext.Context, identityIndex int, name string, endpoints *fakeNode) nodes.Node {
+	identity := testidentity.MustPregeneratedIdentity(identityIndex, storj.LatestIDVersion())
+	tlsOptions, err := tlsopts.NewOptions(identity, fakeNodeTLSConfig, nil)
+	require.NoError(t, err)
+
+	peer, err := server.New(zaptest.NewLogger(t), tlsOptions, server.Config{
+		Config:         fakeNodeTLSConfig,
+		Address:        "127.0.0.1:0",
+		PrivateAddress: "127.0.0.1:0",
+		DisableQUIC:    true,
+	})
+	require.NoError(t, err)
+	require.NoError(t, multinodepb.DRPCRegisterPayout(peer.DRPC(), endpoints))
+	require.NoError(t, multinodepb.DRPCRegisterNode(peer.DRPC(), endpoints))
+	require.NoError(t, multinodepb.DRPCRegisterStorage(peer.DRPC(), endpoints))
+	require.NoError(t, multinodepb.DRPCRegisterBandwidth(peer.DRPC(), endpoints))
+
+	var group errgroup.Group
+	group.Go(func() error {
+		return peer.Run(ctx)
+	})
+	ctx.Go(func() error {
+		// stops the node once the test finished.
+		<-ctx.Done()
+		return errs.Combine(peer.Close(), group.Wait())
+	})
+
+	return nodes.Node{
+		ID:            identity.ID,
+		Name:          name,
+		PublicAddress: peer.Addr().String(),
+	}
+}
+
+// fakeNode implements multinode endpoints of a fake node returning predefined data.
+// Methods which are not set up return unimplemented error.
+type fakeNode struct {
+	multinodepb.DRPCPayoutUnimplementedServer
+	multinodepb.DRPCNodeUnimplementedServer
+	multinodepb.DRPCStorageUnimplementedServer
+	multinodepb.DRPCBandwidthUnimplementedServer
+
+	// periods are payouts of all satellites per period.
+	periods map[string]*multinodepb.PayoutInfo
+	// estimated is current month estimate in cents.
+	estimated  int64
+	usedPieces int64
+	egress     int64
+	// satellitePeriods are payouts per satellite and period.
+	satellitePeriods map[storj.NodeID]map[string]*multinodepb.PayoutInfo
+	// earnedSatellites are all time earnings per satellite.
+	earnedSatellites []*multinodepb.EarnedSatellite
+	// previousEstimated is estimate of previousPeriod in cents.
+	previousPeriod    string
+	previousEstimated int64
+	undistributed     []*multinodepb.UndistributedSatellite
+	checkIns          []*multinodepb.SatelliteCheckInsResponse_CheckIn
+	earned            int64
+	heldRates         []*multinodepb.HeldRatesResponse_HeldRate
+	net               int64
+	wallet            string
+	// firstPeriods are first paystub periods per satellite.
+	firstPeriods map[storj.NodeID]string
+	payments     []*multinodepb.PaymentsPerSatelliteResponse_SatellitePayments
+	// satellites are ids of satellites which paid to the node.
+	satellites  []storj.NodeID
+	heldHistory []*multinodepb.HeldHistoryEntry
+	// groupRequests is the number of satellite summaries requests of nodes sharing the process.
+	groupRequests int64
+	// groupUnsupported makes satellite summaries requests fail, like on nodes of older versions.
+	groupUnsupported bool
+	scores           []*multinodepb.ReputationScoresResponse_SatelliteScores
+}
+
+func (node *fakeNode) AllSatellitesPeriodSummary(ctx context.Context, req *multinodepb.AllSatellitesPeriodSummaryRequest) (*multinodepb.AllSatellitesPeriodSummaryResponse, error) {
+	info, ok := node.periods[req.Period]
+	if !ok {
+		info = &multinodepb.PayoutInfo{}
+	}
+	return &multinodepb.AllSatellitesPeriodSummaryResponse{PayoutInfo: info}, nil
+}
+
+func (node *fakeNode) EstimatedPayoutTotal(ctx context.Context, req *multinodepb.EstimatedPayoutTotalRequest) (*multinodepb.EstimatedPayoutTotalResponse, error) {
+	return &multinodepb.EstimatedPayoutTotalResponse{
+		EstimatedEarnings:              node.estimated,
+		PreviousMonthEstimatedEarnings: node.previousEstimated,
+		PreviousMonthPeriod:            node.previousPeriod,
+	}, nil
+}
+
+func (node *fakeNode) DiskSpace(ctx context.Context, req *multinodepb.DiskSpaceRequest) (*multinodepb.DiskSpaceResponse, error) {
+	return &multinodepb.DiskSpaceResponse{UsedPieces: node.usedPieces}, nil
+}
+
+func (node *fakeNode) MonthSummary(ctx context.Context, req *multinodepb.BandwidthMonthSummaryRequest) (*multinodepb.BandwidthMonthSummaryResponse, error) {
+	return &multinodepb.BandwidthMonthSummaryResponse{Egress: node.egress}, nil
+}
+
+func (node *fakeNode) EarnedPerSatellite(ctx context.Context, req *multinodepb.EarnedPerSatelliteRequest) (*multinodepb.EarnedPerSatelliteResponse, error) {
+	return &multinodepb.EarnedPerSatelliteResponse{EarnedSatellite: node.earnedSatellites}, nil
+}
+
+func (node *fakeNode) SatelliteSummary(ctx context.Context, req *multinodepb.SatelliteSummaryRequest) (*multinodepb.SatelliteSummaryResponse, error) {
+	return &multinodepb.SatelliteSummaryResponse{PayoutInfo: node.satellitePayout(req.SatelliteId, "")}, nil
+}
+
+func (node *fakeNode) SatellitePeriodSummary(ctx context.Context, req *multinodepb.SatellitePeriodSummaryRequest) (*multinodepb.SatellitePeriodSummaryResponse, error) {
+	return &multinodepb.SatellitePeriodSummaryResponse{PayoutInfo: node.satellitePayout(req.SatelliteId, req.Period)}, nil
+}
+
+// satellitePayout sums payouts of the satellite in the period, or in all periods when the period is empty.
+func (node *fakeNode) satellitePayout(satelliteID storj.NodeID, period string) *multinodepb.PayoutInfo {
+	var info multinodepb.PayoutInfo
+	for satellitePeriod, payout := range node.satellitePeriods[satelliteID] {
+		if period == "" || period == satellitePeriod {
+			info.Held += payout.Held
+			info.Paid += payout.Paid
+		}
+	}
+	return &info
+}
+
+func (node *fakeNode) UndistributedPerSatellite(ctx context.Context, req *multinodepb.UndistributedPerSatelliteRequest) (*multinodepb.UndistributedPerSatelliteResponse, error) {
+	return &multinodepb.UndistributedPerSatelliteResponse{UndistributedSatellite: node.undistributed}, nil
+}
+
+func (node *fakeNode) AllSatellitesSummary(ctx context.Context, req *multinodepb.AllSatellitesSummaryRequest) (*multinodepb.AllSatellitesSummaryResponse, error) {
+	var info multinodepb.PayoutInfo
+	for _, period := range node.periods {
+		info.Held += period.Held
+		info.Paid += period.Paid
+	}
+	return &multinodepb.AllSatellitesSummaryResponse{PayoutInfo: &info}, nil
+}
+
+func (node *fakeNode) SatelliteCheckIns(ctx context.Context, req *multinodepb.SatelliteCheckInsRequest) (*multinodepb.SatelliteCheckInsResponse, error) {
+	return &multinodepb.SatelliteCheckInsResponse{CheckIns: node.checkIns}, nil
+}
+
+func (node *fakeNode) AvailablePeriods(ctx context.Context, req *multinodepb.AvailablePeriodsRequest) (*multinodepb.AvailablePeriodsResponse, error) {
+	var response multinodepb.AvailablePeriodsResponse
+	for period := range node.periods {
+		response.Period = append(response.Period, period)
+	}
+	sort.Strings(response.Period)
+	return &response, nil
+}
+
+func (node *fakeNode) Earned(ctx context.Context, req *multinodepb.EarnedRequest) (*multinodepb.EarnedResponse, error) {
+	return &multinodepb.EarnedResponse{Total: node.earned, Net: node.net}, nil
+}
+
+func (node *fakeNode) HeldRates(ctx context.Context, req *multinodepb.HeldRatesRequest) (*multinodepb.HeldRatesResponse, error) {
+	return &multinodepb.HeldRatesResponse{HeldRates: node.heldRates}, nil
+}
+
+func (node *fakeNode) PayoutConfig(ctx context.Context, req *multinodepb.PayoutConfigRequest) (*multinodepb.PayoutConfigResponse, error) {
+	return &multinodepb.PayoutConfigResponse{Wallet: node.wallet}, nil
+}
+
+func (node *fakeNode) FirstPaystubPeriod(ctx context.Context, req *multinodepb.FirstPaystubPeriodRequest) (*multinodepb.FirstPaystubPeriodResponse, error) {
+	return &multinodepb.FirstPaystubPeriodResponse{Period: node.firstPeriods[req.SatelliteId]}, nil
+}
+
+func (node *fakeNode) PaymentsPerSatellite(ctx context.Context, req *multinodepb.PaymentsPerSatelliteRequest) (*multinodepb.PaymentsPerSatelliteResponse, error) {
+	return &multinodepb.PaymentsPerSatelliteResponse{SatellitePayments: node.payments}, nil
+}
+
+func (node *fakeNode) PayingSatellites(ctx context.Context, req *multinodepb.PayingSatellitesRequest) (*multinodepb.PayingSatellitesResponse, error) {
+	return &multinodepb.PayingSatellitesResponse{SatelliteIds: node.satellites}, nil
+}
+
+func (node *fakeNode) HeldHistoryStream(req *multinodepb.HeldHistoryRequest, stream multinodepb.DRPCPayout_HeldHistoryStreamStream) error {
+	for _, entry := range node.heldHistory {
+		if err := stream.Send(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (node *fakeNode) SatelliteSummaries(ctx context.Context, req *multinodepb.SatelliteSummariesRequest) (*multinodepb.SatelliteSummariesResponse, error) {
+	atomic.AddInt64(&node.groupRequests, 1)
+	if node.groupUnsupported {
+		return node.DRPCPayoutUnimplementedServer.SatelliteSummaries(ctx, req)
+	}
+
+	info := node.satellitePayout(req.SatelliteId, req.Period)
+
+	var response multinodepb.SatelliteSummariesResponse
+	for range req.Headers {
+		response.NodeSummaries = append(response.NodeSummaries, &multinodepb.SatelliteSummariesResponse_NodeSummary{PayoutInfo: info})
+	}
+	return &response, nil
+}
+
+func (node *fakeNode) ReputationScores(ctx context.Context, req *multinodepb.ReputationScoresRequest) (*multinodepb.ReputationScoresResponse, error) {
+	return &multinodepb.ReputationScoresResponse{Scores: node.scores}, nil
+}
@@ -0,0 +1,183 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+)
+
+// PeerController is the data of a multinode controller merged by the FederatedService.
+// Service implements it for the local controller and HTTPPeer for remote ones.
+type PeerController interface {
+	NodesSummary(ctx context.Context) (Summary, error)
+	GetAllNodesAllTimeEarned(ctx context.Context) (Earned, error)
+}
+
+var _ PeerController = (*Service)(nil)
+var _ PeerController = (*HTTPPeer)(nil)
+
+// FederatedPeer is a named multinode controller.
+type FederatedPeer struct {
+	Name       string
+	Controller PeerController
+}
+
+// FederatedSummary is a summary merged from multiple controllers.
+type FederatedSummary struct {
+	Summary
+	// FailedPeers contains names of controllers which failed to respond and are not included.
+	FailedPeers []string `json:"failedPeers"`
+}
+
+// FederatedEarned is an earned amount merged from multiple controllers.
+type FederatedEarned struct {
+	Earned
+	// FailedPeers contains names of controllers which failed to respond and are not included.
+	FailedPeers []string `json:"failedPeers"`
+}
+
+// FederatedService merges payouts data of multiple multinode controllers.
+//
+// architecture: Service
+type FederatedService struct {
+	log   *zap.Logger
+	peers []FederatedPeer
+}
+
+// NewFederatedService creates new instance of FederatedService.
+func NewFederatedService(log *zap.Logger, peers []FederatedPeer) *FederatedService {
+	return &FederatedService{
+		log:   log,
+		peers: peers,
+	}
+}
+
+// NodesSummary merges summaries of all peers. Peers which fail to respond are reported as failed.
+// Error is returned only when all peers failed.
+func (service *FederatedService) NodesSummary(ctx context.Context) (_ FederatedSummary, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	summaries := make([]Summary, len(service.peers))
+	peerErrs, failed, err := service.fanOut(ctx, func(ctx context.Context, i int, peer PeerController) (err error) {
+		summaries[i], err = peer.NodesSummary(ctx)
+		return err
+	})
+
+	var merged FederatedSummary
+	merged.FailedPeers = failed
+	for i, summary := range summaries {
+		if peerErrs[i] == nil {
+			merged.Merge(summary)
+		}
+	}
+	return merged, err
+}
+
+// GetAllNodesAllTimeEarned sums earned amounts of all peers. Peers which fail to respond are reported as failed.
+// Error is returned only when all peers failed.
+func (service *FederatedService) GetAllNodesAllTimeEarned(ctx context.Context) (_ FederatedEarned, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	earned := make([]Earned, len(service.peers))
+	peerErrs, failed, err := service.fanOut(ctx, func(ctx context.Context, i int, peer PeerController) (err error) {
+		earned[i], err = peer.GetAllNodesAllTimeEarned(ctx)
+		return err
+	})
+
+	var merged FederatedEarned
+	merged.FailedPeers = failed
+	for i, amount := range earned {
+		if peerErrs[i] == nil {
+			merged.Gross += amount.Gross
+			merged.Net += amount.Net
+		}
+	}
+	return merged, err
+}
+
+// fanOut calls every peer at once and returns errors of the peers and names of the failed ones, in the peers order.
+func (service *FederatedService) fanOut(ctx context.Context, call func(ctx context.Context, i int, peer PeerController) error) (peerErrs []error, failed []string, err error) {
+	peerErrs = make([]error, len(service.peers))
+
+	var wg sync.WaitGroup
+	for i, peer := range service.peers {
+		wg.Add(1)
+		go func(i int, peer FederatedPeer) {
+			defer wg.Done()
+			peerErrs[i] = call(ctx, i, peer.Controller)
+		}(i, peer)
+	}
+	wg.Wait()
+
+	var group errs.Group
+	for i, peerErr := range peerErrs {
+		if peerErr == nil {
+			continue
+		}
+		service.log.Error("failed to query peer controller", zap.String("peer", service.peers[i].Name), zap.Error(peerErr))
+		failed = append(failed, service.peers[i].Name)
+		group.Add(peerErr)
+	}
+
+	if len(service.peers) > 0 && len(failed) == len(service.peers) {
+		return peerErrs, failed, Error.Wrap(group.Err())
+	}
+	return peerErrs, failed, nil
+}
+
+// HTTPPeer is a remote multinode controller queried over its console api.
+type HTTPPeer struct {
+	client  *http.Client
+	address string
+}
+
+// NewHTTPPeer creates new HTTPPeer for the controller console address, e.g. http://controller:15000.
+func NewHTTPPeer(client *http.Client, address string) *HTTPPeer {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &HTTPPeer{
+		client:  client,
+		address: strings.TrimSuffix(address, "/"),
+	}
+}
+
+// NodesSummary returns summary of all nodes of the peer.
+func (peer *HTTPPeer) NodesSummary(ctx context.Context) (summary Summary, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return summary, peer.get(ctx, "/api/v0/payouts/summary", &summary)
+}
+
+// GetAllNodesAllTimeEarned returns earned amount of all nodes of the peer.
+func (peer *HTTPPeer) GetAllNodesAllTimeEarned(ctx context.Context) (earned Earned, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return earned, peer.get(ctx, "/api/v0/payouts/total-earned", &earned)
+}
+
+// get requests the api path and decodes the json response into v.
+func (peer *HTTPPeer) get(ctx context.Context, path string, v interface{}) (err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, peer.address+path, nil)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	resp, err := peer.client.Do(req)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, Error.Wrap(resp.Body.Close())) }()
+
+	if resp.StatusCode != http.StatusOK {
+		return Error.New("peer %s responded with %s", peer.address, resp.Status)
+	}
+
+	return Error.Wrap(json.NewDecoder(resp.Body).Decode(v))
+}
@@ -0,0 +1,107 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/multinode/payouts"
+)
+
+// newFakeController serves summary and total earned like the console api of a multinode controller.
+func newFakeController(t *testing.T, summary payouts.Summary, earned payouts.Earned) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v0/payouts/summary", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewEncoder(w).Encode(summary))
+	})
+	mux.HandleFunc("/api/v0/payouts/total-earned", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewEncoder(w).Encode(earned))
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestFederatedService(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	node1, node2, node3 := testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+
+	var summary1 payouts.Summary
+	summary1.Add(10, 20, node1, "node1")
+	summary1.Add(5, 5, node2, "node2")
+	controller1 := newFakeController(t, summary1, payouts.Earned{Gross: 100, Net: 80})
+	defer controller1.Close()
+
+	var summary2 payouts.Summary
+	summary2.Add(1, 2, node3, "node3")
+	controller2 := newFakeController(t, summary2, payouts.Earned{Gross: 10, Net: 9})
+	defer controller2.Close()
+
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+
+	service := payouts.NewFederatedService(zaptest.NewLogger(t), []payouts.FederatedPeer{
+		{Name: "first", Controller: payouts.NewHTTPPeer(nil, controller1.URL)},
+		{Name: "unreachable", Controller: payouts.NewHTTPPeer(nil, unreachable.URL)},
+		{Name: "second", Controller: payouts.NewHTTPPeer(nil, controller2.URL+"/")},
+	})
+
+	summary, err := service.NodesSummary(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"unreachable"}, summary.FailedPeers)
+	require.EqualValues(t, 43, summary.TotalEarned)
+	require.EqualValues(t, 16, summary.TotalHeld)
+	require.EqualValues(t, 27, summary.TotalPaid)
+	require.Len(t, summary.NodeSummary, 3)
+	require.Equal(t, node1, summary.NodeSummary[0].NodeID)
+	require.Equal(t, node3, summary.NodeSummary[2].NodeID)
+
+	earned, err := service.GetAllNodesAllTimeEarned(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"unreachable"}, earned.FailedPeers)
+	require.Equal(t, payouts.Earned{Gross: 110, Net: 89}, earned.Earned)
+}
+
+func TestFederatedServiceAllPeersFailed(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	service := payouts.NewFederatedService(zaptest.NewLogger(t), []payouts.FederatedPeer{
+		{Name: "failing", Controller: payouts.NewHTTPPeer(nil, failing.URL)},
+	})
+
+	summary, err := service.NodesSummary(ctx)
+	require.Error(t, err)
+	require.Equal(t, []string{"failing"}, summary.FailedPeers)
+	require.Zero(t, summary.TotalEarned)
+}
+
+func TestSummaryMerge(t *testing.T) {
+	node1, node2 := testrand.NodeID(), testrand.NodeID()
+
+	var summary, other payouts.Summary
+	summary.Add(1, 2, node1, "node1")
+	other.Add(3, 4, node2, "node2")
+	other.AddCircuitOpen(testrand.NodeID(), "open")
+
+	summary.Merge(other)
+	require.EqualValues(t, 10, summary.TotalEarned)
+	require.EqualValues(t, 4, summary.TotalHeld)
+	require.EqualValues(t, 6, summary.TotalPaid)
+	require.Len(t, summary.NodeSummary, 3)
+	require.True(t, summary.NodeSummary[2].CircuitOpen)
+}
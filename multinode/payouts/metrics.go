@@ -0,0 +1,84 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// metric describes a single gauge in Prometheus exposition format.
+type metric struct {
+	name  string
+	help  string
+	value func(Snapshot) int64
+}
+
+// fleetMetrics are metrics of the whole fleet, exported without labels.
+var fleetMetrics = []metric{
+	{"multinode_payouts_earned_micro_usd", "All time earnings of reached nodes, in micro USD.", func(s Snapshot) int64 { return s.Summary.TotalEarned }},
+	{"multinode_payouts_held_micro_usd", "All time held amount of reached nodes, in micro USD.", func(s Snapshot) int64 { return s.Summary.TotalHeld }},
+	{"multinode_payouts_paid_micro_usd", "All time paid amount of reached nodes, in micro USD.", func(s Snapshot) int64 { return s.Summary.TotalPaid }},
+	{"multinode_payouts_estimated_cents", "Current month estimated earnings of reached nodes, in cents.", func(s Snapshot) int64 { return s.Estimated }},
+	{"multinode_payouts_nodes_total", "Number of nodes at the time of the snapshot.", func(s Snapshot) int64 { return int64(s.NodesTotal) }},
+	{"multinode_payouts_nodes_reached", "Number of nodes which responded during the snapshot.", func(s Snapshot) int64 { return int64(s.NodesReached) }},
+	{"multinode_payouts_snapshot_timestamp_seconds", "Unix time the snapshot was collected at.", func(s Snapshot) int64 { return s.StaleSince.Unix() }},
+}
+
+// WriteMetrics writes snapshot fleet metrics in Prometheus text exposition format.
+// Node metrics are labeled with node id and name, so they are written only when perNode is set
+// to keep the amount of series bounded for large fleets.
+func WriteMetrics(w io.Writer, snapshot Snapshot, perNode bool) error {
+	buf := bufio.NewWriter(w)
+
+	for _, m := range fleetMetrics {
+		writeMetricHeader(buf, m.name, m.help)
+		fmt.Fprintf(buf, "%s %d\n", m.name, m.value(snapshot))
+	}
+
+	if perNode {
+		nodeMetrics := []struct {
+			name  string
+			help  string
+			value func(NodeSummary) int64
+		}{
+			{"multinode_payouts_node_held_micro_usd", "All time held amount of the node, in micro USD.", func(n NodeSummary) int64 { return n.Held }},
+			{"multinode_payouts_node_paid_micro_usd", "All time paid amount of the node, in micro USD.", func(n NodeSummary) int64 { return n.Paid }},
+		}
+
+		for _, m := range nodeMetrics {
+			writeMetricHeader(buf, m.name, m.help)
+			for _, node := range snapshot.Summary.NodeSummary {
+				fmt.Fprintf(buf, "%s{node_id=\"%s\",node_name=\"%s\"} %d\n",
+					m.name, escapeLabelValue(node.NodeID.String()), escapeLabelValue(node.NodeName), m.value(node))
+			}
+		}
+	}
+
+	return Error.Wrap(buf.Flush())
+}
+
+// WriteMetrics writes metrics of the last snapshot in Prometheus text exposition format.
+func (service *Service) WriteMetrics(w io.Writer) error {
+	snapshot, err := service.Snapshot()
+	if err != nil {
+		return err
+	}
+
+	return WriteMetrics(w, snapshot, service.metricsPerNode)
+}
+
+func writeMetricHeader(w io.Writer, name, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s gauge\n", name)
+}
+
+// labelEscaper escapes label value as required by Prometheus exposition format.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(value string) string {
+	return labelEscaper.Replace(value)
+}
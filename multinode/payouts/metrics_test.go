@@ -0,0 +1,84 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts_test
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testrand"
+	"storj.io/storj/multinode/payouts"
+)
+
+// parseMetrics parses Prometheus text exposition format into sample values by series
+// and metric types by metric name.
+func parseMetrics(t *testing.T, exposition string) (samples map[string]int64, types map[string]string) {
+	samples, types = make(map[string]int64), make(map[string]string)
+
+	for _, line := range strings.Split(strings.TrimSpace(exposition), "\n") {
+		if strings.HasPrefix(line, "# TYPE ") {
+			fields := strings.Fields(line)
+			require.Len(t, fields, 4, line)
+			types[fields[2]] = fields[3]
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		index := strings.LastIndex(line, " ")
+		require.Positive(t, index, line)
+
+		value, err := strconv.ParseInt(line[index+1:], 10, 64)
+		require.NoError(t, err, line)
+		samples[line[:index]] = value
+	}
+
+	return samples, types
+}
+
+func TestWriteMetrics(t *testing.T) {
+	first, second := testrand.NodeID(), testrand.NodeID()
+
+	snapshot := payouts.Snapshot{
+		Estimated:    500,
+		NodesTotal:   3,
+		NodesReached: 2,
+		StaleSince:   time.Unix(1620000000, 0),
+	}
+	snapshot.Summary.Add(100, 200, first, "first")
+	snapshot.Summary.Add(10, 20, second, `quoted "name"`)
+
+	var buf bytes.Buffer
+	require.NoError(t, payouts.WriteMetrics(&buf, snapshot, false))
+
+	samples, types := parseMetrics(t, buf.String())
+	require.Equal(t, map[string]int64{
+		"multinode_payouts_earned_micro_usd":           330,
+		"multinode_payouts_held_micro_usd":             110,
+		"multinode_payouts_paid_micro_usd":             220,
+		"multinode_payouts_estimated_cents":            500,
+		"multinode_payouts_nodes_total":                3,
+		"multinode_payouts_nodes_reached":              2,
+		"multinode_payouts_snapshot_timestamp_seconds": 1620000000,
+	}, samples)
+	for name := range samples {
+		require.Equal(t, "gauge", types[name], name)
+	}
+
+	// per node metrics are opt-in.
+	buf.Reset()
+	require.NoError(t, payouts.WriteMetrics(&buf, snapshot, true))
+
+	samples, types = parseMetrics(t, buf.String())
+	require.Len(t, samples, 11)
+	require.Equal(t, "gauge", types["multinode_payouts_node_paid_micro_usd"])
+	require.EqualValues(t, 200, samples[`multinode_payouts_node_paid_micro_usd{node_id="`+first.String()+`",node_name="first"}`])
+	require.EqualValues(t, 10, samples[`multinode_payouts_node_held_micro_usd{node_id="`+second.String()+`",node_name="quoted \"name\""}`])
+}
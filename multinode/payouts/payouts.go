@@ -4,13 +4,125 @@
 package payouts
 
 import (
+	"errors"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
 	"storj.io/common/storj"
+	"storj.io/storj/multinode/nodes"
+	"storj.io/storj/private/multinodepb"
+	nodepayouts "storj.io/storj/storagenode/payouts"
 )
 
+// Earned contains gross earned amount and net amount, which is gross minus amount currently held by satellites.
+// Net amount differs from paid amount, since paid includes surge and doesn't include earnings not paid out yet.
+type Earned struct {
+	Gross int64 `json:"gross"`
+	Net   int64 `json:"net"`
+}
+
 // SatelliteSummary contains satellite id and earned amount.
 type SatelliteSummary struct {
 	SatelliteID storj.NodeID `json:"satelliteID"`
 	Earned      int64        `json:"earned"`
+	// Net is earned amount minus amount currently held by the satellite.
+	Net int64 `json:"net"`
+	// Currency is the denomination of earned amount, amounts in different denominations are never summed.
+	Currency string `json:"currency"`
+}
+
+// defaultCurrency is the denomination of amounts sent by nodes without amount unit.
+const defaultCurrency = "USD"
+
+// GroupEarnedBySatellite sums earned amounts of all nodes per satellite and denomination.
+// Satellites are returned in order of the first appearance.
+func GroupEarnedBySatellite(responses []*multinodepb.EarnedPerSatelliteResponse) []SatelliteSummary {
+	type key struct {
+		satelliteID storj.NodeID
+		currency    string
+	}
+
+	var summaries []SatelliteSummary
+	indexes := make(map[key]int)
+
+	for _, response := range responses {
+		for _, satellite := range response.EarnedSatellite {
+			currency := currencyOf(satellite.Unit, currencyOf(response.Unit, defaultCurrency))
+
+			k := key{satelliteID: satellite.SatelliteId, currency: currency}
+			index, ok := indexes[k]
+			if !ok {
+				index = len(summaries)
+				indexes[k] = index
+				summaries = append(summaries, SatelliteSummary{
+					SatelliteID: satellite.SatelliteId,
+					Currency:    currency,
+				})
+			}
+
+			summaries[index].Earned += satellite.Total
+			summaries[index].Net += satellite.Net
+		}
+	}
+
+	return summaries
+}
+
+// TotalsByCurrency sums earned amounts of satellite summaries per denomination.
+func TotalsByCurrency(summaries []SatelliteSummary) map[string]int64 {
+	totals := make(map[string]int64)
+	for _, summary := range summaries {
+		totals[summary.Currency] += summary.Earned
+	}
+	return totals
+}
+
+// SatellitePayoutShare contains the fraction of total earnings coming from the satellite.
+type SatellitePayoutShare struct {
+	SatelliteID storj.NodeID `json:"satelliteId"`
+	Earned      int64        `json:"earned"`
+	Currency    string       `json:"currency"`
+	// Share is the percentage of total earned in the same currency.
+	Share float64 `json:"share"`
+}
+
+// PayoutShares returns share of every satellite in total earned amount of its currency, sorted by share descending.
+// Shares are zero when nothing was earned in the currency.
+func PayoutShares(summaries []SatelliteSummary) []SatellitePayoutShare {
+	totals := TotalsByCurrency(summaries)
+
+	shares := make([]SatellitePayoutShare, 0, len(summaries))
+	for _, summary := range summaries {
+		share := SatellitePayoutShare{
+			SatelliteID: summary.SatelliteID,
+			Earned:      summary.Earned,
+			Currency:    summary.Currency,
+		}
+		if total := totals[summary.Currency]; total > 0 {
+			share.Share = float64(summary.Earned) / float64(total) * 100
+		}
+		shares = append(shares, share)
+	}
+
+	sort.SliceStable(shares, func(i, j int) bool {
+		if shares[i].Share != shares[j].Share {
+			return shares[i].Share > shares[j].Share
+		}
+		return shares[i].SatelliteID.Less(shares[j].SatelliteID)
+	})
+
+	return shares
+}
+
+// currencyOf returns currency of the amount unit, or fallback when unit or its currency is not set.
+func currencyOf(unit *multinodepb.AmountUnit, fallback string) string {
+	if unit == nil || unit.Currency == "" {
+		return fallback
+	}
+	return unit.Currency
 }
 
 // NodeSummary contains node's payout information.
@@ -19,6 +131,12 @@ type NodeSummary struct {
 	NodeName string       `json:"nodeName"`
 	Held     int64        `json:"held"`
 	Paid     int64        `json:"paid"`
+	// LastContact is time of the most recent successful response of the node.
+	LastContact time.Time `json:"lastContact"`
+	// CircuitOpen is set when node was not dialed because of repeated failures.
+	CircuitOpen bool `json:"circuitOpen"`
+	// Annotation is the note operator attached to the node, empty when there is none.
+	Annotation string `json:"annotation"`
 }
 
 // Summary contains payouts page data.
@@ -41,3 +159,1319 @@ func (summary *Summary) Add(held, paid int64, id storj.NodeID, name string) {
 		NodeName: name,
 	})
 }
+
+// AddCircuitOpen appends node which was not dialed because of repeated failures to summary.
+func (summary *Summary) AddCircuitOpen(id storj.NodeID, name string) {
+	summary.NodeSummary = append(summary.NodeSummary, NodeSummary{
+		NodeID:      id,
+		NodeName:    name,
+		CircuitOpen: true,
+	})
+}
+
+// Merge adds totals and node summaries of the other summary, e.g. of another multinode controller.
+func (summary *Summary) Merge(other Summary) {
+	summary.TotalEarned += other.TotalEarned
+	summary.TotalHeld += other.TotalHeld
+	summary.TotalPaid += other.TotalPaid
+	summary.NodeSummary = append(summary.NodeSummary, other.NodeSummary...)
+}
+
+// NodeGrowthRate contains node earnings change between two periods.
+type NodeGrowthRate struct {
+	NodeID     storj.NodeID `json:"nodeId"`
+	NodeName   string       `json:"nodeName"`
+	From       int64        `json:"from"`
+	To         int64        `json:"to"`
+	Change     int64        `json:"change"`
+	Percentage float64      `json:"percentage"`
+	// Infinite is set when node earned nothing in the base period, so percentage can't be calculated.
+	Infinite bool `json:"infinite"`
+}
+
+// CalculateGrowthRates calculates earnings change of every node between base and current summaries.
+// Result is sorted from the fastest growing node to the fastest shrinking one,
+// nodes which earned nothing in the base period are placed first.
+func CalculateGrowthRates(base, current Summary) []NodeGrowthRate {
+	var rates []NodeGrowthRate
+	indexes := make(map[storj.NodeID]int)
+
+	for _, node := range base.NodeSummary {
+		indexes[node.NodeID] = len(rates)
+		rates = append(rates, NodeGrowthRate{
+			NodeID:   node.NodeID,
+			NodeName: node.NodeName,
+			From:     node.Held + node.Paid,
+		})
+	}
+
+	for _, node := range current.NodeSummary {
+		index, ok := indexes[node.NodeID]
+		if !ok {
+			index = len(rates)
+			indexes[node.NodeID] = index
+			rates = append(rates, NodeGrowthRate{NodeID: node.NodeID})
+		}
+
+		// node could be renamed since the base period, the current name is displayed.
+		rates[index].NodeName = node.NodeName
+		rates[index].To += node.Held + node.Paid
+	}
+
+	for i := range rates {
+		rate := &rates[i]
+		rate.Change = rate.To - rate.From
+
+		switch {
+		case rate.From != 0:
+			rate.Percentage = float64(rate.Change) / float64(rate.From) * 100
+		case rate.To > 0:
+			rate.Infinite = true
+		}
+	}
+
+	sort.SliceStable(rates, func(i, j int) bool {
+		if rates[i].Infinite != rates[j].Infinite {
+			return rates[i].Infinite
+		}
+		if rates[i].Percentage != rates[j].Percentage {
+			return rates[i].Percentage > rates[j].Percentage
+		}
+		return rates[i].Change > rates[j].Change
+	})
+
+	return rates
+}
+
+// NodeEfficiency contains node current month estimated earnings relative to the provided resources.
+type NodeEfficiency struct {
+	NodeID   storj.NodeID `json:"nodeId"`
+	NodeName string       `json:"nodeName"`
+	Earned   int64        `json:"earned"`
+	// DiskSpaceUsed is amount of bytes used by pieces.
+	DiskSpaceUsed int64 `json:"diskSpaceUsed"`
+	// Egress is amount of bytes sent by node in current month.
+	Egress int64 `json:"egress"`
+	// EarnedPerTBStored is zero when node doesn't store any data.
+	EarnedPerTBStored float64 `json:"earnedPerTBStored"`
+	// EarnedPerTBEgress is zero when node had no egress.
+	EarnedPerTBEgress float64 `json:"earnedPerTBEgress"`
+	// LastContact is time of the most recent successful response of the node.
+	LastContact time.Time `json:"lastContact"`
+	// Annotation is the note operator attached to the node, empty when there is none.
+	Annotation string `json:"annotation"`
+}
+
+// Calculate calculates earnings per TB stored and per TB of egress.
+func (efficiency *NodeEfficiency) Calculate() {
+	const tb = 1e12
+
+	efficiency.EarnedPerTBStored = 0
+	if efficiency.DiskSpaceUsed > 0 {
+		efficiency.EarnedPerTBStored = float64(efficiency.Earned) / (float64(efficiency.DiskSpaceUsed) / tb)
+	}
+
+	efficiency.EarnedPerTBEgress = 0
+	if efficiency.Egress > 0 {
+		efficiency.EarnedPerTBEgress = float64(efficiency.Earned) / (float64(efficiency.Egress) / tb)
+	}
+}
+
+// SatellitePayout contains node payout information for a single satellite.
+type SatellitePayout struct {
+	NodeID      storj.NodeID `json:"nodeId"`
+	NodeName    string       `json:"nodeName"`
+	SatelliteID storj.NodeID `json:"satelliteId"`
+	// Period is empty for all time payouts.
+	Period string `json:"period"`
+	Held   int64  `json:"held"`
+	Paid   int64  `json:"paid"`
+}
+
+// IsNegative returns true if held or paid amount is negative, e.g. because of adjustments.
+func (payout SatellitePayout) IsNegative() bool {
+	return payout.Held < 0 || payout.Paid < 0
+}
+
+// NegativePayouts returns payouts with negative held or paid amounts.
+func NegativePayouts(payouts []SatellitePayout) []SatellitePayout {
+	var negative []SatellitePayout
+	for _, payout := range payouts {
+		if payout.IsNegative() {
+			negative = append(negative, payout)
+		}
+	}
+
+	return negative
+}
+
+// NodeEstimateAccuracy contains comparison of node estimated and actual earnings for a completed period.
+type NodeEstimateAccuracy struct {
+	NodeID   storj.NodeID `json:"nodeId"`
+	NodeName string       `json:"nodeName"`
+	Period   string       `json:"period"`
+	// Known is false when node has no recorded estimate for the period.
+	Known bool `json:"known"`
+	// Estimated and Actual are amounts in micro USD.
+	Estimated int64 `json:"estimated"`
+	Actual    int64 `json:"actual"`
+	// Accuracy is 100 when estimate matches actual earnings, decreasing with relative error down to 0.
+	Accuracy float64 `json:"accuracy"`
+}
+
+// Calculate calculates accuracy of the estimate.
+func (accuracy *NodeEstimateAccuracy) Calculate() {
+	accuracy.Accuracy = 0
+	if !accuracy.Known {
+		return
+	}
+
+	diff := math.Abs(float64(accuracy.Estimated - accuracy.Actual))
+	switch {
+	case accuracy.Actual != 0:
+		accuracy.Accuracy = math.Max(0, 100-diff/math.Abs(float64(accuracy.Actual))*100)
+	case diff == 0:
+		accuracy.Accuracy = 100
+	}
+}
+
+// Rescale converts amount with fromDecimals decimal places to amount with toDecimals decimal places.
+func Rescale(amount int64, fromDecimals, toDecimals int32) int64 {
+	for ; fromDecimals < toDecimals; fromDecimals++ {
+		amount *= 10
+	}
+	for ; fromDecimals > toDecimals; fromDecimals-- {
+		amount /= 10
+	}
+	return amount
+}
+
+// SatelliteUndistributed contains amount paid by the satellite to all nodes, which was not yet distributed.
+type SatelliteUndistributed struct {
+	SatelliteID   storj.NodeID `json:"satelliteId"`
+	Undistributed int64        `json:"undistributed"`
+}
+
+// PendingPayouts sums undistributed amounts of every satellite across nodes and returns satellites
+// with sum above minAmount, sorted from the biggest amount.
+func PendingPayouts(undistributed []*multinodepb.UndistributedSatellite, minAmount int64) []SatelliteUndistributed {
+	var satellites []SatelliteUndistributed
+	indexes := make(map[storj.NodeID]int)
+
+	for _, satellite := range undistributed {
+		index, ok := indexes[satellite.SatelliteId]
+		if !ok {
+			index = len(satellites)
+			indexes[satellite.SatelliteId] = index
+			satellites = append(satellites, SatelliteUndistributed{SatelliteID: satellite.SatelliteId})
+		}
+
+		satellites[index].Undistributed += satellite.Total
+	}
+
+	var pending []SatelliteUndistributed
+	for _, satellite := range satellites {
+		if satellite.Undistributed > minAmount {
+			pending = append(pending, satellite)
+		}
+	}
+
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].Undistributed > pending[j].Undistributed
+	})
+
+	return pending
+}
+
+// DroppedNodes returns nodes which earned in the previous summary, but didn't earn anything in the current one.
+// Nodes which were not reached in the current summary are not reported.
+func DroppedNodes(previous, current Summary) storj.NodeIDList {
+	earned := make(map[storj.NodeID]bool)
+	unknown := make(map[storj.NodeID]bool)
+	for _, node := range current.NodeSummary {
+		if node.CircuitOpen {
+			unknown[node.NodeID] = true
+			continue
+		}
+		if node.Held+node.Paid > 0 {
+			earned[node.NodeID] = true
+		}
+	}
+
+	var dropped storj.NodeIDList
+	for _, node := range previous.NodeSummary {
+		if node.CircuitOpen || node.Held+node.Paid <= 0 {
+			continue
+		}
+		if !earned[node.NodeID] && !unknown[node.NodeID] {
+			dropped = append(dropped, node.NodeID)
+		}
+	}
+
+	return dropped
+}
+
+// PreviousPeriod returns period preceding the provided one, both in YYYY-MM format.
+func PreviousPeriod(period string) (string, error) {
+	t, err := time.Parse("2006-01", period)
+	if err != nil {
+		return "", err
+	}
+
+	return t.AddDate(0, -1, 0).Format("2006-01"), nil
+}
+
+// SatelliteConnectivity contains result of the node's last check-in on a satellite.
+type SatelliteConnectivity struct {
+	SatelliteID storj.NodeID `json:"satelliteId"`
+	// Attempted is false when node hasn't tried to check-in on the satellite since it started.
+	Attempted bool      `json:"attempted"`
+	Reachable bool      `json:"reachable"`
+	CheckedAt time.Time `json:"checkedAt"`
+	Error     string    `json:"error"`
+}
+
+// NodeConnectivity contains connectivity of the node to its trusted satellites.
+type NodeConnectivity struct {
+	NodeID   storj.NodeID `json:"nodeId"`
+	NodeName string       `json:"nodeName"`
+	// Error is set when the node itself could not be reached, Satellites are empty in that case.
+	Error      string                  `json:"error"`
+	Satellites []SatelliteConnectivity `json:"satellites"`
+}
+
+// NewNodeConnectivity creates node connectivity from the node check-in results.
+func NewNodeConnectivity(nodeID storj.NodeID, nodeName string, checkIns []*multinodepb.SatelliteCheckInsResponse_CheckIn) NodeConnectivity {
+	connectivity := NodeConnectivity{
+		NodeID:   nodeID,
+		NodeName: nodeName,
+	}
+
+	for _, checkIn := range checkIns {
+		connectivity.Satellites = append(connectivity.Satellites, SatelliteConnectivity{
+			SatelliteID: checkIn.SatelliteId,
+			Attempted:   checkIn.Attempted,
+			Reachable:   checkIn.Attempted && checkIn.Succeeded,
+			CheckedAt:   checkIn.CheckedAt,
+			Error:       checkIn.Error,
+		})
+	}
+
+	return connectivity
+}
+
+// UnreachableSatellites returns satellites on which the last node check-in failed.
+func (connectivity NodeConnectivity) UnreachableSatellites() storj.NodeIDList {
+	var unreachable storj.NodeIDList
+	for _, satellite := range connectivity.Satellites {
+		if satellite.Attempted && !satellite.Reachable {
+			unreachable = append(unreachable, satellite.SatelliteID)
+		}
+	}
+
+	return unreachable
+}
+
+// NodeError describes a node which failed to respond during aggregation.
+type NodeError struct {
+	NodeID   storj.NodeID `json:"nodeId"`
+	NodeName string       `json:"nodeName"`
+	Error    string       `json:"error"`
+}
+
+// Estimation contains estimated earnings summed over reachable nodes,
+// with errors of nodes which failed to respond and are not included in the total.
+type Estimation struct {
+	EstimatedEarnings int64 `json:"estimatedEarnings"`
+	// WeightedEstimatedEarnings scales estimations of satellites by the configured weights.
+	WeightedEstimatedEarnings int64       `json:"weightedEstimatedEarnings"`
+	NodeErrors                []NodeError `json:"nodeErrors"`
+}
+
+// PaystubCoverage contains range of periods node has paystubs for, with periods missing in that range.
+type PaystubCoverage struct {
+	NodeID   storj.NodeID `json:"nodeId"`
+	NodeName string       `json:"nodeName"`
+	// Earliest and Latest are empty when node has no paystubs.
+	Earliest string   `json:"earliest"`
+	Latest   string   `json:"latest"`
+	Gaps     []string `json:"gaps"`
+	HasGaps  bool     `json:"hasGaps"`
+}
+
+// NewPaystubCoverage creates paystub coverage from unordered list of periods the node has paystubs for.
+func NewPaystubCoverage(nodeID storj.NodeID, nodeName string, periods []string) (_ PaystubCoverage, err error) {
+	coverage := PaystubCoverage{
+		NodeID:   nodeID,
+		NodeName: nodeName,
+	}
+
+	covered := make(map[time.Time]bool, len(periods))
+	var earliest, latest time.Time
+	for _, period := range periods {
+		month, err := time.Parse("2006-01", period)
+		if err != nil {
+			return PaystubCoverage{}, err
+		}
+
+		covered[month] = true
+		if earliest.IsZero() || month.Before(earliest) {
+			earliest = month
+		}
+		if month.After(latest) {
+			latest = month
+		}
+	}
+
+	if len(covered) == 0 {
+		return coverage, nil
+	}
+
+	coverage.Earliest = earliest.Format("2006-01")
+	coverage.Latest = latest.Format("2006-01")
+	for month := earliest; month.Before(latest); month = month.AddDate(0, 1, 0) {
+		if !covered[month] {
+			coverage.Gaps = append(coverage.Gaps, month.Format("2006-01"))
+		}
+	}
+	coverage.HasGaps = len(coverage.Gaps) > 0
+
+	return coverage, nil
+}
+
+// NodeEarned contains all time earned amount of the node.
+type NodeEarned struct {
+	NodeID   storj.NodeID `json:"nodeId"`
+	NodeName string       `json:"nodeName"`
+	Earned
+	// Annotation is the note operator attached to the node, empty when there is none.
+	Annotation string `json:"annotation"`
+}
+
+// EarningsByNodeID contains all time earned amount of every node keyed by node id, which unlike the node name
+// doesn't change when the operator renames the node, so earnings collected at different times can be matched.
+type EarningsByNodeID map[storj.NodeID]NodeEarned
+
+// NewEarningsByNodeID indexes earnings of the nodes by node id, the last entry of a node listed more than once wins.
+func NewEarningsByNodeID(earned []NodeEarned) EarningsByNodeID {
+	byID := make(EarningsByNodeID, len(earned))
+	for _, node := range earned {
+		byID[node.NodeID] = node
+	}
+	return byID
+}
+
+// NodePayoutAfterCut contains node payout split into the operator cut and the net payout.
+type NodePayoutAfterCut struct {
+	NodeID   storj.NodeID `json:"nodeId"`
+	NodeName string       `json:"nodeName"`
+	// Gross, Cut and Net are amounts in micro USD, Net is Gross minus Cut.
+	Gross int64 `json:"gross"`
+	Cut   int64 `json:"cut"`
+	Net   int64 `json:"net"`
+}
+
+// PayoutAfterCut contains payouts of all nodes split into the operator cut and the net payout,
+// e.g. for operators hosting nodes for others under revenue share.
+type PayoutAfterCut struct {
+	// CutFraction is the fraction of the payout kept by the operator.
+	CutFraction float64 `json:"cutFraction"`
+	Gross       int64   `json:"gross"`
+	Cut         int64   `json:"cut"`
+	Net         int64   `json:"net"`
+	// Nodes contains split of every node, only when requested.
+	Nodes []NodePayoutAfterCut `json:"nodes,omitempty"`
+}
+
+// ValidateCutFraction checks that the operator cut fraction is between 0 and 1 inclusive.
+func ValidateCutFraction(cutFraction float64) error {
+	if math.IsNaN(cutFraction) || cutFraction < 0 || cutFraction > 1 {
+		return Error.New("cut fraction must be between 0 and 1: %v", cutFraction)
+	}
+	return nil
+}
+
+// ApplyOperatorCut splits gross earnings of every node into the operator cut and the net payout.
+// The cut of each node is rounded to the nearest micro USD and totals are sums of the nodes,
+// so they always add up. Split of every node is included when perNode is true.
+func ApplyOperatorCut(earned []NodeEarned, cutFraction float64, perNode bool) (PayoutAfterCut, error) {
+	if err := ValidateCutFraction(cutFraction); err != nil {
+		return PayoutAfterCut{}, err
+	}
+
+	payout := PayoutAfterCut{CutFraction: cutFraction}
+	for _, node := range earned {
+		cut := int64(math.Round(float64(node.Gross) * cutFraction))
+		split := NodePayoutAfterCut{
+			NodeID:   node.NodeID,
+			NodeName: node.NodeName,
+			Gross:    node.Gross,
+			Cut:      cut,
+			Net:      node.Gross - cut,
+		}
+
+		payout.Gross += split.Gross
+		payout.Cut += split.Cut
+		payout.Net += split.Net
+		if perNode {
+			payout.Nodes = append(payout.Nodes, split)
+		}
+	}
+
+	return payout, nil
+}
+
+// ConcentrationRatio returns the fraction of the total earned by the top n earning nodes.
+// It's 1 when there are no more than n nodes earning anything, and 0 when nothing was earned.
+func ConcentrationRatio(earned []int64, n int) float64 {
+	sorted := append([]int64(nil), earned...)
+	sort.Slice(sorted, func(i, k int) bool { return sorted[i] > sorted[k] })
+
+	var total, top int64
+	for i, amount := range sorted {
+		total += amount
+		if i < n {
+			top += amount
+		}
+	}
+
+	if total <= 0 {
+		return 0
+	}
+	return float64(top) / float64(total)
+}
+
+// Quartiles contains quartiles of earned amounts, Median is the second quartile.
+// All quartiles are zero when nothing is known.
+type Quartiles struct {
+	Lower  int64 `json:"lower"`
+	Median int64 `json:"median"`
+	Upper  int64 `json:"upper"`
+}
+
+// EarnedQuartiles returns quartiles of the earned amounts. Median of an even count of amounts is the mean
+// of the middle two, rounded down. Lower and upper quartiles are medians of the halves below and above the median,
+// which exclude the middle amount of an odd count.
+func EarnedQuartiles(earned []int64) Quartiles {
+	if len(earned) == 0 {
+		return Quartiles{}
+	}
+
+	sorted := append([]int64(nil), earned...)
+	sort.Slice(sorted, func(i, k int) bool { return sorted[i] < sorted[k] })
+
+	half := len(sorted) / 2
+	if len(sorted) == 1 {
+		return Quartiles{Lower: sorted[0], Median: sorted[0], Upper: sorted[0]}
+	}
+
+	return Quartiles{
+		Lower:  median(sorted[:half]),
+		Median: median(sorted),
+		Upper:  median(sorted[len(sorted)-half:]),
+	}
+}
+
+// median returns median of the sorted non-empty amounts.
+func median(sorted []int64) int64 {
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[middle]
+	}
+	// adding half of the difference avoids overflow of the sum.
+	low, high := sorted[middle-1], sorted[middle]
+	return low + (high-low)/2
+}
+
+// HistogramBucket contains number of nodes whose earnings fall into the bucket.
+// Bucket holds earnings above the previous bucket bound, up to and including its own UpperBound.
+type HistogramBucket struct {
+	UpperBound int64 `json:"upperBound"`
+	// Overflow bucket holds earnings above the highest bound, its UpperBound is not set.
+	Overflow bool `json:"overflow"`
+	Count    int  `json:"count"`
+}
+
+// EarningsHistogram classifies earned amounts into buckets with provided strictly increasing bounds,
+// followed by the overflow bucket.
+func EarningsHistogram(bucketBounds []int64, earned []int64) (_ []HistogramBucket, err error) {
+	histogram := make([]HistogramBucket, 0, len(bucketBounds)+1)
+	for i, bound := range bucketBounds {
+		if i > 0 && bound <= bucketBounds[i-1] {
+			return nil, Error.New("bucket bounds must be strictly increasing: %d follows %d", bound, bucketBounds[i-1])
+		}
+		histogram = append(histogram, HistogramBucket{UpperBound: bound})
+	}
+	histogram = append(histogram, HistogramBucket{Overflow: true})
+
+	for _, amount := range earned {
+		index := sort.Search(len(bucketBounds), func(i int) bool {
+			return amount <= bucketBounds[i]
+		})
+		histogram[index].Count++
+	}
+
+	return histogram, nil
+}
+
+// NodeHeldRate contains amount held by the satellite from the node earnings in a period.
+type NodeHeldRate struct {
+	NodeID      storj.NodeID
+	NodeName    string
+	SatelliteID storj.NodeID
+	JoinedAt    time.Time
+	// Held and Earned are amounts in micro USD.
+	Held   int64
+	Earned int64
+}
+
+// HeldPercent returns percentage of the earnings held by the satellite.
+func (rate NodeHeldRate) HeldPercent() float64 {
+	if rate.Earned <= 0 {
+		return 0
+	}
+	return float64(rate.Held) / float64(rate.Earned) * 100
+}
+
+// HeldOutlier contains node whose held percentage diverges from nodes which joined the satellite in the same month.
+type HeldOutlier struct {
+	NodeID      storj.NodeID `json:"nodeId"`
+	NodeName    string       `json:"nodeName"`
+	SatelliteID storj.NodeID `json:"satelliteId"`
+	// Cohort is the month node joined the satellite in YYYY-MM format.
+	Cohort string `json:"cohort"`
+	// Expected is the median held percentage of the cohort, Actual is held percentage of the node.
+	Expected float64 `json:"expected"`
+	Actual   float64 `json:"actual"`
+}
+
+// minHeldCohortSize is the smallest cohort outliers are looked for in,
+// since with two nodes it's not possible to tell which of them diverges.
+const minHeldCohortSize = 3
+
+// FindHeldOutliers groups held rates into cohorts of nodes which joined the same satellite in the same month,
+// and returns nodes whose held percentage differs from the cohort median by more than tolerance percentage points.
+// Rates without earnings are ignored, since held percentage is not defined for them.
+func FindHeldOutliers(rates []NodeHeldRate, tolerance float64) []HeldOutlier {
+	type cohortKey struct {
+		satelliteID storj.NodeID
+		joined      string
+	}
+
+	var keys []cohortKey
+	cohorts := make(map[cohortKey][]NodeHeldRate)
+	for _, rate := range rates {
+		if rate.Earned <= 0 {
+			continue
+		}
+
+		key := cohortKey{satelliteID: rate.SatelliteID, joined: rate.JoinedAt.UTC().Format("2006-01")}
+		if _, ok := cohorts[key]; !ok {
+			keys = append(keys, key)
+		}
+		cohorts[key] = append(cohorts[key], rate)
+	}
+
+	var outliers []HeldOutlier
+	for _, key := range keys {
+		cohort := cohorts[key]
+		if len(cohort) < minHeldCohortSize {
+			continue
+		}
+
+		percents := make([]float64, 0, len(cohort))
+		for _, rate := range cohort {
+			percents = append(percents, rate.HeldPercent())
+		}
+		sort.Float64s(percents)
+
+		expected := percents[len(percents)/2]
+		if len(percents)%2 == 0 {
+			expected = (percents[len(percents)/2-1] + expected) / 2
+		}
+
+		for _, rate := range cohort {
+			actual := rate.HeldPercent()
+			if math.Abs(actual-expected) <= tolerance {
+				continue
+			}
+
+			outliers = append(outliers, HeldOutlier{
+				NodeID:      rate.NodeID,
+				NodeName:    rate.NodeName,
+				SatelliteID: rate.SatelliteID,
+				Cohort:      key.joined,
+				Expected:    expected,
+				Actual:      actual,
+			})
+		}
+	}
+
+	return outliers
+}
+
+// NodeEstimate contains node estimated earnings of the current month in micro USD.
+type NodeEstimate struct {
+	NodeID   storj.NodeID
+	NodeName string
+	Estimate int64
+}
+
+// EstimateAnomaly contains node whose current month estimate dropped sharply compared to its baseline,
+// e.g. node which suddenly reports zero estimate.
+type EstimateAnomaly struct {
+	NodeID   storj.NodeID `json:"nodeId"`
+	NodeName string       `json:"nodeName"`
+	// Baseline and Current are estimates in micro USD.
+	Baseline int64 `json:"baseline"`
+	Current  int64 `json:"current"`
+	// Drop is the percentage current estimate is below the baseline.
+	Drop float64 `json:"drop"`
+}
+
+// FindEstimateAnomalies returns nodes whose estimate dropped by more than dropPercent of their baseline.
+// Nodes without positive baseline are ignored, since drop is not defined for them.
+func FindEstimateAnomalies(baseline map[storj.NodeID]int64, estimates []NodeEstimate, dropPercent float64) []EstimateAnomaly {
+	var anomalies []EstimateAnomaly
+	for _, estimate := range estimates {
+		base := baseline[estimate.NodeID]
+		if base <= 0 {
+			continue
+		}
+
+		drop := float64(base-estimate.Estimate) / float64(base) * 100
+		if drop <= dropPercent {
+			continue
+		}
+
+		anomalies = append(anomalies, EstimateAnomaly{
+			NodeID:   estimate.NodeID,
+			NodeName: estimate.NodeName,
+			Baseline: base,
+			Current:  estimate.Estimate,
+			Drop:     drop,
+		})
+	}
+
+	return anomalies
+}
+
+// NodeTrend contains node earnings in consecutive periods, e.g. to render a sparkline.
+type NodeTrend struct {
+	NodeID   storj.NodeID `json:"nodeId"`
+	NodeName string       `json:"nodeName"`
+	Periods  []string     `json:"periods"`
+	// Earned contains amount earned in each of the periods, in the periods order.
+	Earned []int64 `json:"earned"`
+	// Annotation is the note operator attached to the node, empty when there is none.
+	Annotation string `json:"annotation"`
+}
+
+// NewNodeTrend creates node trend with earnings aligned to the periods, periods without earnings are zero.
+func NewNodeTrend(nodeID storj.NodeID, nodeName string, periods []string, earned map[string]int64) NodeTrend {
+	trend := NodeTrend{
+		NodeID:   nodeID,
+		NodeName: nodeName,
+		Periods:  periods,
+		Earned:   make([]int64, len(periods)),
+	}
+
+	for i, period := range periods {
+		trend.Earned[i] = earned[period]
+	}
+
+	return trend
+}
+
+// ChartMetric is the payouts value plotted on a chart.
+type ChartMetric string
+
+const (
+	// ChartEarned plots the amount earned, which is held and paid together.
+	ChartEarned ChartMetric = "earned"
+	// ChartHeld plots the amount held by satellites.
+	ChartHeld ChartMetric = "held"
+	// ChartPaid plots the amount paid out.
+	ChartPaid ChartMetric = "paid"
+)
+
+// Validate returns an error when the metric is not known.
+func (metric ChartMetric) Validate() error {
+	switch metric {
+	case ChartEarned, ChartHeld, ChartPaid:
+		return nil
+	default:
+		return Error.New("invalid chart metric %q: must be earned, held or paid", string(metric))
+	}
+}
+
+// Value returns the metric value of the payout info, zero when the metric is not known.
+func (metric ChartMetric) Value(info *multinodepb.PayoutInfo) int64 {
+	switch metric {
+	case ChartEarned:
+		return info.Held + info.Paid
+	case ChartHeld:
+		return info.Held
+	case ChartPaid:
+		return info.Paid
+	default:
+		return 0
+	}
+}
+
+// ChartPoint is the value of the metric in a single period.
+type ChartPoint struct {
+	Period string `json:"period"`
+	Value  int64  `json:"value"`
+}
+
+// NewChartPoints creates chart points aligned to the periods, periods without values are zero.
+func NewChartPoints(periods []string, values map[string]int64) []ChartPoint {
+	points := make([]ChartPoint, len(periods))
+	for i, period := range periods {
+		points[i] = ChartPoint{Period: period, Value: values[period]}
+	}
+
+	return points
+}
+
+// NodeVelocity contains node earnings in a period normalized per day.
+type NodeVelocity struct {
+	NodeID   storj.NodeID `json:"nodeId"`
+	NodeName string       `json:"nodeName"`
+	// Earned and PerDay are amounts in micro USD.
+	Earned int64 `json:"earned"`
+	PerDay int64 `json:"perDay"`
+}
+
+// EarningsVelocity contains earnings of all nodes in a period normalized per day.
+type EarningsVelocity struct {
+	Period string `json:"period"`
+	// Days is the number of days of the period, only the elapsed ones for the current period.
+	Days   int            `json:"days"`
+	Earned int64          `json:"earned"`
+	PerDay int64          `json:"perDay"`
+	Nodes  []NodeVelocity `json:"nodes"`
+}
+
+// PeriodDays returns the number of full days elapsed in the period in YYYY-MM format as of now.
+// It's the number of days in the month for completed periods and zero for periods which haven't started yet.
+func PeriodDays(period string, now time.Time) (int, error) {
+	start, err := time.Parse("2006-01", period)
+	if err != nil {
+		return 0, err
+	}
+
+	end := start.AddDate(0, 1, 0)
+	now = now.UTC()
+	switch {
+	case !now.After(start):
+		return 0, nil
+	case now.Before(end):
+		return int(now.Sub(start) / (24 * time.Hour)), nil
+	default:
+		return int(end.Sub(start) / (24 * time.Hour)), nil
+	}
+}
+
+// NewEarningsVelocity divides earnings of every node and their total by the number of days.
+// Velocity is zero when no day of the period has elapsed yet.
+func NewEarningsVelocity(period string, days int, nodes []NodeVelocity) EarningsVelocity {
+	perDay := func(earned int64) int64 {
+		if days <= 0 {
+			return 0
+		}
+		return earned / int64(days)
+	}
+
+	velocity := EarningsVelocity{Period: period, Days: days, Nodes: nodes}
+	for i := range velocity.Nodes {
+		velocity.Nodes[i].PerDay = perDay(velocity.Nodes[i].Earned)
+		velocity.Earned += velocity.Nodes[i].Earned
+	}
+	velocity.PerDay = perDay(velocity.Earned)
+
+	return velocity
+}
+
+// NodeWallet is the wallet configured by the operator of the node, empty when not configured.
+type NodeWallet struct {
+	NodeID   storj.NodeID
+	NodeName string
+	Wallet   string
+}
+
+// DistinctWallet is a wallet address configured on one or more nodes.
+type DistinctWallet struct {
+	Wallet    string `json:"wallet"`
+	NodeCount int    `json:"nodeCount"`
+}
+
+// Wallets contains distinct wallets configured across the nodes.
+type Wallets struct {
+	Wallets []DistinctWallet `json:"wallets"`
+	// WithoutWallet contains nodes which have no wallet configured.
+	WithoutWallet storj.NodeIDList `json:"withoutWallet"`
+}
+
+// NewWallets deduplicates wallets of the nodes, ordered by node count descending.
+// Addresses are compared case insensitively, since the case of hex addresses is only a checksum.
+func NewWallets(nodeWallets []NodeWallet) Wallets {
+	var wallets Wallets
+	index := make(map[string]int)
+
+	for _, nodeWallet := range nodeWallets {
+		address := strings.TrimSpace(nodeWallet.Wallet)
+		if address == "" {
+			wallets.WithoutWallet = append(wallets.WithoutWallet, nodeWallet.NodeID)
+			continue
+		}
+
+		key := strings.ToLower(address)
+		i, ok := index[key]
+		if !ok {
+			i = len(wallets.Wallets)
+			index[key] = i
+			wallets.Wallets = append(wallets.Wallets, DistinctWallet{Wallet: address})
+		}
+		wallets.Wallets[i].NodeCount++
+	}
+
+	sort.SliceStable(wallets.Wallets, func(i, j int) bool {
+		return wallets.Wallets[i].NodeCount > wallets.Wallets[j].NodeCount
+	})
+
+	return wallets
+}
+
+// NodeTenure contains the first period the node earned on the satellite.
+type NodeTenure struct {
+	NodeID      storj.NodeID `json:"nodeId"`
+	NodeName    string       `json:"nodeName"`
+	SatelliteID storj.NodeID `json:"satelliteId"`
+	FirstPeriod string       `json:"firstPeriod"`
+	// Months is the number of months from the first period until now, the first period is month 1.
+	Months int `json:"months"`
+}
+
+// TenureMonths returns the number of months from the first period in YYYY-MM format until now,
+// counting the first period as month 1.
+func TenureMonths(firstPeriod string, now time.Time) (int, error) {
+	first, err := time.Parse("2006-01", firstPeriod)
+	if err != nil {
+		return 0, err
+	}
+
+	months := (now.Year()-first.Year())*12 + int(now.Month()-first.Month()) + 1
+	if months < 1 {
+		return 0, nil
+	}
+	return months, nil
+}
+
+// ReconciliationStatus is the result of comparing amount paid according to paystubs with payments recorded by the node.
+type ReconciliationStatus string
+
+const (
+	// ReconciliationMatch means paid and received amounts differ at most by the tolerance.
+	ReconciliationMatch ReconciliationStatus = "match"
+	// ReconciliationDiscrepancy means paid and received amounts differ by more than the tolerance.
+	ReconciliationDiscrepancy ReconciliationStatus = "discrepancy"
+	// ReconciliationNoRecords means the node has no payment records to compare with.
+	ReconciliationNoRecords ReconciliationStatus = "no-records"
+)
+
+// PaidReconciliation compares amount paid by the satellite according to paystubs with payments recorded by the node.
+type PaidReconciliation struct {
+	NodeID      storj.NodeID `json:"nodeId"`
+	NodeName    string       `json:"nodeName"`
+	SatelliteID storj.NodeID `json:"satelliteId"`
+	Paid        int64        `json:"paid"`
+	Received    int64        `json:"received"`
+	// Difference is paid minus received, positive when the node recorded less than the satellite reported.
+	Difference int64                `json:"difference"`
+	Status     ReconciliationStatus `json:"status"`
+}
+
+// Reconcile compares paid with received amount of the given number of payments.
+func Reconcile(paid, received int64, payments int, tolerance int64) ReconciliationStatus {
+	if payments == 0 {
+		return ReconciliationNoRecords
+	}
+
+	difference := paid - received
+	if difference < 0 {
+		difference = -difference
+	}
+	if difference > tolerance {
+		return ReconciliationDiscrepancy
+	}
+	return ReconciliationMatch
+}
+
+// EarnedComponents contains all time earned amount split by the payout component.
+type EarnedComponents struct {
+	nodepayouts.EarnedComponents
+	// Unattributed is earned amount of nodes which don't report components, e.g. older versions.
+	Unattributed int64 `json:"unattributed"`
+}
+
+// Add adds earned amount of the satellite, using its components when reported.
+func (components *EarnedComponents) Add(satellite *multinodepb.EarnedSatellite) {
+	if satellite.Components == nil {
+		components.Unattributed += satellite.Total
+		return
+	}
+
+	components.EarnedComponents.Add(nodeEarnedComponents(satellite.Components))
+}
+
+// Total returns sum of all components, which is the total earned amount.
+func (components EarnedComponents) Total() int64 {
+	return components.EarnedComponents.Total() + components.Unattributed
+}
+
+// nodeEarnedComponents converts earned components received from the node.
+func nodeEarnedComponents(components *multinodepb.EarnedComponents) nodepayouts.EarnedComponents {
+	return nodepayouts.EarnedComponents{
+		AtRest:    components.CompAtRest,
+		Get:       components.CompGet,
+		GetRepair: components.CompGetRepair,
+		GetAudit:  components.CompGetAudit,
+	}
+}
+
+// NodeTimeToThreshold is a projection of when the node undistributed balance reaches the payout threshold.
+type NodeTimeToThreshold struct {
+	NodeID   storj.NodeID `json:"nodeId"`
+	NodeName string       `json:"nodeName"`
+	// Undistributed and Estimated are amounts in micro USD.
+	Undistributed int64 `json:"undistributed"`
+	Estimated     int64 `json:"estimated"`
+	// Months until the threshold is met, zero when it's already met.
+	Months int `json:"months"`
+	// Unknown is set when the threshold is never met with the current estimate, Months is zero then.
+	Unknown bool `json:"unknown"`
+}
+
+// MonthsToThreshold projects how many months of monthly earnings it takes for balance to reach threshold.
+// Reports false when the threshold is not met and monthly earnings are not positive.
+func MonthsToThreshold(balance, monthly, threshold int64) (months int, ok bool) {
+	if balance >= threshold {
+		return 0, true
+	}
+	if monthly <= 0 {
+		return 0, false
+	}
+
+	missing := threshold - balance
+	return int((missing + monthly - 1) / monthly), true
+}
+
+// SatelliteMembership reports satellites of the node which differ from the expected ones.
+type SatelliteMembership struct {
+	NodeID   storj.NodeID `json:"nodeId"`
+	NodeName string       `json:"nodeName"`
+	// Extra are satellites the node is paid by, which are not expected.
+	Extra storj.NodeIDList `json:"extra"`
+	// Missing are expected satellites, which never paid the node.
+	Missing storj.NodeIDList `json:"missing"`
+}
+
+// CompareSatellites returns sorted satellites contained only in actual and only in expected.
+func CompareSatellites(expected, actual []storj.NodeID) (extra, missing storj.NodeIDList) {
+	expectedSet := make(map[storj.NodeID]bool, len(expected))
+	for _, id := range expected {
+		expectedSet[id] = true
+	}
+	actualSet := make(map[storj.NodeID]bool, len(actual))
+	for _, id := range actual {
+		actualSet[id] = true
+	}
+
+	for id := range actualSet {
+		if !expectedSet[id] {
+			extra = append(extra, id)
+		}
+	}
+	for id := range expectedSet {
+		if !actualSet[id] {
+			missing = append(missing, id)
+		}
+	}
+
+	sort.Sort(extra)
+	sort.Sort(missing)
+	return extra, missing
+}
+
+// NodeSatelliteCount contains the number of distinct satellites that ever paid the node.
+type NodeSatelliteCount struct {
+	NodeID     storj.NodeID `json:"nodeId"`
+	NodeName   string       `json:"nodeName"`
+	Satellites int          `json:"satellites"`
+	// Unreachable is true when the node failed to respond, its count is unknown.
+	Unreachable bool `json:"unreachable"`
+}
+
+// SatelliteCounts contains satellite counts of every node and their distribution across the fleet.
+// Unreachable nodes are listed, but they don't count towards the distribution.
+type SatelliteCounts struct {
+	Nodes   []NodeSatelliteCount `json:"nodes"`
+	Min     int                  `json:"min"`
+	Max     int                  `json:"max"`
+	Average float64              `json:"average"`
+}
+
+// NewSatelliteCounts calculates distribution of satellite counts of reachable nodes.
+func NewSatelliteCounts(counts []NodeSatelliteCount) SatelliteCounts {
+	distribution := SatelliteCounts{Nodes: counts}
+
+	var reachable, total int
+	for _, count := range counts {
+		if count.Unreachable {
+			continue
+		}
+
+		if reachable == 0 || count.Satellites < distribution.Min {
+			distribution.Min = count.Satellites
+		}
+		if count.Satellites > distribution.Max {
+			distribution.Max = count.Satellites
+		}
+		total += count.Satellites
+		reachable++
+	}
+
+	if reachable > 0 {
+		distribution.Average = float64(total) / float64(reachable)
+	}
+
+	return distribution
+}
+
+// HeldHistoryPeriod contains amount held and disposed by the satellite in a single period, summed across nodes.
+type HeldHistoryPeriod struct {
+	SatelliteID storj.NodeID `json:"satelliteId"`
+	Period      string       `json:"period"`
+	Held        int64        `json:"held"`
+	Disposed    int64        `json:"disposed"`
+}
+
+// HeldHistory sums held history entries of all nodes per satellite and period.
+// Its size is bounded by the number of satellites and periods, regardless of the number of nodes.
+type HeldHistory struct {
+	periods map[heldHistoryKey]*HeldHistoryPeriod
+}
+
+type heldHistoryKey struct {
+	satelliteID storj.NodeID
+	period      string
+}
+
+// Add adds amounts of the entry to its satellite and period.
+func (history *HeldHistory) Add(satelliteID storj.NodeID, entry *multinodepb.HeldHistoryEntry) {
+	if history.periods == nil {
+		history.periods = make(map[heldHistoryKey]*HeldHistoryPeriod)
+	}
+
+	key := heldHistoryKey{satelliteID: satelliteID, period: entry.Period}
+	period, ok := history.periods[key]
+	if !ok {
+		period = &HeldHistoryPeriod{SatelliteID: satelliteID, Period: entry.Period}
+		history.periods[key] = period
+	}
+
+	period.Held += entry.Held
+	period.Disposed += entry.Disposed
+}
+
+// List returns all periods sorted by satellite id and period.
+func (history *HeldHistory) List() []HeldHistoryPeriod {
+	list := make([]HeldHistoryPeriod, 0, len(history.periods))
+	for _, period := range history.periods {
+		list = append(list, *period)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].SatelliteID != list[j].SatelliteID {
+			return list[i].SatelliteID.Less(list[j].SatelliteID)
+		}
+		return list[i].Period < list[j].Period
+	})
+	return list
+}
+
+// SatelliteReputation contains reputation scores nodes received from the satellite, averaged across nodes.
+type SatelliteReputation struct {
+	SatelliteID storj.NodeID `json:"satelliteId"`
+	// Nodes is the number of nodes the satellite reported scores to, scores are averaged across them.
+	Nodes int `json:"nodes"`
+	// Unreported is the number of nodes trusting the satellite, which didn't receive scores from it yet.
+	Unreported      int     `json:"unreported"`
+	AuditScore      float64 `json:"auditScore"`
+	SuspensionScore float64 `json:"suspensionScore"`
+	OnlineScore     float64 `json:"onlineScore"`
+}
+
+// ReputationScores averages reputation scores of all nodes per satellite.
+type ReputationScores struct {
+	satellites map[storj.NodeID]*SatelliteReputation
+}
+
+// Add adds scores a node received from the satellite.
+func (reputation *ReputationScores) Add(satelliteID storj.NodeID, scores *multinodepb.ReputationScoresResponse_SatelliteScores) {
+	if reputation.satellites == nil {
+		reputation.satellites = make(map[storj.NodeID]*SatelliteReputation)
+	}
+
+	satellite, ok := reputation.satellites[satelliteID]
+	if !ok {
+		satellite = &SatelliteReputation{SatelliteID: satelliteID}
+		reputation.satellites[satelliteID] = satellite
+	}
+
+	if !scores.Reported {
+		satellite.Unreported++
+		return
+	}
+
+	// scores are summed until they are listed.
+	satellite.Nodes++
+	satellite.AuditScore += scores.AuditScore
+	satellite.SuspensionScore += scores.SuspensionScore
+	satellite.OnlineScore += scores.OnlineScore
+}
+
+// List returns average scores of every satellite sorted by satellite id.
+// Scores of satellites which didn't report scores to any node are zero.
+func (reputation *ReputationScores) List() []SatelliteReputation {
+	list := make([]SatelliteReputation, 0, len(reputation.satellites))
+	for _, satellite := range reputation.satellites {
+		average := *satellite
+		if average.Nodes > 0 {
+			average.AuditScore /= float64(average.Nodes)
+			average.SuspensionScore /= float64(average.Nodes)
+			average.OnlineScore /= float64(average.Nodes)
+		}
+		list = append(list, average)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].SatelliteID.Less(list[j].SatelliteID)
+	})
+	return list
+}
+
+// HeldHistoryReceiver receives held history entries one by one, e.g. DRPCPayout_HeldHistoryStreamClient.
+type HeldHistoryReceiver interface {
+	Recv() (*multinodepb.HeldHistoryEntry, error)
+}
+
+// ReceiveHeldHistory passes every entry received from the stream to add, until the stream ends.
+func ReceiveHeldHistory(stream HeldHistoryReceiver, add func(*multinodepb.HeldHistoryEntry)) error {
+	for {
+		entry, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		add(entry)
+	}
+}
+
+// heldReturnMonth is the month of node tenure on a satellite, counting the first period as month 1,
+// in which the satellite returns heldReturnPercent of the amount held so far.
+const (
+	heldReturnMonth   = 16
+	heldReturnPercent = 50
+)
+
+// HeldReturn contains held amount expected to be returned to the nodes in a period.
+type HeldReturn struct {
+	Period   string `json:"period"`
+	Returned int64  `json:"returned"`
+}
+
+// NodeHeldReturns returns held amounts expected to be returned to a single node, keyed by period.
+// Satellite returns half of the held balance in the 16th month of node tenure, which is counted from
+// the first period in the history. Satellites which already disposed held amount don't return anything more
+// until graceful exit. Amounts held in future periods aren't known, so only the current balance is returned.
+func NodeHeldReturns(history []HeldHistoryPeriod) (map[string]int64, error) {
+	type satelliteHeld struct {
+		first    string
+		balance  int64
+		disposed bool
+	}
+
+	satellites := make(map[storj.NodeID]*satelliteHeld)
+	for _, period := range history {
+		satellite, ok := satellites[period.SatelliteID]
+		if !ok {
+			satellite = &satelliteHeld{first: period.Period}
+			satellites[period.SatelliteID] = satellite
+		}
+
+		if period.Period < satellite.first {
+			satellite.first = period.Period
+		}
+		satellite.balance += period.Held - period.Disposed
+		if period.Disposed > 0 {
+			satellite.disposed = true
+		}
+	}
+
+	returns := make(map[string]int64)
+	for _, satellite := range satellites {
+		if satellite.disposed || satellite.balance <= 0 {
+			continue
+		}
+
+		first, err := time.Parse("2006-01", satellite.first)
+		if err != nil {
+			return nil, err
+		}
+
+		period := first.AddDate(0, heldReturnMonth-1, 0).Format("2006-01")
+		returns[period] += satellite.balance * heldReturnPercent / 100
+	}
+
+	return returns, nil
+}
+
+// NewHeldReturnForecast aligns returns to the given number of consecutive periods starting with the period of now.
+// Periods without returns are zero.
+func NewHeldReturnForecast(returns map[string]int64, now time.Time, months int) []HeldReturn {
+	current := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	forecast := make([]HeldReturn, months)
+	for i := range forecast {
+		period := current.AddDate(0, i, 0).Format("2006-01")
+		forecast[i] = HeldReturn{Period: period, Returned: returns[period]}
+	}
+
+	return forecast
+}
+
+// ProcessNodeSummaries matches satellite summaries returned by a process serving multiple nodes with the nodes
+// of the request. Every node has to be authenticated by the process.
+func ProcessNodeSummaries(group []nodes.Node, summaries []*multinodepb.SatelliteSummariesResponse_NodeSummary) ([]*multinodepb.PayoutInfo, error) {
+	if len(summaries) != len(group) {
+		return nil, Error.New("process returned %d summaries for %d nodes", len(summaries), len(group))
+	}
+
+	infos := make([]*multinodepb.PayoutInfo, 0, len(group))
+	for i, summary := range summaries {
+		if summary.Unauthenticated {
+			return nil, Error.New("node %s: api secret was not accepted", group[i].ID)
+		}
+		if summary.PayoutInfo == nil {
+			return nil, Error.New("node %s: summary is missing", group[i].ID)
+		}
+		infos = append(infos, summary.PayoutInfo)
+	}
+
+	return infos, nil
+}
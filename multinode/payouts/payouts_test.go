@@ -0,0 +1,1112 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts_test
+
+import (
+	"io"
+	"math"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+	"storj.io/common/testrand"
+	"storj.io/storj/multinode/nodes"
+	"storj.io/storj/multinode/payouts"
+	"storj.io/storj/private/multinodepb"
+	nodepayouts "storj.io/storj/storagenode/payouts"
+)
+
+func TestCalculateGrowthRates(t *testing.T) {
+	growing, shrinking, newNode, idle := testrand.NodeID(), testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+
+	var base payouts.Summary
+	base.Add(50, 50, growing, "growing")
+	base.Add(100, 300, shrinking, "shrinking")
+	base.Add(0, 0, newNode, "new")
+	base.Add(0, 0, idle, "idle")
+
+	var current payouts.Summary
+	current.Add(100, 150, growing, "growing")
+	current.Add(50, 50, shrinking, "shrinking")
+	current.Add(10, 20, newNode, "new")
+	current.Add(0, 0, idle, "idle")
+
+	rates := payouts.CalculateGrowthRates(base, current)
+	require.Len(t, rates, 4)
+
+	require.Equal(t, newNode, rates[0].NodeID)
+	require.True(t, rates[0].Infinite)
+	require.EqualValues(t, 0, rates[0].From)
+	require.EqualValues(t, 30, rates[0].To)
+	require.EqualValues(t, 30, rates[0].Change)
+	require.Zero(t, rates[0].Percentage)
+
+	require.Equal(t, growing, rates[1].NodeID)
+	require.False(t, rates[1].Infinite)
+	require.EqualValues(t, 150, rates[1].Change)
+	require.Equal(t, 150.0, rates[1].Percentage)
+
+	require.Equal(t, idle, rates[2].NodeID)
+	require.False(t, rates[2].Infinite)
+	require.Zero(t, rates[2].Change)
+	require.Zero(t, rates[2].Percentage)
+
+	require.Equal(t, shrinking, rates[3].NodeID)
+	require.False(t, rates[3].Infinite)
+	require.EqualValues(t, -300, rates[3].Change)
+	require.Equal(t, -75.0, rates[3].Percentage)
+}
+
+func TestCalculateGrowthRatesRenamedNode(t *testing.T) {
+	renamed := testrand.NodeID()
+
+	var base payouts.Summary
+	base.Add(10, 40, renamed, "old name")
+
+	var current payouts.Summary
+	current.Add(20, 80, renamed, "new name")
+
+	rates := payouts.CalculateGrowthRates(base, current)
+	require.Equal(t, []payouts.NodeGrowthRate{{
+		NodeID:     renamed,
+		NodeName:   "new name",
+		From:       50,
+		To:         100,
+		Change:     50,
+		Percentage: 100,
+	}}, rates)
+}
+
+func TestCalculateGrowthRatesMissingNodes(t *testing.T) {
+	added, removed := testrand.NodeID(), testrand.NodeID()
+
+	var base payouts.Summary
+	base.Add(10, 10, removed, "removed")
+
+	var current payouts.Summary
+	current.Add(5, 5, added, "added")
+
+	rates := payouts.CalculateGrowthRates(base, current)
+	require.Len(t, rates, 2)
+
+	require.Equal(t, added, rates[0].NodeID)
+	require.True(t, rates[0].Infinite)
+
+	require.Equal(t, removed, rates[1].NodeID)
+	require.Equal(t, -100.0, rates[1].Percentage)
+}
+
+func TestNodeEfficiency(t *testing.T) {
+	tests := []struct {
+		name              string
+		efficiency        payouts.NodeEfficiency
+		earnedPerTBStored float64
+		earnedPerTBEgress float64
+	}{
+		{
+			name:              "stores and serves data",
+			efficiency:        payouts.NodeEfficiency{Earned: 300, DiskSpaceUsed: 2e12, Egress: 5e11},
+			earnedPerTBStored: 150,
+			earnedPerTBEgress: 600,
+		},
+		{
+			name:              "no egress",
+			efficiency:        payouts.NodeEfficiency{Earned: 100, DiskSpaceUsed: 4e12},
+			earnedPerTBStored: 25,
+		},
+		{
+			name:              "no usage",
+			efficiency:        payouts.NodeEfficiency{Earned: 100},
+			earnedPerTBStored: 0,
+			earnedPerTBEgress: 0,
+		},
+		{
+			name:       "no earnings",
+			efficiency: payouts.NodeEfficiency{DiskSpaceUsed: 1e12, Egress: 1e12},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			test.efficiency.Calculate()
+			require.InDelta(t, test.earnedPerTBStored, test.efficiency.EarnedPerTBStored, 1e-9)
+			require.InDelta(t, test.earnedPerTBEgress, test.efficiency.EarnedPerTBEgress, 1e-9)
+		})
+	}
+}
+
+func TestNegativePayouts(t *testing.T) {
+	node, satellite := testrand.NodeID(), testrand.NodeID()
+
+	list := []payouts.SatellitePayout{
+		{NodeID: node, SatelliteID: satellite, Period: "2021-01", Held: 100, Paid: 300},
+		{NodeID: node, SatelliteID: satellite, Period: "2021-02", Held: 0, Paid: -50},
+		{NodeID: node, SatelliteID: satellite, Period: "2021-03", Held: -10, Paid: 200},
+		{NodeID: node, SatelliteID: satellite, Period: "2021-04", Held: 0, Paid: 0},
+	}
+
+	negative := payouts.NegativePayouts(list)
+	require.Len(t, negative, 2)
+	require.Equal(t, "2021-02", negative[0].Period)
+	require.EqualValues(t, -50, negative[0].Paid)
+	require.Equal(t, "2021-03", negative[1].Period)
+	require.EqualValues(t, -10, negative[1].Held)
+
+	require.Empty(t, payouts.NegativePayouts(list[:1]))
+}
+
+func TestNodeEstimateAccuracy(t *testing.T) {
+	recorded := payouts.NodeEstimateAccuracy{
+		Known:     true,
+		Estimated: 90,
+		Actual:    100,
+	}
+	recorded.Calculate()
+	require.InDelta(t, 90, recorded.Accuracy, 1e-9)
+
+	overestimated := payouts.NodeEstimateAccuracy{
+		Known:     true,
+		Estimated: 350,
+		Actual:    100,
+	}
+	overestimated.Calculate()
+	require.Zero(t, overestimated.Accuracy)
+
+	nothingEarned := payouts.NodeEstimateAccuracy{Known: true}
+	nothingEarned.Calculate()
+	require.EqualValues(t, 100, nothingEarned.Accuracy)
+
+	missing := payouts.NodeEstimateAccuracy{
+		Known:  false,
+		Actual: 100,
+	}
+	missing.Calculate()
+	require.Zero(t, missing.Accuracy)
+}
+
+func TestRescale(t *testing.T) {
+	require.EqualValues(t, 1230000, payouts.Rescale(123, 2, 6))
+	require.EqualValues(t, 123, payouts.Rescale(1234567, 6, 2))
+	require.EqualValues(t, 42, payouts.Rescale(42, 6, 6))
+}
+
+func TestPendingPayouts(t *testing.T) {
+	above, below, single := testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+
+	undistributed := []*multinodepb.UndistributedSatellite{
+		{SatelliteId: above, Total: 300},
+		{SatelliteId: below, Total: 200},
+		{SatelliteId: above, Total: 400},
+		{SatelliteId: below, Total: 100},
+		{SatelliteId: single, Total: 900},
+	}
+
+	pending := payouts.PendingPayouts(undistributed, 500)
+	require.Equal(t, []payouts.SatelliteUndistributed{
+		{SatelliteID: single, Undistributed: 900},
+		{SatelliteID: above, Undistributed: 700},
+	}, pending)
+
+	require.Empty(t, payouts.PendingPayouts(undistributed, 1000))
+}
+
+func TestDroppedNodes(t *testing.T) {
+	dropped, persisted, joined, unreachable := testrand.NodeID(), testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+
+	var previous payouts.Summary
+	previous.Add(10, 90, dropped, "dropped")
+	previous.Add(20, 80, persisted, "persisted")
+	previous.Add(0, 0, joined, "joined")
+	previous.Add(5, 5, unreachable, "unreachable")
+
+	var current payouts.Summary
+	current.Add(0, 0, dropped, "dropped")
+	current.Add(30, 70, persisted, "persisted")
+	current.Add(10, 10, joined, "joined")
+	current.AddCircuitOpen(unreachable, "unreachable")
+
+	require.Equal(t, storj.NodeIDList{dropped}, payouts.DroppedNodes(previous, current))
+
+	// first period has nothing to compare with.
+	var first payouts.Summary
+	first.Add(0, 0, dropped, "dropped")
+	first.Add(0, 0, persisted, "persisted")
+	require.Empty(t, payouts.DroppedNodes(first, current))
+}
+
+func TestPreviousPeriod(t *testing.T) {
+	period, err := payouts.PreviousPeriod("2021-03")
+	require.NoError(t, err)
+	require.Equal(t, "2021-02", period)
+
+	period, err = payouts.PreviousPeriod("2021-01")
+	require.NoError(t, err)
+	require.Equal(t, "2020-12", period)
+
+	_, err = payouts.PreviousPeriod("March")
+	require.Error(t, err)
+}
+
+func TestGroupEarnedBySatellite(t *testing.T) {
+	usdSatellite, tokenSatellite, legacySatellite := testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+
+	usd := &multinodepb.AmountUnit{Currency: "USD", Decimals: 6}
+	token := &multinodepb.AmountUnit{Currency: "STORJ", Decimals: 8}
+
+	responses := []*multinodepb.EarnedPerSatelliteResponse{
+		{
+			Unit: usd,
+			EarnedSatellite: []*multinodepb.EarnedSatellite{
+				{SatelliteId: usdSatellite, Total: 100, Net: 60, Unit: usd},
+				{SatelliteId: tokenSatellite, Total: 30, Net: 30, Unit: token},
+			},
+		},
+		{
+			Unit: usd,
+			EarnedSatellite: []*multinodepb.EarnedSatellite{
+				{SatelliteId: usdSatellite, Total: 50, Net: 25},
+				{SatelliteId: tokenSatellite, Total: 20, Net: 20, Unit: token},
+				{SatelliteId: tokenSatellite, Total: 5, Unit: usd},
+			},
+		},
+		{
+			// node without amount units.
+			EarnedSatellite: []*multinodepb.EarnedSatellite{
+				{SatelliteId: legacySatellite, Total: 7},
+			},
+		},
+	}
+
+	summaries := payouts.GroupEarnedBySatellite(responses)
+	require.Equal(t, []payouts.SatelliteSummary{
+		{SatelliteID: usdSatellite, Earned: 150, Net: 85, Currency: "USD"},
+		{SatelliteID: tokenSatellite, Earned: 50, Net: 50, Currency: "STORJ"},
+		{SatelliteID: tokenSatellite, Earned: 5, Currency: "USD"},
+		{SatelliteID: legacySatellite, Earned: 7, Currency: "USD"},
+	}, summaries)
+
+	require.Equal(t, map[string]int64{
+		"USD":   162,
+		"STORJ": 50,
+	}, payouts.TotalsByCurrency(summaries))
+
+	require.Empty(t, payouts.GroupEarnedBySatellite(nil))
+}
+
+func TestPayoutShares(t *testing.T) {
+	us1, eu1, ap1, stefan := testrand.NodeID(), testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+
+	shares := payouts.PayoutShares([]payouts.SatelliteSummary{
+		{SatelliteID: us1, Earned: 250, Currency: "USD"},
+		{SatelliteID: eu1, Earned: 500, Currency: "USD"},
+		{SatelliteID: ap1, Earned: 250, Currency: "USD"},
+		{SatelliteID: stefan, Earned: 0, Currency: "USD"},
+	})
+	require.Len(t, shares, 4)
+
+	var sum float64
+	for i, share := range shares {
+		sum += share.Share
+		if i > 0 {
+			require.GreaterOrEqual(t, shares[i-1].Share, share.Share)
+		}
+	}
+	require.InDelta(t, 100, sum, 1e-9)
+
+	require.Equal(t, eu1, shares[0].SatelliteID)
+	require.InDelta(t, 50, shares[0].Share, 1e-9)
+	require.InDelta(t, 25, shares[1].Share, 1e-9)
+	require.InDelta(t, 25, shares[2].Share, 1e-9)
+	require.True(t, shares[1].SatelliteID.Less(shares[2].SatelliteID))
+	require.Equal(t, payouts.SatellitePayoutShare{SatelliteID: stefan, Currency: "USD"}, shares[3])
+
+	// nothing earned.
+	shares = payouts.PayoutShares([]payouts.SatelliteSummary{
+		{SatelliteID: us1, Currency: "USD"},
+		{SatelliteID: eu1, Currency: "USD"},
+	})
+	require.Len(t, shares, 2)
+	for _, share := range shares {
+		require.Zero(t, share.Share)
+	}
+
+	// shares are relative to the total of the currency.
+	shares = payouts.PayoutShares([]payouts.SatelliteSummary{
+		{SatelliteID: us1, Earned: 300, Currency: "USD"},
+		{SatelliteID: eu1, Earned: 100, Currency: "USD"},
+		{SatelliteID: us1, Earned: 10, Currency: "STORJ"},
+	})
+	require.Equal(t, "STORJ", shares[0].Currency)
+	require.InDelta(t, 100, shares[0].Share, 1e-9)
+	require.InDelta(t, 75, shares[1].Share, 1e-9)
+	require.InDelta(t, 25, shares[2].Share, 1e-9)
+
+	require.Empty(t, payouts.PayoutShares(nil))
+}
+
+func TestNodeConnectivity(t *testing.T) {
+	nodeID := testrand.NodeID()
+	healthy, failing, unknown := testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+	checkedAt := time.Now().UTC()
+
+	connectivity := payouts.NewNodeConnectivity(nodeID, "node", []*multinodepb.SatelliteCheckInsResponse_CheckIn{
+		{SatelliteId: healthy, Attempted: true, Succeeded: true, CheckedAt: checkedAt},
+		{SatelliteId: failing, Attempted: true, Succeeded: false, CheckedAt: checkedAt, Error: "ping satellite: connection refused"},
+		{SatelliteId: unknown},
+	})
+
+	require.Equal(t, nodeID, connectivity.NodeID)
+	require.Empty(t, connectivity.Error)
+	require.Equal(t, []payouts.SatelliteConnectivity{
+		{SatelliteID: healthy, Attempted: true, Reachable: true, CheckedAt: checkedAt},
+		{SatelliteID: failing, Attempted: true, Reachable: false, CheckedAt: checkedAt, Error: "ping satellite: connection refused"},
+		{SatelliteID: unknown},
+	}, connectivity.Satellites)
+	require.Equal(t, storj.NodeIDList{failing}, connectivity.UnreachableSatellites())
+}
+
+func TestNewPaystubCoverage(t *testing.T) {
+	continuous, gapped, empty := testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+
+	coverage, err := payouts.NewPaystubCoverage(continuous, "continuous", []string{"2021-02", "2020-12", "2021-01"})
+	require.NoError(t, err)
+	require.Equal(t, payouts.PaystubCoverage{
+		NodeID:   continuous,
+		NodeName: "continuous",
+		Earliest: "2020-12",
+		Latest:   "2021-02",
+	}, coverage)
+
+	coverage, err = payouts.NewPaystubCoverage(gapped, "gapped", []string{"2021-01", "2021-04", "2021-03", "2020-11"})
+	require.NoError(t, err)
+	require.Equal(t, "2020-11", coverage.Earliest)
+	require.Equal(t, "2021-04", coverage.Latest)
+	require.Equal(t, []string{"2020-12", "2021-02"}, coverage.Gaps)
+	require.True(t, coverage.HasGaps)
+
+	coverage, err = payouts.NewPaystubCoverage(empty, "empty", nil)
+	require.NoError(t, err)
+	require.Empty(t, coverage.Earliest)
+	require.False(t, coverage.HasGaps)
+
+	_, err = payouts.NewPaystubCoverage(gapped, "gapped", []string{"2021-13"})
+	require.Error(t, err)
+}
+
+func TestEarningsHistogram(t *testing.T) {
+	histogram, err := payouts.EarningsHistogram([]int64{100, 1000, 10000}, []int64{0, 100, 101, 500, 1000, 9999, 10001, 50000, -5})
+	require.NoError(t, err)
+	require.Equal(t, []payouts.HistogramBucket{
+		{UpperBound: 100, Count: 3},
+		{UpperBound: 1000, Count: 3},
+		{UpperBound: 10000, Count: 1},
+		{Overflow: true, Count: 2},
+	}, histogram)
+
+	histogram, err = payouts.EarningsHistogram(nil, []int64{1, 2})
+	require.NoError(t, err)
+	require.Equal(t, []payouts.HistogramBucket{{Overflow: true, Count: 2}}, histogram)
+
+	_, err = payouts.EarningsHistogram([]int64{100, 100}, nil)
+	require.Error(t, err)
+}
+
+func TestFindHeldOutliers(t *testing.T) {
+	satellite, other := testrand.NodeID(), testrand.NodeID()
+	joined := time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC)
+	outlier := testrand.NodeID()
+
+	rate := func(nodeID storj.NodeID, satelliteID storj.NodeID, joinedAt time.Time, held, earned int64) payouts.NodeHeldRate {
+		return payouts.NodeHeldRate{NodeID: nodeID, NodeName: "node", SatelliteID: satelliteID, JoinedAt: joinedAt, Held: held, Earned: earned}
+	}
+
+	rates := []payouts.NodeHeldRate{
+		rate(testrand.NodeID(), satellite, joined, 500, 1000),
+		rate(testrand.NodeID(), satellite, joined.AddDate(0, 0, 5), 510, 1000),
+		rate(outlier, satellite, joined.AddDate(0, 0, 10), 100, 1000),
+		rate(testrand.NodeID(), satellite, joined, 490, 1000),
+		// node without earnings is ignored.
+		rate(testrand.NodeID(), satellite, joined, 0, 0),
+		// node from a different cohort is not compared with the first one.
+		rate(testrand.NodeID(), satellite, joined.AddDate(0, 6, 0), 0, 1000),
+		// cohort of two nodes is too small to find an outlier in.
+		rate(testrand.NodeID(), other, joined, 750, 1000),
+		rate(testrand.NodeID(), other, joined, 0, 1000),
+	}
+
+	outliers := payouts.FindHeldOutliers(rates, 5)
+	require.Len(t, outliers, 1)
+	require.Equal(t, outlier, outliers[0].NodeID)
+	require.Equal(t, satellite, outliers[0].SatelliteID)
+	require.Equal(t, "2021-01", outliers[0].Cohort)
+	require.InDelta(t, 49.5, outliers[0].Expected, 1e-9)
+	require.InDelta(t, 10, outliers[0].Actual, 1e-9)
+
+	// within tolerance nothing is flagged.
+	require.Empty(t, payouts.FindHeldOutliers(rates, 50))
+}
+
+func TestFindEstimateAnomalies(t *testing.T) {
+	normal, collapsed, dropped, unknown := testrand.NodeID(), testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+
+	baseline := map[storj.NodeID]int64{
+		normal:    1000,
+		collapsed: 2000,
+		dropped:   1000,
+	}
+	estimates := []payouts.NodeEstimate{
+		{NodeID: normal, NodeName: "normal", Estimate: 900},
+		{NodeID: collapsed, NodeName: "collapsed", Estimate: 0},
+		{NodeID: dropped, NodeName: "dropped", Estimate: 400},
+		{NodeID: unknown, NodeName: "unknown", Estimate: 0},
+	}
+
+	anomalies := payouts.FindEstimateAnomalies(baseline, estimates, 50)
+	require.Equal(t, []payouts.EstimateAnomaly{
+		{NodeID: collapsed, NodeName: "collapsed", Baseline: 2000, Current: 0, Drop: 100},
+		{NodeID: dropped, NodeName: "dropped", Baseline: 1000, Current: 400, Drop: 60},
+	}, anomalies)
+
+	anomalies = payouts.FindEstimateAnomalies(baseline, estimates, 90)
+	require.Len(t, anomalies, 1)
+	require.Equal(t, collapsed, anomalies[0].NodeID)
+}
+
+func TestNewNodeTrend(t *testing.T) {
+	nodeID := testrand.NodeID()
+	periods := []string{"2021-01", "2021-02", "2021-03", "2021-04"}
+
+	trend := payouts.NewNodeTrend(nodeID, "node", periods, map[string]int64{
+		"2021-04": 40,
+		"2021-02": 20,
+		// periods which were not requested are ignored.
+		"2020-12": 100,
+	})
+	require.Equal(t, nodeID, trend.NodeID)
+	require.Equal(t, "node", trend.NodeName)
+	require.Equal(t, periods, trend.Periods)
+	require.Equal(t, []int64{0, 20, 0, 40}, trend.Earned)
+
+	// node without any payouts data has zero earnings in every period.
+	require.Equal(t, []int64{0, 0, 0, 0}, payouts.NewNodeTrend(nodeID, "node", periods, nil).Earned)
+}
+
+func TestChartMetric(t *testing.T) {
+	info := &multinodepb.PayoutInfo{Held: 30, Paid: 70}
+
+	require.EqualValues(t, 100, payouts.ChartEarned.Value(info))
+	require.EqualValues(t, 30, payouts.ChartHeld.Value(info))
+	require.EqualValues(t, 70, payouts.ChartPaid.Value(info))
+
+	for _, metric := range []payouts.ChartMetric{payouts.ChartEarned, payouts.ChartHeld, payouts.ChartPaid} {
+		require.NoError(t, metric.Validate())
+	}
+	require.Error(t, payouts.ChartMetric("surge").Validate())
+	require.Error(t, payouts.ChartMetric("").Validate())
+}
+
+func TestNewChartPoints(t *testing.T) {
+	periods := []string{"2021-01", "2021-02", "2021-03"}
+
+	points := payouts.NewChartPoints(periods, map[string]int64{
+		"2021-03": 30,
+		"2021-01": 10,
+		// periods which were not requested are ignored.
+		"2020-12": 100,
+	})
+	require.Equal(t, []payouts.ChartPoint{
+		{Period: "2021-01", Value: 10},
+		{Period: "2021-02", Value: 0},
+		{Period: "2021-03", Value: 30},
+	}, points)
+
+	// without any payouts data every period is zero.
+	require.Equal(t, []payouts.ChartPoint{
+		{Period: "2021-01"}, {Period: "2021-02"}, {Period: "2021-03"},
+	}, payouts.NewChartPoints(periods, nil))
+}
+
+func TestNewWallets(t *testing.T) {
+	shared1, shared2, shared3 := testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+	unique, noWallet1, noWallet2 := testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+
+	wallets := payouts.NewWallets([]payouts.NodeWallet{
+		{NodeID: unique, Wallet: "0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"},
+		{NodeID: noWallet1, Wallet: ""},
+		{NodeID: shared1, Wallet: "0xAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAa"},
+		{NodeID: shared2, Wallet: "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"},
+		{NodeID: noWallet2, Wallet: "  "},
+		{NodeID: shared3, Wallet: " 0xAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAa"},
+	})
+
+	require.Equal(t, []payouts.DistinctWallet{
+		{Wallet: "0xAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAa", NodeCount: 3},
+		{Wallet: "0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", NodeCount: 1},
+	}, wallets.Wallets)
+	require.Equal(t, storj.NodeIDList{noWallet1, noWallet2}, wallets.WithoutWallet)
+
+	require.Equal(t, payouts.Wallets{}, payouts.NewWallets(nil))
+}
+
+func TestTenureMonths(t *testing.T) {
+	now := time.Date(2021, 5, 17, 12, 0, 0, 0, time.UTC)
+
+	for _, tt := range []struct {
+		first  string
+		months int
+	}{
+		{"2021-05", 1},
+		{"2021-04", 2},
+		{"2021-01", 5},
+		{"2020-05", 13},
+		{"2019-12", 18},
+		// paystub from the future, e.g. because of a wrong clock.
+		{"2021-06", 0},
+	} {
+		months, err := payouts.TenureMonths(tt.first, now)
+		require.NoError(t, err)
+		require.Equal(t, tt.months, months, tt.first)
+	}
+
+	_, err := payouts.TenureMonths("May 2021", now)
+	require.Error(t, err)
+}
+
+func TestPeriodDays(t *testing.T) {
+	now := time.Date(2021, 3, 11, 18, 0, 0, 0, time.UTC)
+
+	for _, tt := range []struct {
+		period string
+		days   int
+	}{
+		// completed periods.
+		{"2021-02", 28},
+		{"2020-02", 29},
+		{"2021-01", 31},
+		// current period.
+		{"2021-03", 10},
+		// period which hasn't started yet.
+		{"2021-04", 0},
+	} {
+		days, err := payouts.PeriodDays(tt.period, now)
+		require.NoError(t, err)
+		require.Equal(t, tt.days, days, tt.period)
+	}
+
+	// the first day of the current period hasn't elapsed yet.
+	days, err := payouts.PeriodDays("2021-03", time.Date(2021, 3, 1, 8, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Zero(t, days)
+
+	_, err = payouts.PeriodDays("March 2021", now)
+	require.Error(t, err)
+}
+
+func TestNewEarningsVelocity(t *testing.T) {
+	first, second := testrand.NodeID(), testrand.NodeID()
+	nodeVelocities := func() []payouts.NodeVelocity {
+		return []payouts.NodeVelocity{
+			{NodeID: first, NodeName: "first", Earned: 2800000},
+			{NodeID: second, NodeName: "second", Earned: 1400000},
+		}
+	}
+
+	// completed period.
+	velocity := payouts.NewEarningsVelocity("2021-02", 28, nodeVelocities())
+	require.Equal(t, payouts.EarningsVelocity{
+		Period: "2021-02",
+		Days:   28,
+		Earned: 4200000,
+		PerDay: 150000,
+		Nodes: []payouts.NodeVelocity{
+			{NodeID: first, NodeName: "first", Earned: 2800000, PerDay: 100000},
+			{NodeID: second, NodeName: "second", Earned: 1400000, PerDay: 50000},
+		},
+	}, velocity)
+
+	// current period, 10 days elapsed.
+	velocity = payouts.NewEarningsVelocity("2021-03", 10, nodeVelocities())
+	require.EqualValues(t, 420000, velocity.PerDay)
+	require.EqualValues(t, 280000, velocity.Nodes[0].PerDay)
+	require.EqualValues(t, 140000, velocity.Nodes[1].PerDay)
+
+	// no elapsed days.
+	velocity = payouts.NewEarningsVelocity("2021-03", 0, nodeVelocities())
+	require.EqualValues(t, 4200000, velocity.Earned)
+	require.Zero(t, velocity.PerDay)
+	for _, node := range velocity.Nodes {
+		require.Zero(t, node.PerDay)
+	}
+}
+
+func TestConcentrationRatio(t *testing.T) {
+	even := []int64{100, 100, 100, 100}
+	skewed := []int64{10, 900, 40, 50}
+
+	for _, tt := range []struct {
+		earned   []int64
+		n        int
+		expected float64
+	}{
+		{even, 1, 0.25},
+		{even, 2, 0.5},
+		{skewed, 1, 0.9},
+		{skewed, 2, 0.95},
+		{skewed, 4, 1},
+		{skewed, 10, 1},
+		{[]int64{0, 0}, 1, 0},
+		{nil, 1, 0},
+	} {
+		require.InDelta(t, tt.expected, payouts.ConcentrationRatio(tt.earned, tt.n), 1e-9, "%v top %d", tt.earned, tt.n)
+	}
+
+	// input is not reordered.
+	require.Equal(t, []int64{10, 900, 40, 50}, skewed)
+}
+
+func TestNewEarningsByNodeIDRenamedNode(t *testing.T) {
+	renamed, other := testrand.NodeID(), testrand.NodeID()
+
+	// node is renamed between two aggregations.
+	before := payouts.NewEarningsByNodeID([]payouts.NodeEarned{
+		{NodeID: renamed, NodeName: "storage-1", Earned: payouts.Earned{Gross: 1000, Net: 800}},
+		{NodeID: other, NodeName: "storage-2", Earned: payouts.Earned{Gross: 500, Net: 500}},
+	})
+	after := payouts.NewEarningsByNodeID([]payouts.NodeEarned{
+		{NodeID: other, NodeName: "storage-2", Earned: payouts.Earned{Gross: 600, Net: 600}},
+		{NodeID: renamed, NodeName: "rack-3", Earned: payouts.Earned{Gross: 1200, Net: 950}},
+	})
+
+	require.Len(t, before, 2)
+	require.Len(t, after, 2)
+	for id := range before {
+		require.Contains(t, after, id)
+	}
+
+	require.Equal(t, "rack-3", after[renamed].NodeName)
+	require.EqualValues(t, 200, after[renamed].Gross-before[renamed].Gross)
+	require.EqualValues(t, 150, after[renamed].Net-before[renamed].Net)
+	require.EqualValues(t, 100, after[other].Gross-before[other].Gross)
+}
+
+func TestApplyOperatorCut(t *testing.T) {
+	first, second := testrand.NodeID(), testrand.NodeID()
+	earned := []payouts.NodeEarned{
+		{NodeID: first, NodeName: "first", Earned: payouts.Earned{Gross: 1000000, Net: 800000}},
+		{NodeID: second, NodeName: "second", Earned: payouts.Earned{Gross: 333333, Net: 300000}},
+	}
+
+	// no cut.
+	payout, err := payouts.ApplyOperatorCut(earned, 0, false)
+	require.NoError(t, err)
+	require.Equal(t, payouts.PayoutAfterCut{Gross: 1333333, Net: 1333333}, payout)
+
+	// 20% cut, with split of every node.
+	payout, err = payouts.ApplyOperatorCut(earned, 0.2, true)
+	require.NoError(t, err)
+	require.Equal(t, payouts.PayoutAfterCut{
+		CutFraction: 0.2,
+		Gross:       1333333,
+		Cut:         266667,
+		Net:         1066666,
+		Nodes: []payouts.NodePayoutAfterCut{
+			{NodeID: first, NodeName: "first", Gross: 1000000, Cut: 200000, Net: 800000},
+			{NodeID: second, NodeName: "second", Gross: 333333, Cut: 66667, Net: 266666},
+		},
+	}, payout)
+	require.Equal(t, payout.Gross, payout.Cut+payout.Net)
+
+	// whole payout.
+	payout, err = payouts.ApplyOperatorCut(earned, 1, false)
+	require.NoError(t, err)
+	require.Equal(t, payout.Gross, payout.Cut)
+	require.Zero(t, payout.Net)
+
+	for _, invalid := range []float64{-0.1, 1.01, 20, math.NaN()} {
+		_, err := payouts.ApplyOperatorCut(earned, invalid, false)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestEarnedQuartiles(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		earned   []int64
+		expected payouts.Quartiles
+	}{
+		{"empty", nil, payouts.Quartiles{}},
+		{"single", []int64{7}, payouts.Quartiles{Lower: 7, Median: 7, Upper: 7}},
+		{"odd", []int64{900, 10, 30, 20, 40}, payouts.Quartiles{Lower: 15, Median: 30, Upper: 470}},
+		{"even", []int64{40, 10, 30, 20}, payouts.Quartiles{Lower: 15, Median: 25, Upper: 35}},
+		{"even truncated", []int64{1, 2}, payouts.Quartiles{Lower: 1, Median: 1, Upper: 2}},
+		{"negative", []int64{-3, -1, 5}, payouts.Quartiles{Lower: -3, Median: -1, Upper: 5}},
+		{"mixed sign", []int64{-1, 2}, payouts.Quartiles{Lower: -1, Median: 0, Upper: 2}},
+		{"negative rounded down", []int64{-3, -2}, payouts.Quartiles{Lower: -3, Median: -3, Upper: -2}},
+		{"large", []int64{math.MaxInt64, math.MaxInt64 - 2}, payouts.Quartiles{Lower: math.MaxInt64 - 2, Median: math.MaxInt64 - 1, Upper: math.MaxInt64}},
+	} {
+		require.Equal(t, tt.expected, payouts.EarnedQuartiles(tt.earned), tt.name)
+	}
+
+	// input is not reordered.
+	earned := []int64{3, 1, 2}
+	payouts.EarnedQuartiles(earned)
+	require.Equal(t, []int64{3, 1, 2}, earned)
+}
+
+func TestReconcile(t *testing.T) {
+	for _, tt := range []struct {
+		paid, received int64
+		payments       int
+		status         payouts.ReconciliationStatus
+	}{
+		{paid: 500, received: 500, payments: 2, status: payouts.ReconciliationMatch},
+		// rounding differences within tolerance.
+		{paid: 500, received: 495, payments: 2, status: payouts.ReconciliationMatch},
+		{paid: 500, received: 510, payments: 3, status: payouts.ReconciliationMatch},
+		{paid: 500, received: 300, payments: 1, status: payouts.ReconciliationDiscrepancy},
+		{paid: 500, received: 600, payments: 3, status: payouts.ReconciliationDiscrepancy},
+		// node without payment records can't be reconciled.
+		{paid: 500, received: 0, payments: 0, status: payouts.ReconciliationNoRecords},
+		{paid: 0, received: 0, payments: 0, status: payouts.ReconciliationNoRecords},
+	} {
+		require.Equal(t, tt.status, payouts.Reconcile(tt.paid, tt.received, tt.payments, 10), "%d %d", tt.paid, tt.received)
+	}
+}
+
+func TestEarnedComponents(t *testing.T) {
+	satellites := []*multinodepb.EarnedSatellite{
+		{
+			Total: 1234,
+			Components: &multinodepb.EarnedComponents{
+				CompAtRest: 1000, CompGet: 200, CompGetRepair: 30, CompGetAudit: 4,
+			},
+		},
+		{
+			Total: 5500,
+			Components: &multinodepb.EarnedComponents{
+				CompAtRest: 500, CompGet: 5000,
+			},
+		},
+		// older node without components.
+		{Total: 700},
+	}
+
+	var components payouts.EarnedComponents
+	var total int64
+	for _, satellite := range satellites {
+		components.Add(satellite)
+		total += satellite.Total
+	}
+
+	require.Equal(t, payouts.EarnedComponents{
+		EarnedComponents: nodepayouts.EarnedComponents{
+			AtRest:    1500,
+			Get:       5200,
+			GetRepair: 30,
+			GetAudit:  4,
+		},
+		Unattributed: 700,
+	}, components)
+	require.Equal(t, total, components.Total())
+}
+
+func TestMonthsToThreshold(t *testing.T) {
+	for _, tt := range []struct {
+		name                        string
+		balance, monthly, threshold int64
+		months                      int
+		ok                          bool
+	}{
+		{name: "above threshold", balance: 150, monthly: 10, threshold: 100, months: 0, ok: true},
+		{name: "at threshold", balance: 100, monthly: 0, threshold: 100, months: 0, ok: true},
+		{name: "exact months", balance: 40, monthly: 20, threshold: 100, months: 3, ok: true},
+		{name: "partial month", balance: 40, monthly: 25, threshold: 100, months: 3, ok: true},
+		{name: "zero estimate", balance: 40, monthly: 0, threshold: 100, months: 0, ok: false},
+		{name: "negative estimate", balance: 40, monthly: -5, threshold: 100, months: 0, ok: false},
+	} {
+		months, ok := payouts.MonthsToThreshold(tt.balance, tt.monthly, tt.threshold)
+		require.Equal(t, tt.months, months, tt.name)
+		require.Equal(t, tt.ok, ok, tt.name)
+	}
+}
+
+func TestCompareSatellites(t *testing.T) {
+	us1, eu1, ap1 := testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+
+	// exact match, duplicates and order don't matter.
+	extra, missing := payouts.CompareSatellites([]storj.NodeID{us1, eu1}, []storj.NodeID{eu1, us1, eu1})
+	require.Empty(t, extra)
+	require.Empty(t, missing)
+
+	// extra satellite.
+	extra, missing = payouts.CompareSatellites([]storj.NodeID{us1, eu1}, []storj.NodeID{us1, eu1, ap1})
+	require.Equal(t, storj.NodeIDList{ap1}, extra)
+	require.Empty(t, missing)
+
+	// missing satellite.
+	extra, missing = payouts.CompareSatellites([]storj.NodeID{us1, eu1, ap1}, []storj.NodeID{eu1})
+	require.Empty(t, extra)
+	expected := storj.NodeIDList{us1, ap1}
+	sort.Sort(expected)
+	require.Equal(t, expected, missing)
+
+	// both at once.
+	extra, missing = payouts.CompareSatellites([]storj.NodeID{us1}, []storj.NodeID{ap1})
+	require.Equal(t, storj.NodeIDList{ap1}, extra)
+	require.Equal(t, storj.NodeIDList{us1}, missing)
+}
+
+func TestNewSatelliteCounts(t *testing.T) {
+	counts := []payouts.NodeSatelliteCount{
+		{NodeID: testrand.NodeID(), NodeName: "single", Satellites: 1},
+		{NodeID: testrand.NodeID(), NodeName: "all", Satellites: 6},
+		{NodeID: testrand.NodeID(), NodeName: "some", Satellites: 4},
+		{NodeID: testrand.NodeID(), NodeName: "unreachable", Unreachable: true},
+	}
+
+	distribution := payouts.NewSatelliteCounts(counts)
+	require.Equal(t, counts, distribution.Nodes)
+	require.Equal(t, 1, distribution.Min)
+	require.Equal(t, 6, distribution.Max)
+	require.InDelta(t, 11.0/3, distribution.Average, 1e-9)
+
+	// nodes without satellites count towards the minimum.
+	distribution = payouts.NewSatelliteCounts(append(counts, payouts.NodeSatelliteCount{NodeID: testrand.NodeID(), NodeName: "new"}))
+	require.Zero(t, distribution.Min)
+	require.Equal(t, 6, distribution.Max)
+	require.InDelta(t, 11.0/4, distribution.Average, 1e-9)
+
+	// no reachable nodes.
+	distribution = payouts.NewSatelliteCounts(counts[3:])
+	require.Len(t, distribution.Nodes, 1)
+	require.Zero(t, distribution.Min)
+	require.Zero(t, distribution.Max)
+	require.Zero(t, distribution.Average)
+}
+
+func TestReputationScores(t *testing.T) {
+	us1, eu1, ap1 := testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+
+	var reputation payouts.ReputationScores
+	// first node.
+	reputation.Add(us1, &multinodepb.ReputationScoresResponse_SatelliteScores{Reported: true, AuditScore: 1, SuspensionScore: 1, OnlineScore: 0.9})
+	reputation.Add(eu1, &multinodepb.ReputationScoresResponse_SatelliteScores{Reported: true, AuditScore: 0.8, SuspensionScore: 0.6, OnlineScore: 0.7})
+	reputation.Add(ap1, &multinodepb.ReputationScoresResponse_SatelliteScores{})
+	// second node.
+	reputation.Add(us1, &multinodepb.ReputationScoresResponse_SatelliteScores{Reported: true, AuditScore: 0.9, SuspensionScore: 0.8, OnlineScore: 0.95})
+	reputation.Add(eu1, &multinodepb.ReputationScoresResponse_SatelliteScores{})
+	reputation.Add(ap1, &multinodepb.ReputationScoresResponse_SatelliteScores{})
+
+	scores := reputation.List()
+	require.Len(t, scores, 3)
+	for i := 1; i < len(scores); i++ {
+		require.True(t, scores[i-1].SatelliteID.Less(scores[i].SatelliteID))
+	}
+
+	bySatellite := make(map[storj.NodeID]payouts.SatelliteReputation)
+	for _, satellite := range scores {
+		bySatellite[satellite.SatelliteID] = satellite
+	}
+
+	require.Equal(t, 2, bySatellite[us1].Nodes)
+	require.Zero(t, bySatellite[us1].Unreported)
+	require.InDelta(t, 0.95, bySatellite[us1].AuditScore, 1e-9)
+	require.InDelta(t, 0.9, bySatellite[us1].SuspensionScore, 1e-9)
+	require.InDelta(t, 0.925, bySatellite[us1].OnlineScore, 1e-9)
+
+	// unreported scores don't lower the average.
+	require.Equal(t, 1, bySatellite[eu1].Nodes)
+	require.Equal(t, 1, bySatellite[eu1].Unreported)
+	require.InDelta(t, 0.8, bySatellite[eu1].AuditScore, 1e-9)
+	require.InDelta(t, 0.6, bySatellite[eu1].SuspensionScore, 1e-9)
+	require.InDelta(t, 0.7, bySatellite[eu1].OnlineScore, 1e-9)
+
+	require.Equal(t, payouts.SatelliteReputation{SatelliteID: ap1, Unreported: 2}, bySatellite[ap1])
+
+	// listing doesn't change the sums.
+	require.Equal(t, scores, reputation.List())
+}
+
+// heldHistoryStream sends entries one by one and ends with err, io.EOF when nil.
+type heldHistoryStream struct {
+	entries []*multinodepb.HeldHistoryEntry
+	err     error
+}
+
+func (stream *heldHistoryStream) Recv() (*multinodepb.HeldHistoryEntry, error) {
+	if len(stream.entries) == 0 {
+		if stream.err != nil {
+			return nil, stream.err
+		}
+		return nil, io.EOF
+	}
+
+	entry := stream.entries[0]
+	stream.entries = stream.entries[1:]
+	return entry, nil
+}
+
+func TestReceiveHeldHistory(t *testing.T) {
+	us1, eu1 := testrand.NodeID(), testrand.NodeID()
+	if eu1.Less(us1) {
+		us1, eu1 = eu1, us1
+	}
+
+	var entries []*multinodepb.HeldHistoryEntry
+	for month := 12; month >= 1; month-- {
+		period := time.Date(2020, time.Month(month), 1, 0, 0, 0, 0, time.UTC).Format("2006-01")
+		entries = append(entries,
+			&multinodepb.HeldHistoryEntry{SatelliteId: eu1, Period: period, Held: 10, Disposed: 1},
+			&multinodepb.HeldHistoryEntry{SatelliteId: us1, Period: period, Held: int64(month), Disposed: 0},
+		)
+	}
+
+	var history payouts.HeldHistory
+	var received int
+	add := func(entry *multinodepb.HeldHistoryEntry) {
+		received++
+		history.Add(entry.SatelliteId, entry)
+	}
+
+	// two nodes send the same history.
+	require.NoError(t, payouts.ReceiveHeldHistory(&heldHistoryStream{entries: entries}, add))
+	require.NoError(t, payouts.ReceiveHeldHistory(&heldHistoryStream{entries: entries}, add))
+	require.Equal(t, 2*len(entries), received)
+
+	list := history.List()
+	require.Len(t, list, 24)
+	for i, period := range list[:12] {
+		require.Equal(t, us1, period.SatelliteID)
+		require.Equal(t, time.Date(2020, time.Month(i+1), 1, 0, 0, 0, 0, time.UTC).Format("2006-01"), period.Period)
+		require.EqualValues(t, 2*(i+1), period.Held)
+		require.Zero(t, period.Disposed)
+	}
+	for _, period := range list[12:] {
+		require.Equal(t, eu1, period.SatelliteID)
+		require.EqualValues(t, 20, period.Held)
+		require.EqualValues(t, 2, period.Disposed)
+	}
+
+	// stream error is returned after the entries received so far.
+	received = 0
+	err := payouts.ReceiveHeldHistory(&heldHistoryStream{entries: entries[:3], err: errs.New("connection reset")}, add)
+	require.Error(t, err)
+	require.Equal(t, 3, received)
+}
+
+func TestNodeHeldReturns(t *testing.T) {
+	satellite1, satellite2 := testrand.NodeID(), testrand.NodeID()
+
+	returns, err := payouts.NodeHeldReturns([]payouts.HeldHistoryPeriod{
+		{SatelliteID: satellite1, Period: "2021-02", Held: 100},
+		{SatelliteID: satellite1, Period: "2021-01", Held: 300},
+		{SatelliteID: satellite2, Period: "2021-03", Held: 50},
+		{SatelliteID: satellite2, Period: "2021-04", Held: 30},
+	})
+	require.NoError(t, err)
+	// half of held balance is returned in the 16th month counting the first period as month 1.
+	require.Equal(t, map[string]int64{
+		"2022-04": 200,
+		"2022-06": 40,
+	}, returns)
+
+	// satellite which already disposed held amount doesn't return more.
+	returns, err = payouts.NodeHeldReturns([]payouts.HeldHistoryPeriod{
+		{SatelliteID: satellite1, Period: "2019-01", Held: 300},
+		{SatelliteID: satellite1, Period: "2020-04", Disposed: 150},
+	})
+	require.NoError(t, err)
+	require.Empty(t, returns)
+
+	_, err = payouts.NodeHeldReturns([]payouts.HeldHistoryPeriod{{SatelliteID: satellite1, Period: "invalid", Held: 1}})
+	require.Error(t, err)
+}
+
+func TestNewHeldReturnForecast(t *testing.T) {
+	satellite := testrand.NodeID()
+	now := time.Date(2021, 11, 20, 0, 0, 0, 0, time.UTC)
+
+	// nodes at varied tenures on the satellite.
+	histories := [][]payouts.HeldHistoryPeriod{
+		// new node, joined in 2021-10, returns in 2023-01.
+		{
+			{SatelliteID: satellite, Period: "2021-10", Held: 20},
+		},
+		// returns in 2021-12.
+		{
+			{SatelliteID: satellite, Period: "2020-09", Held: 100},
+			{SatelliteID: satellite, Period: "2020-10", Held: 100},
+		},
+		// returns in 2022-01.
+		{
+			{SatelliteID: satellite, Period: "2020-10", Held: 60},
+		},
+		// returns in 2021-12 as well.
+		{
+			{SatelliteID: satellite, Period: "2020-09", Held: 10},
+		},
+		// completed its held period, contributes zero.
+		{
+			{SatelliteID: satellite, Period: "2019-01", Held: 500},
+			{SatelliteID: satellite, Period: "2020-04", Disposed: 250},
+		},
+	}
+
+	returns := make(map[string]int64)
+	for _, history := range histories {
+		nodeReturns, err := payouts.NodeHeldReturns(history)
+		require.NoError(t, err)
+		for period, returned := range nodeReturns {
+			returns[period] += returned
+		}
+	}
+
+	require.Equal(t, []payouts.HeldReturn{
+		{Period: "2021-11", Returned: 0},
+		{Period: "2021-12", Returned: 105},
+		{Period: "2022-01", Returned: 30},
+		{Period: "2022-02", Returned: 0},
+	}, payouts.NewHeldReturnForecast(returns, now, 4))
+
+	require.Empty(t, payouts.NewHeldReturnForecast(returns, now, 0))
+}
+
+func TestProcessNodeSummaries(t *testing.T) {
+	group := []nodes.Node{
+		{ID: testrand.NodeID(), PublicAddress: "10.0.0.1:28967"},
+		{ID: testrand.NodeID(), PublicAddress: "10.0.0.1:28967"},
+	}
+
+	first := &multinodepb.PayoutInfo{Held: 10, Paid: 100}
+	second := &multinodepb.PayoutInfo{Held: 20, Paid: 200}
+	infos, err := payouts.ProcessNodeSummaries(group, []*multinodepb.SatelliteSummariesResponse_NodeSummary{
+		{PayoutInfo: first},
+		{PayoutInfo: second},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*multinodepb.PayoutInfo{first, second}, infos)
+
+	// node with refused api secret fails the whole group.
+	_, err = payouts.ProcessNodeSummaries(group, []*multinodepb.SatelliteSummariesResponse_NodeSummary{
+		{PayoutInfo: first},
+		{Unauthenticated: true},
+	})
+	require.Error(t, err)
+
+	// summaries not matching the nodes are refused.
+	_, err = payouts.ProcessNodeSummaries(group, []*multinodepb.SatelliteSummariesResponse_NodeSummary{
+		{PayoutInfo: first},
+	})
+	require.Error(t, err)
+	_, err = payouts.ProcessNodeSummaries(group, []*multinodepb.SatelliteSummariesResponse_NodeSummary{
+		{PayoutInfo: first},
+		{},
+	})
+	require.Error(t, err)
+}
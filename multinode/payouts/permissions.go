@@ -0,0 +1,107 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts
+
+import (
+	"context"
+	"time"
+
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/common/storj"
+	"storj.io/storj/private/multinodepb"
+)
+
+// PermissionStatus is the result of probing a single category of payout RPCs.
+type PermissionStatus string
+
+const (
+	// PermissionGranted means the node served the probe request.
+	PermissionGranted PermissionStatus = "granted"
+	// PermissionDenied means the node accepted the api secret, but refused the request.
+	PermissionDenied PermissionStatus = "denied"
+	// PermissionUnauthenticated means the node didn't accept the api secret.
+	PermissionUnauthenticated PermissionStatus = "unauthenticated"
+	// PermissionUnknown means the probe failed for other reason than authorization, e.g. a database error.
+	PermissionUnknown PermissionStatus = "unknown"
+)
+
+// CategoryPermission contains result of probing a single category of payout RPCs.
+type CategoryPermission struct {
+	Category string           `json:"category"`
+	Status   PermissionStatus `json:"status"`
+	Error    string           `json:"error,omitempty"`
+}
+
+// NodePermissions contains permissions of the node api secret for all categories of payout RPCs.
+type NodePermissions struct {
+	NodeID   storj.NodeID `json:"nodeId"`
+	NodeName string       `json:"nodeName"`
+	// Unauthenticated is set when the api secret was refused by every probe, which means it's not valid at all.
+	Unauthenticated bool                 `json:"unauthenticated"`
+	Categories      []CategoryPermission `json:"categories"`
+}
+
+// permissionProbe probes a category of payout RPCs with its cheapest request.
+type permissionProbe struct {
+	category string
+	probe    func(ctx context.Context, client multinodepb.DRPCPayoutClient, header *multinodepb.RequestHeader) error
+}
+
+// permissionProbes contains probes of all categories of payout RPCs.
+var permissionProbes = []permissionProbe{
+	{category: "summary", probe: func(ctx context.Context, client multinodepb.DRPCPayoutClient, header *multinodepb.RequestHeader) error {
+		_, err := client.AllSatellitesSummary(ctx, &multinodepb.AllSatellitesSummaryRequest{Header: header})
+		return err
+	}},
+	{category: "earned", probe: func(ctx context.Context, client multinodepb.DRPCPayoutClient, header *multinodepb.RequestHeader) error {
+		_, err := client.Earned(ctx, &multinodepb.EarnedRequest{Header: header})
+		return err
+	}},
+	{category: "undistributed", probe: func(ctx context.Context, client multinodepb.DRPCPayoutClient, header *multinodepb.RequestHeader) error {
+		_, err := client.UndistributedPerSatellite(ctx, &multinodepb.UndistributedPerSatelliteRequest{Header: header})
+		return err
+	}},
+	{category: "estimations", probe: func(ctx context.Context, client multinodepb.DRPCPayoutClient, header *multinodepb.RequestHeader) error {
+		_, err := client.EstimatedPayoutTotal(ctx, &multinodepb.EstimatedPayoutTotalRequest{Header: header, AsOf: time.Now().UTC()})
+		return err
+	}},
+	{category: "history", probe: func(ctx context.Context, client multinodepb.DRPCPayoutClient, header *multinodepb.RequestHeader) error {
+		_, err := client.AvailablePeriods(ctx, &multinodepb.AvailablePeriodsRequest{Header: header})
+		return err
+	}},
+	{category: "config", probe: func(ctx context.Context, client multinodepb.DRPCPayoutClient, header *multinodepb.RequestHeader) error {
+		_, err := client.PayoutConfig(ctx, &multinodepb.PayoutConfigRequest{Header: header})
+		return err
+	}},
+}
+
+// ProbePermissions probes every category of payout RPCs with the header and reports which are permitted.
+func ProbePermissions(ctx context.Context, client multinodepb.DRPCPayoutClient, header *multinodepb.RequestHeader) (permissions NodePermissions) {
+	permissions.Unauthenticated = true
+	for _, probe := range permissionProbes {
+		permission := CategoryPermission{
+			Category: probe.category,
+			Status:   PermissionGranted,
+		}
+
+		if err := probe.probe(ctx, client, header); err != nil {
+			permission.Error = err.Error()
+			switch rpcstatus.Code(err) {
+			case rpcstatus.PermissionDenied:
+				permission.Status = PermissionDenied
+			case rpcstatus.Unauthenticated:
+				permission.Status = PermissionUnauthenticated
+			default:
+				permission.Status = PermissionUnknown
+			}
+		}
+
+		if permission.Status != PermissionUnauthenticated {
+			permissions.Unauthenticated = false
+		}
+		permissions.Categories = append(permissions.Categories, permission)
+	}
+
+	return permissions
+}
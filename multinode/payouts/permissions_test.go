@@ -0,0 +1,120 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/common/testcontext"
+	"storj.io/storj/multinode/payouts"
+	"storj.io/storj/private/multinodepb"
+)
+
+// scopedPayoutClient serves payout requests like a node whose api keys grant only some categories.
+type scopedPayoutClient struct {
+	multinodepb.DRPCPayoutClient
+
+	secret []byte
+	// granted are categories permitted for the secret, categories missing here are denied.
+	granted map[string]bool
+	// broken are categories failing with a database error.
+	broken map[string]bool
+}
+
+func (client *scopedPayoutClient) check(header *multinodepb.RequestHeader, category string) error {
+	switch {
+	case !bytes.Equal(header.GetApiKey(), client.secret):
+		return rpcstatus.Error(rpcstatus.Unauthenticated, "invalid api key")
+	case client.broken[category]:
+		return rpcstatus.Error(rpcstatus.Internal, "database error")
+	case !client.granted[category]:
+		return rpcstatus.Error(rpcstatus.PermissionDenied, "api key is not permitted to access "+category)
+	}
+	return nil
+}
+
+func (client *scopedPayoutClient) AllSatellitesSummary(ctx context.Context, req *multinodepb.AllSatellitesSummaryRequest) (*multinodepb.AllSatellitesSummaryResponse, error) {
+	return &multinodepb.AllSatellitesSummaryResponse{}, client.check(req.Header, "summary")
+}
+
+func (client *scopedPayoutClient) Earned(ctx context.Context, req *multinodepb.EarnedRequest) (*multinodepb.EarnedResponse, error) {
+	return &multinodepb.EarnedResponse{}, client.check(req.Header, "earned")
+}
+
+func (client *scopedPayoutClient) UndistributedPerSatellite(ctx context.Context, req *multinodepb.UndistributedPerSatelliteRequest) (*multinodepb.UndistributedPerSatelliteResponse, error) {
+	return &multinodepb.UndistributedPerSatelliteResponse{}, client.check(req.Header, "undistributed")
+}
+
+func (client *scopedPayoutClient) EstimatedPayoutTotal(ctx context.Context, req *multinodepb.EstimatedPayoutTotalRequest) (*multinodepb.EstimatedPayoutTotalResponse, error) {
+	return &multinodepb.EstimatedPayoutTotalResponse{}, client.check(req.Header, "estimations")
+}
+
+func (client *scopedPayoutClient) AvailablePeriods(ctx context.Context, req *multinodepb.AvailablePeriodsRequest) (*multinodepb.AvailablePeriodsResponse, error) {
+	return &multinodepb.AvailablePeriodsResponse{}, client.check(req.Header, "history")
+}
+
+func (client *scopedPayoutClient) PayoutConfig(ctx context.Context, req *multinodepb.PayoutConfigRequest) (*multinodepb.PayoutConfigResponse, error) {
+	return &multinodepb.PayoutConfigResponse{}, client.check(req.Header, "config")
+}
+
+func statuses(permissions payouts.NodePermissions) map[string]payouts.PermissionStatus {
+	statuses := make(map[string]payouts.PermissionStatus)
+	for _, category := range permissions.Categories {
+		statuses[category.Category] = category.Status
+	}
+	return statuses
+}
+
+func TestProbePermissions(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	secret := []byte("secret")
+	client := &scopedPayoutClient{
+		secret:  secret,
+		granted: map[string]bool{"summary": true, "earned": true, "history": true},
+		broken:  map[string]bool{"history": true},
+	}
+
+	// valid secret with partial access.
+	permissions := payouts.ProbePermissions(ctx, client, &multinodepb.RequestHeader{ApiKey: secret})
+	require.False(t, permissions.Unauthenticated)
+	require.Equal(t, map[string]payouts.PermissionStatus{
+		"summary":       payouts.PermissionGranted,
+		"earned":        payouts.PermissionGranted,
+		"undistributed": payouts.PermissionDenied,
+		"estimations":   payouts.PermissionDenied,
+		"history":       payouts.PermissionUnknown,
+		"config":        payouts.PermissionDenied,
+	}, statuses(permissions))
+	for _, category := range permissions.Categories {
+		if category.Status == payouts.PermissionGranted {
+			require.Empty(t, category.Error)
+		} else {
+			require.NotEmpty(t, category.Error)
+		}
+	}
+
+	// invalid secret isn't reported as denied.
+	permissions = payouts.ProbePermissions(ctx, client, &multinodepb.RequestHeader{ApiKey: []byte("invalid")})
+	require.True(t, permissions.Unauthenticated)
+	for category, status := range statuses(permissions) {
+		require.Equal(t, payouts.PermissionUnauthenticated, status, category)
+	}
+
+	// secret with full access.
+	client.granted = map[string]bool{"summary": true, "earned": true, "undistributed": true, "estimations": true, "history": true, "config": true}
+	client.broken = nil
+	permissions = payouts.ProbePermissions(ctx, client, &multinodepb.RequestHeader{ApiKey: secret})
+	require.False(t, permissions.Unauthenticated)
+	require.Len(t, permissions.Categories, 6)
+	for category, status := range statuses(permissions) {
+		require.Equal(t, payouts.PermissionGranted, status, category)
+	}
+}
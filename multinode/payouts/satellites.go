@@ -0,0 +1,107 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts
+
+import (
+	"net"
+	"net/url"
+	"strings"
+
+	"storj.io/common/storj"
+)
+
+// KnownSatellites is used to hold a list of trusted satellite urls, so that satellites
+// can be referred to by their hostname instead of the node id.
+type KnownSatellites []storj.NodeURL
+
+// String formats the satellite urls.
+func (satellites KnownSatellites) String() string {
+	return storj.NodeURLs(satellites).String()
+}
+
+// Set implements pflag.Value by parsing a comma separated list of satellite urls.
+func (satellites *KnownSatellites) Set(value string) error {
+	urls, err := storj.ParseNodeURLs(value)
+	if err != nil {
+		return Error.New("invalid satellite urls %q: %w", value, err)
+	}
+
+	for _, url := range urls {
+		if url.ID.IsZero() || url.Address == "" {
+			return Error.New("satellite url %q must contain both id and address", url.String())
+		}
+	}
+
+	*satellites = KnownSatellites(urls)
+	return nil
+}
+
+// Type returns the type of the pflag.Value.
+func (satellites KnownSatellites) Type() string {
+	return "known-satellites"
+}
+
+// Resolve returns id of the satellite referred to either by the node id, node url,
+// or hostname of one of the known satellites, with optional scheme and port.
+func (satellites KnownSatellites) Resolve(satellite string) (storj.NodeID, error) {
+	satellite = strings.TrimSpace(satellite)
+
+	if id, err := storj.NodeIDFromString(satellite); err == nil {
+		return id, nil
+	}
+
+	address := satellite
+	if parsed, err := url.Parse(satellite); err == nil && parsed.Host != "" {
+		address = parsed.Host
+	}
+	if nodeURL, err := storj.ParseNodeURL(address); err == nil && !nodeURL.ID.IsZero() {
+		return nodeURL.ID, nil
+	}
+
+	var resolved storj.NodeIDList
+	for _, known := range satellites {
+		if !sameHost(known.Address, address) {
+			continue
+		}
+		if !containsID(resolved, known.ID) {
+			resolved = append(resolved, known.ID)
+		}
+	}
+
+	switch len(resolved) {
+	case 0:
+		return storj.NodeID{}, Error.New("unknown satellite %q: use node id or address of a known satellite", satellite)
+	case 1:
+		return resolved[0], nil
+	default:
+		return storj.NodeID{}, Error.New("ambiguous satellite %q: %d known satellites share the address", satellite, len(resolved))
+	}
+}
+
+// sameHost returns true when the address refers to the known satellite address.
+// Port is compared only when the address contains it.
+func sameHost(known, address string) bool {
+	if strings.EqualFold(known, address) {
+		return true
+	}
+
+	if _, _, err := net.SplitHostPort(address); err == nil {
+		return false
+	}
+
+	host, _, err := net.SplitHostPort(known)
+	if err != nil {
+		host = known
+	}
+	return strings.EqualFold(host, address)
+}
+
+func containsID(ids storj.NodeIDList, id storj.NodeID) bool {
+	for _, existing := range ids {
+		if existing == id {
+			return true
+		}
+	}
+	return false
+}
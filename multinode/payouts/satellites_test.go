@@ -0,0 +1,68 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testrand"
+	"storj.io/storj/multinode/payouts"
+)
+
+func TestKnownSatellitesResolve(t *testing.T) {
+	us1, eu1 := testrand.NodeID(), testrand.NodeID()
+
+	var known payouts.KnownSatellites
+	require.NoError(t, known.Set(us1.String()+"@us1.storj.io:7777,"+eu1.String()+"@eu1.storj.io:7777"))
+	require.Len(t, known, 2)
+
+	for _, satellite := range []string{
+		"us1.storj.io",
+		"US1.storj.io",
+		"us1.storj.io:7777",
+		"https://us1.storj.io",
+		"https://us1.storj.io:7777/",
+		" us1.storj.io ",
+	} {
+		id, err := known.Resolve(satellite)
+		require.NoError(t, err, satellite)
+		require.Equal(t, us1, id, satellite)
+	}
+
+	// raw ids and node urls resolve even when satellite is not known.
+	unknown := testrand.NodeID()
+	for _, satellite := range []string{
+		unknown.String(),
+		unknown.String() + "@unknown.example.com:7777",
+	} {
+		id, err := known.Resolve(satellite)
+		require.NoError(t, err, satellite)
+		require.Equal(t, unknown, id, satellite)
+	}
+
+	// node url of a migrated satellite resolves by id, its address is not checked against the known one.
+	id, err := known.Resolve(us1.String() + "@us1-migrated.storj.io:7777")
+	require.NoError(t, err)
+	require.Equal(t, us1, id)
+
+	for _, satellite := range []string{
+		"ap1.storj.io",
+		"us1.storj.io:8888",
+		"",
+	} {
+		_, err := known.Resolve(satellite)
+		require.Error(t, err, satellite)
+	}
+}
+
+func TestKnownSatellitesFlag(t *testing.T) {
+	var known payouts.KnownSatellites
+	require.NoError(t, known.Set(""))
+	require.Empty(t, known)
+
+	require.Error(t, known.Set("us1.storj.io:7777"))
+	require.Error(t, known.Set(testrand.NodeID().String()))
+}
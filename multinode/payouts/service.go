@@ -5,6 +5,11 @@ package payouts
 
 import (
 	"context"
+	"math"
+	"net"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/spacemonkeygo/monkit/v3"
 	"github.com/zeebo/errs"
@@ -12,6 +17,8 @@ import (
 
 	"storj.io/common/rpc"
 	"storj.io/common/storj"
+	"storj.io/common/sync2"
+	"storj.io/private/version"
 	"storj.io/storj/multinode/nodes"
 	"storj.io/storj/private/multinodepb"
 )
@@ -22,103 +29,366 @@ var (
 	Error = errs.Class("payouts")
 )
 
+// Config contains configurable values for payouts service.
+//
+// MaxConnections bounds connections opened by the service itself. When the dialer uses
+// connection pool, closed connections are kept cached in the pool and don't count against the limit.
+//
+// ExcludedSatellites are left out of every aggregation. The exclusion takes precedence over satellite
+// requested by the caller, e.g. summary of an excluded satellite contains no nodes.
+//
+// SatelliteAliases merge legacy ids of renamed satellites into their canonical id. Satellites are always
+// aggregated by id only, their addresses are never taken into account.
+type Config struct {
+	MaxConnections int `help:"maximum number of simultaneously open node connections, zero means unlimited" default:"20"`
+
+	RefreshInterval time.Duration `help:"how frequently the payouts snapshot is refreshed in the background" default:"15m"`
+
+	CircuitBreaker CircuitBreakerConfig
+
+	SummaryCache SummaryCacheConfig
+
+	MetricsPerNode bool `help:"if true, payouts metrics are exported for every node, labeled with node id and name" default:"false"`
+
+	ExcludedSatellites SatelliteIDs `help:"comma separated list of satellite ids excluded from all payouts aggregations, e.g. decommissioned test satellites" default:""`
+
+	KnownSatellites KnownSatellites `help:"comma separated list of trusted satellite urls, which can be referred to by hostname instead of node id" default:""`
+
+	SatelliteWeights SatelliteWeights `help:"comma separated list of satellite id=weight pairs scaling satellite estimations in the weighted estimated earnings, e.g. to down-weight volatile satellites; satellites without weight have weight 1" default:""`
+
+	AddressFamily AddressFamily `help:"address family preferred when dialing nodes with host names resolving to both, ip4 or ip6; empty leaves the choice to the system" default:""`
+
+	SnapshotPath string `help:"path of the file the payouts snapshot is persisted to after every refresh and loaded from at startup; empty disables persistence" default:""`
+
+	NodeNamePrefix string `help:"if set, only nodes whose name starts with the prefix are included in payouts aggregations, nodes are filtered by the database" default:""`
+
+	SatelliteAliases SatelliteAliases `help:"comma separated list of legacy-id=canonical-id pairs merging payouts of renamed satellites into a single satellite" default:""`
+
+	PayoutThreshold int64 `help:"minimum undistributed amount in micro USD paid out to the node wallet, used to project time to payout" default:"10000000"`
+
+	NodesPageSize int `help:"maximum number of nodes loaded from the database at once by aggregations of fleet totals, zero uses the default" default:"1000"`
+
+	EstimateDropPercent float64 `help:"percentage of the baseline the current month estimate of a node has to drop by to be reported as anomaly, zero uses the default" default:"50"`
+}
+
+// defaultNodesPageSize is the number of nodes loaded at once when the page size isn't configured.
+const defaultNodesPageSize = 1000
+
+// defaultEstimateDropPercent is the estimate drop reported as anomaly when the percentage isn't configured.
+const defaultEstimateDropPercent = 50
+
+// earnedOnSatelliteTimeout is how long earned per satellite of a node is waited for. Nodes stop gathering
+// satellites after 10 seconds and return truncated response, which has to arrive before the timeout.
+const earnedOnSatelliteTimeout = 15 * time.Second
+
+// nodeFilter returns filter of nodes included in aggregations.
+func (config Config) nodeFilter() nodes.ListFilter {
+	return nodes.ListFilter{NamePrefix: config.NodeNamePrefix}
+}
+
+// nodesPageSize returns the number of nodes loaded from the database at once.
+func (config Config) nodesPageSize() int {
+	if config.NodesPageSize <= 0 {
+		return defaultNodesPageSize
+	}
+	return config.NodesPageSize
+}
+
+// estimateDropPercent returns percentage of the baseline estimate has to drop by to be reported as anomaly.
+func (config Config) estimateDropPercent() float64 {
+	if config.EstimateDropPercent <= 0 {
+		return defaultEstimateDropPercent
+	}
+	return config.EstimateDropPercent
+}
+
 // Service exposes all payouts related logic.
 //
 // architecture: Service
 type Service struct {
-	log    *zap.Logger
-	dialer rpc.Dialer
-	nodes  nodes.DB
+	log     *zap.Logger
+	dialer  rpc.Dialer
+	dialers Dialers
+	nodes   nodes.DB
+
+	breaker     *circuitBreaker
+	summaries   *summaryCache
+	connections *connectionLimiter
+	refresher   *sync2.Cycle
+
+	metricsPerNode bool
+	excluded       SatelliteIDs
+	known          KnownSatellites
+	weights        SatelliteWeights
+	aliases        SatelliteAliases
+	family         AddressFamily
+	resolver       hostResolver
+	snapshotPath   string
+	nodeFilter     nodes.ListFilter
+	pageSize       int
+	threshold      int64
+	estimateDrop   float64
+
+	mu sync.Mutex
+	// lastContact holds time of the most recent successful response of every node.
+	lastContact map[storj.NodeID]time.Time
+	// snapshot is the last data collected by the background refresher.
+	snapshot *Snapshot
 }
 
 // NewService creates new instance of Service.
-func NewService(log *zap.Logger, dialer rpc.Dialer, nodes nodes.DB) *Service {
+func NewService(log *zap.Logger, dialer rpc.Dialer, nodes nodes.DB, config Config) *Service {
 	return &Service{
-		log:    log,
-		dialer: dialer,
-		nodes:  nodes,
+		log:     log,
+		dialer:  dialer,
+		nodes:   nodes,
+		breaker: newCircuitBreaker(config.CircuitBreaker),
+
+		connections: newConnectionLimiter(config.MaxConnections),
+		refresher:   sync2.NewCycle(config.RefreshInterval),
+		summaries:   newSummaryCache(config.SummaryCache),
+
+		metricsPerNode: config.MetricsPerNode,
+		excluded:       config.ExcludedSatellites,
+		known:          config.KnownSatellites,
+		weights:        config.SatelliteWeights,
+		aliases:        config.SatelliteAliases,
+		family:         config.AddressFamily,
+		resolver:       net.DefaultResolver,
+		snapshotPath:   config.SnapshotPath,
+		nodeFilter:     config.nodeFilter(),
+		pageSize:       config.nodesPageSize(),
+		threshold:      config.PayoutThreshold,
+		estimateDrop:   config.estimateDropPercent(),
+
+		lastContact: make(map[storj.NodeID]time.Time),
+	}
+}
+
+// GetAllNodesAllTimeEarned retrieves all nodes gross and net earned amount for all time.
+func (service *Service) GetAllNodesAllTimeEarned(ctx context.Context) (earned Earned, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = service.iterateNodes(ctx, func(node nodes.Node) {
+		amount, err := service.getAmount(ctx, node)
+		if err != nil {
+			service.log.Error("failed to getAmount", zap.Error(err))
+			return
+		}
+		service.contacted(node.ID)
+
+		earned.Gross += amount.Gross
+		earned.Net += amount.Net
+	})
+	if err != nil {
+		return Earned{}, Error.Wrap(err)
 	}
+
+	return earned, nil
 }
 
-// GetAllNodesAllTimeEarned retrieves all nodes earned amount for all time.
-func (service *Service) GetAllNodesAllTimeEarned(ctx context.Context) (earned int64, err error) {
+// GetEarningsHistogram returns number of reachable nodes whose all time earnings fall into each bucket.
+// Buckets are defined by strictly increasing bounds, earnings above the highest bound are counted in overflow bucket.
+func (service *Service) GetEarningsHistogram(ctx context.Context, bucketBounds []int64) (_ []HistogramBucket, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	storageNodes, err := service.nodes.List(ctx)
+	perNode, err := service.GetPerNodeAllTimeEarned(ctx)
 	if err != nil {
-		return 0, Error.Wrap(err)
+		return nil, err
+	}
+
+	earned := make([]int64, 0, len(perNode))
+	for _, node := range perNode {
+		earned = append(earned, node.Gross)
+	}
+
+	return EarningsHistogram(bucketBounds, earned)
+}
+
+// GetPerNodeAllTimeEarned retrieves gross and net earned amount for all time of every node.
+// Nodes which fail to respond are skipped.
+func (service *Service) GetPerNodeAllTimeEarned(ctx context.Context) (_ []NodeEarned, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	storageNodes, err := service.listNodes(ctx)
+	if err != nil {
+		return nil, Error.Wrap(err)
 	}
 
+	annotations := service.annotations(ctx)
+
+	var earned []NodeEarned
 	for _, node := range storageNodes {
 		amount, err := service.getAmount(ctx, node)
 		if err != nil {
-			service.log.Error("failed to getAmount", zap.Error(err))
+			service.log.Error("failed to getAmount", zap.Stringer("node", node.ID), zap.Error(err))
 			continue
 		}
+		service.contacted(node.ID)
 
-		earned += amount
+		earned = append(earned, NodeEarned{
+			NodeID:     node.ID,
+			NodeName:   node.Name,
+			Earned:     amount,
+			Annotation: annotations[node.ID].Note,
+		})
 	}
 
 	return earned, nil
 }
 
+// GetEarningsByStableID returns all time gross and net earned amount of every node keyed by node id,
+// so results of different calls match even when nodes were renamed in between. Node names are only
+// included for display and are the current ones. Nodes which fail to respond are skipped.
+func (service *Service) GetEarningsByStableID(ctx context.Context) (_ EarningsByNodeID, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	earned, err := service.GetPerNodeAllTimeEarned(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewEarningsByNodeID(earned), nil
+}
+
+// GetConcentration returns the fraction of all time gross earnings of all nodes earned by the top n earning nodes.
+func (service *Service) GetConcentration(ctx context.Context, n int) (_ float64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if n < 1 {
+		return 0, Error.New("number of top nodes must be positive: %d", n)
+	}
+
+	perNode, err := service.GetPerNodeAllTimeEarned(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	earned := make([]int64, 0, len(perNode))
+	for _, node := range perNode {
+		earned = append(earned, node.Gross)
+	}
+
+	return ConcentrationRatio(earned, n), nil
+}
+
+// GetNetPayoutAfterCut returns all time gross earnings of all nodes split into the operator cut of cutFraction
+// and the net payout, with split of every node when perNode is true. Nodes which fail to respond are skipped.
+func (service *Service) GetNetPayoutAfterCut(ctx context.Context, cutFraction float64, perNode bool) (_ PayoutAfterCut, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := ValidateCutFraction(cutFraction); err != nil {
+		return PayoutAfterCut{}, err
+	}
+
+	earned, err := service.GetPerNodeAllTimeEarned(ctx)
+	if err != nil {
+		return PayoutAfterCut{}, err
+	}
+
+	return ApplyOperatorCut(earned, cutFraction, perNode)
+}
+
+// GetMedianEarnedPerNode returns quartiles of all time gross earnings of the nodes, which unlike the average
+// aren't skewed by outliers. Nodes which fail to respond are skipped, quartiles are zero when there are no nodes.
+func (service *Service) GetMedianEarnedPerNode(ctx context.Context) (_ Quartiles, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	perNode, err := service.GetPerNodeAllTimeEarned(ctx)
+	if err != nil {
+		return Quartiles{}, err
+	}
+
+	earned := make([]int64, 0, len(perNode))
+	for _, node := range perNode {
+		earned = append(earned, node.Gross)
+	}
+
+	return EarnedQuartiles(earned), nil
+}
+
 // GetAllNodesEarnedOnSatellite retrieves all nodes earned amount for all time per satellite.
-func (service *Service) GetAllNodesEarnedOnSatellite(ctx context.Context) (earned []SatelliteSummary, err error) {
+// Nodes omit satellites they earned less than minAmount on, in the node amount unit, zero includes all satellites.
+func (service *Service) GetAllNodesEarnedOnSatellite(ctx context.Context, minAmount int64) (earned []SatelliteSummary, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	storageNodes, err := service.nodes.List(ctx)
+	storageNodes, err := service.listNodes(ctx)
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
 
-	var listSatellites storj.NodeIDList
-	var listNodesEarnedPerSatellite []multinodepb.EarnedPerSatelliteResponse
+	var listNodesEarnedPerSatellite []*multinodepb.EarnedPerSatelliteResponse
 
 	for _, node := range storageNodes {
-		earnedPerSatellite, err := service.getEarnedOnSatellite(ctx, node)
+		earnedPerSatellite, err := service.getEarnedOnSatellite(ctx, node, minAmount)
 		if err != nil {
 			service.log.Error("failed to getEarnedFromSatellite", zap.Error(err))
 			continue
 		}
+		service.contacted(node.ID)
 
-		listNodesEarnedPerSatellite = append(listNodesEarnedPerSatellite, earnedPerSatellite)
-		for i := 0; i < len(earnedPerSatellite.EarnedSatellite); i++ {
-			listSatellites = append(listSatellites, earnedPerSatellite.EarnedSatellite[i].SatelliteId)
-		}
+		listNodesEarnedPerSatellite = append(listNodesEarnedPerSatellite, &earnedPerSatellite)
 	}
 
-	if listSatellites == nil {
+	earned = service.aliases.MergeSummaries(GroupEarnedBySatellite(listNodesEarnedPerSatellite))
+	if earned == nil {
 		return []SatelliteSummary{}, nil
 	}
 
-	uniqueSatelliteIDs := listSatellites.Unique()
-	for t := 0; t < len(uniqueSatelliteIDs); t++ {
-		earned = append(earned, SatelliteSummary{
-			SatelliteID: uniqueSatelliteIDs[t],
-		})
+	return earned, nil
+}
+
+// GetSatellitePayoutShares returns percentage of total earnings of all nodes coming from each satellite,
+// sorted by share descending. Shares of different currencies are relative to the total of their currency.
+func (service *Service) GetSatellitePayoutShares(ctx context.Context) (_ []SatellitePayoutShare, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	earned, err := service.GetAllNodesEarnedOnSatellite(ctx, 0)
+	if err != nil {
+		return nil, err
 	}
 
-	for i := 0; i < len(listNodesEarnedPerSatellite); i++ {
-		singleNodeEarnedPerSatellite := listNodesEarnedPerSatellite[i].EarnedSatellite
-		for j := 0; j < len(singleNodeEarnedPerSatellite); j++ {
-			for k := 0; k < len(earned); k++ {
-				if singleNodeEarnedPerSatellite[j].SatelliteId == earned[k].SatelliteID {
-					earned[k].Earned += singleNodeEarnedPerSatellite[j].Total
-				}
-			}
+	return PayoutShares(earned), nil
+}
+
+// GetAllNodesEarnedComponents retrieves all nodes earned amount for all time split by the payout component,
+// e.g. to tell apart earnings from storage and egress. Nodes which fail to respond are skipped.
+func (service *Service) GetAllNodesEarnedComponents(ctx context.Context) (components EarnedComponents, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = service.iterateNodes(ctx, func(node nodes.Node) {
+		earnedPerSatellite, err := service.getEarnedOnSatellite(ctx, node, 0)
+		if err != nil {
+			service.log.Error("failed to get node earned components", zap.Stringer("node", node.ID), zap.Error(err))
+			return
+		}
+		service.contacted(node.ID)
+
+		for _, satellite := range earnedPerSatellite.EarnedSatellite {
+			components.Add(satellite)
 		}
+	})
+	if err != nil {
+		return EarnedComponents{}, Error.Wrap(err)
 	}
 
-	return earned, nil
+	return components, nil
 }
 
 // NodesSummary returns all satellites all time stats.
 func (service *Service) NodesSummary(ctx context.Context) (_ Summary, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	return service.cachedSummary(ctx, summaryCacheKey{method: "NodesSummary"}, func() (Summary, error) {
+		return service.nodesSummary(ctx)
+	})
+}
+
+// nodesSummary collects all satellites all time stats from all nodes.
+func (service *Service) nodesSummary(ctx context.Context) (_ Summary, err error) {
 	var summary Summary
 
-	list, err := service.nodes.List(ctx)
+	list, err := service.listNodes(ctx)
 	if err != nil {
 		return Summary{}, Error.Wrap(err)
 	}
@@ -126,12 +396,18 @@ func (service *Service) NodesSummary(ctx context.Context) (_ Summary, err error)
 	for _, node := range list {
 		info, err := service.getAllSatellitesAllTime(ctx, node)
 		if err != nil {
+			if ErrCircuitOpen.Has(err) {
+				summary.AddCircuitOpen(node.ID, node.Name)
+				continue
+			}
 			return Summary{}, Error.Wrap(err)
 		}
+		service.contacted(node.ID)
 
 		summary.Add(info.Held, info.Paid, node.ID, node.Name)
 	}
 
+	service.fillLastContact(&summary)
 	return summary, nil
 }
 
@@ -139,9 +415,16 @@ func (service *Service) NodesSummary(ctx context.Context) (_ Summary, err error)
 func (service *Service) NodesPeriodSummary(ctx context.Context, period string) (_ Summary, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	return service.cachedSummary(ctx, summaryCacheKey{method: "NodesPeriodSummary", period: period}, func() (Summary, error) {
+		return service.nodesPeriodSummary(ctx, period)
+	})
+}
+
+// nodesPeriodSummary collects all satellites stats for specific period from all nodes.
+func (service *Service) nodesPeriodSummary(ctx context.Context, period string) (_ Summary, err error) {
 	var summary Summary
 
-	list, err := service.nodes.List(ctx)
+	list, err := service.listNodes(ctx)
 	if err != nil {
 		return Summary{}, Error.Wrap(err)
 	}
@@ -149,146 +432,197 @@ func (service *Service) NodesPeriodSummary(ctx context.Context, period string) (
 	for _, node := range list {
 		info, err := service.getAllSatellitesPeriod(ctx, node, period)
 		if err != nil {
+			if ErrCircuitOpen.Has(err) {
+				summary.AddCircuitOpen(node.ID, node.Name)
+				continue
+			}
 			return Summary{}, Error.Wrap(err)
 		}
+		service.contacted(node.ID)
 
 		summary.Add(info.Held, info.Paid, node.ID, node.Name)
 	}
 
+	service.fillLastContact(&summary)
 	return summary, nil
 }
 
-// NodesSatelliteSummary returns specific satellite all time stats.
-func (service *Service) NodesSatelliteSummary(ctx context.Context, satelliteID storj.NodeID) (_ Summary, err error) {
+// GetNodeGrowthRates returns earnings change of every node between two periods, sorted from the fastest growing node.
+func (service *Service) GetNodeGrowthRates(ctx context.Context, fromPeriod, toPeriod string) (_ []NodeGrowthRate, err error) {
 	defer mon.Task()(&ctx)(&err)
-	var summary Summary
 
-	list, err := service.nodes.List(ctx)
+	base, err := service.NodesPeriodSummary(ctx, fromPeriod)
 	if err != nil {
-		return Summary{}, Error.Wrap(err)
+		return nil, Error.Wrap(err)
 	}
 
-	for _, node := range list {
-		info, err := service.nodeSatelliteSummary(ctx, node, satelliteID)
-		if err != nil {
-			return Summary{}, Error.Wrap(err)
-		}
-
-		summary.Add(info.Held, info.Paid, node.ID, node.Name)
+	current, err := service.NodesPeriodSummary(ctx, toPeriod)
+	if err != nil {
+		return nil, Error.Wrap(err)
 	}
 
-	return summary, nil
+	return CalculateGrowthRates(base, current), nil
+}
+
+// ResolveSatellite returns id of the satellite referred to by node id, node url or hostname of a known satellite.
+func (service *Service) ResolveSatellite(satellite string) (storj.NodeID, error) {
+	return service.known.Resolve(satellite)
+}
+
+// NodesSatelliteSummary returns specific satellite all time stats.
+// Stats of legacy ids of the satellite are merged into the summary.
+func (service *Service) NodesSatelliteSummary(ctx context.Context, satelliteID storj.NodeID) (_ Summary, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	key := summaryCacheKey{method: "NodesSatelliteSummary", satelliteID: service.aliases.Canonical(satelliteID)}
+	return service.cachedSummary(ctx, key, func() (Summary, error) {
+		return service.satelliteSummary(ctx, satelliteID, "")
+	})
 }
 
 // NodesSatellitePeriodSummary returns specific satellite stats for specific period.
+// Stats of legacy ids of the satellite are merged into the summary.
 func (service *Service) NodesSatellitePeriodSummary(ctx context.Context, satelliteID storj.NodeID, period string) (_ Summary, err error) {
 	defer mon.Task()(&ctx)(&err)
-	var summary Summary
 
-	list, err := service.nodes.List(ctx)
-	if err != nil {
-		return Summary{}, Error.Wrap(err)
-	}
+	key := summaryCacheKey{method: "NodesSatellitePeriodSummary", period: period, satelliteID: service.aliases.Canonical(satelliteID)}
+	return service.cachedSummary(ctx, key, func() (Summary, error) {
+		return service.satelliteSummary(ctx, satelliteID, period)
+	})
+}
 
-	for _, node := range list {
-		info, err := service.nodeSatellitePeriodSummary(ctx, node, satelliteID, period)
-		if err != nil {
-			return Summary{}, Error.Wrap(err)
-		}
+// cachedSummary returns summary cached for the key, collecting it with summarize on cache miss.
+// Last contact, names and annotations of cached summaries are refreshed, since they may have changed since.
+func (service *Service) cachedSummary(ctx context.Context, key summaryCacheKey, summarize func() (Summary, error)) (Summary, error) {
+	if summary, ok := service.summaries.Get(key); ok {
+		mon.Event("payouts_summary_cache_hit")
+		service.fillLastContact(&summary)
+		service.fillNames(ctx, &summary)
+		service.fillAnnotations(ctx, &summary)
+		return summary, nil
+	}
+	mon.Event("payouts_summary_cache_miss")
 
-		summary.Add(info.Held, info.Paid, node.ID, node.Name)
+	summary, err := summarize()
+	if err != nil {
+		return Summary{}, err
 	}
 
+	service.summaries.Put(key, summary)
+	service.fillAnnotations(ctx, &summary)
 	return summary, nil
 }
 
-// nodeSatelliteSummary returns payout info for single satellite, for specific node.
-func (service *Service) nodeSatelliteSummary(ctx context.Context, node nodes.Node, satelliteID storj.NodeID) (info *multinodepb.PayoutInfo, err error) {
-	conn, err := service.dialer.DialNodeURL(ctx, storj.NodeURL{
-		ID:      node.ID,
-		Address: node.PublicAddress,
-	})
-	if err != nil {
-		return &multinodepb.PayoutInfo{}, Error.Wrap(err)
+// satelliteSummary sums payout info of all nodes for the canonical id of the satellite and all its legacy ids,
+// for the period or for all time when period is empty.
+func (service *Service) satelliteSummary(ctx context.Context, satelliteID storj.NodeID, period string) (_ Summary, err error) {
+	if service.excluded.Contains(service.aliases.Canonical(satelliteID)) {
+		return Summary{}, nil
 	}
 
-	defer func() {
-		err = errs.Combine(err, conn.Close())
-	}()
-
-	payoutClient := multinodepb.NewDRPCPayoutClient(conn)
-	header := &multinodepb.RequestHeader{
-		ApiKey: node.APISecret,
+	var satelliteIDs storj.NodeIDList
+	for _, id := range service.aliases.IDs(satelliteID) {
+		if !service.excluded.Contains(id) {
+			satelliteIDs = append(satelliteIDs, id)
+		}
 	}
-
-	response, err := payoutClient.SatelliteSummary(ctx, &multinodepb.SatelliteSummaryRequest{Header: header, SatelliteId: satelliteID})
-	if err != nil {
-		return &multinodepb.PayoutInfo{}, Error.Wrap(err)
+	if len(satelliteIDs) == 0 {
+		return Summary{}, nil
 	}
 
-	return response.PayoutInfo, nil
-}
+	var summary Summary
 
-// nodeSatellitePeriodSummary returns satellite payout info for specific node for specific period.
-func (service *Service) nodeSatellitePeriodSummary(ctx context.Context, node nodes.Node, satelliteID storj.NodeID, period string) (info *multinodepb.PayoutInfo, err error) {
-	conn, err := service.dialer.DialNodeURL(ctx, storj.NodeURL{
-		ID:      node.ID,
-		Address: node.PublicAddress,
-	})
+	list, err := service.listNodes(ctx)
 	if err != nil {
-		return &multinodepb.PayoutInfo{}, Error.Wrap(err)
+		return Summary{}, Error.Wrap(err)
 	}
 
-	defer func() {
-		err = errs.Combine(err, conn.Close())
-	}()
+	for _, group := range nodes.GroupByAddress(list) {
+		held := make([]int64, len(group))
+		paid := make([]int64, len(group))
+		circuitOpen := make([]bool, len(group))
+		for _, id := range satelliteIDs {
+			infos, err := service.groupSatelliteSummaries(ctx, group, id, period)
+			if err != nil {
+				return Summary{}, Error.Wrap(err)
+			}
+			for i, info := range infos {
+				if info == nil {
+					circuitOpen[i] = true
+					continue
+				}
+				held[i] += info.Held
+				paid[i] += info.Paid
+			}
+		}
 
-	payoutClient := multinodepb.NewDRPCPayoutClient(conn)
-	header := &multinodepb.RequestHeader{
-		ApiKey: node.APISecret,
-	}
+		for i, node := range group {
+			if circuitOpen[i] {
+				summary.AddCircuitOpen(node.ID, node.Name)
+				continue
+			}
+			service.contacted(node.ID)
 
-	response, err := payoutClient.SatellitePeriodSummary(ctx, &multinodepb.SatellitePeriodSummaryRequest{Header: header, SatelliteId: satelliteID, Period: period})
-	if err != nil {
-		return &multinodepb.PayoutInfo{}, Error.Wrap(err)
+			summary.Add(held[i], paid[i], node.ID, node.Name)
+		}
 	}
 
-	return response.PayoutInfo, nil
+	service.fillLastContact(&summary)
+	return summary, nil
 }
 
-func (service *Service) getAllSatellitesPeriod(ctx context.Context, node nodes.Node, period string) (info *multinodepb.PayoutInfo, err error) {
-	conn, err := service.dialer.DialNodeURL(ctx, storj.NodeURL{
-		ID:      node.ID,
-		Address: node.PublicAddress,
-	})
-	if err != nil {
-		return &multinodepb.PayoutInfo{}, Error.Wrap(err)
+// groupSatelliteSummaries retrieves satellite payout info of every node of the group sharing the same address,
+// with nil info for nodes which were not dialed because of repeated failures. Nodes sharing the address are
+// served by the same process, so they are queried with a single request, unless the group is made
+// of entries of the same node only. When the request fails, e.g. because the process doesn't support it,
+// every node is queried on its own.
+func (service *Service) groupSatelliteSummaries(ctx context.Context, group []nodes.Node, satelliteID storj.NodeID, period string) (_ []*multinodepb.PayoutInfo, err error) {
+	if hasDistinctIDs(group) {
+		infos, err := service.processSatelliteSummaries(ctx, group, satelliteID, period)
+		if err == nil {
+			return infos, nil
+		}
+		service.log.Debug("failed to get satellite summaries of nodes sharing address, querying nodes one by one",
+			zap.String("address", group[0].PublicAddress), zap.Error(err))
 	}
 
-	defer func() {
-		err = errs.Combine(err, conn.Close())
-	}()
-
-	payoutClient := multinodepb.NewDRPCPayoutClient(conn)
-	header := &multinodepb.RequestHeader{
-		ApiKey: node.APISecret,
+	infos := make([]*multinodepb.PayoutInfo, 0, len(group))
+	for _, node := range group {
+		var info *multinodepb.PayoutInfo
+		if period == "" {
+			info, err = service.nodeSatelliteSummary(ctx, node, satelliteID)
+		} else {
+			info, err = service.nodeSatellitePeriodSummary(ctx, node, satelliteID, period)
+		}
+		if err != nil {
+			if ErrCircuitOpen.Has(err) {
+				infos = append(infos, nil)
+				continue
+			}
+			return nil, err
+		}
+		infos = append(infos, info)
 	}
 
-	response, err := payoutClient.AllSatellitesPeriodSummary(ctx, &multinodepb.AllSatellitesPeriodSummaryRequest{Header: header, Period: period})
-	if err != nil {
-		return &multinodepb.PayoutInfo{}, Error.Wrap(err)
-	}
+	return infos, nil
+}
 
-	return response.PayoutInfo, nil
+// hasDistinctIDs returns true when the group contains more than one node id.
+func hasDistinctIDs(group []nodes.Node) bool {
+	for _, node := range group {
+		if node.ID != group[0].ID {
+			return true
+		}
+	}
+	return false
 }
 
-func (service *Service) getAllSatellitesAllTime(ctx context.Context, node nodes.Node) (info *multinodepb.PayoutInfo, err error) {
-	conn, err := service.dialer.DialNodeURL(ctx, storj.NodeURL{
-		ID:      node.ID,
-		Address: node.PublicAddress,
-	})
+// processSatelliteSummaries retrieves satellite payout info of all nodes of the group with a single request
+// to the process serving them.
+func (service *Service) processSatelliteSummaries(ctx context.Context, group []nodes.Node, satelliteID storj.NodeID, period string) (_ []*multinodepb.PayoutInfo, err error) {
+	conn, err := service.dial(ctx, group[0])
 	if err != nil {
-		return &multinodepb.PayoutInfo{}, Error.Wrap(err)
+		return nil, Error.Wrap(err)
 	}
 
 	defer func() {
@@ -296,72 +630,1116 @@ func (service *Service) getAllSatellitesAllTime(ctx context.Context, node nodes.
 	}()
 
 	payoutClient := multinodepb.NewDRPCPayoutClient(conn)
-	header := &multinodepb.RequestHeader{
-		ApiKey: node.APISecret,
+
+	headers := make([]*multinodepb.RequestHeader, 0, len(group))
+	for _, node := range group {
+		headers = append(headers, service.requestHeader(ctx, node))
 	}
 
-	response, err := payoutClient.AllSatellitesSummary(ctx, &multinodepb.AllSatellitesSummaryRequest{Header: header})
+	response, err := payoutClient.SatelliteSummaries(ctx, &multinodepb.SatelliteSummariesRequest{
+		Headers:     headers,
+		SatelliteId: satelliteID,
+		Period:      period,
+	})
 	if err != nil {
-		return &multinodepb.PayoutInfo{}, Error.Wrap(err)
+		return nil, rpcError(group[0], err)
 	}
 
-	return response.PayoutInfo, nil
+	return ProcessNodeSummaries(group, response.NodeSummaries)
 }
 
-// NodesSatelliteEstimations returns specific satellite all time estimated earnings.
-func (service *Service) NodesSatelliteEstimations(ctx context.Context, satelliteID storj.NodeID) (_ int64, err error) {
+// NodesNegativePayouts returns every node satellite payout with negative held or paid amount for specific period,
+// or for all time when period is empty. Such payouts are usually adjustments, which are hidden in summed totals.
+func (service *Service) NodesNegativePayouts(ctx context.Context, period string) (_ []SatellitePayout, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var estimatedEarnings int64
-
-	list, err := service.nodes.List(ctx)
+	list, err := service.listNodes(ctx)
 	if err != nil {
-		return 0, Error.Wrap(err)
+		return nil, Error.Wrap(err)
 	}
 
+	var satellitePayouts []SatellitePayout
 	for _, node := range list {
-		estimation, err := service.nodeSatelliteEstimations(ctx, node, satelliteID)
+		nodePayouts, err := service.nodeSatellitePayouts(ctx, node, period)
 		if err != nil {
-			return 0, Error.Wrap(err)
+			service.log.Error("failed to get node satellite payouts", zap.Stringer("node", node.ID), zap.Error(err))
+			continue
 		}
+		service.contacted(node.ID)
 
-		estimatedEarnings += estimation
+		satellitePayouts = append(satellitePayouts, nodePayouts...)
 	}
 
-	return estimatedEarnings, nil
+	return NegativePayouts(satellitePayouts), nil
 }
 
-// NodesEstimations returns all satellites all time estimated earnings.
-func (service *Service) NodesEstimations(ctx context.Context) (_ int64, err error) {
-	defer mon.Task()(&ctx)(&err)
-
-	var estimatedEarnings int64
-
-	list, err := service.nodes.List(ctx)
+// nodeSatellitePayouts returns payouts of every satellite the node earned on, for specific period or for all time.
+func (service *Service) nodeSatellitePayouts(ctx context.Context, node nodes.Node, period string) (_ []SatellitePayout, err error) {
+	earned, err := service.getEarnedOnSatellite(ctx, node, 0)
 	if err != nil {
-		return 0, Error.Wrap(err)
+		return nil, err
 	}
 
-	for _, node := range list {
-		estimation, err := service.nodeEstimations(ctx, node)
+	var satellitePayouts []SatellitePayout
+	for _, satellite := range earned.EarnedSatellite {
+		var info *multinodepb.PayoutInfo
+		if period == "" {
+			info, err = service.nodeSatelliteSummary(ctx, node, satellite.SatelliteId)
+		} else {
+			info, err = service.nodeSatellitePeriodSummary(ctx, node, satellite.SatelliteId, period)
+		}
 		if err != nil {
-			return 0, Error.Wrap(err)
+			return nil, err
 		}
 
-		estimatedEarnings += estimation
+		satellitePayouts = append(satellitePayouts, SatellitePayout{
+			NodeID:      node.ID,
+			NodeName:    node.Name,
+			SatelliteID: satellite.SatelliteId,
+			Period:      period,
+			Held:        info.Held,
+			Paid:        info.Paid,
+		})
 	}
 
-	return estimatedEarnings, nil
+	return satellitePayouts, nil
 }
 
-// nodeEstimations retrieves data from a single node.
-func (service *Service) nodeEstimations(ctx context.Context, node nodes.Node) (estimation int64, err error) {
-	conn, err := service.dialer.DialNodeURL(ctx, storj.NodeURL{
-		ID:      node.ID,
-		Address: node.PublicAddress,
+// GetNodesDroppedFromSatellite returns nodes which earned on the satellite in the period preceding the provided one,
+// but didn't earn anything in the provided period. Nothing is returned when no node earned in the previous period.
+func (service *Service) GetNodesDroppedFromSatellite(ctx context.Context, satelliteID storj.NodeID, period string) (_ storj.NodeIDList, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	previousPeriod, err := PreviousPeriod(period)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	previous, err := service.NodesSatellitePeriodSummary(ctx, satelliteID, previousPeriod)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	current, err := service.NodesSatellitePeriodSummary(ctx, satelliteID, period)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return DroppedNodes(previous, current), nil
+}
+
+// nodeSatelliteSummary returns payout info for single satellite, for specific node.
+func (service *Service) nodeSatelliteSummary(ctx context.Context, node nodes.Node, satelliteID storj.NodeID) (info *multinodepb.PayoutInfo, err error) {
+	conn, err := service.dial(ctx, node)
+	if err != nil {
+		return &multinodepb.PayoutInfo{}, Error.Wrap(err)
+	}
+
+	defer func() {
+		err = errs.Combine(err, conn.Close())
+	}()
+
+	payoutClient := multinodepb.NewDRPCPayoutClient(conn)
+	header := service.requestHeader(ctx, node)
+
+	response, err := payoutClient.SatelliteSummary(ctx, &multinodepb.SatelliteSummaryRequest{Header: header, SatelliteId: satelliteID})
+	if err != nil {
+		return &multinodepb.PayoutInfo{}, rpcError(node, err)
+	}
+
+	return response.PayoutInfo, nil
+}
+
+// nodeSatellitePeriodSummary returns satellite payout info for specific node for specific period.
+func (service *Service) nodeSatellitePeriodSummary(ctx context.Context, node nodes.Node, satelliteID storj.NodeID, period string) (info *multinodepb.PayoutInfo, err error) {
+	conn, err := service.dial(ctx, node)
+	if err != nil {
+		return &multinodepb.PayoutInfo{}, Error.Wrap(err)
+	}
+
+	defer func() {
+		err = errs.Combine(err, conn.Close())
+	}()
+
+	payoutClient := multinodepb.NewDRPCPayoutClient(conn)
+	header := service.requestHeader(ctx, node)
+
+	response, err := payoutClient.SatellitePeriodSummary(ctx, &multinodepb.SatellitePeriodSummaryRequest{Header: header, SatelliteId: satelliteID, Period: period})
+	if err != nil {
+		return &multinodepb.PayoutInfo{}, rpcError(node, err)
+	}
+
+	return response.PayoutInfo, nil
+}
+
+func (service *Service) getAllSatellitesPeriod(ctx context.Context, node nodes.Node, period string) (info *multinodepb.PayoutInfo, err error) {
+	conn, err := service.dial(ctx, node)
+	if err != nil {
+		return &multinodepb.PayoutInfo{}, Error.Wrap(err)
+	}
+
+	defer func() {
+		err = errs.Combine(err, conn.Close())
+	}()
+
+	payoutClient := multinodepb.NewDRPCPayoutClient(conn)
+	header := service.requestHeader(ctx, node)
+
+	return service.periodSummary(ctx, payoutClient, header, node, period)
+}
+
+// periodSummary retrieves all satellites summary for the period over an open node connection.
+func (service *Service) periodSummary(ctx context.Context, payoutClient multinodepb.DRPCPayoutClient, header *multinodepb.RequestHeader, node nodes.Node, period string) (info *multinodepb.PayoutInfo, err error) {
+	response, err := payoutClient.AllSatellitesPeriodSummary(ctx, &multinodepb.AllSatellitesPeriodSummaryRequest{Header: header, Period: period})
+	if err != nil {
+		return &multinodepb.PayoutInfo{}, rpcError(node, err)
+	}
+
+	for _, satelliteID := range service.excluded {
+		excluded, err := payoutClient.SatellitePeriodSummary(ctx, &multinodepb.SatellitePeriodSummaryRequest{Header: header, SatelliteId: satelliteID, Period: period})
+		if err != nil {
+			return &multinodepb.PayoutInfo{}, rpcError(node, err)
+		}
+		subtractPayoutInfo(response.PayoutInfo, excluded.PayoutInfo)
+	}
+
+	return response.PayoutInfo, nil
+}
+
+func (service *Service) getAllSatellitesAllTime(ctx context.Context, node nodes.Node) (info *multinodepb.PayoutInfo, err error) {
+	conn, err := service.dial(ctx, node)
+	if err != nil {
+		return &multinodepb.PayoutInfo{}, Error.Wrap(err)
+	}
+
+	defer func() {
+		err = errs.Combine(err, conn.Close())
+	}()
+
+	payoutClient := multinodepb.NewDRPCPayoutClient(conn)
+	header := service.requestHeader(ctx, node)
+
+	response, err := payoutClient.AllSatellitesSummary(ctx, &multinodepb.AllSatellitesSummaryRequest{Header: header})
+	if err != nil {
+		return &multinodepb.PayoutInfo{}, rpcError(node, err)
+	}
+
+	for _, satelliteID := range service.excluded {
+		excluded, err := payoutClient.SatelliteSummary(ctx, &multinodepb.SatelliteSummaryRequest{Header: header, SatelliteId: satelliteID})
+		if err != nil {
+			return &multinodepb.PayoutInfo{}, rpcError(node, err)
+		}
+		subtractPayoutInfo(response.PayoutInfo, excluded.PayoutInfo)
+	}
+
+	return response.PayoutInfo, nil
+}
+
+// GetNodeEfficiency returns current month estimated earnings of every node relative to its disk space and egress usage.
+func (service *Service) GetNodeEfficiency(ctx context.Context) (_ []NodeEfficiency, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	list, err := service.listNodes(ctx)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	annotations := service.annotations(ctx)
+
+	var efficiencies []NodeEfficiency
+	for _, node := range list {
+		efficiency, err := service.nodeEfficiency(ctx, node)
+		if err != nil {
+			service.log.Error("failed to get node efficiency", zap.Stringer("node", node.ID), zap.Error(err))
+			continue
+		}
+		efficiency.LastContact = service.contacted(node.ID)
+		efficiency.Annotation = annotations[node.ID].Note
+
+		efficiencies = append(efficiencies, efficiency)
+	}
+
+	return efficiencies, nil
+}
+
+// nodeEfficiency retrieves estimated earnings and usage from a single node.
+func (service *Service) nodeEfficiency(ctx context.Context, node nodes.Node) (_ NodeEfficiency, err error) {
+	conn, err := service.dial(ctx, node)
+	if err != nil {
+		return NodeEfficiency{}, Error.Wrap(err)
+	}
+
+	defer func() {
+		err = errs.Combine(err, conn.Close())
+	}()
+
+	payoutClient := multinodepb.NewDRPCPayoutClient(conn)
+	storageClient := multinodepb.NewDRPCStorageClient(conn)
+	bandwidthClient := multinodepb.NewDRPCBandwidthClient(conn)
+	header := service.requestHeader(ctx, node)
+
+	estimated, err := payoutClient.EstimatedPayoutTotal(ctx, &multinodepb.EstimatedPayoutTotalRequest{Header: header, AsOf: time.Now().UTC()})
+	if err != nil {
+		return NodeEfficiency{}, rpcError(node, err)
+	}
+
+	diskSpace, err := storageClient.DiskSpace(ctx, &multinodepb.DiskSpaceRequest{Header: header})
+	if err != nil {
+		return NodeEfficiency{}, rpcError(node, err)
+	}
+
+	bandwidth, err := bandwidthClient.MonthSummary(ctx, &multinodepb.BandwidthMonthSummaryRequest{Header: header})
+	if err != nil {
+		return NodeEfficiency{}, rpcError(node, err)
+	}
+
+	efficiency := NodeEfficiency{
+		NodeID:        node.ID,
+		NodeName:      node.Name,
+		Earned:        estimated.EstimatedEarnings,
+		DiskSpaceUsed: diskSpace.UsedPieces,
+		Egress:        bandwidth.Egress,
+	}
+	efficiency.Calculate()
+
+	return efficiency, nil
+}
+
+// GetSatellitesWithPendingPayout returns satellites which paid all nodes more than minAmount micro USD
+// that was not yet distributed.
+func (service *Service) GetSatellitesWithPendingPayout(ctx context.Context, minAmount int64) (_ []SatelliteUndistributed, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	list, err := service.listNodes(ctx)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	var undistributed []*multinodepb.UndistributedSatellite
+	for _, node := range list {
+		nodeUndistributed, err := service.nodeUndistributed(ctx, node)
+		if err != nil {
+			service.log.Error("failed to get node undistributed payouts", zap.Stringer("node", node.ID), zap.Error(err))
+			continue
+		}
+		service.contacted(node.ID)
+
+		undistributed = append(undistributed, service.aliases.CanonicalUndistributed(nodeUndistributed)...)
+	}
+
+	return PendingPayouts(undistributed, minAmount), nil
+}
+
+// nodeUndistributed retrieves undistributed amounts per satellite from a single node.
+func (service *Service) nodeUndistributed(ctx context.Context, node nodes.Node) (_ []*multinodepb.UndistributedSatellite, err error) {
+	conn, err := service.dial(ctx, node)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	defer func() {
+		err = errs.Combine(err, conn.Close())
+	}()
+
+	payoutClient := multinodepb.NewDRPCPayoutClient(conn)
+	header := service.requestHeader(ctx, node)
+
+	response, err := payoutClient.UndistributedPerSatellite(ctx, &multinodepb.UndistributedPerSatelliteRequest{Header: header})
+	if err != nil {
+		return nil, rpcError(node, err)
+	}
+
+	return service.excluded.FilterUndistributed(response.UndistributedSatellite), nil
+}
+
+// GetTimeToThreshold projects for every node how many months it takes until its undistributed balance
+// reaches the payout threshold, using the current month estimate.
+func (service *Service) GetTimeToThreshold(ctx context.Context) (_ []NodeTimeToThreshold, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	list, err := service.listNodes(ctx)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	var projections []NodeTimeToThreshold
+	for _, node := range list {
+		projection, err := service.nodeTimeToThreshold(ctx, node)
+		if err != nil {
+			service.log.Error("failed to get node time to payout threshold", zap.Stringer("node", node.ID), zap.Error(err))
+			continue
+		}
+		service.contacted(node.ID)
+
+		projections = append(projections, projection)
+	}
+
+	return projections, nil
+}
+
+// nodeTimeToThreshold retrieves undistributed balance and estimation of a single node and projects time to threshold.
+func (service *Service) nodeTimeToThreshold(ctx context.Context, node nodes.Node) (_ NodeTimeToThreshold, err error) {
+	undistributed, err := service.nodeUndistributed(ctx, node)
+	if err != nil {
+		return NodeTimeToThreshold{}, err
+	}
+
+	// estimate is rescaled to micro USD, the unit of undistributed balance and the threshold.
+	estimated, err := service.nodeCurrentEstimate(ctx, node)
+	if err != nil {
+		return NodeTimeToThreshold{}, err
+	}
+
+	projection := NodeTimeToThreshold{
+		NodeID:    node.ID,
+		NodeName:  node.Name,
+		Estimated: estimated,
+	}
+	for _, satellite := range undistributed {
+		projection.Undistributed += satellite.Total
+	}
+
+	months, ok := MonthsToThreshold(projection.Undistributed, projection.Estimated, service.threshold)
+	projection.Months, projection.Unknown = months, !ok
+
+	return projection, nil
+}
+
+// CheckSatelliteMembership compares satellites every node was ever paid by with the expected satellites
+// and returns nodes with extra or missing satellites. Legacy satellite ids are compared by their canonical id
+// and excluded satellites are ignored. Nodes which fail to respond are skipped.
+func (service *Service) CheckSatelliteMembership(ctx context.Context, expected []storj.NodeID) (_ []SatelliteMembership, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	list, err := service.listNodes(ctx)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	var mismatched []SatelliteMembership
+	for _, node := range list {
+		actual, err := service.nodePayingSatellites(ctx, node)
+		if err != nil {
+			service.log.Error("failed to get node paying satellites", zap.Stringer("node", node.ID), zap.Error(err))
+			continue
+		}
+		service.contacted(node.ID)
+
+		extra, missing := CompareSatellites(service.membershipSatellites(expected), service.membershipSatellites(actual))
+		if len(extra) == 0 && len(missing) == 0 {
+			continue
+		}
+
+		mismatched = append(mismatched, SatelliteMembership{
+			NodeID:   node.ID,
+			NodeName: node.Name,
+			Extra:    extra,
+			Missing:  missing,
+		})
+	}
+
+	return mismatched, nil
+}
+
+// GetSatelliteCounts returns the number of distinct satellites that ever paid every node, along with
+// the fleet minimum, maximum and average. Legacy satellite ids are counted by their canonical id and
+// excluded satellites are ignored. Nodes which fail to respond are reported as unreachable.
+func (service *Service) GetSatelliteCounts(ctx context.Context) (_ SatelliteCounts, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	list, err := service.listNodes(ctx)
+	if err != nil {
+		return SatelliteCounts{}, Error.Wrap(err)
+	}
+
+	counts := make([]NodeSatelliteCount, 0, len(list))
+	for _, node := range list {
+		count := NodeSatelliteCount{
+			NodeID:   node.ID,
+			NodeName: node.Name,
+		}
+
+		satellites, err := service.nodePayingSatellites(ctx, node)
+		if err != nil {
+			service.log.Error("failed to get node paying satellites", zap.Stringer("node", node.ID), zap.Error(err))
+			count.Unreachable = true
+			counts = append(counts, count)
+			continue
+		}
+		service.contacted(node.ID)
+
+		distinct := make(map[storj.NodeID]struct{})
+		for _, id := range service.membershipSatellites(satellites) {
+			distinct[id] = struct{}{}
+		}
+		count.Satellites = len(distinct)
+
+		counts = append(counts, count)
+	}
+
+	return NewSatelliteCounts(counts), nil
+}
+
+// membershipSatellites returns canonical ids of satellites, which are not excluded.
+func (service *Service) membershipSatellites(satelliteIDs []storj.NodeID) []storj.NodeID {
+	var included []storj.NodeID
+	for _, id := range satelliteIDs {
+		canonical := service.aliases.Canonical(id)
+		if !service.excluded.Contains(canonical) {
+			included = append(included, canonical)
+		}
+	}
+	return included
+}
+
+// nodePayingSatellites retrieves ids of satellites that ever paid to a single node.
+func (service *Service) nodePayingSatellites(ctx context.Context, node nodes.Node) (_ []storj.NodeID, err error) {
+	conn, err := service.dial(ctx, node)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	defer func() {
+		err = errs.Combine(err, conn.Close())
+	}()
+
+	payoutClient := multinodepb.NewDRPCPayoutClient(conn)
+	header := service.requestHeader(ctx, node)
+
+	response, err := payoutClient.PayingSatellites(ctx, &multinodepb.PayingSatellitesRequest{Header: header})
+	if err != nil {
+		return nil, rpcError(node, err)
+	}
+
+	return response.SatelliteIds, nil
+}
+
+// GetHeldHistory returns amounts held and disposed by every satellite in every period, summed across nodes.
+// Nodes send their history as a stream, so memory is bounded by the number of satellites and periods.
+// Nodes which fail to respond are skipped, along with the entries they sent before failing.
+func (service *Service) GetHeldHistory(ctx context.Context) (_ []HeldHistoryPeriod, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var history HeldHistory
+	err = service.iterateNodes(ctx, func(node nodes.Node) {
+		var nodeHistory HeldHistory
+		if err := service.nodeHeldHistory(ctx, node, &nodeHistory); err != nil {
+			service.log.Error("failed to get node held history", zap.Stringer("node", node.ID), zap.Error(err))
+			return
+		}
+		service.contacted(node.ID)
+
+		for _, period := range nodeHistory.List() {
+			history.Add(period.SatelliteID, &multinodepb.HeldHistoryEntry{
+				Period:   period.Period,
+				Held:     period.Held,
+				Disposed: period.Disposed,
+			})
+		}
+	})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return history.List(), nil
+}
+
+// ForecastHeldReturns returns held amount expected to be returned to the nodes in each of the upcoming months,
+// starting with the current one. Nodes which have already got their held amount back contribute zero.
+// Nodes which fail to respond are skipped.
+func (service *Service) ForecastHeldReturns(ctx context.Context, months int) (_ []HeldReturn, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if months < 1 {
+		return nil, Error.New("invalid number of months %d: must be positive", months)
+	}
+
+	returns := make(map[string]int64)
+	err = service.iterateNodes(ctx, func(node nodes.Node) {
+		var history HeldHistory
+		if err := service.nodeHeldHistory(ctx, node, &history); err != nil {
+			service.log.Error("failed to get node held history", zap.Stringer("node", node.ID), zap.Error(err))
+			return
+		}
+		service.contacted(node.ID)
+
+		nodeReturns, err := NodeHeldReturns(history.List())
+		if err != nil {
+			service.log.Error("invalid node held history", zap.Stringer("node", node.ID), zap.Error(err))
+			return
+		}
+
+		for period, returned := range nodeReturns {
+			returns[period] += returned
+		}
+	})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return NewHeldReturnForecast(returns, time.Now().UTC(), months), nil
+}
+
+// nodeHeldHistory receives held history of a single node into history.
+func (service *Service) nodeHeldHistory(ctx context.Context, node nodes.Node, history *HeldHistory) (err error) {
+	conn, err := service.dial(ctx, node)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	defer func() {
+		err = errs.Combine(err, conn.Close())
+	}()
+
+	payoutClient := multinodepb.NewDRPCPayoutClient(conn)
+	header := service.requestHeader(ctx, node)
+
+	stream, err := payoutClient.HeldHistoryStream(ctx, &multinodepb.HeldHistoryRequest{Header: header})
+	if err != nil {
+		return rpcError(node, err)
+	}
+	defer func() {
+		err = errs.Combine(err, stream.Close())
+	}()
+
+	err = ReceiveHeldHistory(stream, func(entry *multinodepb.HeldHistoryEntry) {
+		satelliteID := service.aliases.Canonical(entry.SatelliteId)
+		if !service.excluded.Contains(satelliteID) {
+			history.Add(satelliteID, entry)
+		}
+	})
+	if err != nil {
+		return rpcError(node, err)
+	}
+
+	return nil
+}
+
+// CheckPermissions probes every category of payout RPCs on the node with its api secret and reports which are
+// permitted, to tell apart a secret with partial access from an invalid one. Failed dial is returned as an error.
+func (service *Service) CheckPermissions(ctx context.Context, node nodes.Node) (_ NodePermissions, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	conn, err := service.dial(ctx, node)
+	if err != nil {
+		return NodePermissions{}, Error.Wrap(err)
+	}
+
+	defer func() {
+		err = errs.Combine(err, conn.Close())
+	}()
+
+	payoutClient := multinodepb.NewDRPCPayoutClient(conn)
+	header := service.requestHeader(ctx, node)
+
+	permissions := ProbePermissions(ctx, payoutClient, header)
+	permissions.NodeID = node.ID
+	permissions.NodeName = node.Name
+
+	if !permissions.Unauthenticated {
+		service.contacted(node.ID)
+	}
+
+	return permissions, nil
+}
+
+// nodeCurrentEstimate retrieves current month estimate in micro USD from a single node.
+func (service *Service) nodeCurrentEstimate(ctx context.Context, node nodes.Node) (_ int64, err error) {
+	conn, err := service.dial(ctx, node)
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+
+	defer func() {
+		err = errs.Combine(err, conn.Close())
+	}()
+
+	payoutClient := multinodepb.NewDRPCPayoutClient(conn)
+	header := service.requestHeader(ctx, node)
+
+	response, err := payoutClient.EstimatedPayoutTotal(ctx, &multinodepb.EstimatedPayoutTotalRequest{Header: header, AsOf: time.Now().UTC()})
+	if err != nil {
+		return 0, rpcError(node, err)
+	}
+
+	estimation := response.EstimatedEarnings
+	for _, satelliteID := range service.excluded {
+		excluded, err := payoutClient.EstimatedPayoutSatellite(ctx, &multinodepb.EstimatedPayoutSatelliteRequest{Header: header, SatelliteId: satelliteID, AsOf: time.Now().UTC()})
+		if err != nil {
+			return 0, rpcError(node, err)
+		}
+		estimation -= excluded.EstimatedEarnings
+	}
+
+	return Rescale(estimation, unitDecimals(response.Unit, estimationDecimals), microDecimals), nil
+}
+
+// CheckNodeSatelliteConnectivity returns result of the last check-in of every node on each of its trusted satellites.
+// Nodes which can't be reached are reported with an error instead of being skipped,
+// to distinguish multinode connectivity issues from node connectivity issues.
+func (service *Service) CheckNodeSatelliteConnectivity(ctx context.Context) (_ []NodeConnectivity, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	list, err := service.listNodes(ctx)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	var connectivity []NodeConnectivity
+	for _, node := range list {
+		checkIns, err := service.nodeCheckIns(ctx, node)
+		if err != nil {
+			service.log.Error("failed to get node satellite check-ins", zap.Stringer("node", node.ID), zap.Error(err))
+			connectivity = append(connectivity, NodeConnectivity{
+				NodeID:   node.ID,
+				NodeName: node.Name,
+				Error:    err.Error(),
+			})
+			continue
+		}
+		service.contacted(node.ID)
+
+		connectivity = append(connectivity, NewNodeConnectivity(node.ID, node.Name, checkIns))
+	}
+
+	return connectivity, nil
+}
+
+// nodeCheckIns retrieves results of the last check-in on every trusted satellite from a single node.
+func (service *Service) nodeCheckIns(ctx context.Context, node nodes.Node) (_ []*multinodepb.SatelliteCheckInsResponse_CheckIn, err error) {
+	conn, err := service.dial(ctx, node)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	defer func() {
+		err = errs.Combine(err, conn.Close())
+	}()
+
+	nodeClient := multinodepb.NewDRPCNodeClient(conn)
+	header := service.requestHeader(ctx, node)
+
+	response, err := nodeClient.SatelliteCheckIns(ctx, &multinodepb.SatelliteCheckInsRequest{Header: header})
+	if err != nil {
+		return nil, rpcError(node, err)
+	}
+
+	return response.CheckIns, nil
+}
+
+// GetAllNodesReputationScores returns audit, suspension and online scores of every satellite averaged across nodes,
+// explaining e.g. declining earnings. Nodes which didn't receive scores from the satellite yet are counted
+// separately and don't affect the averages. Legacy satellite ids are merged into their canonical id and
+// excluded satellites are ignored. Nodes which fail to respond are skipped.
+func (service *Service) GetAllNodesReputationScores(ctx context.Context) (_ []SatelliteReputation, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var reputation ReputationScores
+	err = service.iterateNodes(ctx, func(node nodes.Node) {
+		scores, err := service.nodeReputationScores(ctx, node)
+		if err != nil {
+			service.log.Error("failed to get node reputation scores", zap.Stringer("node", node.ID), zap.Error(err))
+			return
+		}
+		service.contacted(node.ID)
+
+		for _, satelliteScores := range scores {
+			satelliteID := service.aliases.Canonical(satelliteScores.SatelliteId)
+			if service.excluded.Contains(satelliteID) {
+				continue
+			}
+			reputation.Add(satelliteID, satelliteScores)
+		}
+	})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return reputation.List(), nil
+}
+
+// nodeReputationScores retrieves reputation scores of every trusted satellite from a single node.
+func (service *Service) nodeReputationScores(ctx context.Context, node nodes.Node) (_ []*multinodepb.ReputationScoresResponse_SatelliteScores, err error) {
+	conn, err := service.dial(ctx, node)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	defer func() {
+		err = errs.Combine(err, conn.Close())
+	}()
+
+	nodeClient := multinodepb.NewDRPCNodeClient(conn)
+	header := service.requestHeader(ctx, node)
+
+	response, err := nodeClient.ReputationScores(ctx, &multinodepb.ReputationScoresRequest{Header: header})
+	if err != nil {
+		return nil, rpcError(node, err)
+	}
+
+	return response.Scores, nil
+}
+
+// GetPaystubCoverage returns range of periods every node has paystubs for, flagging nodes with missing periods in it.
+func (service *Service) GetPaystubCoverage(ctx context.Context) (_ []PaystubCoverage, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	list, err := service.listNodes(ctx)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	var coverages []PaystubCoverage
+	for _, node := range list {
+		periods, err := service.nodeAvailablePeriods(ctx, node)
+		if err != nil {
+			service.log.Error("failed to get node available periods", zap.Stringer("node", node.ID), zap.Error(err))
+			continue
+		}
+		service.contacted(node.ID)
+
+		coverage, err := NewPaystubCoverage(node.ID, node.Name, periods)
+		if err != nil {
+			service.log.Error("invalid node period", zap.Stringer("node", node.ID), zap.Error(err))
+			continue
+		}
+
+		coverages = append(coverages, coverage)
+	}
+
+	return coverages, nil
+}
+
+// nodeAvailablePeriods retrieves periods a single node has paystubs for.
+func (service *Service) nodeAvailablePeriods(ctx context.Context, node nodes.Node) (_ []string, err error) {
+	conn, err := service.dial(ctx, node)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	defer func() {
+		err = errs.Combine(err, conn.Close())
+	}()
+
+	payoutClient := multinodepb.NewDRPCPayoutClient(conn)
+	header := service.requestHeader(ctx, node)
+
+	response, err := payoutClient.AvailablePeriods(ctx, &multinodepb.AvailablePeriodsRequest{Header: header})
+	if err != nil {
+		return nil, rpcError(node, err)
+	}
+
+	return response.Period, nil
+}
+
+// GetInconsistentHeld returns nodes whose held percentage in the period diverges by more than tolerance
+// percentage points from nodes which joined the same satellite in the same month. Such nodes should have
+// the same held percentage, so divergence may indicate a data issue. Nodes which fail to respond are skipped.
+func (service *Service) GetInconsistentHeld(ctx context.Context, period string, tolerance float64) (_ []HeldOutlier, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	list, err := service.listNodes(ctx)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	var rates []NodeHeldRate
+	for _, node := range list {
+		nodeRates, err := service.nodeHeldRates(ctx, node, period)
+		if err != nil {
+			service.log.Error("failed to get node held rates", zap.Stringer("node", node.ID), zap.Error(err))
+			continue
+		}
+		service.contacted(node.ID)
+
+		rates = append(rates, nodeRates...)
+	}
+
+	return FindHeldOutliers(rates, tolerance), nil
+}
+
+// nodeHeldRates retrieves held amounts of every satellite for the period from a single node.
+func (service *Service) nodeHeldRates(ctx context.Context, node nodes.Node, period string) (_ []NodeHeldRate, err error) {
+	conn, err := service.dial(ctx, node)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	defer func() {
+		err = errs.Combine(err, conn.Close())
+	}()
+
+	payoutClient := multinodepb.NewDRPCPayoutClient(conn)
+	header := service.requestHeader(ctx, node)
+
+	response, err := payoutClient.HeldRates(ctx, &multinodepb.HeldRatesRequest{Header: header, Period: period})
+	if err != nil {
+		return nil, rpcError(node, err)
+	}
+
+	decimals := unitDecimals(response.Unit, paystubDecimals)
+
+	var rates []NodeHeldRate
+	for _, rate := range service.excluded.FilterHeldRates(response.HeldRates) {
+		rates = append(rates, NodeHeldRate{
+			NodeID:      node.ID,
+			NodeName:    node.Name,
+			SatelliteID: rate.SatelliteId,
+			JoinedAt:    rate.JoinedAt,
+			Held:        Rescale(rate.Held, decimals, microDecimals),
+			Earned:      Rescale(rate.Earned, decimals, microDecimals),
+		})
+	}
+
+	return rates, nil
+}
+
+// GetNodeTrends returns earnings of every node in each of the periods, aligned to the periods order.
+// Every node is dialed once and asked only for the periods it has payouts data for.
+// Nodes which fail to respond are skipped.
+func (service *Service) GetNodeTrends(ctx context.Context, periods []string) (_ []NodeTrend, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	list, err := service.listNodes(ctx)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	annotations := service.annotations(ctx)
+
+	var trends []NodeTrend
+	for _, node := range list {
+		earned, err := service.nodeEarnedPerPeriod(ctx, node, periods)
+		if err != nil {
+			service.log.Error("failed to get node earnings trend", zap.Stringer("node", node.ID), zap.Error(err))
+			continue
+		}
+		service.contacted(node.ID)
+
+		trend := NewNodeTrend(node.ID, node.Name, periods, earned)
+		trend.Annotation = annotations[node.ID].Note
+		trends = append(trends, trend)
+	}
+
+	return trends, nil
+}
+
+// nodeEarnedPerPeriod retrieves earnings of every requested period the node has payouts data for from a single node.
+func (service *Service) nodeEarnedPerPeriod(ctx context.Context, node nodes.Node, periods []string) (_ map[string]int64, err error) {
+	summaries, err := service.nodePeriodSummaries(ctx, node, periods)
+	if err != nil {
+		return nil, err
+	}
+
+	earned := make(map[string]int64, len(summaries))
+	for period, info := range summaries {
+		earned[period] = ChartEarned.Value(info)
+	}
+
+	return earned, nil
+}
+
+// GetChartData returns the metric summed across the nodes in each of the periods, aligned to the periods order.
+// Periods without payouts data are zero. Nodes which fail to respond are skipped.
+func (service *Service) GetChartData(ctx context.Context, metric ChartMetric, periods []string) (_ []ChartPoint, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := metric.Validate(); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]int64, len(periods))
+	err = service.iterateNodes(ctx, func(node nodes.Node) {
+		summaries, err := service.nodePeriodSummaries(ctx, node, periods)
+		if err != nil {
+			service.log.Error("failed to get node chart data", zap.Stringer("node", node.ID), zap.Error(err))
+			return
+		}
+		service.contacted(node.ID)
+
+		for period, info := range summaries {
+			values[period] += metric.Value(info)
+		}
 	})
 	if err != nil {
-		return 0, Error.Wrap(err)
+		return nil, Error.Wrap(err)
+	}
+
+	return NewChartPoints(periods, values), nil
+}
+
+// GetEarningsVelocity returns earnings of every node and the whole fleet in the period divided by the number
+// of days in the period, or the number of days elapsed so far for the current period. Velocity is zero when
+// no day of the period has elapsed yet. Nodes which fail to respond are skipped.
+func (service *Service) GetEarningsVelocity(ctx context.Context, period string) (_ EarningsVelocity, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	days, err := PeriodDays(period, time.Now())
+	if err != nil {
+		return EarningsVelocity{}, Error.Wrap(err)
+	}
+
+	var velocities []NodeVelocity
+	err = service.iterateNodes(ctx, func(node nodes.Node) {
+		info, err := service.getAllSatellitesPeriod(ctx, node, period)
+		if err != nil {
+			service.log.Error("failed to get node period summary", zap.Stringer("node", node.ID), zap.Error(err))
+			return
+		}
+		service.contacted(node.ID)
+
+		velocities = append(velocities, NodeVelocity{
+			NodeID:   node.ID,
+			NodeName: node.Name,
+			Earned:   Rescale(ChartEarned.Value(info), unitDecimals(info.Unit, paystubDecimals), microDecimals),
+		})
+	})
+	if err != nil {
+		return EarningsVelocity{}, Error.Wrap(err)
+	}
+
+	return NewEarningsVelocity(period, days, velocities), nil
+}
+
+// nodePeriodSummaries retrieves summaries of every requested period the node has payouts data for,
+// dialing the node only once.
+func (service *Service) nodePeriodSummaries(ctx context.Context, node nodes.Node, periods []string) (_ map[string]*multinodepb.PayoutInfo, err error) {
+	requested := make(map[string]bool, len(periods))
+	for _, period := range periods {
+		requested[period] = true
+	}
+
+	return service.nodeFilteredPeriodSummaries(ctx, node, func(period string) bool {
+		return requested[period]
+	})
+}
+
+// nodeFilteredPeriodSummaries retrieves summaries of every period the node has payouts data for, which is included
+// by the filter, from a single node.
+func (service *Service) nodeFilteredPeriodSummaries(ctx context.Context, node nodes.Node, include func(period string) bool) (_ map[string]*multinodepb.PayoutInfo, err error) {
+	conn, err := service.dial(ctx, node)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	defer func() {
+		err = errs.Combine(err, conn.Close())
+	}()
+
+	payoutClient := multinodepb.NewDRPCPayoutClient(conn)
+	header := service.requestHeader(ctx, node)
+
+	available, err := payoutClient.AvailablePeriods(ctx, &multinodepb.AvailablePeriodsRequest{Header: header})
+	if err != nil {
+		return nil, rpcError(node, err)
+	}
+
+	summaries := make(map[string]*multinodepb.PayoutInfo)
+	for _, period := range available.Period {
+		if !include(period) {
+			continue
+		}
+
+		info, err := service.periodSummary(ctx, payoutClient, header, node, period)
+		if err != nil {
+			return nil, err
+		}
+		summaries[period] = info
+	}
+
+	return summaries, nil
+}
+
+// GetDistinctWallets returns distinct wallets configured across the nodes with number of nodes per wallet.
+// Nodes which fail to respond are skipped.
+func (service *Service) GetDistinctWallets(ctx context.Context) (_ Wallets, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	list, err := service.listNodes(ctx)
+	if err != nil {
+		return Wallets{}, Error.Wrap(err)
+	}
+
+	var nodeWallets []NodeWallet
+	for _, node := range list {
+		wallet, err := service.nodeWallet(ctx, node)
+		if err != nil {
+			service.log.Error("failed to get node wallet", zap.Stringer("node", node.ID), zap.Error(err))
+			continue
+		}
+		service.contacted(node.ID)
+
+		nodeWallets = append(nodeWallets, NodeWallet{
+			NodeID:   node.ID,
+			NodeName: node.Name,
+			Wallet:   wallet,
+		})
+	}
+
+	return NewWallets(nodeWallets), nil
+}
+
+// nodeWallet retrieves wallet configured on a single node.
+func (service *Service) nodeWallet(ctx context.Context, node nodes.Node) (_ string, err error) {
+	conn, err := service.dial(ctx, node)
+	if err != nil {
+		return "", Error.Wrap(err)
+	}
+
+	defer func() {
+		err = errs.Combine(err, conn.Close())
+	}()
+
+	payoutClient := multinodepb.NewDRPCPayoutClient(conn)
+
+	config, err := payoutClient.PayoutConfig(ctx, &multinodepb.PayoutConfigRequest{Header: service.requestHeader(ctx, node)})
+	if err != nil {
+		return "", rpcError(node, err)
+	}
+
+	return config.Wallet, nil
+}
+
+// GetNodeTenure returns the first period and months of tenure of every node on every satellite it earned on.
+// Nodes which fail to respond are skipped.
+func (service *Service) GetNodeTenure(ctx context.Context) (_ []NodeTenure, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	list, err := service.listNodes(ctx)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	now := time.Now().UTC()
+
+	var tenures []NodeTenure
+	for _, node := range list {
+		nodeTenures, err := service.nodeFirstPeriods(ctx, node)
+		if err != nil {
+			service.log.Error("failed to get node tenure", zap.Stringer("node", node.ID), zap.Error(err))
+			continue
+		}
+		service.contacted(node.ID)
+
+		for _, tenure := range nodeTenures {
+			tenure.Months, err = TenureMonths(tenure.FirstPeriod, now)
+			if err != nil {
+				service.log.Error("invalid first period", zap.Stringer("node", node.ID), zap.Stringer("satellite", tenure.SatelliteID), zap.Error(err))
+				continue
+			}
+			tenures = append(tenures, tenure)
+		}
+	}
+
+	return tenures, nil
+}
+
+// nodeFirstPeriods retrieves the first paystub period of every satellite the node earned on from a single node.
+// Satellites without paystubs are omitted, months of tenure are left to the caller.
+func (service *Service) nodeFirstPeriods(ctx context.Context, node nodes.Node) (_ []NodeTenure, err error) {
+	conn, err := service.dial(ctx, node)
+	if err != nil {
+		return nil, Error.Wrap(err)
 	}
 
 	defer func() {
@@ -369,49 +1747,312 @@ func (service *Service) nodeEstimations(ctx context.Context, node nodes.Node) (e
 	}()
 
 	payoutClient := multinodepb.NewDRPCPayoutClient(conn)
-	header := &multinodepb.RequestHeader{
-		ApiKey: node.APISecret,
+	header := service.requestHeader(ctx, node)
+
+	earned, err := payoutClient.EarnedPerSatellite(ctx, &multinodepb.EarnedPerSatelliteRequest{Header: header})
+	if err != nil {
+		return nil, rpcError(node, err)
+	}
+
+	var tenures []NodeTenure
+	for _, satellite := range service.excluded.FilterEarned(earned.EarnedSatellite) {
+		first, err := payoutClient.FirstPaystubPeriod(ctx, &multinodepb.FirstPaystubPeriodRequest{Header: header, SatelliteId: satellite.SatelliteId})
+		if err != nil {
+			return nil, rpcError(node, err)
+		}
+		if first.Period == "" {
+			continue
+		}
+
+		tenures = append(tenures, NodeTenure{
+			NodeID:      node.ID,
+			NodeName:    node.Name,
+			SatelliteID: satellite.SatelliteId,
+			FirstPeriod: first.Period,
+		})
 	}
 
-	response, err := payoutClient.EstimatedPayoutTotal(ctx, &multinodepb.EstimatedPayoutTotalRequest{Header: header})
+	return tenures, nil
+}
+
+// GetPaidReconciliation compares amount paid according to paystubs with payments recorded by every node
+// for every satellite, flagging differences larger than tolerance. Nodes which fail to respond are skipped.
+func (service *Service) GetPaidReconciliation(ctx context.Context, tolerance int64) (_ []PaidReconciliation, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	list, err := service.listNodes(ctx)
 	if err != nil {
-		return 0, Error.Wrap(err)
+		return nil, Error.Wrap(err)
 	}
 
-	return response.EstimatedEarnings, nil
+	var reconciliations []PaidReconciliation
+	for _, node := range list {
+		payments, err := service.nodePayments(ctx, node)
+		if err != nil {
+			service.log.Error("failed to get node payments", zap.Stringer("node", node.ID), zap.Error(err))
+			continue
+		}
+		service.contacted(node.ID)
+
+		for _, satellite := range payments {
+			if service.excluded.Contains(satellite.SatelliteId) {
+				continue
+			}
+
+			reconciliations = append(reconciliations, PaidReconciliation{
+				NodeID:      node.ID,
+				NodeName:    node.Name,
+				SatelliteID: satellite.SatelliteId,
+				Paid:        satellite.Paid,
+				Received:    satellite.Received,
+				Difference:  satellite.Paid - satellite.Received,
+				Status:      Reconcile(satellite.Paid, satellite.Received, int(satellite.Payments), tolerance),
+			})
+		}
+	}
+
+	return reconciliations, nil
 }
 
-// nodeSatelliteEstimations retrieves data from a single node.
-func (service *Service) nodeSatelliteEstimations(ctx context.Context, node nodes.Node, satelliteID storj.NodeID) (estimation int64, err error) {
-	conn, err := service.dialer.DialNodeURL(ctx, storj.NodeURL{
-		ID:      node.ID,
-		Address: node.PublicAddress,
+// nodePayments retrieves paid amount and recorded payments of every paying satellite from a single node.
+func (service *Service) nodePayments(ctx context.Context, node nodes.Node) (_ []*multinodepb.PaymentsPerSatelliteResponse_SatellitePayments, err error) {
+	conn, err := service.dial(ctx, node)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	defer func() {
+		err = errs.Combine(err, conn.Close())
+	}()
+
+	payoutClient := multinodepb.NewDRPCPayoutClient(conn)
+	header := service.requestHeader(ctx, node)
+
+	response, err := payoutClient.PaymentsPerSatellite(ctx, &multinodepb.PaymentsPerSatelliteRequest{Header: header})
+	if err != nil {
+		return nil, rpcError(node, err)
+	}
+
+	return response.SatellitePayments, nil
+}
+
+// GetEstimateAccuracy compares estimated and actual earnings of every node for the completed period.
+// Nodes keep the estimate only for the previous month, for other periods accuracy is reported as unknown.
+func (service *Service) GetEstimateAccuracy(ctx context.Context, period string) (_ []NodeEstimateAccuracy, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	list, err := service.listNodes(ctx)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	var accuracies []NodeEstimateAccuracy
+	for _, node := range list {
+		accuracy, err := service.nodeEstimateAccuracy(ctx, node, period)
+		if err != nil {
+			service.log.Error("failed to get node estimate accuracy", zap.Stringer("node", node.ID), zap.Error(err))
+			continue
+		}
+		service.contacted(node.ID)
+
+		accuracies = append(accuracies, accuracy)
+	}
+
+	return accuracies, nil
+}
+
+// nodeEstimateAccuracy retrieves estimated and actual earnings for the period from a single node.
+func (service *Service) nodeEstimateAccuracy(ctx context.Context, node nodes.Node, period string) (_ NodeEstimateAccuracy, err error) {
+	conn, err := service.dial(ctx, node)
+	if err != nil {
+		return NodeEstimateAccuracy{}, Error.Wrap(err)
+	}
+
+	defer func() {
+		err = errs.Combine(err, conn.Close())
+	}()
+
+	payoutClient := multinodepb.NewDRPCPayoutClient(conn)
+	header := service.requestHeader(ctx, node)
+
+	estimated, err := payoutClient.EstimatedPayoutTotal(ctx, &multinodepb.EstimatedPayoutTotalRequest{Header: header, AsOf: time.Now().UTC()})
+	if err != nil {
+		return NodeEstimateAccuracy{}, rpcError(node, err)
+	}
+
+	actual, err := payoutClient.AllSatellitesPeriodSummary(ctx, &multinodepb.AllSatellitesPeriodSummaryRequest{Header: header, Period: period})
+	if err != nil {
+		return NodeEstimateAccuracy{}, rpcError(node, err)
+	}
+
+	accuracy := NodeEstimateAccuracy{
+		NodeID:   node.ID,
+		NodeName: node.Name,
+		Period:   period,
+		Known:    estimated.PreviousMonthPeriod == period,
+		Actual:   Rescale(actual.PayoutInfo.Held+actual.PayoutInfo.Paid, unitDecimals(actual.PayoutInfo.Unit, paystubDecimals), microDecimals),
+	}
+	if accuracy.Known {
+		accuracy.Estimated = Rescale(estimated.PreviousMonthEstimatedEarnings, unitDecimals(estimated.Unit, estimationDecimals), microDecimals)
+	}
+	accuracy.Calculate()
+
+	return accuracy, nil
+}
+
+// DetectEstimateAnomalies compares current month estimate of every node with its baseline in micro USD from history,
+// e.g. estimates collected earlier, and returns nodes whose estimate dropped by more than the configured percentage.
+// Only nodes with positive baseline are dialed. Nodes which fail to respond are skipped.
+func (service *Service) DetectEstimateAnomalies(ctx context.Context, history map[storj.NodeID]int64) (_ []EstimateAnomaly, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	list, err := service.listNodes(ctx)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	var estimates []NodeEstimate
+	for _, node := range list {
+		if history[node.ID] <= 0 {
+			continue
+		}
+
+		estimate, err := service.nodeCurrentEstimate(ctx, node)
+		if err != nil {
+			service.log.Error("failed to get node estimate", zap.Stringer("node", node.ID), zap.Error(err))
+			continue
+		}
+		service.contacted(node.ID)
+
+		estimates = append(estimates, NodeEstimate{
+			NodeID:   node.ID,
+			NodeName: node.Name,
+			Estimate: estimate,
+		})
+	}
+
+	return FindEstimateAnomalies(history, estimates, service.estimateDrop), nil
+}
+
+// NodesSatelliteEstimations returns specific satellite all time estimated earnings.
+// Nodes which fail to respond are skipped and reported in the estimation node errors.
+func (service *Service) NodesSatelliteEstimations(ctx context.Context, satelliteID storj.NodeID) (_ Estimation, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if service.excluded.Contains(satelliteID) {
+		return Estimation{}, nil
+	}
+
+	weight := service.weights.Weight(satelliteID)
+	return service.sumEstimations(ctx, func(ctx context.Context, node nodes.Node) (estimated, weighted int64, err error) {
+		estimated, err = service.nodeSatelliteEstimations(ctx, node, satelliteID)
+		return estimated, int64(math.Round(float64(estimated) * weight)), err
+	})
+}
+
+// NodesEstimations returns all satellites all time estimated earnings.
+// Nodes which fail to respond are skipped and reported in the estimation node errors.
+func (service *Service) NodesEstimations(ctx context.Context) (_ Estimation, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return service.sumEstimations(ctx, service.nodeEstimations)
+}
+
+// sumEstimations sums raw and weighted estimations of all nodes retrieved with estimate.
+// A failing node doesn't fail the whole estimation, its error is recorded instead.
+func (service *Service) sumEstimations(ctx context.Context, estimate func(context.Context, nodes.Node) (estimated, weighted int64, err error)) (_ Estimation, err error) {
+	var estimation Estimation
+	err = service.iterateNodes(ctx, func(node nodes.Node) {
+		estimated, weighted, err := estimate(ctx, node)
+		if err != nil {
+			service.log.Warn("failed to get node estimations", zap.Stringer("node", node.ID), zap.Error(err))
+			estimation.NodeErrors = append(estimation.NodeErrors, NodeError{
+				NodeID:   node.ID,
+				NodeName: node.Name,
+				Error:    err.Error(),
+			})
+			return
+		}
+		service.contacted(node.ID)
+
+		estimation.EstimatedEarnings += estimated
+		estimation.WeightedEstimatedEarnings += weighted
 	})
 	if err != nil {
-		return 0, Error.Wrap(err)
+		return Estimation{}, Error.Wrap(err)
+	}
+
+	return estimation, nil
+}
+
+// nodeEstimations retrieves raw and weighted estimation from a single node.
+func (service *Service) nodeEstimations(ctx context.Context, node nodes.Node) (estimation, weighted int64, err error) {
+	conn, err := service.dial(ctx, node)
+	if err != nil {
+		return 0, 0, Error.Wrap(err)
 	}
 
 	defer func() {
 		err = errs.Combine(err, conn.Close())
 	}()
+
 	payoutClient := multinodepb.NewDRPCPayoutClient(conn)
-	header := &multinodepb.RequestHeader{
-		ApiKey: node.APISecret,
+	header := service.requestHeader(ctx, node)
+
+	response, err := payoutClient.EstimatedPayoutTotal(ctx, &multinodepb.EstimatedPayoutTotalRequest{Header: header, AsOf: time.Now().UTC()})
+	if err != nil {
+		return 0, 0, rpcError(node, err)
 	}
-	response, err := payoutClient.EstimatedPayoutSatellite(ctx, &multinodepb.EstimatedPayoutSatelliteRequest{Header: header, SatelliteId: satelliteID})
+
+	estimation = response.EstimatedEarnings
+	for _, satelliteID := range service.excluded {
+		excluded, err := payoutClient.EstimatedPayoutSatellite(ctx, &multinodepb.EstimatedPayoutSatelliteRequest{Header: header, SatelliteId: satelliteID, AsOf: time.Now().UTC()})
+		if err != nil {
+			return 0, 0, rpcError(node, err)
+		}
+		estimation -= excluded.EstimatedEarnings
+	}
+
+	// only satellites with weight other than 1 change the weighted estimation.
+	perSatellite := make(map[storj.NodeID]int64)
+	for _, satelliteID := range service.weights.Weighted() {
+		if service.excluded.Contains(satelliteID) {
+			continue
+		}
+
+		weighted, err := payoutClient.EstimatedPayoutSatellite(ctx, &multinodepb.EstimatedPayoutSatelliteRequest{Header: header, SatelliteId: satelliteID, AsOf: time.Now().UTC()})
+		if err != nil {
+			return 0, 0, rpcError(node, err)
+		}
+		perSatellite[satelliteID] = weighted.EstimatedEarnings
+	}
+
+	return estimation, WeightedEstimate(estimation, perSatellite, service.weights), nil
+}
+
+// nodeSatelliteEstimations retrieves data from a single node.
+func (service *Service) nodeSatelliteEstimations(ctx context.Context, node nodes.Node, satelliteID storj.NodeID) (estimation int64, err error) {
+	conn, err := service.dial(ctx, node)
 	if err != nil {
 		return 0, Error.Wrap(err)
 	}
+
+	defer func() {
+		err = errs.Combine(err, conn.Close())
+	}()
+	payoutClient := multinodepb.NewDRPCPayoutClient(conn)
+	header := service.requestHeader(ctx, node)
+	response, err := payoutClient.EstimatedPayoutSatellite(ctx, &multinodepb.EstimatedPayoutSatelliteRequest{Header: header, SatelliteId: satelliteID, AsOf: time.Now().UTC()})
+	if err != nil {
+		return 0, rpcError(node, err)
+	}
 	return response.EstimatedEarnings, nil
 }
 
-func (service *Service) getAmount(ctx context.Context, node nodes.Node) (_ int64, err error) {
-	conn, err := service.dialer.DialNodeURL(ctx, storj.NodeURL{
-		ID:      node.ID,
-		Address: node.PublicAddress,
-	})
+func (service *Service) getAmount(ctx context.Context, node nodes.Node) (_ Earned, err error) {
+	conn, err := service.dial(ctx, node)
 	if err != nil {
-		return 0, Error.Wrap(err)
+		return Earned{}, Error.Wrap(err)
 	}
 
 	defer func() {
@@ -419,23 +2060,35 @@ func (service *Service) getAmount(ctx context.Context, node nodes.Node) (_ int64
 	}()
 
 	payoutClient := multinodepb.NewDRPCPayoutClient(conn)
-	header := &multinodepb.RequestHeader{
-		ApiKey: node.APISecret,
-	}
+	header := service.requestHeader(ctx, node)
 
 	amount, err := payoutClient.Earned(ctx, &multinodepb.EarnedRequest{Header: header})
 	if err != nil {
-		return 0, Error.Wrap(err)
+		return Earned{}, rpcError(node, err)
+	}
+
+	earned := Earned{Gross: amount.Total, Net: amount.Net}
+	if len(service.excluded) == 0 {
+		return earned, nil
+	}
+
+	perSatellite, err := payoutClient.EarnedPerSatellite(ctx, &multinodepb.EarnedPerSatelliteRequest{Header: header})
+	if err != nil {
+		return Earned{}, rpcError(node, err)
+	}
+
+	for _, satellite := range perSatellite.EarnedSatellite {
+		if service.excluded.Contains(satellite.SatelliteId) {
+			earned.Gross -= satellite.Total
+			earned.Net -= satellite.Net
+		}
 	}
 
-	return amount.Total, nil
+	return earned, nil
 }
 
-func (service *Service) getEarnedOnSatellite(ctx context.Context, node nodes.Node) (_ multinodepb.EarnedPerSatelliteResponse, err error) {
-	conn, err := service.dialer.DialNodeURL(ctx, storj.NodeURL{
-		ID:      node.ID,
-		Address: node.PublicAddress,
-	})
+func (service *Service) getEarnedOnSatellite(ctx context.Context, node nodes.Node, minAmount int64) (_ multinodepb.EarnedPerSatelliteResponse, err error) {
+	conn, err := service.dial(ctx, node)
 	if err != nil {
 		return multinodepb.EarnedPerSatelliteResponse{}, Error.Wrap(err)
 	}
@@ -445,14 +2098,234 @@ func (service *Service) getEarnedOnSatellite(ctx context.Context, node nodes.Nod
 	}()
 
 	payoutClient := multinodepb.NewDRPCPayoutClient(conn)
+	header := service.requestHeader(ctx, node)
+
+	ctx, cancel := context.WithTimeout(ctx, earnedOnSatelliteTimeout)
+	defer cancel()
+
+	response, err := payoutClient.EarnedPerSatellite(ctx, &multinodepb.EarnedPerSatelliteRequest{Header: header, MinAmount: minAmount})
+	if err != nil {
+		return multinodepb.EarnedPerSatelliteResponse{}, rpcError(node, err)
+	}
+
+	if response.Truncated {
+		service.log.Warn("node returned earned amounts of some satellites only", zap.Stringer("node", node.ID), zap.Int("satellites", len(response.EarnedSatellite)))
+	}
+
+	response.EarnedSatellite = service.excluded.FilterEarned(response.EarnedSatellite)
+
+	return *response, nil
+}
+
+// dial dials the node unless its circuit is open, recording the dial result in circuit breaker.
+// It waits until amount of open connections is below the limit, the slot is released when connection is closed.
+func (service *Service) dial(ctx context.Context, node nodes.Node) (_ *limitedConn, err error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if !service.breaker.Allow(node.ID) {
+		return nil, ErrCircuitOpen.New("node %s failed too many times", node.ID)
+	}
+
+	// node is authenticated by its id, so it can be dialed by ip instead of the host name.
+	address, err := preferAddress(ctx, service.resolver, service.family, node.PublicAddress)
+	if err != nil {
+		if ctx.Err() == nil {
+			service.breaker.Failure(node.ID)
+		}
+		return nil, err
+	}
+
+	if err := service.connections.Acquire(ctx); err != nil {
+		return nil, err
+	}
+
+	conn, err := service.nodeDialer(node).DialNodeURL(ctx, storj.NodeURL{
+		ID:      node.ID,
+		Address: address,
+	})
+	if err != nil {
+		service.connections.Release()
+		// canceled dial says nothing about the node.
+		if ctx.Err() == nil {
+			service.breaker.Failure(node.ID)
+		}
+		return nil, err
+	}
+
+	service.breaker.Success(node.ID)
+	return &limitedConn{Conn: conn, limiter: service.connections}, nil
+}
+
+const (
+	// microDecimals is amount of decimal places of micro USD amounts.
+	microDecimals = 6
+	// paystubDecimals is amount of decimal places of paystub amounts sent by nodes without amount unit.
+	paystubDecimals = 6
+	// estimationDecimals is amount of decimal places of estimations sent by nodes without amount unit.
+	estimationDecimals = 2
+)
+
+// subtractPayoutInfo subtracts held and paid amounts of excluded payout info from info.
+func subtractPayoutInfo(info, excluded *multinodepb.PayoutInfo) {
+	info.Held -= excluded.GetHeld()
+	info.Paid -= excluded.GetPaid()
+}
+
+// unitDecimals returns decimal places of the amount unit, or fallback when node didn't send the unit.
+func unitDecimals(unit *multinodepb.AmountUnit, fallback int32) int32 {
+	if unit == nil {
+		return fallback
+	}
+	return unit.Decimals
+}
+
+// requestHeader creates request header for the node, populated with the api secret
+// and optional client version and trace id.
+func (service *Service) requestHeader(ctx context.Context, node nodes.Node) *multinodepb.RequestHeader {
 	header := &multinodepb.RequestHeader{
-		ApiKey: node.APISecret,
+		ApiKey:        node.APISecret,
+		ClientVersion: version.Build.Version.String(),
+	}
+
+	if span := monkit.SpanFromCtx(ctx); span != nil {
+		header.TraceId = strconv.FormatInt(span.Trace().Id(), 16)
+	}
+
+	return header
+}
+
+// rpcError wraps error returned by node rpc, surfacing structured error details when present.
+func rpcError(node nodes.Node, err error) error {
+	if details, ok := multinodepb.ParseErrorDetails(err); ok {
+		return Error.New("node %s failed with %s: %w", node.ID, details, err)
 	}
 
-	response, err := payoutClient.EarnedPerSatellite(ctx, &multinodepb.EarnedPerSatelliteRequest{Header: header})
+	return Error.Wrap(err)
+}
+
+// listNodes returns all nodes matching the node filter, skipping nodes with duplicated ids
+// to avoid counting their earnings twice.
+func (service *Service) listNodes(ctx context.Context) (list []nodes.Node, err error) {
+	if service.nodeFilter.IsEmpty() {
+		list, err = service.nodes.List(ctx)
+	} else {
+		list, err = service.nodes.ListFiltered(ctx, service.nodeFilter)
+	}
 	if err != nil {
-		return multinodepb.EarnedPerSatelliteResponse{}, Error.Wrap(err)
+		return nil, err
 	}
 
-	return *response, nil
+	for _, identity := range nodes.FindDuplicateIdentities(list) {
+		service.log.Error("node identity is used with different addresses, only the first one is included in payouts",
+			zap.Stringer("node", identity.ID), zap.Strings("addresses", identity.Addresses))
+	}
+
+	if duplicates := nodes.FindDuplicateIDs(list); len(duplicates) > 0 {
+		service.log.Warn("found nodes with duplicated ids", zap.Strings("node ids", duplicates.Strings()))
+		list = nodes.RemoveDuplicates(list)
+	}
+
+	return list, nil
+}
+
+// iterateNodes calls fn for every node included in aggregations, loading a page of nodes at a time,
+// so aggregations of fleet totals don't hold the whole node list in memory.
+// Nodes are ordered by id, so entries with the same id are adjacent and only the first one is passed to fn.
+func (service *Service) iterateNodes(ctx context.Context, fn func(nodes.Node)) error {
+	var previous *nodes.Node
+	return nodes.Iterate(ctx, service.nodes, service.nodeFilter, service.pageSize, func(node nodes.Node) error {
+		if previous != nil && previous.ID == node.ID {
+			if previous.PublicAddress != node.PublicAddress {
+				service.log.Error("node identity is used with different addresses, only the first one is included in payouts",
+					zap.Stringer("node", node.ID), zap.Strings("addresses", []string{previous.PublicAddress, node.PublicAddress}))
+			} else {
+				service.log.Warn("found nodes with duplicated ids", zap.Stringer("node", node.ID))
+			}
+			return nil
+		}
+
+		previous = &node
+		fn(node)
+		return nil
+	})
+}
+
+// LastContact returns time of the most recent successful response of the node.
+func (service *Service) LastContact(nodeID storj.NodeID) (_ time.Time, ok bool) {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	lastContact, ok := service.lastContact[nodeID]
+	return lastContact, ok
+}
+
+// contacted records successful response of the node and returns its time.
+func (service *Service) contacted(nodeID storj.NodeID) time.Time {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	now := time.Now().UTC()
+	service.lastContact[nodeID] = now
+	return now
+}
+
+// annotations returns notes operator attached to the nodes by node id, nodes without note are missing.
+// Annotations only complement payouts data, so when they can't be read, no node is annotated.
+func (service *Service) annotations(ctx context.Context) map[storj.NodeID]nodes.Annotation {
+	annotations, err := service.nodes.ListAnnotations(ctx)
+	if err != nil {
+		service.log.Warn("failed to list node annotations", zap.Error(err))
+		return nil
+	}
+	return annotations
+}
+
+// fillAnnotations sets annotation of every node in summary, nodes without annotation have it empty.
+// Annotations aren't read at all when the summary has no nodes.
+func (service *Service) fillAnnotations(ctx context.Context, summary *Summary) {
+	if len(summary.NodeSummary) == 0 {
+		return
+	}
+
+	annotations := service.annotations(ctx)
+	for i := range summary.NodeSummary {
+		summary.NodeSummary[i].Annotation = annotations[summary.NodeSummary[i].NodeID].Note
+	}
+}
+
+// fillNames sets current name of every node in summary, since nodes may have been renamed after it was collected.
+// Nodes which are no longer listed keep their name, as well as all nodes when the nodes can't be listed.
+func (service *Service) fillNames(ctx context.Context, summary *Summary) {
+	if len(summary.NodeSummary) == 0 {
+		return
+	}
+
+	list, err := service.nodes.List(ctx)
+	if err != nil {
+		service.log.Warn("failed to list node names", zap.Error(err))
+		return
+	}
+
+	names := make(map[storj.NodeID]string, len(list))
+	for _, node := range list {
+		names[node.ID] = node.Name
+	}
+	for i := range summary.NodeSummary {
+		if name, ok := names[summary.NodeSummary[i].NodeID]; ok {
+			summary.NodeSummary[i].NodeName = name
+		}
+	}
+}
+
+// fillLastContact sets last contact time of every node in summary,
+// nodes which failed to respond keep the time of their previous response.
+func (service *Service) fillLastContact(summary *Summary) {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	for i := range summary.NodeSummary {
+		summary.NodeSummary[i].LastContact = service.lastContact[summary.NodeSummary[i].NodeID]
+	}
 }
@@ -0,0 +1,1629 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"io/ioutil"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/identity/testidentity"
+	"storj.io/common/peertls/tlsopts"
+	"storj.io/common/rpc"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/multinode/nodes"
+	"storj.io/storj/private/multinodepb"
+	nodepayouts "storj.io/storj/storagenode/payouts"
+)
+
+// nodesDB is a nodes.DB which returns predefined list of nodes.
+type nodesDB struct {
+	nodes.DB
+	list        []nodes.Node
+	annotations map[storj.NodeID]nodes.Annotation
+
+	filtered int
+}
+
+func (db *nodesDB) List(ctx context.Context) ([]nodes.Node, error) {
+	return db.list, nil
+}
+
+func (db *nodesDB) ListFiltered(ctx context.Context, filter nodes.ListFilter) ([]nodes.Node, error) {
+	db.filtered++
+
+	var list []nodes.Node
+	for _, node := range db.list {
+		if filter.Match(node) {
+			list = append(list, node)
+		}
+	}
+	return list, nil
+}
+
+func (db *nodesDB) UpdateName(ctx context.Context, id storj.NodeID, name string) error {
+	for i := range db.list {
+		if db.list[i].ID == id {
+			db.list[i].Name = name
+		}
+	}
+	return nil
+}
+
+func (db *nodesDB) ListAnnotations(ctx context.Context) (map[storj.NodeID]nodes.Annotation, error) {
+	return db.annotations, nil
+}
+
+func (db *nodesDB) ListPaged(ctx context.Context, filter nodes.ListFilter, cursor nodes.Cursor) (nodes.Page, error) {
+	var list []nodes.Node
+	for _, node := range db.list {
+		if filter.Match(node) && (cursor.After.IsZero() || cursor.After.Less(node.ID)) {
+			list = append(list, node)
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].ID.Less(list[j].ID)
+	})
+
+	if len(list) <= cursor.Limit {
+		return nodes.Page{Nodes: list}, nil
+	}
+	return nodes.Page{
+		Nodes: list[:cursor.Limit],
+		Next:  nodes.Cursor{After: list[cursor.Limit-1].ID, Limit: cursor.Limit},
+		More:  true,
+	}, nil
+}
+
+// generatedNodesDB is a nodes.DB generating count nodes ordered by id page by page,
+// without ever holding all of them in memory.
+type generatedNodesDB struct {
+	nodes.DB
+	count int
+
+	pages    int
+	maxPage  int
+	maxLimit int
+}
+
+// generatedNodeID returns id of the node with the index, ids are ordered the same as indexes.
+func generatedNodeID(index int) storj.NodeID {
+	var id storj.NodeID
+	binary.BigEndian.PutUint64(id[:8], uint64(index)+1)
+	return id
+}
+
+func (db *generatedNodesDB) ListPaged(ctx context.Context, filter nodes.ListFilter, cursor nodes.Cursor) (nodes.Page, error) {
+	db.pages++
+	if cursor.Limit > db.maxLimit {
+		db.maxLimit = cursor.Limit
+	}
+
+	start := 0
+	if !cursor.After.IsZero() {
+		start = int(binary.BigEndian.Uint64(cursor.After[:8]))
+	}
+
+	var page nodes.Page
+	for index := start; index < db.count && len(page.Nodes) < cursor.Limit; index++ {
+		page.Nodes = append(page.Nodes, nodes.Node{ID: generatedNodeID(index), Name: "generated"})
+	}
+	if len(page.Nodes) > db.maxPage {
+		db.maxPage = len(page.Nodes)
+	}
+
+	if start+len(page.Nodes) < db.count {
+		page.Next = nodes.Cursor{After: page.Nodes[len(page.Nodes)-1].ID, Limit: cursor.Limit}
+		page.More = true
+	}
+	return page, nil
+}
+
+func TestIterateNodesLargeFleet(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	const count = 25000
+	db := &generatedNodesDB{count: count}
+	service := NewService(zaptest.NewLogger(t), rpc.Dialer{}, db, Config{NodesPageSize: 100})
+
+	// every node estimates its index, so the total is the sum of all indexes.
+	estimation, err := service.sumEstimations(ctx, func(ctx context.Context, node nodes.Node) (int64, int64, error) {
+		index := int64(binary.BigEndian.Uint64(node.ID[:8])) - 1
+		return index, 1, nil
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, count*(count-1)/2, estimation.EstimatedEarnings)
+	require.EqualValues(t, count, estimation.WeightedEstimatedEarnings)
+	require.Empty(t, estimation.NodeErrors)
+
+	// nodes were loaded a page at a time.
+	require.Equal(t, 100, db.maxLimit)
+	require.Equal(t, 100, db.maxPage)
+	require.Equal(t, count/100, db.pages)
+}
+
+func TestIterateNodesSkipsDuplicates(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	first, second := testrand.NodeID(), testrand.NodeID()
+	db := &nodesDB{list: []nodes.Node{
+		{ID: first, Name: "first", PublicAddress: "first.example.com:28967"},
+		{ID: second, Name: "second", PublicAddress: "second.example.com:28967"},
+		{ID: first, Name: "first duplicate", PublicAddress: "first.example.com:28967"},
+		{ID: second, Name: "second copy", PublicAddress: "copy.example.com:28967"},
+		{ID: testrand.NodeID(), Name: "other"},
+	}}
+	service := NewService(zaptest.NewLogger(t), rpc.Dialer{}, db, Config{NodesPageSize: 1})
+
+	var names []string
+	err := service.iterateNodes(ctx, func(node nodes.Node) {
+		names = append(names, node.Name)
+	})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"first", "second", "other"}, names)
+}
+
+func TestSummaryAnnotations(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	annotated, plain := testrand.NodeID(), testrand.NodeID()
+	db := &nodesDB{annotations: map[storj.NodeID]nodes.Annotation{
+		annotated: {NodeID: annotated, Note: "maintenance until Friday"},
+	}}
+	service := NewService(zaptest.NewLogger(t), rpc.Dialer{}, db, Config{
+		SummaryCache: SummaryCacheConfig{TTL: time.Minute, HistoricalTTL: time.Hour},
+	})
+
+	summarize := func() (Summary, error) {
+		var summary Summary
+		summary.Add(10, 20, annotated, "annotated")
+		summary.Add(30, 40, plain, "plain")
+		return summary, nil
+	}
+
+	key := summaryCacheKey{method: "NodesSummary"}
+	summary, err := service.cachedSummary(ctx, key, summarize)
+	require.NoError(t, err)
+	require.Len(t, summary.NodeSummary, 2)
+	require.Equal(t, "maintenance until Friday", summary.NodeSummary[0].Annotation)
+	require.Empty(t, summary.NodeSummary[1].Annotation)
+
+	// annotations of cached summary are read again.
+	db.annotations[plain] = nodes.Annotation{NodeID: plain, Note: "new disk"}
+	delete(db.annotations, annotated)
+
+	summary, err = service.cachedSummary(ctx, key, summarize)
+	require.NoError(t, err)
+	require.Empty(t, summary.NodeSummary[0].Annotation)
+	require.Equal(t, "new disk", summary.NodeSummary[1].Annotation)
+}
+
+func TestListNodesSkipsDuplicates(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	first, second := testrand.NodeID(), testrand.NodeID()
+	db := &nodesDB{list: []nodes.Node{
+		{ID: first, Name: "first"},
+		{ID: second, Name: "second"},
+		{ID: first, Name: "first"},
+	}}
+
+	service := NewService(zaptest.NewLogger(t), rpc.Dialer{}, db, Config{})
+
+	list, err := service.listNodes(ctx)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+
+	earned := map[storj.NodeID]int64{first: 100, second: 50}
+
+	var summary Summary
+	for _, node := range list {
+		summary.Add(0, earned[node.ID], node.ID, node.Name)
+	}
+	require.EqualValues(t, 150, summary.TotalEarned)
+}
+
+func TestGetNodeGrowthRates(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	growing := startFakeNode(t, ctx, 1, "growing", &fakeNode{periods: map[string]*multinodepb.PayoutInfo{
+		"2021-01": {Held: 100000, Paid: 300000},
+		"2021-02": {Held: 200000, Paid: 400000},
+	}})
+	// node which earned nothing in the first period grows infinitely.
+	joined := startFakeNode(t, ctx, 2, "joined", &fakeNode{periods: map[string]*multinodepb.PayoutInfo{
+		"2021-02": {Paid: 100000},
+	}})
+
+	db := &nodesDB{list: []nodes.Node{growing, joined}}
+	service := NewService(zaptest.NewLogger(t), newFakeNodeDialer(t), db, Config{})
+
+	rates, err := service.GetNodeGrowthRates(ctx, "2021-01", "2021-02")
+	require.NoError(t, err)
+	require.Equal(t, []NodeGrowthRate{
+		{NodeID: joined.ID, NodeName: "joined", To: 100000, Change: 100000, Infinite: true},
+		{NodeID: growing.ID, NodeName: "growing", From: 400000, To: 600000, Change: 200000, Percentage: 50},
+	}, rates)
+}
+
+func TestGetNodeEfficiency(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	// estimates are in cents.
+	busy := startFakeNode(t, ctx, 1, "busy", &fakeNode{estimated: 300, usedPieces: 2e12, egress: 5e11})
+	idle := startFakeNode(t, ctx, 2, "idle", &fakeNode{estimated: 10})
+
+	db := &nodesDB{list: []nodes.Node{busy, {ID: testrand.NodeID(), Name: "unreachable"}, idle}}
+	service := NewService(zaptest.NewLogger(t), newFakeNodeDialer(t), db, Config{})
+
+	efficiencies, err := service.GetNodeEfficiency(ctx)
+	require.NoError(t, err)
+	require.Len(t, efficiencies, 2)
+
+	require.Equal(t, busy.ID, efficiencies[0].NodeID)
+	require.EqualValues(t, 300, efficiencies[0].Earned)
+	require.EqualValues(t, 2e12, efficiencies[0].DiskSpaceUsed)
+	require.EqualValues(t, 5e11, efficiencies[0].Egress)
+	require.Equal(t, 150.0, efficiencies[0].EarnedPerTBStored)
+	require.Equal(t, 600.0, efficiencies[0].EarnedPerTBEgress)
+
+	// node without data and egress doesn't divide by zero.
+	require.Equal(t, idle.ID, efficiencies[1].NodeID)
+	require.Zero(t, efficiencies[1].EarnedPerTBStored)
+	require.Zero(t, efficiencies[1].EarnedPerTBEgress)
+}
+
+func TestNodesNegativePayouts(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	adjusted, regular := storj.NodeID{1}, storj.NodeID{2}
+	node := startFakeNode(t, ctx, 1, "node", &fakeNode{
+		earnedSatellites: []*multinodepb.EarnedSatellite{
+			{SatelliteId: adjusted, Total: 3500000},
+			{SatelliteId: regular, Total: 300000},
+		},
+		satellitePeriods: map[storj.NodeID]map[string]*multinodepb.PayoutInfo{
+			adjusted: {
+				"2021-01": {Held: -500000, Paid: 1000000},
+				"2021-02": {Held: 1000000, Paid: 2000000},
+			},
+			regular: {
+				"2021-01": {Paid: 300000},
+			},
+		},
+	})
+
+	db := &nodesDB{list: []nodes.Node{node, {ID: testrand.NodeID(), Name: "unreachable"}}}
+	service := NewService(zaptest.NewLogger(t), newFakeNodeDialer(t), db, Config{})
+
+	negative, err := service.NodesNegativePayouts(ctx, "2021-01")
+	require.NoError(t, err)
+	require.Equal(t, []SatellitePayout{
+		{NodeID: node.ID, NodeName: "node", SatelliteID: adjusted, Period: "2021-01", Held: -500000, Paid: 1000000},
+	}, negative)
+
+	// adjustment is hidden in all time totals of the satellite.
+	negative, err = service.NodesNegativePayouts(ctx, "")
+	require.NoError(t, err)
+	require.Empty(t, negative)
+}
+
+func TestGetEstimateAccuracy(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	// estimates are in cents, paystubs in micro USD.
+	estimated := startFakeNode(t, ctx, 1, "estimated", &fakeNode{
+		previousPeriod:    "2021-01",
+		previousEstimated: 150,
+		periods: map[string]*multinodepb.PayoutInfo{
+			"2021-01": {Held: 300000, Paid: 900000},
+		},
+	})
+	// node keeps only the estimate of the last month.
+	outdated := startFakeNode(t, ctx, 2, "outdated", &fakeNode{
+		previousPeriod:    "2021-02",
+		previousEstimated: 100,
+		periods: map[string]*multinodepb.PayoutInfo{
+			"2021-01": {Paid: 300000},
+		},
+	})
+
+	db := &nodesDB{list: []nodes.Node{estimated, outdated, {ID: testrand.NodeID(), Name: "unreachable"}}}
+	service := NewService(zaptest.NewLogger(t), newFakeNodeDialer(t), db, Config{})
+
+	accuracies, err := service.GetEstimateAccuracy(ctx, "2021-01")
+	require.NoError(t, err)
+	require.Equal(t, []NodeEstimateAccuracy{
+		{NodeID: estimated.ID, NodeName: "estimated", Period: "2021-01", Known: true, Estimated: 1500000, Actual: 1200000, Accuracy: 75},
+		{NodeID: outdated.ID, NodeName: "outdated", Period: "2021-01", Actual: 300000},
+	}, accuracies)
+}
+
+func TestGetSatellitesWithPendingPayout(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	large, small := storj.NodeID{1}, storj.NodeID{2}
+	first := startFakeNode(t, ctx, 1, "first", &fakeNode{undistributed: []*multinodepb.UndistributedSatellite{
+		{SatelliteId: large, Total: 6000000},
+		{SatelliteId: small, Total: 600000},
+	}})
+	second := startFakeNode(t, ctx, 2, "second", &fakeNode{undistributed: []*multinodepb.UndistributedSatellite{
+		{SatelliteId: large, Total: 5000000},
+		{SatelliteId: small, Total: 600000},
+	}})
+
+	db := &nodesDB{list: []nodes.Node{first, second, {ID: testrand.NodeID(), Name: "unreachable"}}}
+	service := NewService(zaptest.NewLogger(t), newFakeNodeDialer(t), db, Config{})
+
+	// amounts are summed across nodes before comparing with the minimum.
+	pending, err := service.GetSatellitesWithPendingPayout(ctx, 1000000)
+	require.NoError(t, err)
+	require.Equal(t, []SatelliteUndistributed{
+		{SatelliteID: large, Undistributed: 11000000},
+		{SatelliteID: small, Undistributed: 1200000},
+	}, pending)
+
+	pending, err = service.GetSatellitesWithPendingPayout(ctx, 1200000)
+	require.NoError(t, err)
+	require.Equal(t, []SatelliteUndistributed{
+		{SatelliteID: large, Undistributed: 11000000},
+	}, pending)
+}
+
+func TestGetNodesDroppedFromSatellite(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	satellite := storj.NodeID{1}
+	staying := startFakeNode(t, ctx, 1, "staying", &fakeNode{satellitePeriods: map[storj.NodeID]map[string]*multinodepb.PayoutInfo{
+		satellite: {
+			"2021-01": {Paid: 1000000},
+			"2021-02": {Paid: 2000000},
+		},
+	}})
+	dropped := startFakeNode(t, ctx, 2, "dropped", &fakeNode{satellitePeriods: map[storj.NodeID]map[string]*multinodepb.PayoutInfo{
+		satellite: {
+			"2021-01": {Held: 500000},
+		},
+	}})
+	// node which started in the period wasn't dropped.
+	joined := startFakeNode(t, ctx, 3, "joined", &fakeNode{satellitePeriods: map[storj.NodeID]map[string]*multinodepb.PayoutInfo{
+		satellite: {
+			"2021-02": {Paid: 100000},
+		},
+	}})
+
+	db := &nodesDB{list: []nodes.Node{staying, dropped, joined}}
+	service := NewService(zaptest.NewLogger(t), newFakeNodeDialer(t), db, Config{})
+
+	droppedIDs, err := service.GetNodesDroppedFromSatellite(ctx, satellite, "2021-02")
+	require.NoError(t, err)
+	require.Equal(t, storj.NodeIDList{dropped.ID}, droppedIDs)
+
+	_, err = service.GetNodesDroppedFromSatellite(ctx, satellite, "February 2021")
+	require.Error(t, err)
+}
+
+func TestListNodesFiltered(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	db := &nodesDB{list: []nodes.Node{
+		{ID: testrand.NodeID(), Name: "eu-first"},
+		{ID: testrand.NodeID(), Name: "us-first"},
+		{ID: testrand.NodeID(), Name: "eu-second"},
+	}}
+
+	// without filter all nodes are listed.
+	service := NewService(zaptest.NewLogger(t), rpc.Dialer{}, db, Config{})
+	list, err := service.listNodes(ctx)
+	require.NoError(t, err)
+	require.Len(t, list, 3)
+	require.Zero(t, db.filtered)
+
+	service = NewService(zaptest.NewLogger(t), rpc.Dialer{}, db, Config{NodeNamePrefix: "eu-"})
+	list, err = service.listNodes(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, db.filtered)
+	require.Len(t, list, 2)
+	for _, node := range list {
+		require.Contains(t, node.Name, "eu-")
+	}
+
+	require.NoError(t, service.RefreshSnapshot(ctx))
+	snapshot, err := service.Snapshot()
+	require.NoError(t, err)
+	require.Equal(t, 2, snapshot.NodesTotal)
+}
+
+func TestLastContact(t *testing.T) {
+	responded, failed := testrand.NodeID(), testrand.NodeID()
+	service := NewService(zaptest.NewLogger(t), rpc.Dialer{}, &nodesDB{}, Config{})
+
+	_, ok := service.LastContact(responded)
+	require.False(t, ok)
+
+	firstContact := service.contacted(responded)
+	failedContact := service.contacted(failed)
+
+	lastContact, ok := service.LastContact(responded)
+	require.True(t, ok)
+	require.Equal(t, firstContact, lastContact)
+
+	// next round only the first node responds.
+	time.Sleep(time.Millisecond)
+	secondContact := service.contacted(responded)
+	require.True(t, secondContact.After(firstContact))
+
+	summary := Summary{NodeSummary: []NodeSummary{
+		{NodeID: responded},
+		{NodeID: failed},
+	}}
+	service.fillLastContact(&summary)
+
+	require.Equal(t, secondContact, summary.NodeSummary[0].LastContact)
+	require.Equal(t, failedContact, summary.NodeSummary[1].LastContact)
+}
+
+func TestSnapshot(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	db := &nodesDB{list: []nodes.Node{
+		{ID: testrand.NodeID(), Name: "unreachable"},
+	}}
+	service := NewService(zaptest.NewLogger(t), rpc.Dialer{}, db, Config{})
+
+	_, err := service.Snapshot()
+	require.True(t, ErrSnapshotNotReady.Has(err))
+
+	before := time.Now().UTC()
+	require.NoError(t, service.RefreshSnapshot(ctx))
+
+	snapshot, err := service.Snapshot()
+	require.NoError(t, err)
+	require.Equal(t, 1, snapshot.NodesTotal)
+	require.Zero(t, snapshot.NodesReached)
+	require.Empty(t, snapshot.Summary.NodeSummary)
+	require.False(t, snapshot.StaleSince.Before(before))
+}
+
+func TestRefreshSnapshot(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	// estimates are in cents.
+	first := startFakeNode(t, ctx, 1, "first", &fakeNode{estimated: 150, periods: map[string]*multinodepb.PayoutInfo{
+		"2021-01": {Held: 100000, Paid: 300000},
+		"2021-02": {Held: 100000, Paid: 500000},
+	}})
+	second := startFakeNode(t, ctx, 2, "second", &fakeNode{estimated: 50, periods: map[string]*multinodepb.PayoutInfo{
+		"2021-02": {Paid: 200000},
+	}})
+
+	db := &nodesDB{list: []nodes.Node{first, second, {ID: testrand.NodeID(), Name: "unreachable"}}}
+	service := NewService(zaptest.NewLogger(t), newFakeNodeDialer(t), db, Config{})
+
+	require.NoError(t, service.RefreshSnapshot(ctx))
+
+	snapshot, err := service.Snapshot()
+	require.NoError(t, err)
+	require.Equal(t, 3, snapshot.NodesTotal)
+	require.Equal(t, 2, snapshot.NodesReached)
+	require.EqualValues(t, 200, snapshot.Estimated)
+	require.EqualValues(t, 200000, snapshot.Summary.TotalHeld)
+	require.EqualValues(t, 1000000, snapshot.Summary.TotalPaid)
+	require.Len(t, snapshot.Summary.NodeSummary, 2)
+}
+
+func TestSaveLoadSnapshot(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	path := ctx.File("payouts", "snapshot.json")
+
+	service := NewService(zaptest.NewLogger(t), rpc.Dialer{}, &nodesDB{}, Config{})
+	require.True(t, ErrSnapshotNotReady.Has(service.SaveSnapshot(path)))
+
+	snapshot := Snapshot{
+		Estimated:    1234,
+		NodesTotal:   2,
+		NodesReached: 1,
+		StaleSince:   time.Date(2021, 5, 10, 12, 0, 0, 0, time.UTC),
+	}
+	snapshot.Summary.Add(100, 900, testrand.NodeID(), "first")
+	snapshot.Summary.NodeSummary[0].LastContact = time.Date(2021, 5, 10, 11, 0, 0, 0, time.UTC)
+	service.snapshot = &snapshot
+
+	require.NoError(t, service.SaveSnapshot(path))
+
+	// saving again replaces the file.
+	require.NoError(t, service.SaveSnapshot(path))
+
+	restarted := NewService(zaptest.NewLogger(t), rpc.Dialer{}, &nodesDB{}, Config{})
+	require.NoError(t, restarted.LoadSnapshot(path))
+
+	loaded, err := restarted.Snapshot()
+	require.NoError(t, err)
+	require.Equal(t, snapshot, loaded)
+}
+
+func TestLoadSnapshotMissingOrCorrupt(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	service := NewService(zaptest.NewLogger(t), rpc.Dialer{}, &nodesDB{}, Config{})
+
+	// missing file starts empty.
+	require.NoError(t, service.LoadSnapshot(ctx.File("missing.json")))
+	_, err := service.Snapshot()
+	require.True(t, ErrSnapshotNotReady.Has(err))
+
+	corrupt := ctx.File("corrupt.json")
+	require.NoError(t, ioutil.WriteFile(corrupt, []byte(`{"summary": {"totalEarned": `), 0644))
+
+	err = service.LoadSnapshot(corrupt)
+	require.True(t, ErrSnapshotFile.Has(err))
+	_, err = service.Snapshot()
+	require.True(t, ErrSnapshotNotReady.Has(err))
+}
+
+func TestCheckNodeSatelliteConnectivityUnreachableNode(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	nodeID := testrand.NodeID()
+	db := &nodesDB{list: []nodes.Node{
+		{ID: nodeID, Name: "unreachable"},
+	}}
+	service := NewService(zaptest.NewLogger(t), rpc.Dialer{}, db, Config{})
+
+	connectivity, err := service.CheckNodeSatelliteConnectivity(ctx)
+	require.NoError(t, err)
+	require.Len(t, connectivity, 1)
+	require.Equal(t, nodeID, connectivity[0].NodeID)
+	require.NotEmpty(t, connectivity[0].Error)
+	require.Empty(t, connectivity[0].Satellites)
+}
+
+func TestCheckNodeSatelliteConnectivity(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	reachable, failing, unattempted := storj.NodeID{1}, storj.NodeID{2}, storj.NodeID{3}
+	checkedAt := time.Date(2021, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	node := startFakeNode(t, ctx, 1, "node", &fakeNode{checkIns: []*multinodepb.SatelliteCheckInsResponse_CheckIn{
+		{SatelliteId: reachable, Attempted: true, Succeeded: true, CheckedAt: checkedAt},
+		{SatelliteId: failing, Attempted: true, CheckedAt: checkedAt, Error: "connection refused"},
+		{SatelliteId: unattempted},
+	}})
+	offline := nodes.Node{ID: testrand.NodeID(), Name: "offline"}
+
+	db := &nodesDB{list: []nodes.Node{node, offline}}
+	service := NewService(zaptest.NewLogger(t), newFakeNodeDialer(t), db, Config{})
+
+	connectivity, err := service.CheckNodeSatelliteConnectivity(ctx)
+	require.NoError(t, err)
+	require.Len(t, connectivity, 2)
+
+	require.Equal(t, node.ID, connectivity[0].NodeID)
+	require.Empty(t, connectivity[0].Error)
+	require.Equal(t, storj.NodeIDList{failing}, connectivity[0].UnreachableSatellites())
+	require.Len(t, connectivity[0].Satellites, 3)
+	require.True(t, connectivity[0].Satellites[0].Reachable)
+	require.True(t, checkedAt.Equal(connectivity[0].Satellites[0].CheckedAt))
+	require.False(t, connectivity[0].Satellites[1].Reachable)
+	require.Equal(t, "connection refused", connectivity[0].Satellites[1].Error)
+	require.False(t, connectivity[0].Satellites[2].Attempted)
+
+	// node which can't be dialed is reported with the error.
+	require.Equal(t, offline.ID, connectivity[1].NodeID)
+	require.NotEmpty(t, connectivity[1].Error)
+	require.Empty(t, connectivity[1].Satellites)
+}
+
+func TestGetPaystubCoverage(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	complete := startFakeNode(t, ctx, 1, "complete", &fakeNode{periods: map[string]*multinodepb.PayoutInfo{
+		"2021-01": {},
+		"2021-02": {},
+	}})
+	gapped := startFakeNode(t, ctx, 2, "gapped", &fakeNode{periods: map[string]*multinodepb.PayoutInfo{
+		"2021-02": {},
+		"2020-11": {},
+		"2020-12": {},
+	}})
+	fresh := startFakeNode(t, ctx, 3, "fresh", &fakeNode{})
+
+	db := &nodesDB{list: []nodes.Node{complete, gapped, fresh, {ID: testrand.NodeID(), Name: "unreachable"}}}
+	service := NewService(zaptest.NewLogger(t), newFakeNodeDialer(t), db, Config{})
+
+	coverages, err := service.GetPaystubCoverage(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []PaystubCoverage{
+		{NodeID: complete.ID, NodeName: "complete", Earliest: "2021-01", Latest: "2021-02"},
+		{NodeID: gapped.ID, NodeName: "gapped", Earliest: "2020-11", Latest: "2021-02", Gaps: []string{"2021-01"}, HasGaps: true},
+		{NodeID: fresh.ID, NodeName: "fresh"},
+	}, coverages)
+}
+
+func TestGetEarningsHistogram(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	db := &nodesDB{list: []nodes.Node{
+		startFakeNode(t, ctx, 1, "first", &fakeNode{earned: 500000}),
+		startFakeNode(t, ctx, 2, "second", &fakeNode{earned: 1000000}),
+		startFakeNode(t, ctx, 3, "third", &fakeNode{earned: 3000000}),
+		{ID: testrand.NodeID(), Name: "unreachable"},
+	}}
+	service := NewService(zaptest.NewLogger(t), newFakeNodeDialer(t), db, Config{})
+
+	// earnings equal to the upper bound are counted in its bucket.
+	histogram, err := service.GetEarningsHistogram(ctx, []int64{1000000, 2000000})
+	require.NoError(t, err)
+	require.Equal(t, []HistogramBucket{
+		{UpperBound: 1000000, Count: 2},
+		{UpperBound: 2000000},
+		{Overflow: true, Count: 1},
+	}, histogram)
+
+	_, err = service.GetEarningsHistogram(ctx, []int64{2000000, 1000000})
+	require.Error(t, err)
+}
+
+func TestSumEstimationsSkipsFailingNodes(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	reachable, unreachable := testrand.NodeID(), testrand.NodeID()
+	db := &nodesDB{list: []nodes.Node{
+		{ID: reachable, Name: "reachable"},
+		{ID: unreachable, Name: "unreachable"},
+		{ID: testrand.NodeID(), Name: "reachable too"},
+	}}
+	service := NewService(zaptest.NewLogger(t), rpc.Dialer{}, db, Config{})
+
+	estimation, err := service.sumEstimations(ctx, func(ctx context.Context, node nodes.Node) (int64, int64, error) {
+		if node.ID == unreachable {
+			return 0, 0, errs.New("dial failed")
+		}
+		return 100, 80, nil
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 200, estimation.EstimatedEarnings)
+	require.EqualValues(t, 160, estimation.WeightedEstimatedEarnings)
+	require.Equal(t, []NodeError{{NodeID: unreachable, NodeName: "unreachable", Error: "dial failed"}}, estimation.NodeErrors)
+
+	_, ok := service.LastContact(reachable)
+	require.True(t, ok)
+	_, ok = service.LastContact(unreachable)
+	require.False(t, ok)
+}
+
+func TestNodesEstimationsUnreachableNodes(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	db := &nodesDB{list: []nodes.Node{
+		{ID: testrand.NodeID(), Name: "first"},
+		{ID: testrand.NodeID(), Name: "second"},
+	}}
+	service := NewService(zaptest.NewLogger(t), rpc.Dialer{}, db, Config{})
+
+	estimation, err := service.NodesEstimations(ctx)
+	require.NoError(t, err)
+	require.Zero(t, estimation.EstimatedEarnings)
+	require.Len(t, estimation.NodeErrors, 2)
+
+	estimation, err = service.NodesSatelliteEstimations(ctx, testrand.NodeID())
+	require.NoError(t, err)
+	require.Zero(t, estimation.EstimatedEarnings)
+	require.Len(t, estimation.NodeErrors, 2)
+	for _, nodeError := range estimation.NodeErrors {
+		require.NotEmpty(t, nodeError.Error)
+	}
+}
+
+func TestGetInconsistentHeld(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	satellite := storj.NodeID{1}
+	joinedAt := time.Date(2020, 6, 15, 0, 0, 0, 0, time.UTC)
+	heldRates := func(joinedAt time.Time, held int64) []*multinodepb.HeldRatesResponse_HeldRate {
+		return []*multinodepb.HeldRatesResponse_HeldRate{
+			{SatelliteId: satellite, JoinedAt: joinedAt, Held: held, Earned: 1000000},
+		}
+	}
+
+	first := startFakeNode(t, ctx, 1, "first", &fakeNode{heldRates: heldRates(joinedAt, 250000)})
+	second := startFakeNode(t, ctx, 2, "second", &fakeNode{heldRates: heldRates(joinedAt, 250000)})
+	diverging := startFakeNode(t, ctx, 3, "diverging", &fakeNode{heldRates: heldRates(joinedAt, 750000)})
+	// node which joined in other month is not in the cohort.
+	later := startFakeNode(t, ctx, 4, "later", &fakeNode{heldRates: heldRates(joinedAt.AddDate(0, 3, 0), 0)})
+
+	db := &nodesDB{list: []nodes.Node{first, second, diverging, later, {ID: testrand.NodeID(), Name: "unreachable"}}}
+	service := NewService(zaptest.NewLogger(t), newFakeNodeDialer(t), db, Config{})
+
+	outliers, err := service.GetInconsistentHeld(ctx, "2021-01", 10)
+	require.NoError(t, err)
+	require.Equal(t, []HeldOutlier{
+		{NodeID: diverging.ID, NodeName: "diverging", SatelliteID: satellite, Cohort: "2020-06", Expected: 25, Actual: 75},
+	}, outliers)
+}
+
+// blockingConnector is a rpc.Connector counting dials, which block until the dial context is canceled.
+type blockingConnector struct {
+	dials   int64
+	started chan struct{}
+}
+
+func (connector *blockingConnector) DialContext(ctx context.Context, tlsconfig *tls.Config, address string) (rpc.ConnectorConn, error) {
+	if atomic.AddInt64(&connector.dials, 1) == 1 {
+		close(connector.started)
+	}
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestRunEstimateRefresherCanceled(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	tlsOptions, err := tlsopts.NewOptions(testidentity.MustPregeneratedIdentity(0, storj.LatestIDVersion()), tlsopts.Config{}, nil)
+	require.NoError(t, err)
+
+	connector := &blockingConnector{started: make(chan struct{})}
+	dialer := rpc.NewDefaultDialer(tlsOptions)
+	dialer.Connector = connector
+
+	db := &nodesDB{list: []nodes.Node{
+		{ID: testrand.NodeID(), Name: "first", PublicAddress: "127.0.0.1:1"},
+		{ID: testrand.NodeID(), Name: "second", PublicAddress: "127.0.0.1:2"},
+		{ID: testrand.NodeID(), Name: "third", PublicAddress: "127.0.0.1:3"},
+	}}
+	service := NewService(zaptest.NewLogger(t), dialer, db, Config{RefreshInterval: time.Millisecond})
+
+	refresherCtx, cancel := context.WithCancel(ctx)
+	stopped := make(chan error, 1)
+	go func() { stopped <- service.RunEstimateRefresher(refresherCtx) }()
+
+	select {
+	case <-connector.started:
+	case <-time.After(10 * time.Second):
+		t.Fatal("refresh didn't start")
+	}
+	cancel()
+
+	select {
+	case err := <-stopped:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(10 * time.Second):
+		t.Fatal("refresher didn't stop after cancellation")
+	}
+
+	// the in-flight dial was canceled and no other node was dialed.
+	require.EqualValues(t, 1, atomic.LoadInt64(&connector.dials))
+	for _, node := range db.list {
+		require.True(t, service.breaker.Allow(node.ID))
+	}
+
+	// partially collected snapshot is not stored.
+	_, err = service.Snapshot()
+	require.True(t, ErrSnapshotNotReady.Has(err))
+}
+
+func TestGetNodeTrends(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	first := startFakeNode(t, ctx, 1, "first", &fakeNode{periods: map[string]*multinodepb.PayoutInfo{
+		"2020-12": {Paid: 100000},
+		"2021-01": {Held: 100000, Paid: 200000},
+		"2021-03": {Paid: 500000},
+	}})
+	second := startFakeNode(t, ctx, 2, "second", &fakeNode{periods: map[string]*multinodepb.PayoutInfo{
+		"2021-02": {Held: 50000},
+	}})
+
+	db := &nodesDB{list: []nodes.Node{first, second}}
+	service := NewService(zaptest.NewLogger(t), newFakeNodeDialer(t), db, Config{})
+
+	// periods which were not requested are left out, requested periods without paystub are zero.
+	periods := []string{"2021-01", "2021-02", "2021-03"}
+	trends, err := service.GetNodeTrends(ctx, periods)
+	require.NoError(t, err)
+	require.Equal(t, []NodeTrend{
+		{NodeID: first.ID, NodeName: "first", Periods: periods, Earned: []int64{300000, 0, 500000}},
+		{NodeID: second.ID, NodeName: "second", Periods: periods, Earned: []int64{0, 50000, 0}},
+	}, trends)
+}
+
+func TestAggregationsSkipUnreachableNodes(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	unreachable := nodes.Node{ID: testrand.NodeID(), Name: "unreachable"}
+	db := &nodesDB{list: []nodes.Node{unreachable}}
+	service := NewService(zaptest.NewLogger(t), rpc.Dialer{}, db, Config{})
+
+	// expected is the result when no node responds, nil expects an empty result.
+	tests := []struct {
+		name     string
+		call     func() (interface{}, error)
+		expected interface{}
+	}{
+		{name: "GetNodeTrends", call: func() (interface{}, error) {
+			return service.GetNodeTrends(ctx, []string{"2021-01", "2021-02"})
+		}},
+		{name: "GetDistinctWallets", call: func() (interface{}, error) {
+			return service.GetDistinctWallets(ctx)
+		}},
+		{name: "GetNodeTenure", call: func() (interface{}, error) {
+			return service.GetNodeTenure(ctx)
+		}},
+		{name: "GetPaidReconciliation", call: func() (interface{}, error) {
+			return service.GetPaidReconciliation(ctx, 10000)
+		}},
+		{name: "GetAllNodesEarnedComponents", call: func() (interface{}, error) {
+			return service.GetAllNodesEarnedComponents(ctx)
+		}},
+		{name: "GetTimeToThreshold", call: func() (interface{}, error) {
+			return service.GetTimeToThreshold(ctx)
+		}},
+		{name: "CheckSatelliteMembership", call: func() (interface{}, error) {
+			return service.CheckSatelliteMembership(ctx, []storj.NodeID{testrand.NodeID()})
+		}},
+		{name: "GetHeldHistory", call: func() (interface{}, error) {
+			return service.GetHeldHistory(ctx)
+		}},
+		{name: "GetChartData", call: func() (interface{}, error) {
+			return service.GetChartData(ctx, ChartHeld, []string{"2021-01", "2021-02"})
+		}, expected: []ChartPoint{{Period: "2021-01"}, {Period: "2021-02"}}},
+		{name: "ForecastHeldReturns", call: func() (interface{}, error) {
+			return service.ForecastHeldReturns(ctx, 3)
+		}, expected: NewHeldReturnForecast(nil, time.Now().UTC(), 3)},
+		{name: "DetectEstimateAnomalies", call: func() (interface{}, error) {
+			return service.DetectEstimateAnomalies(ctx, map[storj.NodeID]int64{unreachable.ID: 1000})
+		}},
+		{name: "GetAllNodesReputationScores", call: func() (interface{}, error) {
+			return service.GetAllNodesReputationScores(ctx)
+		}},
+		{name: "GetSatellitePayoutShares", call: func() (interface{}, error) {
+			return service.GetSatellitePayoutShares(ctx)
+		}, expected: []SatellitePayoutShare{}},
+		{name: "GetEarningsVelocity", call: func() (interface{}, error) {
+			return service.GetEarningsVelocity(ctx, "2021-02")
+		}, expected: EarningsVelocity{Period: "2021-02", Days: 28}},
+		{name: "GetEarningsByStableID", call: func() (interface{}, error) {
+			return service.GetEarningsByStableID(ctx)
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := test.call()
+			require.NoError(t, err)
+			if test.expected == nil {
+				require.Empty(t, result)
+				return
+			}
+			require.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestGetDistinctWallets(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	// letter case of the address is only a checksum, so it's the same wallet.
+	first := startFakeNode(t, ctx, 1, "first", &fakeNode{wallet: "0xDEADbeef"})
+	second := startFakeNode(t, ctx, 2, "second", &fakeNode{wallet: "0xdeadbeef"})
+	other := startFakeNode(t, ctx, 3, "other", &fakeNode{wallet: "0x0123"})
+	unset := startFakeNode(t, ctx, 4, "unset", &fakeNode{})
+
+	db := &nodesDB{list: []nodes.Node{first, second, other, unset, {ID: testrand.NodeID(), Name: "unreachable"}}}
+	service := NewService(zaptest.NewLogger(t), newFakeNodeDialer(t), db, Config{})
+
+	wallets, err := service.GetDistinctWallets(ctx)
+	require.NoError(t, err)
+	require.Equal(t, Wallets{
+		Wallets: []DistinctWallet{
+			{Wallet: "0xDEADbeef", NodeCount: 2},
+			{Wallet: "0x0123", NodeCount: 1},
+		},
+		WithoutWallet: storj.NodeIDList{unset.ID},
+	}, wallets)
+}
+
+func TestGetNodeTenure(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	paying, fresh := storj.NodeID{1}, storj.NodeID{2}
+	node := startFakeNode(t, ctx, 1, "node", &fakeNode{
+		earnedSatellites: []*multinodepb.EarnedSatellite{
+			{SatelliteId: paying, Total: 1000000},
+			{SatelliteId: fresh},
+		},
+		// satellite without paystubs has no tenure yet.
+		firstPeriods: map[storj.NodeID]string{paying: "2020-01"},
+	})
+
+	db := &nodesDB{list: []nodes.Node{node, {ID: testrand.NodeID(), Name: "unreachable"}}}
+	service := NewService(zaptest.NewLogger(t), newFakeNodeDialer(t), db, Config{})
+
+	tenures, err := service.GetNodeTenure(ctx)
+	require.NoError(t, err)
+
+	months, err := TenureMonths("2020-01", time.Now().UTC())
+	require.NoError(t, err)
+	require.Equal(t, []NodeTenure{
+		{NodeID: node.ID, NodeName: "node", SatelliteID: paying, FirstPeriod: "2020-01", Months: months},
+	}, tenures)
+}
+
+func TestGetEarningsVelocity(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	first := startFakeNode(t, ctx, 1, "first", &fakeNode{periods: map[string]*multinodepb.PayoutInfo{
+		"2021-01": {Held: 1000000, Paid: 1000000},
+		"2021-02": {Held: 700000, Paid: 2100000},
+	}})
+	// amounts of the node with amount unit are rescaled to micro USD.
+	second := startFakeNode(t, ctx, 2, "second", &fakeNode{periods: map[string]*multinodepb.PayoutInfo{
+		"2021-02": {Paid: 56, Unit: &multinodepb.AmountUnit{Currency: "USD", Decimals: 2}},
+	}})
+
+	db := &nodesDB{list: []nodes.Node{first, second, {ID: testrand.NodeID(), Name: "unreachable"}}}
+	service := NewService(zaptest.NewLogger(t), newFakeNodeDialer(t), db, Config{})
+
+	velocity, err := service.GetEarningsVelocity(ctx, "2021-02")
+	require.NoError(t, err)
+	require.Equal(t, "2021-02", velocity.Period)
+	require.Equal(t, 28, velocity.Days)
+	require.EqualValues(t, 3360000, velocity.Earned)
+	require.EqualValues(t, 120000, velocity.PerDay)
+	// nodes are iterated in the order of their ids.
+	require.ElementsMatch(t, []NodeVelocity{
+		{NodeID: first.ID, NodeName: "first", Earned: 2800000, PerDay: 100000},
+		{NodeID: second.ID, NodeName: "second", Earned: 560000, PerDay: 20000},
+	}, velocity.Nodes)
+
+	_, err = service.GetEarningsVelocity(ctx, "February 2021")
+	require.Error(t, err)
+}
+
+func TestMembershipSatellites(t *testing.T) {
+	legacy, canonical, excluded := testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+	service := NewService(zaptest.NewLogger(t), rpc.Dialer{}, nil, Config{
+		ExcludedSatellites: SatelliteIDs{excluded},
+		SatelliteAliases:   SatelliteAliases{legacy: canonical},
+	})
+
+	// node paid by the legacy id matches expected canonical id, excluded satellite is ignored.
+	extra, missing := CompareSatellites(
+		service.membershipSatellites([]storj.NodeID{canonical, excluded}),
+		service.membershipSatellites([]storj.NodeID{legacy}),
+	)
+	require.Empty(t, extra)
+	require.Empty(t, missing)
+}
+
+func TestCheckPermissionsUnreachableNode(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	service := NewService(zaptest.NewLogger(t), rpc.Dialer{}, &nodesDB{}, Config{})
+
+	_, err := service.CheckPermissions(ctx, nodes.Node{ID: testrand.NodeID(), Name: "unreachable"})
+	require.Error(t, err)
+}
+
+func TestSatelliteSummaryNodesSharingAddress(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	shared := []nodes.Node{
+		{ID: testrand.NodeID(), Name: "first", PublicAddress: "127.0.0.1:1"},
+		{ID: testrand.NodeID(), Name: "second", PublicAddress: "127.0.0.1:1"},
+	}
+	alone := nodes.Node{ID: testrand.NodeID(), Name: "alone", PublicAddress: "127.0.0.1:2"}
+
+	db := &nodesDB{list: append(shared, alone)}
+	service := NewService(zaptest.NewLogger(t), rpc.Dialer{}, db, Config{
+		CircuitBreaker: CircuitBreakerConfig{FailureThreshold: 1, Cooldown: time.Hour},
+	})
+
+	// unreachable nodes sharing the address fail the summary, after being queried one by one.
+	_, err := service.NodesSatelliteSummary(ctx, testrand.NodeID())
+	require.Error(t, err)
+
+	// nodes of the group are reported on their own, when the request of the whole group can't be made.
+	for _, node := range db.list {
+		service.breaker.Failure(node.ID)
+	}
+	summary, err := service.NodesSatellitePeriodSummary(ctx, testrand.NodeID(), "2021-05")
+	require.NoError(t, err)
+	require.Len(t, summary.NodeSummary, 3)
+	for i, node := range []nodes.Node{shared[0], shared[1], alone} {
+		require.Equal(t, node.ID, summary.NodeSummary[i].NodeID)
+		require.True(t, summary.NodeSummary[i].CircuitOpen)
+	}
+}
+
+func TestGetMedianEarnedPerNode(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	db := &nodesDB{list: []nodes.Node{
+		{ID: testrand.NodeID(), Name: "unreachable"},
+	}}
+	service := NewService(zaptest.NewLogger(t), rpc.Dialer{}, db, Config{})
+
+	quartiles, err := service.GetMedianEarnedPerNode(ctx)
+	require.NoError(t, err)
+	require.Equal(t, Quartiles{}, quartiles)
+
+	// unreachable node is skipped, median of the even count of earnings is the mean of the middle two.
+	db.list = append(db.list,
+		startFakeNode(t, ctx, 1, "first", &fakeNode{earned: 1000000}),
+		startFakeNode(t, ctx, 2, "second", &fakeNode{earned: 4000000}),
+		startFakeNode(t, ctx, 3, "third", &fakeNode{earned: 2000000}),
+		startFakeNode(t, ctx, 4, "fourth", &fakeNode{earned: 9000000}),
+	)
+	service = NewService(zaptest.NewLogger(t), newFakeNodeDialer(t), db, Config{})
+
+	quartiles, err = service.GetMedianEarnedPerNode(ctx)
+	require.NoError(t, err)
+	require.Equal(t, Quartiles{Lower: 1500000, Median: 3000000, Upper: 6500000}, quartiles)
+}
+
+func TestGetTimeToThreshold(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	// 4 USD undistributed and 1.50 USD estimated per month, 6 USD are missing to reach 10 USD threshold.
+	node := startFakeNode(t, ctx, 1, "node", &fakeNode{undistributed: []*multinodepb.UndistributedSatellite{{SatelliteId: storj.NodeID{1}, Total: 4000000}}, estimated: 150})
+	db := &nodesDB{list: []nodes.Node{node}}
+	service := NewService(zaptest.NewLogger(t), newFakeNodeDialer(t), db, Config{PayoutThreshold: 10000000})
+
+	projections, err := service.GetTimeToThreshold(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []NodeTimeToThreshold{{
+		NodeID:        node.ID,
+		NodeName:      "node",
+		Undistributed: 4000000,
+		Estimated:     1500000,
+		Months:        4,
+	}}, projections)
+}
+
+func TestDetectEstimateAnomalies(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	// estimates are in cents, baselines in micro USD.
+	stalled := startFakeNode(t, ctx, 1, "stalled", &fakeNode{estimated: 0})
+	steady := startFakeNode(t, ctx, 2, "steady", &fakeNode{estimated: 190})
+	dropped := startFakeNode(t, ctx, 3, "dropped", &fakeNode{estimated: 50})
+	// node without baseline is not compared, unreachable node is skipped.
+	fresh := startFakeNode(t, ctx, 4, "fresh", &fakeNode{estimated: 10})
+	unreachable := nodes.Node{ID: testrand.NodeID(), Name: "unreachable"}
+	db := &nodesDB{list: []nodes.Node{stalled, steady, dropped, fresh, unreachable}}
+	service := NewService(zaptest.NewLogger(t), newFakeNodeDialer(t), db, Config{})
+	require.EqualValues(t, defaultEstimateDropPercent, service.estimateDrop)
+
+	anomalies, err := service.DetectEstimateAnomalies(ctx, map[storj.NodeID]int64{
+		stalled.ID:     2000000,
+		steady.ID:      2000000,
+		dropped.ID:     2000000,
+		unreachable.ID: 2000000,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []EstimateAnomaly{
+		{NodeID: stalled.ID, NodeName: "stalled", Baseline: 2000000, Current: 0, Drop: 100},
+		{NodeID: dropped.ID, NodeName: "dropped", Baseline: 2000000, Current: 500000, Drop: 75},
+	}, anomalies)
+}
+
+func TestGetSatelliteCountsReportsUnreachableNodes(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	unreachable := testrand.NodeID()
+	db := &nodesDB{list: []nodes.Node{
+		{ID: unreachable, Name: "unreachable"},
+	}}
+	service := NewService(zaptest.NewLogger(t), rpc.Dialer{}, db, Config{})
+
+	counts, err := service.GetSatelliteCounts(ctx)
+	require.NoError(t, err)
+	require.Equal(t, SatelliteCounts{
+		Nodes: []NodeSatelliteCount{{NodeID: unreachable, NodeName: "unreachable", Unreachable: true}},
+	}, counts)
+
+	_, ok := service.LastContact(unreachable)
+	require.False(t, ok)
+}
+
+func TestGetSatelliteCounts(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	first, second, canonical, legacy, excluded := testrand.NodeID(), testrand.NodeID(), testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+
+	// legacy id of a satellite counts as the same satellite, excluded satellite is ignored.
+	many := startFakeNode(t, ctx, 1, "many", &fakeNode{satellites: []storj.NodeID{first, second, legacy, canonical}})
+	few := startFakeNode(t, ctx, 2, "few", &fakeNode{satellites: []storj.NodeID{canonical, excluded}})
+	unreachable := nodes.Node{ID: testrand.NodeID(), Name: "unreachable"}
+
+	db := &nodesDB{list: []nodes.Node{many, few, unreachable}}
+	service := NewService(zaptest.NewLogger(t), newFakeNodeDialer(t), db, Config{
+		ExcludedSatellites: SatelliteIDs{excluded},
+		SatelliteAliases:   SatelliteAliases{legacy: canonical},
+	})
+
+	counts, err := service.GetSatelliteCounts(ctx)
+	require.NoError(t, err)
+	require.Equal(t, SatelliteCounts{
+		Nodes: []NodeSatelliteCount{
+			{NodeID: many.ID, NodeName: "many", Satellites: 3},
+			{NodeID: few.ID, NodeName: "few", Satellites: 1},
+			{NodeID: unreachable.ID, NodeName: "unreachable", Unreachable: true},
+		},
+		Min:     1,
+		Max:     3,
+		Average: 2,
+	}, counts)
+}
+
+func TestGetAllNodesReputationScores(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	reported, partial, legacy, excluded := storj.NodeID{1}, storj.NodeID{2}, storj.NodeID{3}, storj.NodeID{4}
+
+	first := startFakeNode(t, ctx, 1, "first", &fakeNode{scores: []*multinodepb.ReputationScoresResponse_SatelliteScores{
+		{SatelliteId: reported, Reported: true, AuditScore: 1, SuspensionScore: 1, OnlineScore: 0.5},
+		{SatelliteId: partial, Reported: true, AuditScore: 1, SuspensionScore: 1, OnlineScore: 1},
+		{SatelliteId: excluded, Reported: true, AuditScore: 0.25, SuspensionScore: 0.25, OnlineScore: 0.25},
+	}})
+	// scores of the legacy id are added to the canonical id.
+	second := startFakeNode(t, ctx, 2, "second", &fakeNode{scores: []*multinodepb.ReputationScoresResponse_SatelliteScores{
+		{SatelliteId: legacy, Reported: true, AuditScore: 0.5, SuspensionScore: 1, OnlineScore: 1},
+		{SatelliteId: partial, Reported: false},
+	}})
+
+	db := &nodesDB{list: []nodes.Node{first, second, {ID: testrand.NodeID(), Name: "unreachable"}}}
+	service := NewService(zaptest.NewLogger(t), newFakeNodeDialer(t), db, Config{
+		ExcludedSatellites: SatelliteIDs{excluded},
+		SatelliteAliases:   SatelliteAliases{legacy: reported},
+	})
+
+	scores, err := service.GetAllNodesReputationScores(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []SatelliteReputation{
+		{SatelliteID: reported, Nodes: 2, AuditScore: 0.75, SuspensionScore: 1, OnlineScore: 0.75},
+		{SatelliteID: partial, Nodes: 1, Unreported: 1, AuditScore: 1, SuspensionScore: 1, OnlineScore: 1},
+	}, scores)
+}
+
+func TestGetSatellitePayoutShares(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	major, minor, token := storj.NodeID{1}, storj.NodeID{2}, storj.NodeID{3}
+
+	first := startFakeNode(t, ctx, 1, "first", &fakeNode{earnedSatellites: []*multinodepb.EarnedSatellite{
+		{SatelliteId: major, Total: 3000000},
+		{SatelliteId: minor, Total: 1000000},
+	}})
+	// amounts in other currency are shares of the total of that currency.
+	second := startFakeNode(t, ctx, 2, "second", &fakeNode{earnedSatellites: []*multinodepb.EarnedSatellite{
+		{SatelliteId: major, Total: 2000000},
+		{SatelliteId: minor, Total: 2000000},
+		{SatelliteId: token, Total: 100, Unit: &multinodepb.AmountUnit{Currency: "STORJ", Decimals: 8}},
+	}})
+
+	db := &nodesDB{list: []nodes.Node{first, second, {ID: testrand.NodeID(), Name: "unreachable"}}}
+	service := NewService(zaptest.NewLogger(t), newFakeNodeDialer(t), db, Config{})
+
+	shares, err := service.GetSatellitePayoutShares(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []SatellitePayoutShare{
+		{SatelliteID: token, Earned: 100, Currency: "STORJ", Share: 100},
+		{SatelliteID: major, Earned: 5000000, Currency: "USD", Share: 62.5},
+		{SatelliteID: minor, Earned: 3000000, Currency: "USD", Share: 37.5},
+	}, shares)
+}
+
+func TestGetNetPayoutAfterCut(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	db := &nodesDB{list: []nodes.Node{
+		{ID: testrand.NodeID(), Name: "unreachable"},
+	}}
+	service := NewService(zaptest.NewLogger(t), rpc.Dialer{}, db, Config{})
+
+	_, err := service.GetNetPayoutAfterCut(ctx, 1.5, false)
+	require.Error(t, err)
+	require.Zero(t, db.filtered)
+
+	payout, err := service.GetNetPayoutAfterCut(ctx, 0.2, true)
+	require.NoError(t, err)
+	require.Equal(t, PayoutAfterCut{CutFraction: 0.2}, payout)
+
+	// unreachable node is skipped, cut of every node is rounded to the nearest micro USD.
+	first := startFakeNode(t, ctx, 1, "first", &fakeNode{earned: 1000000})
+	second := startFakeNode(t, ctx, 2, "second", &fakeNode{earned: 333333})
+	db.list = append(db.list, first, second)
+	service = NewService(zaptest.NewLogger(t), newFakeNodeDialer(t), db, Config{})
+
+	payout, err = service.GetNetPayoutAfterCut(ctx, 0.2, true)
+	require.NoError(t, err)
+	require.Equal(t, PayoutAfterCut{
+		CutFraction: 0.2,
+		Gross:       1333333,
+		Cut:         266667,
+		Net:         1066666,
+		Nodes: []NodePayoutAfterCut{
+			{NodeID: first.ID, NodeName: "first", Gross: 1000000, Cut: 200000, Net: 800000},
+			{NodeID: second.ID, NodeName: "second", Gross: 333333, Cut: 66667, Net: 266666},
+		},
+	}, payout)
+
+	payout, err = service.GetNetPayoutAfterCut(ctx, 0.2, false)
+	require.NoError(t, err)
+	require.Empty(t, payout.Nodes)
+	require.EqualValues(t, 1066666, payout.Net)
+}
+
+func TestGetEarningsByStableIDRenamedNode(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	node := startFakeNode(t, ctx, 1, "before", &fakeNode{
+		earned: 3000000,
+		net:    2000000,
+		periods: map[string]*multinodepb.PayoutInfo{
+			"2021-01": {Held: 1000000, Paid: 2000000},
+		},
+	})
+	db := &nodesDB{list: []nodes.Node{node}}
+	service := NewService(zaptest.NewLogger(t), newFakeNodeDialer(t), db, Config{
+		SummaryCache: SummaryCacheConfig{TTL: time.Minute, HistoricalTTL: time.Hour},
+	})
+
+	before, err := service.GetEarningsByStableID(ctx)
+	require.NoError(t, err)
+	require.Equal(t, EarningsByNodeID{
+		node.ID: {NodeID: node.ID, NodeName: "before", Earned: Earned{Gross: 3000000, Net: 2000000}},
+	}, before)
+
+	summary, err := service.NodesSummary(ctx)
+	require.NoError(t, err)
+	require.Len(t, summary.NodeSummary, 1)
+	require.Equal(t, "before", summary.NodeSummary[0].NodeName)
+
+	require.NoError(t, db.UpdateName(ctx, node.ID, "after"))
+
+	// earnings of the renamed node match by its id.
+	after, err := service.GetEarningsByStableID(ctx)
+	require.NoError(t, err)
+	require.Len(t, after, 1)
+	require.Equal(t, before[node.ID].Earned, after[node.ID].Earned)
+	require.Equal(t, "after", after[node.ID].NodeName)
+
+	// cached summary shows the current name.
+	summary, err = service.NodesSummary(ctx)
+	require.NoError(t, err)
+	require.Len(t, summary.NodeSummary, 1)
+	require.Equal(t, "after", summary.NodeSummary[0].NodeName)
+	require.EqualValues(t, 3000000, summary.TotalEarned)
+}
+
+func TestGetConcentration(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	first := startFakeNode(t, ctx, 1, "first", &fakeNode{earned: 6000000, net: 5000000})
+	second := startFakeNode(t, ctx, 2, "second", &fakeNode{earned: 3000000, net: 3000000})
+	third := startFakeNode(t, ctx, 3, "third", &fakeNode{earned: 1000000})
+
+	db := &nodesDB{list: []nodes.Node{first, second, third, {ID: testrand.NodeID(), Name: "unreachable"}}}
+	service := NewService(zaptest.NewLogger(t), newFakeNodeDialer(t), db, Config{})
+
+	earned, err := service.GetPerNodeAllTimeEarned(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []NodeEarned{
+		{NodeID: first.ID, NodeName: "first", Earned: Earned{Gross: 6000000, Net: 5000000}},
+		{NodeID: second.ID, NodeName: "second", Earned: Earned{Gross: 3000000, Net: 3000000}},
+		{NodeID: third.ID, NodeName: "third", Earned: Earned{Gross: 1000000}},
+	}, earned)
+
+	_, err = service.GetConcentration(ctx, 0)
+	require.Error(t, err)
+
+	// top node earns 60% of all nodes.
+	concentration, err := service.GetConcentration(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, 0.6, concentration)
+
+	// more top nodes than nodes include all of them.
+	concentration, err = service.GetConcentration(ctx, 5)
+	require.NoError(t, err)
+	require.Equal(t, 1.0, concentration)
+}
+
+func TestGetPaidReconciliation(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	matching, different, unrecorded := storj.NodeID{1}, storj.NodeID{2}, storj.NodeID{3}
+	node := startFakeNode(t, ctx, 1, "node", &fakeNode{payments: []*multinodepb.PaymentsPerSatelliteResponse_SatellitePayments{
+		{SatelliteId: matching, Paid: 1000000, Received: 995000, Payments: 2},
+		{SatelliteId: different, Paid: 1000000, Received: 500000, Payments: 1},
+		{SatelliteId: unrecorded, Paid: 300000},
+	}})
+
+	db := &nodesDB{list: []nodes.Node{node, {ID: testrand.NodeID(), Name: "unreachable"}}}
+	service := NewService(zaptest.NewLogger(t), newFakeNodeDialer(t), db, Config{})
+
+	reconciliations, err := service.GetPaidReconciliation(ctx, 10000)
+	require.NoError(t, err)
+	require.Equal(t, []PaidReconciliation{
+		{NodeID: node.ID, NodeName: "node", SatelliteID: matching, Paid: 1000000, Received: 995000, Difference: 5000, Status: ReconciliationMatch},
+		{NodeID: node.ID, NodeName: "node", SatelliteID: different, Paid: 1000000, Received: 500000, Difference: 500000, Status: ReconciliationDiscrepancy},
+		{NodeID: node.ID, NodeName: "node", SatelliteID: unrecorded, Paid: 300000, Difference: 300000, Status: ReconciliationNoRecords},
+	}, reconciliations)
+}
+
+func TestGetAllNodesEarnedComponents(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	first := startFakeNode(t, ctx, 1, "first", &fakeNode{earnedSatellites: []*multinodepb.EarnedSatellite{
+		{SatelliteId: storj.NodeID{1}, Total: 1000000, Components: &multinodepb.EarnedComponents{
+			CompAtRest: 400000, CompGet: 300000, CompGetRepair: 200000, CompGetAudit: 100000,
+		}},
+		// earnings of satellite without components are unattributed.
+		{SatelliteId: storj.NodeID{2}, Total: 500000},
+	}})
+	second := startFakeNode(t, ctx, 2, "second", &fakeNode{earnedSatellites: []*multinodepb.EarnedSatellite{
+		{SatelliteId: storj.NodeID{1}, Total: 200000, Components: &multinodepb.EarnedComponents{CompAtRest: 200000}},
+	}})
+
+	db := &nodesDB{list: []nodes.Node{first, second, {ID: testrand.NodeID(), Name: "unreachable"}}}
+	service := NewService(zaptest.NewLogger(t), newFakeNodeDialer(t), db, Config{})
+
+	components, err := service.GetAllNodesEarnedComponents(ctx)
+	require.NoError(t, err)
+	require.Equal(t, EarnedComponents{
+		EarnedComponents: nodepayouts.EarnedComponents{AtRest: 600000, Get: 300000, GetRepair: 200000, GetAudit: 100000},
+		Unattributed:     500000,
+	}, components)
+	require.EqualValues(t, 1700000, components.Total())
+}
+
+func TestCheckSatelliteMembership(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	expected, extra := storj.NodeID{1}, storj.NodeID{2}
+	matching := startFakeNode(t, ctx, 1, "matching", &fakeNode{satellites: []storj.NodeID{expected}})
+	mismatched := startFakeNode(t, ctx, 2, "mismatched", &fakeNode{satellites: []storj.NodeID{extra}})
+
+	db := &nodesDB{list: []nodes.Node{matching, mismatched, {ID: testrand.NodeID(), Name: "unreachable"}}}
+	service := NewService(zaptest.NewLogger(t), newFakeNodeDialer(t), db, Config{})
+
+	memberships, err := service.CheckSatelliteMembership(ctx, []storj.NodeID{expected})
+	require.NoError(t, err)
+	require.Equal(t, []SatelliteMembership{
+		{NodeID: mismatched.ID, NodeName: "mismatched", Extra: storj.NodeIDList{extra}, Missing: storj.NodeIDList{expected}},
+	}, memberships)
+}
+
+func TestGetHeldHistory(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	first, second := storj.NodeID{1}, storj.NodeID{2}
+	a := startFakeNode(t, ctx, 1, "a", &fakeNode{heldHistory: []*multinodepb.HeldHistoryEntry{
+		{SatelliteId: first, Period: "2021-01", Held: 100000},
+		{SatelliteId: first, Period: "2021-02", Held: 50000, Disposed: 20000},
+		{SatelliteId: second, Period: "2021-01", Held: 10000},
+	}})
+	b := startFakeNode(t, ctx, 2, "b", &fakeNode{heldHistory: []*multinodepb.HeldHistoryEntry{
+		{SatelliteId: first, Period: "2021-01", Held: 200000},
+	}})
+
+	db := &nodesDB{list: []nodes.Node{a, b, {ID: testrand.NodeID(), Name: "unreachable"}}}
+	service := NewService(zaptest.NewLogger(t), newFakeNodeDialer(t), db, Config{})
+
+	// amounts are summed across nodes per satellite and period.
+	history, err := service.GetHeldHistory(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []HeldHistoryPeriod{
+		{SatelliteID: first, Period: "2021-01", Held: 300000},
+		{SatelliteID: first, Period: "2021-02", Held: 50000, Disposed: 20000},
+		{SatelliteID: second, Period: "2021-01", Held: 10000},
+	}, history)
+}
+
+func TestCheckPermissions(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	node := startFakeNode(t, ctx, 1, "node", &fakeNode{})
+	service := NewService(zaptest.NewLogger(t), newFakeNodeDialer(t), &nodesDB{}, Config{})
+
+	permissions, err := service.CheckPermissions(ctx, node)
+	require.NoError(t, err)
+	require.Equal(t, node.ID, permissions.NodeID)
+	require.Equal(t, "node", permissions.NodeName)
+	require.False(t, permissions.Unauthenticated)
+	require.Len(t, permissions.Categories, len(permissionProbes))
+	for _, category := range permissions.Categories {
+		require.Equal(t, PermissionGranted, category.Status, category.Category)
+	}
+
+	_, ok := service.LastContact(node.ID)
+	require.True(t, ok)
+}
+
+func TestSatelliteSummaryGroupedRequest(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	satellite := storj.NodeID{1}
+	endpoints := &fakeNode{satellitePeriods: map[storj.NodeID]map[string]*multinodepb.PayoutInfo{
+		satellite: {"2021-05": {Held: 100, Paid: 200}},
+	}}
+	first := startFakeNode(t, ctx, 1, "first", endpoints)
+	// second node is served by the same process.
+	second := nodes.Node{ID: testrand.NodeID(), Name: "second", PublicAddress: first.PublicAddress}
+
+	db := &nodesDB{list: []nodes.Node{first, second}}
+	service := NewService(zaptest.NewLogger(t), newFakeNodeDialer(t), db, Config{})
+
+	summary, err := service.NodesSatellitePeriodSummary(ctx, satellite, "2021-05")
+	require.NoError(t, err)
+	require.EqualValues(t, 1, atomic.LoadInt64(&endpoints.groupRequests))
+	require.EqualValues(t, 600, summary.TotalEarned)
+	require.Len(t, summary.NodeSummary, 2)
+
+	// group of the same node doesn't need a grouped request.
+	infos, err := service.groupSatelliteSummaries(ctx, []nodes.Node{first, first}, satellite, "2021-05")
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+	require.EqualValues(t, 1, atomic.LoadInt64(&endpoints.groupRequests))
+}
+
+func TestSatelliteSummaryGroupFallback(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	satellite := storj.NodeID{1}
+	endpoints := &fakeNode{
+		groupUnsupported: true,
+		satellitePeriods: map[storj.NodeID]map[string]*multinodepb.PayoutInfo{
+			satellite: {"2021-05": {Held: 100, Paid: 200}},
+		},
+	}
+	first := startFakeNode(t, ctx, 1, "first", endpoints)
+	second := nodes.Node{ID: testrand.NodeID(), Name: "second", PublicAddress: first.PublicAddress}
+
+	db := &nodesDB{list: []nodes.Node{first, second}}
+	service := NewService(zaptest.NewLogger(t), newFakeNodeDialer(t), db, Config{
+		CircuitBreaker: CircuitBreakerConfig{FailureThreshold: 1, Cooldown: time.Hour},
+	})
+	service.breaker.Failure(second.ID)
+
+	// nodes are queried one by one, when the process doesn't support grouped requests.
+	summary, err := service.NodesSatellitePeriodSummary(ctx, satellite, "2021-05")
+	require.NoError(t, err)
+	require.EqualValues(t, 1, atomic.LoadInt64(&endpoints.groupRequests))
+	require.Len(t, summary.NodeSummary, 2)
+
+	require.Equal(t, first.ID, summary.NodeSummary[0].NodeID)
+	require.EqualValues(t, 100, summary.NodeSummary[0].Held)
+	require.EqualValues(t, 200, summary.NodeSummary[0].Paid)
+	require.False(t, summary.NodeSummary[0].CircuitOpen)
+
+	require.Equal(t, second.ID, summary.NodeSummary[1].NodeID)
+	require.True(t, summary.NodeSummary[1].CircuitOpen)
+}
+
+func TestGetChartData(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	first := startFakeNode(t, ctx, 1, "first", &fakeNode{periods: map[string]*multinodepb.PayoutInfo{
+		"2021-01": {Held: 100000, Paid: 200000},
+		"2021-02": {Held: 50000, Paid: 400000},
+	}})
+	second := startFakeNode(t, ctx, 2, "second", &fakeNode{periods: map[string]*multinodepb.PayoutInfo{
+		"2021-02": {Paid: 1000000},
+	}})
+
+	db := &nodesDB{list: []nodes.Node{first, second, {ID: testrand.NodeID(), Name: "unreachable"}}}
+	service := NewService(zaptest.NewLogger(t), newFakeNodeDialer(t), db, Config{})
+
+	periods := []string{"2021-01", "2021-02", "2021-03"}
+	points, err := service.GetChartData(ctx, ChartPaid, periods)
+	require.NoError(t, err)
+	require.Equal(t, []ChartPoint{
+		{Period: "2021-01", Value: 200000},
+		{Period: "2021-02", Value: 1400000},
+		{Period: "2021-03"},
+	}, points)
+
+	points, err = service.GetChartData(ctx, ChartEarned, periods)
+	require.NoError(t, err)
+	require.Equal(t, []ChartPoint{
+		{Period: "2021-01", Value: 300000},
+		{Period: "2021-02", Value: 1450000},
+		{Period: "2021-03"},
+	}, points)
+
+	_, err = service.GetChartData(ctx, ChartMetric("surge"), periods)
+	require.Error(t, err)
+}
+
+func TestForecastHeldReturns(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	// held amount of the first period is returned in the current month.
+	now := time.Now().UTC()
+	first := time.Date(now.Year(), now.Month()-15, 1, 0, 0, 0, 0, time.UTC)
+
+	satellite, disposing := storj.NodeID{1}, storj.NodeID{2}
+	node := startFakeNode(t, ctx, 1, "node", &fakeNode{heldHistory: []*multinodepb.HeldHistoryEntry{
+		{SatelliteId: satellite, Period: first.Format("2006-01"), Held: 2000000},
+		{SatelliteId: satellite, Period: first.AddDate(0, 1, 0).Format("2006-01"), Held: 1000000},
+		// held amount which was already disposed is not returned again.
+		{SatelliteId: disposing, Period: first.Format("2006-01"), Held: 1000000, Disposed: 1000000},
+	}})
+
+	db := &nodesDB{list: []nodes.Node{node, {ID: testrand.NodeID(), Name: "unreachable"}}}
+	service := NewService(zaptest.NewLogger(t), newFakeNodeDialer(t), db, Config{})
+
+	forecast, err := service.ForecastHeldReturns(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, forecast, 2)
+	require.Equal(t, now.Format("2006-01"), forecast[0].Period)
+	require.EqualValues(t, 1500000, forecast[0].Returned)
+
+	_, err = service.ForecastHeldReturns(ctx, 0)
+	require.Error(t, err)
+}
@@ -0,0 +1,192 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrSnapshotNotReady is an error class for reading snapshot before the first refresh finished.
+	ErrSnapshotNotReady = errs.Class("snapshot not ready")
+	// ErrSnapshotFile is an error class for failures of persisting or loading snapshot file.
+	ErrSnapshotFile = errs.Class("snapshot file")
+)
+
+// Snapshot contains fleet payouts data collected by the background refresher.
+type Snapshot struct {
+	// Summary contains all time payouts of nodes reached during refresh.
+	Summary Summary `json:"summary"`
+	// Estimated is current month estimated earnings of all reached nodes, in cents.
+	Estimated int64 `json:"estimated"`
+	// NodesTotal is amount of nodes at the time of refresh, NodesReached is amount of nodes which responded.
+	NodesTotal   int `json:"nodesTotal"`
+	NodesReached int `json:"nodesReached"`
+	// StaleSince is the time the snapshot was collected at.
+	StaleSince time.Time `json:"staleSince"`
+}
+
+// Snapshot returns the last snapshot collected by the background refresher, without dialing any node.
+func (service *Service) Snapshot() (Snapshot, error) {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	if service.snapshot == nil {
+		return Snapshot{}, ErrSnapshotNotReady.New("no snapshot was collected yet")
+	}
+
+	return *service.snapshot, nil
+}
+
+// RunEstimateRefresher periodically refreshes the snapshot until ctx is canceled or service is closed.
+// Cancellation interrupts the refresh in progress, the snapshot is not replaced by partially collected data.
+func (service *Service) RunEstimateRefresher(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if service.snapshotPath != "" {
+		// last known state is served until the first refresh finishes.
+		if err := service.LoadSnapshot(service.snapshotPath); err != nil {
+			service.log.Warn("failed to load payouts snapshot, starting empty", zap.String("path", service.snapshotPath), zap.Error(err))
+		}
+	}
+
+	return service.refresher.Run(ctx, func(ctx context.Context) error {
+		if err := service.RefreshSnapshot(ctx); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			service.log.Error("failed to refresh payouts snapshot", zap.Error(err))
+			return nil
+		}
+
+		if service.snapshotPath != "" {
+			if err := service.SaveSnapshot(service.snapshotPath); err != nil {
+				service.log.Error("failed to save payouts snapshot", zap.String("path", service.snapshotPath), zap.Error(err))
+			}
+		}
+		return nil
+	})
+}
+
+// RefreshSnapshot collects payouts of all nodes and replaces the snapshot.
+// Nodes which fail to respond are skipped, previous snapshot is kept when nodes can't be listed
+// or ctx is canceled before all nodes are queried.
+func (service *Service) RefreshSnapshot(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	list, err := service.listNodes(ctx)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	snapshot := Snapshot{
+		NodesTotal: len(list),
+	}
+
+	for _, node := range list {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		info, err := service.getAllSatellitesAllTime(ctx, node)
+		if err != nil {
+			service.log.Warn("failed to get node payouts for snapshot", zap.Stringer("node", node.ID), zap.Error(err))
+			continue
+		}
+
+		estimation, _, err := service.nodeEstimations(ctx, node)
+		if err != nil {
+			service.log.Warn("failed to get node estimations for snapshot", zap.Stringer("node", node.ID), zap.Error(err))
+			continue
+		}
+		service.contacted(node.ID)
+
+		snapshot.Summary.Add(info.Held, info.Paid, node.ID, node.Name)
+		snapshot.Estimated += estimation
+		snapshot.NodesReached++
+	}
+
+	service.fillLastContact(&snapshot.Summary)
+	snapshot.StaleSince = time.Now().UTC()
+
+	service.mu.Lock()
+	service.snapshot = &snapshot
+	service.mu.Unlock()
+
+	return nil
+}
+
+// SaveSnapshot writes the last snapshot to the file at path.
+// The file is replaced atomically, so a crash while saving doesn't corrupt the previously saved snapshot.
+func (service *Service) SaveSnapshot(path string) (err error) {
+	snapshot, err := service.Snapshot()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return ErrSnapshotFile.Wrap(err)
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return ErrSnapshotFile.Wrap(err)
+	}
+	defer func() {
+		if err != nil {
+			err = errs.Combine(err, os.Remove(tmp.Name()))
+		}
+	}()
+
+	_, err = tmp.Write(data)
+	err = errs.Combine(err, tmp.Sync(), tmp.Close())
+	if err != nil {
+		return ErrSnapshotFile.Wrap(err)
+	}
+
+	return ErrSnapshotFile.Wrap(os.Rename(tmp.Name(), path))
+}
+
+// LoadSnapshot replaces the snapshot with the one saved to the file at path.
+// Missing file is not an error, the snapshot is left untouched then. Corrupt file is reported
+// and the snapshot is left untouched as well.
+func (service *Service) LoadSnapshot(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return ErrSnapshotFile.Wrap(err)
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return ErrSnapshotFile.New("corrupt snapshot %q: %v", path, err)
+	}
+
+	service.mu.Lock()
+	service.snapshot = &snapshot
+	service.mu.Unlock()
+
+	return nil
+}
+
+// Close stops the background refresher.
+func (service *Service) Close() error {
+	service.refresher.Close()
+	return nil
+}
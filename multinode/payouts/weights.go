@@ -0,0 +1,98 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"storj.io/common/storj"
+)
+
+// SatelliteWeights holds confidence in estimations of satellites, e.g. to down-weight volatile satellites.
+// Satellites without weight have weight 1.
+type SatelliteWeights map[storj.NodeID]float64
+
+// String formats the satellite weights sorted by satellite id.
+func (weights SatelliteWeights) String() string {
+	ids := make(storj.NodeIDList, 0, len(weights))
+	for id := range weights {
+		ids = append(ids, id)
+	}
+	sort.Sort(ids)
+
+	s := make([]string, 0, len(ids))
+	for _, id := range ids {
+		s = append(s, id.String()+"="+strconv.FormatFloat(weights[id], 'f', -1, 64))
+	}
+	return strings.Join(s, ",")
+}
+
+// Set implements pflag.Value by parsing a comma separated list of satellite id and weight pairs.
+func (weights *SatelliteWeights) Set(value string) error {
+	var entries []string
+	if value != "" {
+		entries = strings.Split(value, ",")
+	}
+
+	toSet := make(SatelliteWeights, len(entries))
+	for _, entry := range entries {
+		idx := strings.IndexByte(entry, '=')
+		if idx < 0 {
+			return Error.New("invalid satellite weight %q: must be id=weight", entry)
+		}
+
+		id, err := storj.NodeIDFromString(strings.TrimSpace(entry[:idx]))
+		if err != nil {
+			return Error.New("invalid satellite id %q: %w", entry[:idx], err)
+		}
+
+		weight, err := strconv.ParseFloat(strings.TrimSpace(entry[idx+1:]), 64)
+		if err != nil || weight < 0 || math.IsInf(weight, 0) {
+			return Error.New("invalid weight %q of satellite %s: must be a non-negative number", entry[idx+1:], id)
+		}
+
+		toSet[id] = weight
+	}
+
+	*weights = toSet
+	return nil
+}
+
+// Type returns the type of the pflag.Value.
+func (weights SatelliteWeights) Type() string {
+	return "satellite-weights"
+}
+
+// Weight returns weight of the satellite, 1 when not set.
+func (weights SatelliteWeights) Weight(satelliteID storj.NodeID) float64 {
+	if weight, ok := weights[satelliteID]; ok {
+		return weight
+	}
+	return 1
+}
+
+// Weighted returns ids of satellites with weight other than 1, sorted.
+func (weights SatelliteWeights) Weighted() storj.NodeIDList {
+	var ids storj.NodeIDList
+	for id, weight := range weights {
+		if weight != 1 {
+			ids = append(ids, id)
+		}
+	}
+	sort.Sort(ids)
+	return ids
+}
+
+// WeightedEstimate returns total estimation with estimations of the satellites scaled by their weights.
+// The perSatellite estimations need to contain only the satellites with weight other than 1.
+func WeightedEstimate(total int64, perSatellite map[storj.NodeID]int64, weights SatelliteWeights) int64 {
+	weighted := float64(total)
+	for satelliteID, estimated := range perSatellite {
+		weighted += (weights.Weight(satelliteID) - 1) * float64(estimated)
+	}
+	return int64(math.Round(weighted))
+}
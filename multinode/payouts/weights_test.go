@@ -0,0 +1,57 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/storj"
+	"storj.io/common/testrand"
+	"storj.io/storj/multinode/payouts"
+)
+
+func TestSatelliteWeightsSet(t *testing.T) {
+	volatile, stable := testrand.NodeID(), testrand.NodeID()
+
+	var weights payouts.SatelliteWeights
+	require.NoError(t, weights.Set(volatile.String()+"=0.5, "+stable.String()+"=1"))
+	require.Equal(t, payouts.SatelliteWeights{volatile: 0.5, stable: 1}, weights)
+	require.Equal(t, 0.5, weights.Weight(volatile))
+	require.Equal(t, 1.0, weights.Weight(testrand.NodeID()))
+	require.Equal(t, storj.NodeIDList{volatile}, weights.Weighted())
+
+	var parsed payouts.SatelliteWeights
+	require.NoError(t, parsed.Set(weights.String()))
+	require.Equal(t, weights, parsed)
+
+	require.NoError(t, weights.Set(""))
+	require.Empty(t, weights)
+
+	for _, invalid := range []string{
+		volatile.String(),
+		volatile.String() + "=-1",
+		volatile.String() + "=high",
+		"satellite=0.5",
+	} {
+		require.Error(t, weights.Set(invalid), invalid)
+	}
+}
+
+func TestWeightedEstimate(t *testing.T) {
+	volatile, unreliable, stable := testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+	weights := payouts.SatelliteWeights{volatile: 0.5, unreliable: 0, stable: 1}
+
+	// total 1000 of which 400 on volatile, 100 on unreliable and the rest on unweighted satellites.
+	perSatellite := map[storj.NodeID]int64{volatile: 400, unreliable: 100}
+	require.EqualValues(t, 700, payouts.WeightedEstimate(1000, perSatellite, weights))
+
+	// without weighted satellites both estimates are equal.
+	require.EqualValues(t, 1000, payouts.WeightedEstimate(1000, nil, weights))
+	require.EqualValues(t, 1000, payouts.WeightedEstimate(1000, nil, nil))
+
+	// weights above 1 increase the estimate.
+	require.EqualValues(t, 1401, payouts.WeightedEstimate(1000, map[storj.NodeID]int64{volatile: 401}, payouts.SatelliteWeights{volatile: 2}))
+}
@@ -8,6 +8,7 @@ import (
 	"net"
 
 	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 
@@ -44,6 +45,7 @@ type Config struct {
 	Debug    debug.Config
 
 	Console server.Config
+	Payouts payouts.Config
 }
 
 // Peer is the a Multinode Dashboard application itself.
@@ -73,7 +75,8 @@ type Peer struct {
 		Endpoint *server.Server
 	}
 
-	Servers *lifecycle.Group
+	Servers  *lifecycle.Group
+	Services *lifecycle.Group
 }
 
 // New creates a new instance of Multinode Dashboard application.
@@ -83,6 +86,7 @@ func New(log *zap.Logger, full *identity.FullIdentity, config Config, db DB) (_
 		Identity: full,
 		DB:       db,
 		Servers:  lifecycle.NewGroup(log.Named("servers")),
+		Services: lifecycle.NewGroup(log.Named("services")),
 	}
 
 	tlsConfig := tlsopts.Config{
@@ -110,7 +114,14 @@ func New(log *zap.Logger, full *identity.FullIdentity, config Config, db DB) (_
 			peer.Log.Named("payouts:service"),
 			peer.Dialer,
 			peer.DB.Nodes(),
+			config.Payouts,
 		)
+
+		peer.Services.Add(lifecycle.Item{
+			Name:  "payouts:refresher",
+			Run:   peer.Payouts.Service.RunEstimateRefresher,
+			Close: peer.Payouts.Service.Close,
+		})
 	}
 
 	{ // console setup
@@ -147,11 +158,15 @@ func (peer *Peer) Run(ctx context.Context) (err error) {
 	group, ctx := errgroup.WithContext(ctx)
 
 	peer.Servers.Run(ctx, group)
+	peer.Services.Run(ctx, group)
 
 	return group.Wait()
 }
 
 // Close closes all the resources.
 func (peer *Peer) Close() error {
-	return peer.Servers.Close()
+	return errs.Combine(
+		peer.Servers.Close(),
+		peer.Services.Close(),
+	)
 }
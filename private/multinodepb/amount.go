@@ -0,0 +1,34 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package multinodepb
+
+import (
+	"strconv"
+	"strings"
+)
+
+// FormatAmount formats amount in minor units described by unit, e.g. 12345678 with 6 decimals USD is "12.345678 USD".
+// Integer math is used, so the formatted value always matches the raw amount exactly.
+func FormatAmount(amount int64, unit *AmountUnit) string {
+	decimals := int(unit.GetDecimals())
+
+	var sign string
+	digits := strconv.FormatInt(amount, 10)
+	if amount < 0 {
+		sign, digits = "-", digits[1:]
+	}
+
+	formatted := digits
+	if decimals > 0 {
+		if len(digits) <= decimals {
+			digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+		}
+		formatted = digits[:len(digits)-decimals] + "." + digits[len(digits)-decimals:]
+	}
+
+	if unit.GetCurrency() == "" {
+		return sign + formatted
+	}
+	return sign + formatted + " " + unit.GetCurrency()
+}
@@ -0,0 +1,33 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package multinodepb_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/private/multinodepb"
+)
+
+func TestFormatAmount(t *testing.T) {
+	usd := &multinodepb.AmountUnit{Currency: "USD", Decimals: 6}
+
+	for _, tt := range []struct {
+		amount   int64
+		unit     *multinodepb.AmountUnit
+		expected string
+	}{
+		{amount: 0, unit: usd, expected: "0.000000 USD"},
+		{amount: 1, unit: usd, expected: "0.000001 USD"},
+		{amount: 12345678, unit: usd, expected: "12.345678 USD"},
+		{amount: 1000000, unit: usd, expected: "1.000000 USD"},
+		{amount: -250000, unit: usd, expected: "-0.250000 USD"},
+		{amount: 1234, unit: &multinodepb.AmountUnit{Currency: "USD", Decimals: 2}, expected: "12.34 USD"},
+		{amount: 42, unit: &multinodepb.AmountUnit{Currency: "STORJ"}, expected: "42 STORJ"},
+		{amount: 5, unit: nil, expected: "5"},
+	} {
+		require.Equal(t, tt.expected, multinodepb.FormatAmount(tt.amount, tt.unit), tt.amount)
+	}
+}
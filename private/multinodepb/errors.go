@@ -0,0 +1,74 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package multinodepb
+
+import (
+	"encoding/json"
+	"strings"
+
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/common/storj"
+)
+
+// Error codes used in ErrorDetails.
+const (
+	// ErrorCodeDatabase indicates that storagenode failed to query its database.
+	ErrorCodeDatabase = "database"
+	// ErrorCodeEstimation indicates that storagenode failed to estimate payouts.
+	ErrorCodeEstimation = "estimation"
+)
+
+// errorDetailsSeparator separates error message from encoded error details.
+const errorDetailsSeparator = "; details: "
+
+// ErrorDetails contains structured details about failed multinode rpc.
+// It must not contain any sensitive information, since it's sent to the client as is.
+type ErrorDetails struct {
+	Code        string       `json:"code"`
+	SatelliteID storj.NodeID `json:"satelliteId"`
+	Period      string       `json:"period,omitempty"`
+}
+
+// Error creates rpc error with provided status code, message and encoded error details.
+func (details ErrorDetails) Error(code rpcstatus.StatusCode, message string) error {
+	encoded, err := json.Marshal(details)
+	if err != nil {
+		return rpcstatus.Error(code, message)
+	}
+
+	return rpcstatus.Error(code, message+errorDetailsSeparator+string(encoded))
+}
+
+// String returns human readable representation of error details.
+func (details ErrorDetails) String() string {
+	var b strings.Builder
+	b.WriteString("code: " + details.Code)
+	if !details.SatelliteID.IsZero() {
+		b.WriteString(", satellite: " + details.SatelliteID.String())
+	}
+	if details.Period != "" {
+		b.WriteString(", period: " + details.Period)
+	}
+	return b.String()
+}
+
+// ParseErrorDetails extracts error details from error returned by multinode rpc.
+func ParseErrorDetails(err error) (_ ErrorDetails, ok bool) {
+	if err == nil {
+		return ErrorDetails{}, false
+	}
+
+	message := err.Error()
+	index := strings.LastIndex(message, errorDetailsSeparator)
+	if index < 0 {
+		return ErrorDetails{}, false
+	}
+
+	var details ErrorDetails
+	if err := json.Unmarshal([]byte(message[index+len(errorDetailsSeparator):]), &details); err != nil {
+		return ErrorDetails{}, false
+	}
+
+	return details, true
+}
@@ -0,0 +1,51 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package multinodepb_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zeebo/errs"
+
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/common/testrand"
+	"storj.io/drpc/drpcerr"
+	"storj.io/storj/private/multinodepb"
+)
+
+func TestErrorDetails(t *testing.T) {
+	details := multinodepb.ErrorDetails{
+		Code:        multinodepb.ErrorCodeDatabase,
+		SatelliteID: testrand.NodeID(),
+		Period:      "2021-05",
+	}
+
+	serverErr := details.Error(rpcstatus.Internal, "failed to get satellite period summary")
+	require.Equal(t, rpcstatus.Internal, rpcstatus.Code(serverErr))
+
+	// drpc transfers only error message and code to the client.
+	clientErr := errs.Wrap(drpcerr.WithCode(errs.New("%s", serverErr.Error()), uint64(rpcstatus.Code(serverErr))))
+	require.Equal(t, rpcstatus.Internal, rpcstatus.Code(clientErr))
+
+	parsed, ok := multinodepb.ParseErrorDetails(clientErr)
+	require.True(t, ok)
+	require.Equal(t, details, parsed)
+	require.Contains(t, parsed.String(), details.SatelliteID.String())
+	require.Contains(t, parsed.String(), details.Period)
+}
+
+func TestErrorDetailsMissing(t *testing.T) {
+	_, ok := multinodepb.ParseErrorDetails(nil)
+	require.False(t, ok)
+
+	_, ok = multinodepb.ParseErrorDetails(rpcstatus.Error(rpcstatus.Internal, "database is locked"))
+	require.False(t, ok)
+
+	details := multinodepb.ErrorDetails{Code: multinodepb.ErrorCodeEstimation}
+	parsed, ok := multinodepb.ParseErrorDetails(details.Error(rpcstatus.Internal, "failed to estimate payout"))
+	require.True(t, ok)
+	require.Equal(t, details, parsed)
+	require.Equal(t, "code: estimation", parsed.String())
+}
@@ -25,6 +25,8 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 type RequestHeader struct {
 	ApiKey               []byte   `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	ClientVersion        string   `protobuf:"bytes,2,opt,name=client_version,json=clientVersion,proto3" json:"client_version,omitempty"`
+	TraceId              string   `protobuf:"bytes,3,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -61,6 +63,20 @@ func (m *RequestHeader) GetApiKey() []byte {
 	return nil
 }
 
+func (m *RequestHeader) GetClientVersion() string {
+	if m != nil {
+		return m.ClientVersion
+	}
+	return ""
+}
+
+func (m *RequestHeader) GetTraceId() string {
+	if m != nil {
+		return m.TraceId
+	}
+	return ""
+}
+
 type DiskSpaceRequest struct {
 	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
@@ -217,6 +233,7 @@ func (m *BandwidthMonthSummaryRequest) GetHeader() *RequestHeader {
 
 type BandwidthMonthSummaryResponse struct {
 	Used                 int64    `protobuf:"varint,1,opt,name=used,proto3" json:"used,omitempty"`
+	Egress               int64    `protobuf:"varint,2,opt,name=egress,proto3" json:"egress,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -253,6 +270,13 @@ func (m *BandwidthMonthSummaryResponse) GetUsed() int64 {
 	return 0
 }
 
+func (m *BandwidthMonthSummaryResponse) GetEgress() int64 {
+	if m != nil {
+		return m.Egress
+	}
+	return 0
+}
+
 type VersionRequest struct {
 	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
@@ -689,19 +713,302 @@ func (m *TrustedSatellitesResponse_NodeURL) GetAddress() string {
 	return ""
 }
 
-type EstimatedPayoutSatelliteRequest struct {
+type SatelliteCheckInsRequest struct {
+	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *SatelliteCheckInsRequest) Reset()         { *m = SatelliteCheckInsRequest{} }
+func (m *SatelliteCheckInsRequest) String() string { return proto.CompactTextString(m) }
+func (*SatelliteCheckInsRequest) ProtoMessage()    {}
+func (*SatelliteCheckInsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{13}
+}
+func (m *SatelliteCheckInsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SatelliteCheckInsRequest.Unmarshal(m, b)
+}
+func (m *SatelliteCheckInsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SatelliteCheckInsRequest.Marshal(b, m, deterministic)
+}
+func (m *SatelliteCheckInsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SatelliteCheckInsRequest.Merge(m, src)
+}
+func (m *SatelliteCheckInsRequest) XXX_Size() int {
+	return xxx_messageInfo_SatelliteCheckInsRequest.Size(m)
+}
+func (m *SatelliteCheckInsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SatelliteCheckInsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SatelliteCheckInsRequest proto.InternalMessageInfo
+
+func (m *SatelliteCheckInsRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+type SatelliteCheckInsResponse struct {
+	CheckIns             []*SatelliteCheckInsResponse_CheckIn `protobuf:"bytes,1,rep,name=check_ins,json=checkIns,proto3" json:"check_ins,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                             `json:"-"`
+	XXX_unrecognized     []byte                               `json:"-"`
+	XXX_sizecache        int32                                `json:"-"`
+}
+
+func (m *SatelliteCheckInsResponse) Reset()         { *m = SatelliteCheckInsResponse{} }
+func (m *SatelliteCheckInsResponse) String() string { return proto.CompactTextString(m) }
+func (*SatelliteCheckInsResponse) ProtoMessage()    {}
+func (*SatelliteCheckInsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{14}
+}
+func (m *SatelliteCheckInsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SatelliteCheckInsResponse.Unmarshal(m, b)
+}
+func (m *SatelliteCheckInsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SatelliteCheckInsResponse.Marshal(b, m, deterministic)
+}
+func (m *SatelliteCheckInsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SatelliteCheckInsResponse.Merge(m, src)
+}
+func (m *SatelliteCheckInsResponse) XXX_Size() int {
+	return xxx_messageInfo_SatelliteCheckInsResponse.Size(m)
+}
+func (m *SatelliteCheckInsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SatelliteCheckInsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SatelliteCheckInsResponse proto.InternalMessageInfo
+
+func (m *SatelliteCheckInsResponse) GetCheckIns() []*SatelliteCheckInsResponse_CheckIn {
+	if m != nil {
+		return m.CheckIns
+	}
+	return nil
+}
+
+type SatelliteCheckInsResponse_CheckIn struct {
+	SatelliteId          NodeID    `protobuf:"bytes,1,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
+	Attempted            bool      `protobuf:"varint,2,opt,name=attempted,proto3" json:"attempted,omitempty"`
+	Succeeded            bool      `protobuf:"varint,3,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	CheckedAt            time.Time `protobuf:"bytes,4,opt,name=checked_at,json=checkedAt,proto3,stdtime" json:"checked_at"`
+	Error                string    `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *SatelliteCheckInsResponse_CheckIn) Reset()         { *m = SatelliteCheckInsResponse_CheckIn{} }
+func (m *SatelliteCheckInsResponse_CheckIn) String() string { return proto.CompactTextString(m) }
+func (*SatelliteCheckInsResponse_CheckIn) ProtoMessage()    {}
+func (*SatelliteCheckInsResponse_CheckIn) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{14, 0}
+}
+func (m *SatelliteCheckInsResponse_CheckIn) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SatelliteCheckInsResponse_CheckIn.Unmarshal(m, b)
+}
+func (m *SatelliteCheckInsResponse_CheckIn) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SatelliteCheckInsResponse_CheckIn.Marshal(b, m, deterministic)
+}
+func (m *SatelliteCheckInsResponse_CheckIn) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SatelliteCheckInsResponse_CheckIn.Merge(m, src)
+}
+func (m *SatelliteCheckInsResponse_CheckIn) XXX_Size() int {
+	return xxx_messageInfo_SatelliteCheckInsResponse_CheckIn.Size(m)
+}
+func (m *SatelliteCheckInsResponse_CheckIn) XXX_DiscardUnknown() {
+	xxx_messageInfo_SatelliteCheckInsResponse_CheckIn.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SatelliteCheckInsResponse_CheckIn proto.InternalMessageInfo
+
+func (m *SatelliteCheckInsResponse_CheckIn) GetAttempted() bool {
+	if m != nil {
+		return m.Attempted
+	}
+	return false
+}
+
+func (m *SatelliteCheckInsResponse_CheckIn) GetSucceeded() bool {
+	if m != nil {
+		return m.Succeeded
+	}
+	return false
+}
+
+func (m *SatelliteCheckInsResponse_CheckIn) GetCheckedAt() time.Time {
+	if m != nil {
+		return m.CheckedAt
+	}
+	return time.Time{}
+}
+
+func (m *SatelliteCheckInsResponse_CheckIn) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type ReputationScoresRequest struct {
 	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	SatelliteId          NodeID         `protobuf:"bytes,2,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
 	XXX_unrecognized     []byte         `json:"-"`
 	XXX_sizecache        int32          `json:"-"`
 }
 
+func (m *ReputationScoresRequest) Reset()         { *m = ReputationScoresRequest{} }
+func (m *ReputationScoresRequest) String() string { return proto.CompactTextString(m) }
+func (*ReputationScoresRequest) ProtoMessage()    {}
+func (*ReputationScoresRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{15}
+}
+func (m *ReputationScoresRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReputationScoresRequest.Unmarshal(m, b)
+}
+func (m *ReputationScoresRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReputationScoresRequest.Marshal(b, m, deterministic)
+}
+func (m *ReputationScoresRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReputationScoresRequest.Merge(m, src)
+}
+func (m *ReputationScoresRequest) XXX_Size() int {
+	return xxx_messageInfo_ReputationScoresRequest.Size(m)
+}
+func (m *ReputationScoresRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReputationScoresRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReputationScoresRequest proto.InternalMessageInfo
+
+func (m *ReputationScoresRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+type ReputationScoresResponse struct {
+	Scores               []*ReputationScoresResponse_SatelliteScores `protobuf:"bytes,1,rep,name=scores,proto3" json:"scores,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                    `json:"-"`
+	XXX_unrecognized     []byte                                      `json:"-"`
+	XXX_sizecache        int32                                       `json:"-"`
+}
+
+func (m *ReputationScoresResponse) Reset()         { *m = ReputationScoresResponse{} }
+func (m *ReputationScoresResponse) String() string { return proto.CompactTextString(m) }
+func (*ReputationScoresResponse) ProtoMessage()    {}
+func (*ReputationScoresResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{16}
+}
+func (m *ReputationScoresResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReputationScoresResponse.Unmarshal(m, b)
+}
+func (m *ReputationScoresResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReputationScoresResponse.Marshal(b, m, deterministic)
+}
+func (m *ReputationScoresResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReputationScoresResponse.Merge(m, src)
+}
+func (m *ReputationScoresResponse) XXX_Size() int {
+	return xxx_messageInfo_ReputationScoresResponse.Size(m)
+}
+func (m *ReputationScoresResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReputationScoresResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReputationScoresResponse proto.InternalMessageInfo
+
+func (m *ReputationScoresResponse) GetScores() []*ReputationScoresResponse_SatelliteScores {
+	if m != nil {
+		return m.Scores
+	}
+	return nil
+}
+
+type ReputationScoresResponse_SatelliteScores struct {
+	SatelliteId NodeID `protobuf:"bytes,1,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
+	// reported is false when the satellite hasn't sent reputation stats to the node yet, scores are zero then.
+	Reported             bool     `protobuf:"varint,2,opt,name=reported,proto3" json:"reported,omitempty"`
+	AuditScore           float64  `protobuf:"fixed64,3,opt,name=audit_score,json=auditScore,proto3" json:"audit_score,omitempty"`
+	SuspensionScore      float64  `protobuf:"fixed64,4,opt,name=suspension_score,json=suspensionScore,proto3" json:"suspension_score,omitempty"`
+	OnlineScore          float64  `protobuf:"fixed64,5,opt,name=online_score,json=onlineScore,proto3" json:"online_score,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReputationScoresResponse_SatelliteScores) Reset() {
+	*m = ReputationScoresResponse_SatelliteScores{}
+}
+func (m *ReputationScoresResponse_SatelliteScores) String() string { return proto.CompactTextString(m) }
+func (*ReputationScoresResponse_SatelliteScores) ProtoMessage()    {}
+func (*ReputationScoresResponse_SatelliteScores) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{16, 0}
+}
+func (m *ReputationScoresResponse_SatelliteScores) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReputationScoresResponse_SatelliteScores.Unmarshal(m, b)
+}
+func (m *ReputationScoresResponse_SatelliteScores) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReputationScoresResponse_SatelliteScores.Marshal(b, m, deterministic)
+}
+func (m *ReputationScoresResponse_SatelliteScores) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReputationScoresResponse_SatelliteScores.Merge(m, src)
+}
+func (m *ReputationScoresResponse_SatelliteScores) XXX_Size() int {
+	return xxx_messageInfo_ReputationScoresResponse_SatelliteScores.Size(m)
+}
+func (m *ReputationScoresResponse_SatelliteScores) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReputationScoresResponse_SatelliteScores.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReputationScoresResponse_SatelliteScores proto.InternalMessageInfo
+
+func (m *ReputationScoresResponse_SatelliteScores) GetReported() bool {
+	if m != nil {
+		return m.Reported
+	}
+	return false
+}
+
+func (m *ReputationScoresResponse_SatelliteScores) GetAuditScore() float64 {
+	if m != nil {
+		return m.AuditScore
+	}
+	return 0
+}
+
+func (m *ReputationScoresResponse_SatelliteScores) GetSuspensionScore() float64 {
+	if m != nil {
+		return m.SuspensionScore
+	}
+	return 0
+}
+
+func (m *ReputationScoresResponse_SatelliteScores) GetOnlineScore() float64 {
+	if m != nil {
+		return m.OnlineScore
+	}
+	return 0
+}
+
+type EstimatedPayoutSatelliteRequest struct {
+	Header      *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	SatelliteId NodeID         `protobuf:"bytes,2,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
+	// as_of overrides the node clock used for estimation, when set.
+	AsOf                 time.Time `protobuf:"bytes,3,opt,name=as_of,json=asOf,proto3,stdtime" json:"as_of"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
 func (m *EstimatedPayoutSatelliteRequest) Reset()         { *m = EstimatedPayoutSatelliteRequest{} }
 func (m *EstimatedPayoutSatelliteRequest) String() string { return proto.CompactTextString(m) }
 func (*EstimatedPayoutSatelliteRequest) ProtoMessage()    {}
 func (*EstimatedPayoutSatelliteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9a45fd79b06f3a1b, []int{13}
+	return fileDescriptor_9a45fd79b06f3a1b, []int{17}
 }
 func (m *EstimatedPayoutSatelliteRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EstimatedPayoutSatelliteRequest.Unmarshal(m, b)
@@ -728,18 +1035,26 @@ func (m *EstimatedPayoutSatelliteRequest) GetHeader() *RequestHeader {
 	return nil
 }
 
+func (m *EstimatedPayoutSatelliteRequest) GetAsOf() time.Time {
+	if m != nil {
+		return m.AsOf
+	}
+	return time.Time{}
+}
+
 type EstimatedPayoutSatelliteResponse struct {
-	EstimatedEarnings    int64    `protobuf:"varint,1,opt,name=estimated_earnings,json=estimatedEarnings,proto3" json:"estimated_earnings,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	EstimatedEarnings    int64       `protobuf:"varint,1,opt,name=estimated_earnings,json=estimatedEarnings,proto3" json:"estimated_earnings,omitempty"`
+	Unit                 *AmountUnit `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *EstimatedPayoutSatelliteResponse) Reset()         { *m = EstimatedPayoutSatelliteResponse{} }
 func (m *EstimatedPayoutSatelliteResponse) String() string { return proto.CompactTextString(m) }
 func (*EstimatedPayoutSatelliteResponse) ProtoMessage()    {}
 func (*EstimatedPayoutSatelliteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9a45fd79b06f3a1b, []int{14}
+	return fileDescriptor_9a45fd79b06f3a1b, []int{18}
 }
 func (m *EstimatedPayoutSatelliteResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EstimatedPayoutSatelliteResponse.Unmarshal(m, b)
@@ -766,18 +1081,27 @@ func (m *EstimatedPayoutSatelliteResponse) GetEstimatedEarnings() int64 {
 	return 0
 }
 
+func (m *EstimatedPayoutSatelliteResponse) GetUnit() *AmountUnit {
+	if m != nil {
+		return m.Unit
+	}
+	return nil
+}
+
 type EstimatedPayoutTotalRequest struct {
-	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	Header *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// as_of overrides the node clock used for estimation, when set.
+	AsOf                 time.Time `protobuf:"bytes,2,opt,name=as_of,json=asOf,proto3,stdtime" json:"as_of"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
 func (m *EstimatedPayoutTotalRequest) Reset()         { *m = EstimatedPayoutTotalRequest{} }
 func (m *EstimatedPayoutTotalRequest) String() string { return proto.CompactTextString(m) }
 func (*EstimatedPayoutTotalRequest) ProtoMessage()    {}
 func (*EstimatedPayoutTotalRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9a45fd79b06f3a1b, []int{15}
+	return fileDescriptor_9a45fd79b06f3a1b, []int{19}
 }
 func (m *EstimatedPayoutTotalRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EstimatedPayoutTotalRequest.Unmarshal(m, b)
@@ -804,8 +1128,20 @@ func (m *EstimatedPayoutTotalRequest) GetHeader() *RequestHeader {
 	return nil
 }
 
+func (m *EstimatedPayoutTotalRequest) GetAsOf() time.Time {
+	if m != nil {
+		return m.AsOf
+	}
+	return time.Time{}
+}
+
 type EstimatedPayoutTotalResponse struct {
-	EstimatedEarnings    int64    `protobuf:"varint,1,opt,name=estimated_earnings,json=estimatedEarnings,proto3" json:"estimated_earnings,omitempty"`
+	EstimatedEarnings int64       `protobuf:"varint,1,opt,name=estimated_earnings,json=estimatedEarnings,proto3" json:"estimated_earnings,omitempty"`
+	Unit              *AmountUnit `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
+	// previous_month_estimated_earnings is the estimate of the completed previous month, in the same unit.
+	PreviousMonthEstimatedEarnings int64 `protobuf:"varint,3,opt,name=previous_month_estimated_earnings,json=previousMonthEstimatedEarnings,proto3" json:"previous_month_estimated_earnings,omitempty"`
+	// previous_month_period is the previous month in YYYY-MM format.
+	PreviousMonthPeriod  string   `protobuf:"bytes,4,opt,name=previous_month_period,json=previousMonthPeriod,proto3" json:"previous_month_period,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -815,7 +1151,7 @@ func (m *EstimatedPayoutTotalResponse) Reset()         { *m = EstimatedPayoutTot
 func (m *EstimatedPayoutTotalResponse) String() string { return proto.CompactTextString(m) }
 func (*EstimatedPayoutTotalResponse) ProtoMessage()    {}
 func (*EstimatedPayoutTotalResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9a45fd79b06f3a1b, []int{16}
+	return fileDescriptor_9a45fd79b06f3a1b, []int{20}
 }
 func (m *EstimatedPayoutTotalResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EstimatedPayoutTotalResponse.Unmarshal(m, b)
@@ -842,8 +1178,30 @@ func (m *EstimatedPayoutTotalResponse) GetEstimatedEarnings() int64 {
 	return 0
 }
 
+func (m *EstimatedPayoutTotalResponse) GetUnit() *AmountUnit {
+	if m != nil {
+		return m.Unit
+	}
+	return nil
+}
+
+func (m *EstimatedPayoutTotalResponse) GetPreviousMonthEstimatedEarnings() int64 {
+	if m != nil {
+		return m.PreviousMonthEstimatedEarnings
+	}
+	return 0
+}
+
+func (m *EstimatedPayoutTotalResponse) GetPreviousMonthPeriod() string {
+	if m != nil {
+		return m.PreviousMonthPeriod
+	}
+	return ""
+}
+
 type AllSatellitesSummaryRequest struct {
 	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Format               bool           `protobuf:"varint,2,opt,name=format,proto3" json:"format,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
 	XXX_unrecognized     []byte         `json:"-"`
 	XXX_sizecache        int32          `json:"-"`
@@ -853,7 +1211,7 @@ func (m *AllSatellitesSummaryRequest) Reset()         { *m = AllSatellitesSummar
 func (m *AllSatellitesSummaryRequest) String() string { return proto.CompactTextString(m) }
 func (*AllSatellitesSummaryRequest) ProtoMessage()    {}
 func (*AllSatellitesSummaryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9a45fd79b06f3a1b, []int{17}
+	return fileDescriptor_9a45fd79b06f3a1b, []int{21}
 }
 func (m *AllSatellitesSummaryRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AllSatellitesSummaryRequest.Unmarshal(m, b)
@@ -880,6 +1238,13 @@ func (m *AllSatellitesSummaryRequest) GetHeader() *RequestHeader {
 	return nil
 }
 
+func (m *AllSatellitesSummaryRequest) GetFormat() bool {
+	if m != nil {
+		return m.Format
+	}
+	return false
+}
+
 type AllSatellitesSummaryResponse struct {
 	PayoutInfo           *PayoutInfo `protobuf:"bytes,1,opt,name=payout_info,json=payoutInfo,proto3" json:"payout_info,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
@@ -891,7 +1256,7 @@ func (m *AllSatellitesSummaryResponse) Reset()         { *m = AllSatellitesSumma
 func (m *AllSatellitesSummaryResponse) String() string { return proto.CompactTextString(m) }
 func (*AllSatellitesSummaryResponse) ProtoMessage()    {}
 func (*AllSatellitesSummaryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9a45fd79b06f3a1b, []int{18}
+	return fileDescriptor_9a45fd79b06f3a1b, []int{22}
 }
 func (m *AllSatellitesSummaryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AllSatellitesSummaryResponse.Unmarshal(m, b)
@@ -921,6 +1286,7 @@ func (m *AllSatellitesSummaryResponse) GetPayoutInfo() *PayoutInfo {
 type AllSatellitesPeriodSummaryRequest struct {
 	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	Period               string         `protobuf:"bytes,2,opt,name=period,proto3" json:"period,omitempty"`
+	Format               bool           `protobuf:"varint,3,opt,name=format,proto3" json:"format,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
 	XXX_unrecognized     []byte         `json:"-"`
 	XXX_sizecache        int32          `json:"-"`
@@ -930,7 +1296,7 @@ func (m *AllSatellitesPeriodSummaryRequest) Reset()         { *m = AllSatellites
 func (m *AllSatellitesPeriodSummaryRequest) String() string { return proto.CompactTextString(m) }
 func (*AllSatellitesPeriodSummaryRequest) ProtoMessage()    {}
 func (*AllSatellitesPeriodSummaryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9a45fd79b06f3a1b, []int{19}
+	return fileDescriptor_9a45fd79b06f3a1b, []int{23}
 }
 func (m *AllSatellitesPeriodSummaryRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AllSatellitesPeriodSummaryRequest.Unmarshal(m, b)
@@ -964,6 +1330,13 @@ func (m *AllSatellitesPeriodSummaryRequest) GetPeriod() string {
 	return ""
 }
 
+func (m *AllSatellitesPeriodSummaryRequest) GetFormat() bool {
+	if m != nil {
+		return m.Format
+	}
+	return false
+}
+
 type AllSatellitesPeriodSummaryResponse struct {
 	PayoutInfo           *PayoutInfo `protobuf:"bytes,1,opt,name=payout_info,json=payoutInfo,proto3" json:"payout_info,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
@@ -975,7 +1348,7 @@ func (m *AllSatellitesPeriodSummaryResponse) Reset()         { *m = AllSatellite
 func (m *AllSatellitesPeriodSummaryResponse) String() string { return proto.CompactTextString(m) }
 func (*AllSatellitesPeriodSummaryResponse) ProtoMessage()    {}
 func (*AllSatellitesPeriodSummaryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9a45fd79b06f3a1b, []int{20}
+	return fileDescriptor_9a45fd79b06f3a1b, []int{24}
 }
 func (m *AllSatellitesPeriodSummaryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AllSatellitesPeriodSummaryResponse.Unmarshal(m, b)
@@ -1005,6 +1378,7 @@ func (m *AllSatellitesPeriodSummaryResponse) GetPayoutInfo() *PayoutInfo {
 type SatelliteSummaryRequest struct {
 	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	SatelliteId          NodeID         `protobuf:"bytes,2,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
+	Format               bool           `protobuf:"varint,3,opt,name=format,proto3" json:"format,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
 	XXX_unrecognized     []byte         `json:"-"`
 	XXX_sizecache        int32          `json:"-"`
@@ -1014,7 +1388,7 @@ func (m *SatelliteSummaryRequest) Reset()         { *m = SatelliteSummaryRequest
 func (m *SatelliteSummaryRequest) String() string { return proto.CompactTextString(m) }
 func (*SatelliteSummaryRequest) ProtoMessage()    {}
 func (*SatelliteSummaryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9a45fd79b06f3a1b, []int{21}
+	return fileDescriptor_9a45fd79b06f3a1b, []int{25}
 }
 func (m *SatelliteSummaryRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SatelliteSummaryRequest.Unmarshal(m, b)
@@ -1041,6 +1415,13 @@ func (m *SatelliteSummaryRequest) GetHeader() *RequestHeader {
 	return nil
 }
 
+func (m *SatelliteSummaryRequest) GetFormat() bool {
+	if m != nil {
+		return m.Format
+	}
+	return false
+}
+
 type SatelliteSummaryResponse struct {
 	PayoutInfo           *PayoutInfo `protobuf:"bytes,1,opt,name=payout_info,json=payoutInfo,proto3" json:"payout_info,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
@@ -1052,7 +1433,7 @@ func (m *SatelliteSummaryResponse) Reset()         { *m = SatelliteSummaryRespon
 func (m *SatelliteSummaryResponse) String() string { return proto.CompactTextString(m) }
 func (*SatelliteSummaryResponse) ProtoMessage()    {}
 func (*SatelliteSummaryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9a45fd79b06f3a1b, []int{22}
+	return fileDescriptor_9a45fd79b06f3a1b, []int{26}
 }
 func (m *SatelliteSummaryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SatelliteSummaryResponse.Unmarshal(m, b)
@@ -1083,6 +1464,7 @@ type SatellitePeriodSummaryRequest struct {
 	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	SatelliteId          NodeID         `protobuf:"bytes,2,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
 	Period               string         `protobuf:"bytes,3,opt,name=period,proto3" json:"period,omitempty"`
+	Format               bool           `protobuf:"varint,4,opt,name=format,proto3" json:"format,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
 	XXX_unrecognized     []byte         `json:"-"`
 	XXX_sizecache        int32          `json:"-"`
@@ -1092,7 +1474,7 @@ func (m *SatellitePeriodSummaryRequest) Reset()         { *m = SatellitePeriodSu
 func (m *SatellitePeriodSummaryRequest) String() string { return proto.CompactTextString(m) }
 func (*SatellitePeriodSummaryRequest) ProtoMessage()    {}
 func (*SatellitePeriodSummaryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9a45fd79b06f3a1b, []int{23}
+	return fileDescriptor_9a45fd79b06f3a1b, []int{27}
 }
 func (m *SatellitePeriodSummaryRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SatellitePeriodSummaryRequest.Unmarshal(m, b)
@@ -1126,6 +1508,13 @@ func (m *SatellitePeriodSummaryRequest) GetPeriod() string {
 	return ""
 }
 
+func (m *SatellitePeriodSummaryRequest) GetFormat() bool {
+	if m != nil {
+		return m.Format
+	}
+	return false
+}
+
 type SatellitePeriodSummaryResponse struct {
 	PayoutInfo           *PayoutInfo `protobuf:"bytes,1,opt,name=payout_info,json=payoutInfo,proto3" json:"payout_info,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
@@ -1137,7 +1526,7 @@ func (m *SatellitePeriodSummaryResponse) Reset()         { *m = SatellitePeriodS
 func (m *SatellitePeriodSummaryResponse) String() string { return proto.CompactTextString(m) }
 func (*SatellitePeriodSummaryResponse) ProtoMessage()    {}
 func (*SatellitePeriodSummaryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9a45fd79b06f3a1b, []int{24}
+	return fileDescriptor_9a45fd79b06f3a1b, []int{28}
 }
 func (m *SatellitePeriodSummaryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SatellitePeriodSummaryResponse.Unmarshal(m, b)
@@ -1164,18 +1553,164 @@ func (m *SatellitePeriodSummaryResponse) GetPayoutInfo() *PayoutInfo {
 	return nil
 }
 
-type EarnedRequest struct {
-	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+// SatelliteSummariesRequest requests satellite summaries of multiple nodes served by the same process at once,
+// every header authenticates a single node.
+type SatelliteSummariesRequest struct {
+	Headers     []*RequestHeader `protobuf:"bytes,1,rep,name=headers,proto3" json:"headers,omitempty"`
+	SatelliteId NodeID           `protobuf:"bytes,2,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
+	// period is in YYYY-MM format, empty period requests all time summary.
+	Period               string   `protobuf:"bytes,3,opt,name=period,proto3" json:"period,omitempty"`
+	Format               bool     `protobuf:"varint,4,opt,name=format,proto3" json:"format,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *EarnedRequest) Reset()         { *m = EarnedRequest{} }
-func (m *EarnedRequest) String() string { return proto.CompactTextString(m) }
-func (*EarnedRequest) ProtoMessage()    {}
-func (*EarnedRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9a45fd79b06f3a1b, []int{25}
+func (m *SatelliteSummariesRequest) Reset()         { *m = SatelliteSummariesRequest{} }
+func (m *SatelliteSummariesRequest) String() string { return proto.CompactTextString(m) }
+func (*SatelliteSummariesRequest) ProtoMessage()    {}
+func (*SatelliteSummariesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{29}
+}
+func (m *SatelliteSummariesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SatelliteSummariesRequest.Unmarshal(m, b)
+}
+func (m *SatelliteSummariesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SatelliteSummariesRequest.Marshal(b, m, deterministic)
+}
+func (m *SatelliteSummariesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SatelliteSummariesRequest.Merge(m, src)
+}
+func (m *SatelliteSummariesRequest) XXX_Size() int {
+	return xxx_messageInfo_SatelliteSummariesRequest.Size(m)
+}
+func (m *SatelliteSummariesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SatelliteSummariesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SatelliteSummariesRequest proto.InternalMessageInfo
+
+func (m *SatelliteSummariesRequest) GetHeaders() []*RequestHeader {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
+func (m *SatelliteSummariesRequest) GetPeriod() string {
+	if m != nil {
+		return m.Period
+	}
+	return ""
+}
+
+func (m *SatelliteSummariesRequest) GetFormat() bool {
+	if m != nil {
+		return m.Format
+	}
+	return false
+}
+
+type SatelliteSummariesResponse struct {
+	// node_summaries correspond to the request headers in the same order.
+	NodeSummaries        []*SatelliteSummariesResponse_NodeSummary `protobuf:"bytes,1,rep,name=node_summaries,json=nodeSummaries,proto3" json:"node_summaries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                  `json:"-"`
+	XXX_unrecognized     []byte                                    `json:"-"`
+	XXX_sizecache        int32                                     `json:"-"`
+}
+
+func (m *SatelliteSummariesResponse) Reset()         { *m = SatelliteSummariesResponse{} }
+func (m *SatelliteSummariesResponse) String() string { return proto.CompactTextString(m) }
+func (*SatelliteSummariesResponse) ProtoMessage()    {}
+func (*SatelliteSummariesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{30}
+}
+func (m *SatelliteSummariesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SatelliteSummariesResponse.Unmarshal(m, b)
+}
+func (m *SatelliteSummariesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SatelliteSummariesResponse.Marshal(b, m, deterministic)
+}
+func (m *SatelliteSummariesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SatelliteSummariesResponse.Merge(m, src)
+}
+func (m *SatelliteSummariesResponse) XXX_Size() int {
+	return xxx_messageInfo_SatelliteSummariesResponse.Size(m)
+}
+func (m *SatelliteSummariesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SatelliteSummariesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SatelliteSummariesResponse proto.InternalMessageInfo
+
+func (m *SatelliteSummariesResponse) GetNodeSummaries() []*SatelliteSummariesResponse_NodeSummary {
+	if m != nil {
+		return m.NodeSummaries
+	}
+	return nil
+}
+
+type SatelliteSummariesResponse_NodeSummary struct {
+	PayoutInfo *PayoutInfo `protobuf:"bytes,1,opt,name=payout_info,json=payoutInfo,proto3" json:"payout_info,omitempty"`
+	// unauthenticated is set when the header was not accepted, payout_info is not set then.
+	Unauthenticated      bool     `protobuf:"varint,2,opt,name=unauthenticated,proto3" json:"unauthenticated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SatelliteSummariesResponse_NodeSummary) Reset() {
+	*m = SatelliteSummariesResponse_NodeSummary{}
+}
+func (m *SatelliteSummariesResponse_NodeSummary) String() string { return proto.CompactTextString(m) }
+func (*SatelliteSummariesResponse_NodeSummary) ProtoMessage()    {}
+func (*SatelliteSummariesResponse_NodeSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{30, 0}
+}
+func (m *SatelliteSummariesResponse_NodeSummary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SatelliteSummariesResponse_NodeSummary.Unmarshal(m, b)
+}
+func (m *SatelliteSummariesResponse_NodeSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SatelliteSummariesResponse_NodeSummary.Marshal(b, m, deterministic)
+}
+func (m *SatelliteSummariesResponse_NodeSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SatelliteSummariesResponse_NodeSummary.Merge(m, src)
+}
+func (m *SatelliteSummariesResponse_NodeSummary) XXX_Size() int {
+	return xxx_messageInfo_SatelliteSummariesResponse_NodeSummary.Size(m)
+}
+func (m *SatelliteSummariesResponse_NodeSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_SatelliteSummariesResponse_NodeSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SatelliteSummariesResponse_NodeSummary proto.InternalMessageInfo
+
+func (m *SatelliteSummariesResponse_NodeSummary) GetPayoutInfo() *PayoutInfo {
+	if m != nil {
+		return m.PayoutInfo
+	}
+	return nil
+}
+
+func (m *SatelliteSummariesResponse_NodeSummary) GetUnauthenticated() bool {
+	if m != nil {
+		return m.Unauthenticated
+	}
+	return false
+}
+
+type EarnedRequest struct {
+	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *EarnedRequest) Reset()         { *m = EarnedRequest{} }
+func (m *EarnedRequest) String() string { return proto.CompactTextString(m) }
+func (*EarnedRequest) ProtoMessage()    {}
+func (*EarnedRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{31}
 }
 func (m *EarnedRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EarnedRequest.Unmarshal(m, b)
@@ -1203,17 +1738,24 @@ func (m *EarnedRequest) GetHeader() *RequestHeader {
 }
 
 type EarnedResponse struct {
-	Total                int64    `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	// total is gross amount earned from all paystubs.
+	Total int64       `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Unit  *AmountUnit `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
+	// net is total minus amount currently held by satellites, i.e. held and not yet returned.
+	// Unlike paid it doesn't include surge and counts amounts not yet paid out.
+	Net int64 `protobuf:"varint,3,opt,name=net,proto3" json:"net,omitempty"`
+	// components split total by the payout component, they sum up to total.
+	Components           *EarnedComponents `protobuf:"bytes,4,opt,name=components,proto3" json:"components,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *EarnedResponse) Reset()         { *m = EarnedResponse{} }
 func (m *EarnedResponse) String() string { return proto.CompactTextString(m) }
 func (*EarnedResponse) ProtoMessage()    {}
 func (*EarnedResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9a45fd79b06f3a1b, []int{26}
+	return fileDescriptor_9a45fd79b06f3a1b, []int{32}
 }
 func (m *EarnedResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EarnedResponse.Unmarshal(m, b)
@@ -1240,18 +1782,104 @@ func (m *EarnedResponse) GetTotal() int64 {
 	return 0
 }
 
+func (m *EarnedResponse) GetUnit() *AmountUnit {
+	if m != nil {
+		return m.Unit
+	}
+	return nil
+}
+
+func (m *EarnedResponse) GetNet() int64 {
+	if m != nil {
+		return m.Net
+	}
+	return 0
+}
+
+func (m *EarnedResponse) GetComponents() *EarnedComponents {
+	if m != nil {
+		return m.Components
+	}
+	return nil
+}
+
+// EarnedComponents contains earned amount of every payout component from paystubs.
+type EarnedComponents struct {
+	CompAtRest           int64    `protobuf:"varint,1,opt,name=comp_at_rest,json=compAtRest,proto3" json:"comp_at_rest,omitempty"`
+	CompGet              int64    `protobuf:"varint,2,opt,name=comp_get,json=compGet,proto3" json:"comp_get,omitempty"`
+	CompGetRepair        int64    `protobuf:"varint,3,opt,name=comp_get_repair,json=compGetRepair,proto3" json:"comp_get_repair,omitempty"`
+	CompGetAudit         int64    `protobuf:"varint,4,opt,name=comp_get_audit,json=compGetAudit,proto3" json:"comp_get_audit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EarnedComponents) Reset()         { *m = EarnedComponents{} }
+func (m *EarnedComponents) String() string { return proto.CompactTextString(m) }
+func (*EarnedComponents) ProtoMessage()    {}
+func (*EarnedComponents) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{33}
+}
+func (m *EarnedComponents) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EarnedComponents.Unmarshal(m, b)
+}
+func (m *EarnedComponents) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EarnedComponents.Marshal(b, m, deterministic)
+}
+func (m *EarnedComponents) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EarnedComponents.Merge(m, src)
+}
+func (m *EarnedComponents) XXX_Size() int {
+	return xxx_messageInfo_EarnedComponents.Size(m)
+}
+func (m *EarnedComponents) XXX_DiscardUnknown() {
+	xxx_messageInfo_EarnedComponents.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EarnedComponents proto.InternalMessageInfo
+
+func (m *EarnedComponents) GetCompAtRest() int64 {
+	if m != nil {
+		return m.CompAtRest
+	}
+	return 0
+}
+
+func (m *EarnedComponents) GetCompGet() int64 {
+	if m != nil {
+		return m.CompGet
+	}
+	return 0
+}
+
+func (m *EarnedComponents) GetCompGetRepair() int64 {
+	if m != nil {
+		return m.CompGetRepair
+	}
+	return 0
+}
+
+func (m *EarnedComponents) GetCompGetAudit() int64 {
+	if m != nil {
+		return m.CompGetAudit
+	}
+	return 0
+}
+
 type EarnedPerSatelliteRequest struct {
-	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	Header *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// min_amount omits satellites with total earned below it, in the response unit. Zero returns all satellites.
+	MinAmount            int64    `protobuf:"varint,2,opt,name=min_amount,json=minAmount,proto3" json:"min_amount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *EarnedPerSatelliteRequest) Reset()         { *m = EarnedPerSatelliteRequest{} }
 func (m *EarnedPerSatelliteRequest) String() string { return proto.CompactTextString(m) }
 func (*EarnedPerSatelliteRequest) ProtoMessage()    {}
 func (*EarnedPerSatelliteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9a45fd79b06f3a1b, []int{27}
+	return fileDescriptor_9a45fd79b06f3a1b, []int{34}
 }
 func (m *EarnedPerSatelliteRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EarnedPerSatelliteRequest.Unmarshal(m, b)
@@ -1278,18 +1906,28 @@ func (m *EarnedPerSatelliteRequest) GetHeader() *RequestHeader {
 	return nil
 }
 
+func (m *EarnedPerSatelliteRequest) GetMinAmount() int64 {
+	if m != nil {
+		return m.MinAmount
+	}
+	return 0
+}
+
 type EarnedPerSatelliteResponse struct {
-	EarnedSatellite      []*EarnedSatellite `protobuf:"bytes,1,rep,name=earned_satellite,json=earnedSatellite,proto3" json:"earned_satellite,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
-	XXX_unrecognized     []byte             `json:"-"`
-	XXX_sizecache        int32              `json:"-"`
+	EarnedSatellite []*EarnedSatellite `protobuf:"bytes,1,rep,name=earned_satellite,json=earnedSatellite,proto3" json:"earned_satellite,omitempty"`
+	Unit            *AmountUnit        `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
+	// truncated is set when the request was canceled before all satellites were gathered.
+	Truncated            bool     `protobuf:"varint,3,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *EarnedPerSatelliteResponse) Reset()         { *m = EarnedPerSatelliteResponse{} }
 func (m *EarnedPerSatelliteResponse) String() string { return proto.CompactTextString(m) }
 func (*EarnedPerSatelliteResponse) ProtoMessage()    {}
 func (*EarnedPerSatelliteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9a45fd79b06f3a1b, []int{28}
+	return fileDescriptor_9a45fd79b06f3a1b, []int{35}
 }
 func (m *EarnedPerSatelliteResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EarnedPerSatelliteResponse.Unmarshal(m, b)
@@ -1316,19 +1954,39 @@ func (m *EarnedPerSatelliteResponse) GetEarnedSatellite() []*EarnedSatellite {
 	return nil
 }
 
+func (m *EarnedPerSatelliteResponse) GetUnit() *AmountUnit {
+	if m != nil {
+		return m.Unit
+	}
+	return nil
+}
+
+func (m *EarnedPerSatelliteResponse) GetTruncated() bool {
+	if m != nil {
+		return m.Truncated
+	}
+	return false
+}
+
 type EarnedSatellite struct {
-	Total                int64    `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
-	SatelliteId          NodeID   `protobuf:"bytes,2,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Total       int64  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	SatelliteId NodeID `protobuf:"bytes,2,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
+	// unit is the denomination the satellite pays in, response unit is used when not set.
+	Unit *AmountUnit `protobuf:"bytes,3,opt,name=unit,proto3" json:"unit,omitempty"`
+	// net is total minus amount currently held by the satellite.
+	Net int64 `protobuf:"varint,4,opt,name=net,proto3" json:"net,omitempty"`
+	// components split total by the payout component, they sum up to total.
+	Components           *EarnedComponents `protobuf:"bytes,5,opt,name=components,proto3" json:"components,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *EarnedSatellite) Reset()         { *m = EarnedSatellite{} }
 func (m *EarnedSatellite) String() string { return proto.CompactTextString(m) }
 func (*EarnedSatellite) ProtoMessage()    {}
 func (*EarnedSatellite) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9a45fd79b06f3a1b, []int{29}
+	return fileDescriptor_9a45fd79b06f3a1b, []int{36}
 }
 func (m *EarnedSatellite) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EarnedSatellite.Unmarshal(m, b)
@@ -1355,48 +2013,1012 @@ func (m *EarnedSatellite) GetTotal() int64 {
 	return 0
 }
 
-type PayoutInfo struct {
-	Held                 int64    `protobuf:"varint,1,opt,name=held,proto3" json:"held,omitempty"`
-	Paid                 int64    `protobuf:"varint,2,opt,name=paid,proto3" json:"paid,omitempty"`
+func (m *EarnedSatellite) GetUnit() *AmountUnit {
+	if m != nil {
+		return m.Unit
+	}
+	return nil
+}
+
+func (m *EarnedSatellite) GetNet() int64 {
+	if m != nil {
+		return m.Net
+	}
+	return 0
+}
+
+func (m *EarnedSatellite) GetComponents() *EarnedComponents {
+	if m != nil {
+		return m.Components
+	}
+	return nil
+}
+
+type UndistributedPerSatelliteRequest struct {
+	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *UndistributedPerSatelliteRequest) Reset()         { *m = UndistributedPerSatelliteRequest{} }
+func (m *UndistributedPerSatelliteRequest) String() string { return proto.CompactTextString(m) }
+func (*UndistributedPerSatelliteRequest) ProtoMessage()    {}
+func (*UndistributedPerSatelliteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{37}
+}
+func (m *UndistributedPerSatelliteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UndistributedPerSatelliteRequest.Unmarshal(m, b)
+}
+func (m *UndistributedPerSatelliteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UndistributedPerSatelliteRequest.Marshal(b, m, deterministic)
+}
+func (m *UndistributedPerSatelliteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UndistributedPerSatelliteRequest.Merge(m, src)
+}
+func (m *UndistributedPerSatelliteRequest) XXX_Size() int {
+	return xxx_messageInfo_UndistributedPerSatelliteRequest.Size(m)
+}
+func (m *UndistributedPerSatelliteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UndistributedPerSatelliteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UndistributedPerSatelliteRequest proto.InternalMessageInfo
+
+func (m *UndistributedPerSatelliteRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+type UndistributedPerSatelliteResponse struct {
+	UndistributedSatellite []*UndistributedSatellite `protobuf:"bytes,1,rep,name=undistributed_satellite,json=undistributedSatellite,proto3" json:"undistributed_satellite,omitempty"`
+	Unit                   *AmountUnit               `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}                  `json:"-"`
+	XXX_unrecognized       []byte                    `json:"-"`
+	XXX_sizecache          int32                     `json:"-"`
+}
+
+func (m *UndistributedPerSatelliteResponse) Reset()         { *m = UndistributedPerSatelliteResponse{} }
+func (m *UndistributedPerSatelliteResponse) String() string { return proto.CompactTextString(m) }
+func (*UndistributedPerSatelliteResponse) ProtoMessage()    {}
+func (*UndistributedPerSatelliteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{38}
+}
+func (m *UndistributedPerSatelliteResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UndistributedPerSatelliteResponse.Unmarshal(m, b)
+}
+func (m *UndistributedPerSatelliteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UndistributedPerSatelliteResponse.Marshal(b, m, deterministic)
+}
+func (m *UndistributedPerSatelliteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UndistributedPerSatelliteResponse.Merge(m, src)
+}
+func (m *UndistributedPerSatelliteResponse) XXX_Size() int {
+	return xxx_messageInfo_UndistributedPerSatelliteResponse.Size(m)
+}
+func (m *UndistributedPerSatelliteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UndistributedPerSatelliteResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UndistributedPerSatelliteResponse proto.InternalMessageInfo
+
+func (m *UndistributedPerSatelliteResponse) GetUndistributedSatellite() []*UndistributedSatellite {
+	if m != nil {
+		return m.UndistributedSatellite
+	}
+	return nil
+}
+
+func (m *UndistributedPerSatelliteResponse) GetUnit() *AmountUnit {
+	if m != nil {
+		return m.Unit
+	}
+	return nil
+}
+
+// UndistributedSatellite contains amount paid by the satellite, which was not yet distributed to the node wallet.
+type UndistributedSatellite struct {
+	Total                int64    `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	SatelliteId          NodeID   `protobuf:"bytes,2,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PayoutInfo) Reset()         { *m = PayoutInfo{} }
-func (m *PayoutInfo) String() string { return proto.CompactTextString(m) }
-func (*PayoutInfo) ProtoMessage()    {}
-func (*PayoutInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9a45fd79b06f3a1b, []int{30}
+func (m *UndistributedSatellite) Reset()         { *m = UndistributedSatellite{} }
+func (m *UndistributedSatellite) String() string { return proto.CompactTextString(m) }
+func (*UndistributedSatellite) ProtoMessage()    {}
+func (*UndistributedSatellite) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{39}
 }
-func (m *PayoutInfo) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_PayoutInfo.Unmarshal(m, b)
+func (m *UndistributedSatellite) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UndistributedSatellite.Unmarshal(m, b)
 }
-func (m *PayoutInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_PayoutInfo.Marshal(b, m, deterministic)
+func (m *UndistributedSatellite) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UndistributedSatellite.Marshal(b, m, deterministic)
 }
-func (m *PayoutInfo) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PayoutInfo.Merge(m, src)
+func (m *UndistributedSatellite) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UndistributedSatellite.Merge(m, src)
 }
-func (m *PayoutInfo) XXX_Size() int {
-	return xxx_messageInfo_PayoutInfo.Size(m)
+func (m *UndistributedSatellite) XXX_Size() int {
+	return xxx_messageInfo_UndistributedSatellite.Size(m)
 }
-func (m *PayoutInfo) XXX_DiscardUnknown() {
-	xxx_messageInfo_PayoutInfo.DiscardUnknown(m)
+func (m *UndistributedSatellite) XXX_DiscardUnknown() {
+	xxx_messageInfo_UndistributedSatellite.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PayoutInfo proto.InternalMessageInfo
+var xxx_messageInfo_UndistributedSatellite proto.InternalMessageInfo
 
-func (m *PayoutInfo) GetHeld() int64 {
+func (m *UndistributedSatellite) GetTotal() int64 {
 	if m != nil {
-		return m.Held
+		return m.Total
 	}
 	return 0
 }
 
-func (m *PayoutInfo) GetPaid() int64 {
+type AvailablePeriodsRequest struct {
+	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *AvailablePeriodsRequest) Reset()         { *m = AvailablePeriodsRequest{} }
+func (m *AvailablePeriodsRequest) String() string { return proto.CompactTextString(m) }
+func (*AvailablePeriodsRequest) ProtoMessage()    {}
+func (*AvailablePeriodsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{40}
+}
+func (m *AvailablePeriodsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AvailablePeriodsRequest.Unmarshal(m, b)
+}
+func (m *AvailablePeriodsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AvailablePeriodsRequest.Marshal(b, m, deterministic)
+}
+func (m *AvailablePeriodsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AvailablePeriodsRequest.Merge(m, src)
+}
+func (m *AvailablePeriodsRequest) XXX_Size() int {
+	return xxx_messageInfo_AvailablePeriodsRequest.Size(m)
+}
+func (m *AvailablePeriodsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AvailablePeriodsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AvailablePeriodsRequest proto.InternalMessageInfo
+
+func (m *AvailablePeriodsRequest) GetHeader() *RequestHeader {
 	if m != nil {
-		return m.Paid
+		return m.Header
+	}
+	return nil
+}
+
+type AvailablePeriodsResponse struct {
+	Period               []string `protobuf:"bytes,1,rep,name=period,proto3" json:"period,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AvailablePeriodsResponse) Reset()         { *m = AvailablePeriodsResponse{} }
+func (m *AvailablePeriodsResponse) String() string { return proto.CompactTextString(m) }
+func (*AvailablePeriodsResponse) ProtoMessage()    {}
+func (*AvailablePeriodsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{41}
+}
+func (m *AvailablePeriodsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AvailablePeriodsResponse.Unmarshal(m, b)
+}
+func (m *AvailablePeriodsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AvailablePeriodsResponse.Marshal(b, m, deterministic)
+}
+func (m *AvailablePeriodsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AvailablePeriodsResponse.Merge(m, src)
+}
+func (m *AvailablePeriodsResponse) XXX_Size() int {
+	return xxx_messageInfo_AvailablePeriodsResponse.Size(m)
+}
+func (m *AvailablePeriodsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AvailablePeriodsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AvailablePeriodsResponse proto.InternalMessageInfo
+
+func (m *AvailablePeriodsResponse) GetPeriod() []string {
+	if m != nil {
+		return m.Period
+	}
+	return nil
+}
+
+type HeldRatesRequest struct {
+	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Period               string         `protobuf:"bytes,2,opt,name=period,proto3" json:"period,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *HeldRatesRequest) Reset()         { *m = HeldRatesRequest{} }
+func (m *HeldRatesRequest) String() string { return proto.CompactTextString(m) }
+func (*HeldRatesRequest) ProtoMessage()    {}
+func (*HeldRatesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{42}
+}
+func (m *HeldRatesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HeldRatesRequest.Unmarshal(m, b)
+}
+func (m *HeldRatesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HeldRatesRequest.Marshal(b, m, deterministic)
+}
+func (m *HeldRatesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HeldRatesRequest.Merge(m, src)
+}
+func (m *HeldRatesRequest) XXX_Size() int {
+	return xxx_messageInfo_HeldRatesRequest.Size(m)
+}
+func (m *HeldRatesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HeldRatesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HeldRatesRequest proto.InternalMessageInfo
+
+func (m *HeldRatesRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *HeldRatesRequest) GetPeriod() string {
+	if m != nil {
+		return m.Period
+	}
+	return ""
+}
+
+type HeldRatesResponse struct {
+	HeldRates            []*HeldRatesResponse_HeldRate `protobuf:"bytes,1,rep,name=held_rates,json=heldRates,proto3" json:"held_rates,omitempty"`
+	Unit                 *AmountUnit                   `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
+}
+
+func (m *HeldRatesResponse) Reset()         { *m = HeldRatesResponse{} }
+func (m *HeldRatesResponse) String() string { return proto.CompactTextString(m) }
+func (*HeldRatesResponse) ProtoMessage()    {}
+func (*HeldRatesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{43}
+}
+func (m *HeldRatesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HeldRatesResponse.Unmarshal(m, b)
+}
+func (m *HeldRatesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HeldRatesResponse.Marshal(b, m, deterministic)
+}
+func (m *HeldRatesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HeldRatesResponse.Merge(m, src)
+}
+func (m *HeldRatesResponse) XXX_Size() int {
+	return xxx_messageInfo_HeldRatesResponse.Size(m)
+}
+func (m *HeldRatesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HeldRatesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HeldRatesResponse proto.InternalMessageInfo
+
+func (m *HeldRatesResponse) GetHeldRates() []*HeldRatesResponse_HeldRate {
+	if m != nil {
+		return m.HeldRates
+	}
+	return nil
+}
+
+func (m *HeldRatesResponse) GetUnit() *AmountUnit {
+	if m != nil {
+		return m.Unit
+	}
+	return nil
+}
+
+// HeldRate contains amount held by the satellite from the node earnings in the period.
+type HeldRatesResponse_HeldRate struct {
+	SatelliteId NodeID    `protobuf:"bytes,1,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
+	JoinedAt    time.Time `protobuf:"bytes,2,opt,name=joined_at,json=joinedAt,proto3,stdtime" json:"joined_at"`
+	Held        int64     `protobuf:"varint,3,opt,name=held,proto3" json:"held,omitempty"`
+	// earned is the period earnings including surge, which held is taken from.
+	Earned               int64    `protobuf:"varint,4,opt,name=earned,proto3" json:"earned,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HeldRatesResponse_HeldRate) Reset()         { *m = HeldRatesResponse_HeldRate{} }
+func (m *HeldRatesResponse_HeldRate) String() string { return proto.CompactTextString(m) }
+func (*HeldRatesResponse_HeldRate) ProtoMessage()    {}
+func (*HeldRatesResponse_HeldRate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{43, 0}
+}
+func (m *HeldRatesResponse_HeldRate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HeldRatesResponse_HeldRate.Unmarshal(m, b)
+}
+func (m *HeldRatesResponse_HeldRate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HeldRatesResponse_HeldRate.Marshal(b, m, deterministic)
+}
+func (m *HeldRatesResponse_HeldRate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HeldRatesResponse_HeldRate.Merge(m, src)
+}
+func (m *HeldRatesResponse_HeldRate) XXX_Size() int {
+	return xxx_messageInfo_HeldRatesResponse_HeldRate.Size(m)
+}
+func (m *HeldRatesResponse_HeldRate) XXX_DiscardUnknown() {
+	xxx_messageInfo_HeldRatesResponse_HeldRate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HeldRatesResponse_HeldRate proto.InternalMessageInfo
+
+func (m *HeldRatesResponse_HeldRate) GetJoinedAt() time.Time {
+	if m != nil {
+		return m.JoinedAt
+	}
+	return time.Time{}
+}
+
+func (m *HeldRatesResponse_HeldRate) GetHeld() int64 {
+	if m != nil {
+		return m.Held
+	}
+	return 0
+}
+
+func (m *HeldRatesResponse_HeldRate) GetEarned() int64 {
+	if m != nil {
+		return m.Earned
+	}
+	return 0
+}
+
+type PayoutConfigRequest struct {
+	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *PayoutConfigRequest) Reset()         { *m = PayoutConfigRequest{} }
+func (m *PayoutConfigRequest) String() string { return proto.CompactTextString(m) }
+func (*PayoutConfigRequest) ProtoMessage()    {}
+func (*PayoutConfigRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{44}
+}
+func (m *PayoutConfigRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PayoutConfigRequest.Unmarshal(m, b)
+}
+func (m *PayoutConfigRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PayoutConfigRequest.Marshal(b, m, deterministic)
+}
+func (m *PayoutConfigRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PayoutConfigRequest.Merge(m, src)
+}
+func (m *PayoutConfigRequest) XXX_Size() int {
+	return xxx_messageInfo_PayoutConfigRequest.Size(m)
+}
+func (m *PayoutConfigRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PayoutConfigRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PayoutConfigRequest proto.InternalMessageInfo
+
+func (m *PayoutConfigRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+type PayoutConfigResponse struct {
+	// wallet is the operator wallet address, empty when not configured.
+	Wallet               string   `protobuf:"bytes,1,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	WalletFeatures       []string `protobuf:"bytes,2,rep,name=wallet_features,json=walletFeatures,proto3" json:"wallet_features,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PayoutConfigResponse) Reset()         { *m = PayoutConfigResponse{} }
+func (m *PayoutConfigResponse) String() string { return proto.CompactTextString(m) }
+func (*PayoutConfigResponse) ProtoMessage()    {}
+func (*PayoutConfigResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{45}
+}
+func (m *PayoutConfigResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PayoutConfigResponse.Unmarshal(m, b)
+}
+func (m *PayoutConfigResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PayoutConfigResponse.Marshal(b, m, deterministic)
+}
+func (m *PayoutConfigResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PayoutConfigResponse.Merge(m, src)
+}
+func (m *PayoutConfigResponse) XXX_Size() int {
+	return xxx_messageInfo_PayoutConfigResponse.Size(m)
+}
+func (m *PayoutConfigResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PayoutConfigResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PayoutConfigResponse proto.InternalMessageInfo
+
+func (m *PayoutConfigResponse) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+func (m *PayoutConfigResponse) GetWalletFeatures() []string {
+	if m != nil {
+		return m.WalletFeatures
+	}
+	return nil
+}
+
+type FirstPaystubPeriodRequest struct {
+	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	SatelliteId          NodeID         `protobuf:"bytes,2,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *FirstPaystubPeriodRequest) Reset()         { *m = FirstPaystubPeriodRequest{} }
+func (m *FirstPaystubPeriodRequest) String() string { return proto.CompactTextString(m) }
+func (*FirstPaystubPeriodRequest) ProtoMessage()    {}
+func (*FirstPaystubPeriodRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{46}
+}
+func (m *FirstPaystubPeriodRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FirstPaystubPeriodRequest.Unmarshal(m, b)
+}
+func (m *FirstPaystubPeriodRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FirstPaystubPeriodRequest.Marshal(b, m, deterministic)
+}
+func (m *FirstPaystubPeriodRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FirstPaystubPeriodRequest.Merge(m, src)
+}
+func (m *FirstPaystubPeriodRequest) XXX_Size() int {
+	return xxx_messageInfo_FirstPaystubPeriodRequest.Size(m)
+}
+func (m *FirstPaystubPeriodRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FirstPaystubPeriodRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FirstPaystubPeriodRequest proto.InternalMessageInfo
+
+func (m *FirstPaystubPeriodRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+type FirstPaystubPeriodResponse struct {
+	// period is the first period with a paystub from the satellite in YYYY-MM format, empty when there is none.
+	Period               string   `protobuf:"bytes,1,opt,name=period,proto3" json:"period,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FirstPaystubPeriodResponse) Reset()         { *m = FirstPaystubPeriodResponse{} }
+func (m *FirstPaystubPeriodResponse) String() string { return proto.CompactTextString(m) }
+func (*FirstPaystubPeriodResponse) ProtoMessage()    {}
+func (*FirstPaystubPeriodResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{47}
+}
+func (m *FirstPaystubPeriodResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FirstPaystubPeriodResponse.Unmarshal(m, b)
+}
+func (m *FirstPaystubPeriodResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FirstPaystubPeriodResponse.Marshal(b, m, deterministic)
+}
+func (m *FirstPaystubPeriodResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FirstPaystubPeriodResponse.Merge(m, src)
+}
+func (m *FirstPaystubPeriodResponse) XXX_Size() int {
+	return xxx_messageInfo_FirstPaystubPeriodResponse.Size(m)
+}
+func (m *FirstPaystubPeriodResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FirstPaystubPeriodResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FirstPaystubPeriodResponse proto.InternalMessageInfo
+
+func (m *FirstPaystubPeriodResponse) GetPeriod() string {
+	if m != nil {
+		return m.Period
+	}
+	return ""
+}
+
+type PaymentsPerSatelliteRequest struct {
+	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *PaymentsPerSatelliteRequest) Reset()         { *m = PaymentsPerSatelliteRequest{} }
+func (m *PaymentsPerSatelliteRequest) String() string { return proto.CompactTextString(m) }
+func (*PaymentsPerSatelliteRequest) ProtoMessage()    {}
+func (*PaymentsPerSatelliteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{48}
+}
+func (m *PaymentsPerSatelliteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PaymentsPerSatelliteRequest.Unmarshal(m, b)
+}
+func (m *PaymentsPerSatelliteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PaymentsPerSatelliteRequest.Marshal(b, m, deterministic)
+}
+func (m *PaymentsPerSatelliteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PaymentsPerSatelliteRequest.Merge(m, src)
+}
+func (m *PaymentsPerSatelliteRequest) XXX_Size() int {
+	return xxx_messageInfo_PaymentsPerSatelliteRequest.Size(m)
+}
+func (m *PaymentsPerSatelliteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PaymentsPerSatelliteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PaymentsPerSatelliteRequest proto.InternalMessageInfo
+
+func (m *PaymentsPerSatelliteRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+type PaymentsPerSatelliteResponse struct {
+	SatellitePayments    []*PaymentsPerSatelliteResponse_SatellitePayments `protobuf:"bytes,1,rep,name=satellite_payments,json=satellitePayments,proto3" json:"satellite_payments,omitempty"`
+	Unit                 *AmountUnit                                       `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                          `json:"-"`
+	XXX_unrecognized     []byte                                            `json:"-"`
+	XXX_sizecache        int32                                             `json:"-"`
+}
+
+func (m *PaymentsPerSatelliteResponse) Reset()         { *m = PaymentsPerSatelliteResponse{} }
+func (m *PaymentsPerSatelliteResponse) String() string { return proto.CompactTextString(m) }
+func (*PaymentsPerSatelliteResponse) ProtoMessage()    {}
+func (*PaymentsPerSatelliteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{49}
+}
+func (m *PaymentsPerSatelliteResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PaymentsPerSatelliteResponse.Unmarshal(m, b)
+}
+func (m *PaymentsPerSatelliteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PaymentsPerSatelliteResponse.Marshal(b, m, deterministic)
+}
+func (m *PaymentsPerSatelliteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PaymentsPerSatelliteResponse.Merge(m, src)
+}
+func (m *PaymentsPerSatelliteResponse) XXX_Size() int {
+	return xxx_messageInfo_PaymentsPerSatelliteResponse.Size(m)
+}
+func (m *PaymentsPerSatelliteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PaymentsPerSatelliteResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PaymentsPerSatelliteResponse proto.InternalMessageInfo
+
+func (m *PaymentsPerSatelliteResponse) GetSatellitePayments() []*PaymentsPerSatelliteResponse_SatellitePayments {
+	if m != nil {
+		return m.SatellitePayments
+	}
+	return nil
+}
+
+func (m *PaymentsPerSatelliteResponse) GetUnit() *AmountUnit {
+	if m != nil {
+		return m.Unit
+	}
+	return nil
+}
+
+// SatellitePayments compares amount paid according to satellite paystubs with payments recorded by the node.
+type PaymentsPerSatelliteResponse_SatellitePayments struct {
+	SatelliteId NodeID `protobuf:"bytes,1,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
+	// paid is the sum of paid amounts of all paystubs.
+	Paid int64 `protobuf:"varint,2,opt,name=paid,proto3" json:"paid,omitempty"`
+	// received is the sum of amounts of all recorded payments.
+	Received int64 `protobuf:"varint,3,opt,name=received,proto3" json:"received,omitempty"`
+	// payments is the number of recorded payments, zero means the node has no records to compare with.
+	Payments             int32    `protobuf:"varint,4,opt,name=payments,proto3" json:"payments,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PaymentsPerSatelliteResponse_SatellitePayments) Reset() {
+	*m = PaymentsPerSatelliteResponse_SatellitePayments{}
+}
+func (m *PaymentsPerSatelliteResponse_SatellitePayments) String() string {
+	return proto.CompactTextString(m)
+}
+func (*PaymentsPerSatelliteResponse_SatellitePayments) ProtoMessage() {}
+func (*PaymentsPerSatelliteResponse_SatellitePayments) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{49, 0}
+}
+func (m *PaymentsPerSatelliteResponse_SatellitePayments) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PaymentsPerSatelliteResponse_SatellitePayments.Unmarshal(m, b)
+}
+func (m *PaymentsPerSatelliteResponse_SatellitePayments) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PaymentsPerSatelliteResponse_SatellitePayments.Marshal(b, m, deterministic)
+}
+func (m *PaymentsPerSatelliteResponse_SatellitePayments) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PaymentsPerSatelliteResponse_SatellitePayments.Merge(m, src)
+}
+func (m *PaymentsPerSatelliteResponse_SatellitePayments) XXX_Size() int {
+	return xxx_messageInfo_PaymentsPerSatelliteResponse_SatellitePayments.Size(m)
+}
+func (m *PaymentsPerSatelliteResponse_SatellitePayments) XXX_DiscardUnknown() {
+	xxx_messageInfo_PaymentsPerSatelliteResponse_SatellitePayments.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PaymentsPerSatelliteResponse_SatellitePayments proto.InternalMessageInfo
+
+func (m *PaymentsPerSatelliteResponse_SatellitePayments) GetPaid() int64 {
+	if m != nil {
+		return m.Paid
+	}
+	return 0
+}
+
+func (m *PaymentsPerSatelliteResponse_SatellitePayments) GetReceived() int64 {
+	if m != nil {
+		return m.Received
+	}
+	return 0
+}
+
+func (m *PaymentsPerSatelliteResponse_SatellitePayments) GetPayments() int32 {
+	if m != nil {
+		return m.Payments
+	}
+	return 0
+}
+
+type PayingSatellitesRequest struct {
+	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *PayingSatellitesRequest) Reset()         { *m = PayingSatellitesRequest{} }
+func (m *PayingSatellitesRequest) String() string { return proto.CompactTextString(m) }
+func (*PayingSatellitesRequest) ProtoMessage()    {}
+func (*PayingSatellitesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{50}
+}
+func (m *PayingSatellitesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PayingSatellitesRequest.Unmarshal(m, b)
+}
+func (m *PayingSatellitesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PayingSatellitesRequest.Marshal(b, m, deterministic)
+}
+func (m *PayingSatellitesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PayingSatellitesRequest.Merge(m, src)
+}
+func (m *PayingSatellitesRequest) XXX_Size() int {
+	return xxx_messageInfo_PayingSatellitesRequest.Size(m)
+}
+func (m *PayingSatellitesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PayingSatellitesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PayingSatellitesRequest proto.InternalMessageInfo
+
+func (m *PayingSatellitesRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+type PayingSatellitesResponse struct {
+	// satellite_ids are ids of all satellites that ever paid to the node, sorted.
+	SatelliteIds         []NodeID `protobuf:"bytes,1,rep,name=satellite_ids,json=satelliteIds,proto3,customtype=NodeID" json:"satellite_ids"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PayingSatellitesResponse) Reset()         { *m = PayingSatellitesResponse{} }
+func (m *PayingSatellitesResponse) String() string { return proto.CompactTextString(m) }
+func (*PayingSatellitesResponse) ProtoMessage()    {}
+func (*PayingSatellitesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{51}
+}
+func (m *PayingSatellitesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PayingSatellitesResponse.Unmarshal(m, b)
+}
+func (m *PayingSatellitesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PayingSatellitesResponse.Marshal(b, m, deterministic)
+}
+func (m *PayingSatellitesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PayingSatellitesResponse.Merge(m, src)
+}
+func (m *PayingSatellitesResponse) XXX_Size() int {
+	return xxx_messageInfo_PayingSatellitesResponse.Size(m)
+}
+func (m *PayingSatellitesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PayingSatellitesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PayingSatellitesResponse proto.InternalMessageInfo
+
+type HeldHistoryRequest struct {
+	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *HeldHistoryRequest) Reset()         { *m = HeldHistoryRequest{} }
+func (m *HeldHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*HeldHistoryRequest) ProtoMessage()    {}
+func (*HeldHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{52}
+}
+func (m *HeldHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HeldHistoryRequest.Unmarshal(m, b)
+}
+func (m *HeldHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HeldHistoryRequest.Marshal(b, m, deterministic)
+}
+func (m *HeldHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HeldHistoryRequest.Merge(m, src)
+}
+func (m *HeldHistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_HeldHistoryRequest.Size(m)
+}
+func (m *HeldHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HeldHistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HeldHistoryRequest proto.InternalMessageInfo
+
+func (m *HeldHistoryRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+// HeldHistoryEntry contains amount held and disposed by the satellite in a single period.
+type HeldHistoryEntry struct {
+	SatelliteId NodeID `protobuf:"bytes,1,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
+	// period is in YYYY-MM format.
+	Period               string      `protobuf:"bytes,2,opt,name=period,proto3" json:"period,omitempty"`
+	Held                 int64       `protobuf:"varint,3,opt,name=held,proto3" json:"held,omitempty"`
+	Disposed             int64       `protobuf:"varint,4,opt,name=disposed,proto3" json:"disposed,omitempty"`
+	Unit                 *AmountUnit `protobuf:"bytes,5,opt,name=unit,proto3" json:"unit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *HeldHistoryEntry) Reset()         { *m = HeldHistoryEntry{} }
+func (m *HeldHistoryEntry) String() string { return proto.CompactTextString(m) }
+func (*HeldHistoryEntry) ProtoMessage()    {}
+func (*HeldHistoryEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{53}
+}
+func (m *HeldHistoryEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HeldHistoryEntry.Unmarshal(m, b)
+}
+func (m *HeldHistoryEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HeldHistoryEntry.Marshal(b, m, deterministic)
+}
+func (m *HeldHistoryEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HeldHistoryEntry.Merge(m, src)
+}
+func (m *HeldHistoryEntry) XXX_Size() int {
+	return xxx_messageInfo_HeldHistoryEntry.Size(m)
+}
+func (m *HeldHistoryEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_HeldHistoryEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HeldHistoryEntry proto.InternalMessageInfo
+
+func (m *HeldHistoryEntry) GetPeriod() string {
+	if m != nil {
+		return m.Period
+	}
+	return ""
+}
+
+func (m *HeldHistoryEntry) GetHeld() int64 {
+	if m != nil {
+		return m.Held
+	}
+	return 0
+}
+
+func (m *HeldHistoryEntry) GetDisposed() int64 {
+	if m != nil {
+		return m.Disposed
+	}
+	return 0
+}
+
+func (m *HeldHistoryEntry) GetUnit() *AmountUnit {
+	if m != nil {
+		return m.Unit
+	}
+	return nil
+}
+
+type HeldHistoryResponse struct {
+	// entries are sorted by satellite id and period.
+	Entries              []*HeldHistoryEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *HeldHistoryResponse) Reset()         { *m = HeldHistoryResponse{} }
+func (m *HeldHistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*HeldHistoryResponse) ProtoMessage()    {}
+func (*HeldHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{54}
+}
+func (m *HeldHistoryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HeldHistoryResponse.Unmarshal(m, b)
+}
+func (m *HeldHistoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HeldHistoryResponse.Marshal(b, m, deterministic)
+}
+func (m *HeldHistoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HeldHistoryResponse.Merge(m, src)
+}
+func (m *HeldHistoryResponse) XXX_Size() int {
+	return xxx_messageInfo_HeldHistoryResponse.Size(m)
+}
+func (m *HeldHistoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HeldHistoryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HeldHistoryResponse proto.InternalMessageInfo
+
+func (m *HeldHistoryResponse) GetEntries() []*HeldHistoryEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+type PayoutInfo struct {
+	Held int64       `protobuf:"varint,1,opt,name=held,proto3" json:"held,omitempty"`
+	Paid int64       `protobuf:"varint,2,opt,name=paid,proto3" json:"paid,omitempty"`
+	Unit *AmountUnit `protobuf:"bytes,3,opt,name=unit,proto3" json:"unit,omitempty"`
+	// held_display and paid_display are set only when format is requested, raw amounts stay authoritative.
+	HeldDisplay          string   `protobuf:"bytes,4,opt,name=held_display,json=heldDisplay,proto3" json:"held_display,omitempty"`
+	PaidDisplay          string   `protobuf:"bytes,5,opt,name=paid_display,json=paidDisplay,proto3" json:"paid_display,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PayoutInfo) Reset()         { *m = PayoutInfo{} }
+func (m *PayoutInfo) String() string { return proto.CompactTextString(m) }
+func (*PayoutInfo) ProtoMessage()    {}
+func (*PayoutInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{55}
+}
+func (m *PayoutInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PayoutInfo.Unmarshal(m, b)
+}
+func (m *PayoutInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PayoutInfo.Marshal(b, m, deterministic)
+}
+func (m *PayoutInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PayoutInfo.Merge(m, src)
+}
+func (m *PayoutInfo) XXX_Size() int {
+	return xxx_messageInfo_PayoutInfo.Size(m)
+}
+func (m *PayoutInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_PayoutInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PayoutInfo proto.InternalMessageInfo
+
+func (m *PayoutInfo) GetHeld() int64 {
+	if m != nil {
+		return m.Held
+	}
+	return 0
+}
+
+func (m *PayoutInfo) GetPaid() int64 {
+	if m != nil {
+		return m.Paid
+	}
+	return 0
+}
+
+func (m *PayoutInfo) GetUnit() *AmountUnit {
+	if m != nil {
+		return m.Unit
+	}
+	return nil
+}
+
+func (m *PayoutInfo) GetHeldDisplay() string {
+	if m != nil {
+		return m.HeldDisplay
+	}
+	return ""
+}
+
+func (m *PayoutInfo) GetPaidDisplay() string {
+	if m != nil {
+		return m.PaidDisplay
+	}
+	return ""
+}
+
+// AmountUnit describes currency and scale of amounts, e.g. 6 decimals means amounts are in millionths of the currency.
+type AmountUnit struct {
+	Currency             string   `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	Decimals             int32    `protobuf:"varint,2,opt,name=decimals,proto3" json:"decimals,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AmountUnit) Reset()         { *m = AmountUnit{} }
+func (m *AmountUnit) String() string { return proto.CompactTextString(m) }
+func (*AmountUnit) ProtoMessage()    {}
+func (*AmountUnit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9a45fd79b06f3a1b, []int{56}
+}
+func (m *AmountUnit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AmountUnit.Unmarshal(m, b)
+}
+func (m *AmountUnit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AmountUnit.Marshal(b, m, deterministic)
+}
+func (m *AmountUnit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AmountUnit.Merge(m, src)
+}
+func (m *AmountUnit) XXX_Size() int {
+	return xxx_messageInfo_AmountUnit.Size(m)
+}
+func (m *AmountUnit) XXX_DiscardUnknown() {
+	xxx_messageInfo_AmountUnit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AmountUnit proto.InternalMessageInfo
+
+func (m *AmountUnit) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+func (m *AmountUnit) GetDecimals() int32 {
+	if m != nil {
+		return m.Decimals
 	}
 	return 0
 }
@@ -1418,6 +3040,12 @@ func init() {
 	proto.RegisterType((*TrustedSatellitesRequest)(nil), "multinode.TrustedSatellitesRequest")
 	proto.RegisterType((*TrustedSatellitesResponse)(nil), "multinode.TrustedSatellitesResponse")
 	proto.RegisterType((*TrustedSatellitesResponse_NodeURL)(nil), "multinode.TrustedSatellitesResponse.NodeURL")
+	proto.RegisterType((*SatelliteCheckInsRequest)(nil), "multinode.SatelliteCheckInsRequest")
+	proto.RegisterType((*SatelliteCheckInsResponse)(nil), "multinode.SatelliteCheckInsResponse")
+	proto.RegisterType((*SatelliteCheckInsResponse_CheckIn)(nil), "multinode.SatelliteCheckInsResponse.CheckIn")
+	proto.RegisterType((*ReputationScoresRequest)(nil), "multinode.ReputationScoresRequest")
+	proto.RegisterType((*ReputationScoresResponse)(nil), "multinode.ReputationScoresResponse")
+	proto.RegisterType((*ReputationScoresResponse_SatelliteScores)(nil), "multinode.ReputationScoresResponse.SatelliteScores")
 	proto.RegisterType((*EstimatedPayoutSatelliteRequest)(nil), "multinode.EstimatedPayoutSatelliteRequest")
 	proto.RegisterType((*EstimatedPayoutSatelliteResponse)(nil), "multinode.EstimatedPayoutSatelliteResponse")
 	proto.RegisterType((*EstimatedPayoutTotalRequest)(nil), "multinode.EstimatedPayoutTotalRequest")
@@ -1430,91 +3058,196 @@ func init() {
 	proto.RegisterType((*SatelliteSummaryResponse)(nil), "multinode.SatelliteSummaryResponse")
 	proto.RegisterType((*SatellitePeriodSummaryRequest)(nil), "multinode.SatellitePeriodSummaryRequest")
 	proto.RegisterType((*SatellitePeriodSummaryResponse)(nil), "multinode.SatellitePeriodSummaryResponse")
+	proto.RegisterType((*SatelliteSummariesRequest)(nil), "multinode.SatelliteSummariesRequest")
+	proto.RegisterType((*SatelliteSummariesResponse)(nil), "multinode.SatelliteSummariesResponse")
+	proto.RegisterType((*SatelliteSummariesResponse_NodeSummary)(nil), "multinode.SatelliteSummariesResponse.NodeSummary")
 	proto.RegisterType((*EarnedRequest)(nil), "multinode.EarnedRequest")
 	proto.RegisterType((*EarnedResponse)(nil), "multinode.EarnedResponse")
+	proto.RegisterType((*EarnedComponents)(nil), "multinode.EarnedComponents")
 	proto.RegisterType((*EarnedPerSatelliteRequest)(nil), "multinode.EarnedPerSatelliteRequest")
 	proto.RegisterType((*EarnedPerSatelliteResponse)(nil), "multinode.EarnedPerSatelliteResponse")
 	proto.RegisterType((*EarnedSatellite)(nil), "multinode.EarnedSatellite")
+	proto.RegisterType((*UndistributedPerSatelliteRequest)(nil), "multinode.UndistributedPerSatelliteRequest")
+	proto.RegisterType((*UndistributedPerSatelliteResponse)(nil), "multinode.UndistributedPerSatelliteResponse")
+	proto.RegisterType((*UndistributedSatellite)(nil), "multinode.UndistributedSatellite")
+	proto.RegisterType((*AvailablePeriodsRequest)(nil), "multinode.AvailablePeriodsRequest")
+	proto.RegisterType((*AvailablePeriodsResponse)(nil), "multinode.AvailablePeriodsResponse")
+	proto.RegisterType((*HeldRatesRequest)(nil), "multinode.HeldRatesRequest")
+	proto.RegisterType((*HeldRatesResponse)(nil), "multinode.HeldRatesResponse")
+	proto.RegisterType((*HeldRatesResponse_HeldRate)(nil), "multinode.HeldRatesResponse.HeldRate")
+	proto.RegisterType((*PayoutConfigRequest)(nil), "multinode.PayoutConfigRequest")
+	proto.RegisterType((*PayoutConfigResponse)(nil), "multinode.PayoutConfigResponse")
+	proto.RegisterType((*FirstPaystubPeriodRequest)(nil), "multinode.FirstPaystubPeriodRequest")
+	proto.RegisterType((*FirstPaystubPeriodResponse)(nil), "multinode.FirstPaystubPeriodResponse")
+	proto.RegisterType((*PaymentsPerSatelliteRequest)(nil), "multinode.PaymentsPerSatelliteRequest")
+	proto.RegisterType((*PaymentsPerSatelliteResponse)(nil), "multinode.PaymentsPerSatelliteResponse")
+	proto.RegisterType((*PaymentsPerSatelliteResponse_SatellitePayments)(nil), "multinode.PaymentsPerSatelliteResponse.SatellitePayments")
+	proto.RegisterType((*PayingSatellitesRequest)(nil), "multinode.PayingSatellitesRequest")
+	proto.RegisterType((*PayingSatellitesResponse)(nil), "multinode.PayingSatellitesResponse")
+	proto.RegisterType((*HeldHistoryRequest)(nil), "multinode.HeldHistoryRequest")
+	proto.RegisterType((*HeldHistoryEntry)(nil), "multinode.HeldHistoryEntry")
+	proto.RegisterType((*HeldHistoryResponse)(nil), "multinode.HeldHistoryResponse")
 	proto.RegisterType((*PayoutInfo)(nil), "multinode.PayoutInfo")
+	proto.RegisterType((*AmountUnit)(nil), "multinode.AmountUnit")
 }
 
 func init() { proto.RegisterFile("multinode.proto", fileDescriptor_9a45fd79b06f3a1b) }
 
 var fileDescriptor_9a45fd79b06f3a1b = []byte{
-	// 1199 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x57, 0xdd, 0x72, 0xdb, 0x44,
-	0x14, 0x46, 0x4d, 0x22, 0xd7, 0xc7, 0x69, 0x7e, 0x96, 0xd0, 0x2a, 0xaa, 0x13, 0x07, 0x25, 0x34,
-	0x29, 0x6d, 0x1d, 0x70, 0x19, 0x66, 0x98, 0x81, 0x19, 0x12, 0x92, 0xd2, 0x0c, 0x09, 0x4d, 0x95,
-	0xd0, 0x61, 0x0a, 0x53, 0xcf, 0xc6, 0xda, 0x38, 0xa2, 0xb2, 0x56, 0x68, 0xd7, 0x81, 0xdc, 0x70,
-	0xc9, 0x35, 0x0f, 0xc0, 0x83, 0x70, 0xc7, 0x70, 0xc3, 0xf0, 0x0c, 0x5c, 0x94, 0xc7, 0xe0, 0x96,
-	0xd9, 0x1f, 0xcb, 0xb2, 0x2d, 0x2b, 0xc1, 0x66, 0xb8, 0xdb, 0x3d, 0x3f, 0xdf, 0x39, 0xbb, 0xe7,
-	0xe8, 0xec, 0x27, 0x98, 0x6d, 0xb5, 0x03, 0xee, 0x87, 0xd4, 0x23, 0xd5, 0x28, 0xa6, 0x9c, 0xa2,
-	0x62, 0x22, 0xb0, 0xa1, 0x49, 0x9b, 0x54, 0x89, 0xed, 0x4a, 0x93, 0xd2, 0x66, 0x40, 0x36, 0xe5,
-	0xee, 0xa4, 0x7d, 0xba, 0xc9, 0xfd, 0x16, 0x61, 0x1c, 0xb7, 0x22, 0x65, 0xe0, 0x6c, 0xc0, 0x0d,
-	0x97, 0x7c, 0xdb, 0x26, 0x8c, 0x3f, 0x26, 0xd8, 0x23, 0x31, 0xba, 0x05, 0x05, 0x1c, 0xf9, 0xf5,
-	0x97, 0xe4, 0xc2, 0x32, 0x56, 0x8c, 0x8d, 0x69, 0xd7, 0xc4, 0x91, 0xff, 0x19, 0xb9, 0x70, 0x76,
-	0x60, 0x6e, 0xc7, 0x67, 0x2f, 0x8f, 0x22, 0xdc, 0x20, 0xda, 0x05, 0xbd, 0x03, 0xe6, 0x99, 0x74,
-	0x93, 0xb6, 0xa5, 0x9a, 0x55, 0xed, 0xe6, 0xd5, 0x03, 0xeb, 0x6a, 0x3b, 0xe7, 0x57, 0x03, 0xe6,
-	0x53, 0x30, 0x2c, 0xa2, 0x21, 0x23, 0xa8, 0x0c, 0x45, 0x1c, 0x04, 0xb4, 0x81, 0x39, 0xf1, 0x24,
-	0xd4, 0x84, 0xdb, 0x15, 0xa0, 0x0a, 0x94, 0xda, 0x8c, 0x78, 0xf5, 0xc8, 0x27, 0x0d, 0xc2, 0xac,
-	0x6b, 0x52, 0x0f, 0x42, 0x74, 0x28, 0x25, 0x68, 0x09, 0xe4, 0xae, 0xce, 0x63, 0xcc, 0xce, 0xac,
-	0x09, 0xe5, 0x2f, 0x24, 0xc7, 0x42, 0x80, 0x10, 0x4c, 0x9e, 0xc6, 0x84, 0x58, 0x93, 0x52, 0x21,
-	0xd7, 0x32, 0xe2, 0x39, 0xf6, 0x03, 0x7c, 0x12, 0x10, 0x6b, 0x4a, 0x47, 0xec, 0x08, 0x90, 0x0d,
-	0xd7, 0xe9, 0x39, 0x89, 0x05, 0x84, 0x65, 0x4a, 0x65, 0xb2, 0x77, 0x0e, 0xa1, 0xbc, 0x8d, 0x43,
-	0xef, 0x3b, 0xdf, 0xe3, 0x67, 0x07, 0x34, 0xe4, 0x67, 0x47, 0xed, 0x56, 0x0b, 0xc7, 0x17, 0xa3,
-	0xdf, 0xc9, 0x43, 0x58, 0x1a, 0x82, 0xa8, 0xaf, 0x07, 0xc1, 0xa4, 0x4c, 0x45, 0xdd, 0x8c, 0x5c,
-	0x3b, 0xdb, 0x30, 0xf3, 0x8c, 0xc4, 0xcc, 0xa7, 0xe1, 0xe8, 0x81, 0xef, 0xc1, 0x6c, 0x82, 0xa1,
-	0x43, 0x59, 0x50, 0x38, 0x57, 0x22, 0x89, 0x52, 0x74, 0x3b, 0x5b, 0xe7, 0x11, 0xa0, 0x7d, 0xcc,
-	0xf8, 0x27, 0x34, 0xe4, 0xb8, 0xc1, 0x47, 0x0f, 0xfa, 0x02, 0x5e, 0xef, 0xc1, 0xd1, 0x81, 0x3f,
-	0x85, 0xe9, 0x00, 0x33, 0x5e, 0x6f, 0x28, 0xb9, 0x86, 0xb3, 0xab, 0xaa, 0x81, 0xab, 0x9d, 0x06,
-	0xae, 0x1e, 0x77, 0x1a, 0x78, 0xfb, 0xfa, 0x1f, 0xaf, 0x2a, 0xaf, 0xfd, 0xf4, 0x57, 0xc5, 0x70,
-	0x4b, 0x41, 0x17, 0xd0, 0xf9, 0x1e, 0xe6, 0x5d, 0x12, 0xb5, 0x39, 0xe6, 0xe3, 0xdc, 0x0d, 0x7a,
-	0x17, 0xa6, 0x19, 0xe6, 0x24, 0x08, 0x7c, 0x4e, 0xea, 0xbe, 0x27, 0xbb, 0x6e, 0x7a, 0x7b, 0x46,
-	0xc4, 0xfc, 0xf3, 0x55, 0xc5, 0xfc, 0x9c, 0x7a, 0x64, 0x6f, 0xc7, 0x2d, 0x25, 0x36, 0x7b, 0x9e,
-	0xf3, 0xb7, 0x01, 0x28, 0x1d, 0x5a, 0x9f, 0xec, 0x43, 0x30, 0x69, 0x18, 0xf8, 0x21, 0xd1, 0xb1,
-	0xd7, 0x7a, 0x62, 0xf7, 0x9b, 0x57, 0x9f, 0x48, 0x5b, 0x57, 0xfb, 0xa0, 0x0f, 0x60, 0x0a, 0xb7,
-	0x3d, 0x9f, 0xcb, 0x04, 0x4a, 0xb5, 0xd5, 0x7c, 0xe7, 0x2d, 0x61, 0xea, 0x2a, 0x0f, 0x7b, 0x19,
-	0x4c, 0x05, 0x86, 0x16, 0x60, 0x8a, 0x35, 0x68, 0xac, 0x32, 0x30, 0x5c, 0xb5, 0xb1, 0x1f, 0xc3,
-	0x94, 0xb4, 0xcf, 0x56, 0xa3, 0xbb, 0x30, 0xc7, 0xda, 0x2c, 0x22, 0xa1, 0x28, 0x7f, 0x5d, 0x19,
-	0x5c, 0x93, 0x06, 0xb3, 0x5d, 0xf9, 0x91, 0x10, 0x3b, 0xfb, 0x60, 0x1d, 0xc7, 0x6d, 0xc6, 0x89,
-	0x77, 0xd4, 0xb9, 0x0f, 0x36, 0x7a, 0x87, 0xfc, 0x6e, 0xc0, 0x62, 0x06, 0x9c, 0xbe, 0xce, 0xaf,
-	0x00, 0x71, 0xa5, 0xac, 0x27, 0x97, 0xcf, 0x2c, 0x63, 0x65, 0x62, 0xa3, 0x54, 0xbb, 0x9f, 0xc2,
-	0x1e, 0x8a, 0x50, 0x15, 0xb5, 0xfb, 0xc2, 0xdd, 0x77, 0xe7, 0x79, 0xbf, 0x89, 0xbd, 0x0f, 0x05,
-	0xad, 0x45, 0xeb, 0x50, 0x10, 0x38, 0xa2, 0xf6, 0x46, 0x66, 0xed, 0x4d, 0xa1, 0xde, 0xf3, 0xc4,
-	0x27, 0x83, 0x3d, 0x2f, 0x26, 0x4c, 0x8d, 0xa6, 0xa2, 0xdb, 0xd9, 0x3a, 0x3f, 0x1a, 0x50, 0xd9,
-	0x65, 0xdc, 0x6f, 0x89, 0x31, 0x76, 0x88, 0x2f, 0x68, 0x9b, 0x27, 0xb1, 0xfe, 0xd7, 0xce, 0x7c,
-	0x0a, 0x2b, 0xc3, 0xf3, 0xd0, 0xf7, 0xfa, 0x00, 0x10, 0xe9, 0xd8, 0xd4, 0x09, 0x8e, 0x43, 0x3f,
-	0x6c, 0x32, 0x3d, 0x72, 0xe6, 0x13, 0xcd, 0xae, 0x56, 0x38, 0x4f, 0xe0, 0x76, 0x1f, 0xe4, 0x31,
-	0xe5, 0x38, 0x18, 0xbd, 0xea, 0x07, 0x50, 0xce, 0x06, 0x1c, 0x39, 0xbf, 0xad, 0x20, 0xe8, 0x96,
-	0x76, 0xec, 0x29, 0xfd, 0x0c, 0xca, 0xd9, 0x80, 0x3a, 0xbf, 0xf7, 0xa1, 0x14, 0xc9, 0xb4, 0xeb,
-	0x7e, 0x78, 0x4a, 0x35, 0xec, 0x1b, 0x29, 0x58, 0x75, 0xa8, 0xbd, 0xf0, 0x94, 0xba, 0x10, 0x25,
-	0x6b, 0xa7, 0x05, 0x6f, 0xf6, 0xe0, 0x1e, 0x92, 0xd8, 0xa7, 0xde, 0xb8, 0xe9, 0xa2, 0x9b, 0x60,
-	0x46, 0x12, 0x49, 0x37, 0xa5, 0xde, 0x39, 0x5f, 0x83, 0x93, 0x17, 0x6e, 0xcc, 0xc3, 0xfc, 0x00,
-	0xb7, 0x12, 0xe8, 0xb1, 0x8f, 0x30, 0x42, 0xa3, 0xbb, 0x60, 0x0d, 0xc6, 0x1f, 0xf3, 0x4c, 0x3f,
-	0x1b, 0xb0, 0x94, 0x80, 0xfe, 0x47, 0xd5, 0xf9, 0xf7, 0x47, 0x4b, 0x15, 0x74, 0xa2, 0xa7, 0xa0,
-	0x5f, 0xc2, 0xf2, 0xb0, 0xec, 0xc6, 0x3c, 0xf8, 0x16, 0xdc, 0x10, 0x9f, 0x13, 0xf1, 0x46, 0xff,
-	0x68, 0xee, 0xc0, 0x4c, 0x07, 0x42, 0x27, 0xb3, 0x00, 0x53, 0x5c, 0x7c, 0xd7, 0xfa, 0xcb, 0x55,
-	0x1b, 0xe7, 0x00, 0x16, 0x95, 0xdd, 0x21, 0x89, 0xc7, 0x1f, 0x91, 0x4e, 0x03, 0xec, 0x2c, 0x38,
-	0x9d, 0xc2, 0x2e, 0xcc, 0x11, 0xa9, 0xed, 0x3e, 0x20, 0xfa, 0xfd, 0xb0, 0x53, 0xc8, 0x0a, 0xa0,
-	0xeb, 0x3d, 0x4b, 0x7a, 0x05, 0xce, 0x73, 0x98, 0xed, 0xb3, 0xc9, 0x3e, 0xdc, 0x28, 0x7d, 0xfc,
-	0x1e, 0x40, 0xb7, 0x28, 0x82, 0xff, 0x9d, 0x91, 0x20, 0xe1, 0x7f, 0x62, 0x2d, 0x64, 0x11, 0xd6,
-	0x60, 0x13, 0xae, 0x5c, 0xd7, 0x9e, 0x42, 0xe1, 0x88, 0xd3, 0x18, 0x37, 0x09, 0x7a, 0x04, 0xc5,
-	0x84, 0x66, 0xa3, 0xdb, 0xa9, 0x63, 0xf5, 0x73, 0x78, 0xbb, 0x9c, 0xad, 0x54, 0x77, 0x55, 0x0b,
-	0xa1, 0x98, 0x70, 0x53, 0x84, 0x61, 0x3a, 0xcd, 0x4f, 0xd1, 0x7a, 0xca, 0x35, 0x8f, 0x13, 0xdb,
-	0x1b, 0x97, 0x1b, 0xea, 0x78, 0xbf, 0x5d, 0x83, 0x49, 0x71, 0x21, 0xe8, 0x63, 0x28, 0x68, 0x6e,
-	0x8a, 0x16, 0x53, 0xde, 0xbd, 0x9c, 0xd7, 0xb6, 0xb3, 0x54, 0xba, 0xcc, 0xfb, 0x50, 0x4a, 0x11,
-	0x4d, 0xb4, 0x94, 0x32, 0x1d, 0x24, 0xb2, 0xf6, 0xf2, 0x30, 0xb5, 0x46, 0xdb, 0x03, 0xe8, 0xf2,
-	0x2d, 0x54, 0x1e, 0x42, 0xc3, 0x14, 0xd6, 0x52, 0x2e, 0x49, 0x43, 0x2f, 0x60, 0x7e, 0x80, 0x9c,
-	0xa0, 0xd5, 0x7c, 0xea, 0xa2, 0x80, 0xd7, 0xae, 0xc2, 0x6f, 0x6a, 0xbf, 0x98, 0x60, 0xaa, 0xee,
-	0x41, 0x4d, 0x58, 0xc8, 0x7a, 0xb4, 0xd0, 0x9d, 0x14, 0x50, 0xce, 0x33, 0x69, 0xaf, 0x5f, 0x6a,
-	0xa7, 0xcf, 0x74, 0x01, 0xf6, 0xf0, 0x67, 0x05, 0xdd, 0x1f, 0x06, 0x93, 0x35, 0x4e, 0xed, 0x07,
-	0x57, 0xb4, 0x4e, 0x08, 0xe1, 0x5c, 0xff, 0xcc, 0x47, 0x4e, 0x0a, 0x62, 0xc8, 0x83, 0x64, 0xaf,
-	0xe6, 0xda, 0x68, 0xf0, 0x16, 0xdc, 0xcc, 0x9e, 0xae, 0x68, 0x23, 0xcb, 0x3d, 0xf3, 0x3c, 0x77,
-	0xaf, 0x60, 0xa9, 0xc3, 0x7d, 0x04, 0xa6, 0x9a, 0x29, 0xc8, 0x1a, 0x18, 0x45, 0x1d, 0xb8, 0xc5,
-	0x0c, 0x8d, 0x76, 0xc7, 0x80, 0x06, 0xe7, 0x1e, 0x5a, 0x1b, 0x70, 0xc8, 0x98, 0xb2, 0xf6, 0x5b,
-	0x97, 0x58, 0xe9, 0x10, 0x0c, 0xac, 0x61, 0x54, 0x12, 0xbd, 0x9d, 0x86, 0xc8, 0xe7, 0xbd, 0xf6,
-	0xbd, 0x2b, 0xd9, 0xea, 0xa0, 0x4d, 0x58, 0xc8, 0xe2, 0x86, 0x3d, 0x6d, 0x9c, 0xc3, 0x46, 0x7b,
-	0xda, 0x38, 0x8f, 0x64, 0x6e, 0xaf, 0x3d, 0x77, 0x18, 0xa7, 0xf1, 0x37, 0x55, 0x9f, 0x6e, 0xca,
-	0xc5, 0x66, 0x14, 0xfb, 0xe7, 0x98, 0x93, 0xcd, 0x04, 0x20, 0x3a, 0x39, 0x31, 0xe5, 0xdf, 0xe8,
-	0xc3, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0x44, 0x72, 0xb4, 0x3b, 0x86, 0x11, 0x00, 0x00,
+	// 2474 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x59, 0xcb, 0x6f, 0x24, 0x49,
+	0xd1, 0xff, 0xca, 0x6d, 0xf7, 0x23, 0xba, 0xfd, 0xca, 0x99, 0x9d, 0x69, 0x97, 0xdf, 0x65, 0xcf,
+	0xda, 0xfb, 0xcd, 0xac, 0x67, 0xd7, 0x03, 0x48, 0x2b, 0x40, 0xc2, 0x33, 0x9e, 0x87, 0x35, 0x86,
+	0xf1, 0x96, 0x67, 0x96, 0xd5, 0xb2, 0xda, 0x56, 0xba, 0x2b, 0xdd, 0xae, 0x99, 0xea, 0xaa, 0xa2,
+	0x2a, 0xcb, 0x4b, 0x4b, 0x1c, 0xb8, 0x70, 0xe1, 0x84, 0x38, 0x70, 0x43, 0xe2, 0xc2, 0x05, 0xed,
+	0x09, 0x38, 0x80, 0x84, 0x84, 0xb8, 0xa0, 0xbd, 0x71, 0x58, 0x4e, 0x1c, 0x96, 0x23, 0x7f, 0xc2,
+	0xde, 0x10, 0xca, 0x47, 0xbd, 0xba, 0xaa, 0xda, 0xee, 0x6e, 0x33, 0xb7, 0xca, 0xc8, 0xa8, 0x88,
+	0xc8, 0x5f, 0x46, 0x46, 0x66, 0x44, 0xc0, 0x6c, 0x37, 0xb0, 0xa8, 0x69, 0x3b, 0x06, 0xd9, 0x71,
+	0x3d, 0x87, 0x3a, 0xa8, 0x16, 0x11, 0x54, 0xe8, 0x38, 0x1d, 0x47, 0x90, 0xd5, 0xd5, 0x8e, 0xe3,
+	0x74, 0x2c, 0x72, 0x97, 0x8f, 0x4e, 0x82, 0xd3, 0xbb, 0xd4, 0xec, 0x12, 0x9f, 0xe2, 0xae, 0x2b,
+	0x18, 0xb4, 0x97, 0x30, 0xad, 0x93, 0x1f, 0x06, 0xc4, 0xa7, 0x4f, 0x08, 0x36, 0x88, 0x87, 0x6e,
+	0x42, 0x05, 0xbb, 0x66, 0xeb, 0x15, 0xe9, 0x35, 0x95, 0x35, 0x65, 0xbb, 0xa1, 0x97, 0xb1, 0x6b,
+	0x3e, 0x25, 0x3d, 0x74, 0x0b, 0x66, 0xda, 0x96, 0x49, 0x6c, 0xda, 0x3a, 0x27, 0x9e, 0x6f, 0x3a,
+	0x76, 0x73, 0x62, 0x4d, 0xd9, 0xae, 0xe9, 0xd3, 0x82, 0xfa, 0x81, 0x20, 0xa2, 0x05, 0xa8, 0x52,
+	0x0f, 0xb7, 0x49, 0xcb, 0x34, 0x9a, 0x25, 0xce, 0x50, 0xe1, 0xe3, 0x03, 0x43, 0xdb, 0x87, 0xb9,
+	0x7d, 0xd3, 0x7f, 0x75, 0xec, 0xe2, 0x36, 0x91, 0x4a, 0xd1, 0x3b, 0x50, 0x3e, 0xe3, 0x8a, 0xb9,
+	0xb6, 0xfa, 0x6e, 0x73, 0x27, 0x5e, 0x59, 0xca, 0x30, 0x5d, 0xf2, 0x69, 0x7f, 0x51, 0x60, 0x3e,
+	0x21, 0xc6, 0x77, 0x1d, 0xdb, 0x27, 0x68, 0x09, 0x6a, 0xd8, 0xb2, 0x9c, 0x36, 0xa6, 0xc4, 0xe0,
+	0xa2, 0x4a, 0x7a, 0x4c, 0x40, 0xab, 0x50, 0x0f, 0x7c, 0x62, 0xb4, 0x5c, 0x93, 0xb4, 0x89, 0xcf,
+	0x0d, 0x2f, 0xe9, 0xc0, 0x48, 0x47, 0x9c, 0x82, 0x96, 0x81, 0x8f, 0x5a, 0xd4, 0xc3, 0xfe, 0x19,
+	0xb7, 0xbb, 0xa4, 0xd7, 0x18, 0xe5, 0x39, 0x23, 0x20, 0x04, 0x93, 0xa7, 0x1e, 0x21, 0xcd, 0x49,
+	0x3e, 0xc1, 0xbf, 0xb9, 0xc6, 0x73, 0x6c, 0x5a, 0xf8, 0xc4, 0x22, 0xcd, 0x29, 0xa9, 0x31, 0x24,
+	0x20, 0x15, 0xaa, 0xce, 0x39, 0xf1, 0x98, 0x88, 0x66, 0x99, 0x4f, 0x46, 0x63, 0xed, 0x08, 0x96,
+	0xee, 0x63, 0xdb, 0xf8, 0xd4, 0x34, 0xe8, 0xd9, 0x77, 0x1d, 0x9b, 0x9e, 0x1d, 0x07, 0xdd, 0x2e,
+	0xf6, 0x7a, 0xa3, 0x63, 0xf2, 0x14, 0x96, 0x0b, 0x24, 0x4a, 0x78, 0x10, 0x4c, 0x72, 0x53, 0x04,
+	0x32, 0xfc, 0x1b, 0xdd, 0x80, 0x32, 0xe9, 0x78, 0xc4, 0x0f, 0xf1, 0x90, 0x23, 0xed, 0x3e, 0xcc,
+	0xc8, 0xcd, 0x1c, 0xdd, 0xa0, 0xdb, 0x30, 0x1b, 0xc9, 0x90, 0x26, 0x34, 0xa1, 0x12, 0x3a, 0x8e,
+	0x22, 0xfc, 0x42, 0x0e, 0xb5, 0x47, 0x80, 0x0e, 0xb1, 0x4f, 0x1f, 0x38, 0x36, 0xc5, 0x6d, 0x3a,
+	0xba, 0xd2, 0x4f, 0xe0, 0x5a, 0x4a, 0x8e, 0x54, 0xfc, 0x18, 0x1a, 0x16, 0xf6, 0x69, 0xab, 0x2d,
+	0xe8, 0x52, 0x9c, 0xba, 0x23, 0x8e, 0xc6, 0x4e, 0x78, 0x34, 0x76, 0x9e, 0x87, 0x47, 0xe3, 0x7e,
+	0xf5, 0xf3, 0x2f, 0x57, 0xff, 0xef, 0xe7, 0xff, 0x5a, 0x55, 0xf4, 0xba, 0x15, 0x0b, 0xd4, 0x7e,
+	0x04, 0xf3, 0x3a, 0x71, 0x03, 0x8a, 0xe9, 0x38, 0xd8, 0xa0, 0x77, 0xa1, 0xe1, 0x63, 0x4a, 0x2c,
+	0xcb, 0xa4, 0xfc, 0x94, 0x30, 0xf4, 0x1b, 0xf7, 0x67, 0x98, 0xce, 0x7f, 0x7e, 0xb9, 0x5a, 0xfe,
+	0x9e, 0x63, 0x90, 0x83, 0x7d, 0xbd, 0x1e, 0xf1, 0x1c, 0x18, 0xda, 0x57, 0x0a, 0xa0, 0xa4, 0x6a,
+	0xb9, 0xb2, 0x6f, 0x41, 0xd9, 0xb1, 0x2d, 0xd3, 0x26, 0x52, 0xf7, 0x66, 0x4a, 0x77, 0x3f, 0xfb,
+	0xce, 0x33, 0xce, 0xab, 0xcb, 0x7f, 0xd0, 0x7b, 0x30, 0x85, 0x03, 0xc3, 0xa4, 0xdc, 0x80, 0xfa,
+	0xee, 0xc6, 0xe0, 0x9f, 0xf7, 0x18, 0xab, 0x2e, 0xfe, 0x50, 0x57, 0xa0, 0x2c, 0x84, 0xa1, 0xeb,
+	0x30, 0xe5, 0xb7, 0x1d, 0x4f, 0x58, 0xa0, 0xe8, 0x62, 0xa0, 0x3e, 0x81, 0x29, 0xce, 0x9f, 0x3f,
+	0x8d, 0xde, 0x82, 0x39, 0x3f, 0xf0, 0x5d, 0x62, 0xb3, 0xed, 0x6f, 0x09, 0x86, 0x09, 0xce, 0x30,
+	0x1b, 0xd3, 0x8f, 0x19, 0x59, 0x3b, 0x84, 0xe6, 0x73, 0x2f, 0xf0, 0x29, 0x31, 0x8e, 0x43, 0x3c,
+	0xfc, 0xd1, 0x3d, 0xe4, 0x6f, 0x0a, 0x2c, 0xe4, 0x88, 0x93, 0x70, 0xfe, 0x00, 0x10, 0x15, 0x93,
+	0xad, 0x08, 0x7c, 0xbf, 0xa9, 0xac, 0x95, 0xb6, 0xeb, 0xbb, 0x77, 0x12, 0xb2, 0x0b, 0x25, 0xec,
+	0xb0, 0xbd, 0x7b, 0xa1, 0x1f, 0xea, 0xf3, 0xb4, 0x9f, 0x45, 0x3d, 0x84, 0x8a, 0x9c, 0x45, 0x5b,
+	0x50, 0x61, 0x72, 0xd8, 0xde, 0x2b, 0xb9, 0x7b, 0x5f, 0x66, 0xd3, 0x07, 0x06, 0x3b, 0x32, 0xd8,
+	0x30, 0xa2, 0x23, 0x5a, 0xd3, 0xc3, 0x21, 0x83, 0x25, 0x92, 0xfd, 0xe0, 0x8c, 0xb4, 0x5f, 0x1d,
+	0xd8, 0x63, 0xc0, 0xf2, 0xe7, 0x09, 0x58, 0xc8, 0x11, 0x27, 0x61, 0x39, 0x80, 0x5a, 0x9b, 0xd1,
+	0x5a, 0xa6, 0x9d, 0x87, 0x46, 0xe1, 0x8f, 0x3b, 0x92, 0xa0, 0x57, 0xdb, 0x72, 0x46, 0xfd, 0x42,
+	0x81, 0x8a, 0xa4, 0x66, 0x8e, 0x81, 0x72, 0xe1, 0x31, 0xe0, 0x21, 0x97, 0x52, 0xd2, 0x75, 0x59,
+	0x90, 0x67, 0x88, 0x54, 0xf5, 0x98, 0xc0, 0x66, 0xfd, 0xa0, 0xdd, 0x26, 0xc4, 0x20, 0xe2, 0xea,
+	0xa9, 0xea, 0x31, 0x01, 0x3d, 0x00, 0xe0, 0x66, 0x10, 0xa3, 0x85, 0x69, 0x73, 0x72, 0x88, 0x18,
+	0x50, 0x93, 0xff, 0xed, 0x71, 0x77, 0x26, 0x9e, 0xe7, 0x78, 0x3c, 0xde, 0xd7, 0x74, 0x31, 0xd0,
+	0x9e, 0xc2, 0xcd, 0xf8, 0xc0, 0x70, 0xb7, 0x1d, 0x63, 0x2f, 0xfe, 0x34, 0x01, 0xcd, 0xac, 0x34,
+	0xb9, 0x15, 0x4f, 0xa1, 0xcc, 0x4f, 0x4b, 0xb8, 0x0f, 0xf7, 0x72, 0xcf, 0x6c, 0xfa, 0xa7, 0x78,
+	0x83, 0x24, 0x5d, 0x8a, 0x50, 0xff, 0xae, 0xc0, 0x6c, 0xdf, 0xdc, 0x28, 0x9b, 0xa2, 0x42, 0xd5,
+	0x23, 0xae, 0xe3, 0xc5, 0x7b, 0x12, 0x8d, 0xd9, 0xbd, 0xcb, 0x03, 0x86, 0x3c, 0xe3, 0x25, 0x7e,
+	0xc6, 0x81, 0x93, 0x8e, 0x0b, 0x23, 0xc1, 0x64, 0x6e, 0x24, 0x40, 0xeb, 0xd0, 0x10, 0x81, 0x4b,
+	0xb2, 0x4d, 0x71, 0xb6, 0xba, 0xa0, 0x89, 0x60, 0xf1, 0x57, 0x05, 0x56, 0x1f, 0xfa, 0xd4, 0xec,
+	0xb2, 0x4b, 0xff, 0x08, 0xf7, 0x9c, 0x80, 0x46, 0x0b, 0x7c, 0x9d, 0xf1, 0x9a, 0x87, 0x56, 0xbf,
+	0xe5, 0x9c, 0x36, 0x4b, 0x43, 0xf8, 0xd9, 0x24, 0xf6, 0x9f, 0x9d, 0x6a, 0x3f, 0x86, 0xb5, 0xe2,
+	0x25, 0x48, 0x37, 0x78, 0x1b, 0x10, 0x09, 0x79, 0x5a, 0x04, 0x7b, 0xb6, 0x69, 0x77, 0x7c, 0x79,
+	0xb7, 0xcf, 0x47, 0x33, 0x0f, 0xe5, 0x04, 0x7a, 0x0b, 0x26, 0x03, 0x3b, 0x8a, 0xf3, 0x6f, 0x24,
+	0x16, 0xbc, 0xd7, 0x75, 0x02, 0x9b, 0xbe, 0xb0, 0x4d, 0xaa, 0x73, 0x16, 0xed, 0x67, 0x0a, 0x2c,
+	0xf6, 0xa9, 0x7f, 0xee, 0x50, 0x6c, 0x8d, 0x8e, 0x5e, 0x04, 0xc5, 0xc4, 0xd0, 0x50, 0x7c, 0xa5,
+	0xc0, 0x52, 0xbe, 0x31, 0xff, 0x6b, 0x1c, 0xd0, 0x01, 0xac, 0xbb, 0x1e, 0x39, 0x37, 0x9d, 0xc0,
+	0x6f, 0x75, 0xd9, 0x83, 0xaa, 0x95, 0xa3, 0x48, 0x3c, 0x13, 0x57, 0x42, 0x46, 0xfe, 0xf0, 0x7a,
+	0x98, 0xd1, 0xba, 0x0b, 0x6f, 0xf4, 0x89, 0x72, 0x89, 0x67, 0x3a, 0x06, 0xf7, 0xf3, 0x9a, 0x7e,
+	0x2d, 0xf5, 0xfb, 0x11, 0x9f, 0xd2, 0x3a, 0xb0, 0xb8, 0x67, 0x59, 0xf1, 0xed, 0x31, 0xee, 0x03,
+	0x91, 0xbd, 0xf5, 0x4e, 0x1d, 0xaf, 0x8b, 0xa9, 0x3c, 0xa2, 0x72, 0xa4, 0x7d, 0x00, 0x4b, 0xf9,
+	0x8a, 0x24, 0xc2, 0xdf, 0x80, 0xba, 0xcb, 0x81, 0x6f, 0x99, 0xf6, 0xa9, 0xd3, 0x54, 0x32, 0xc8,
+	0x89, 0x6d, 0x39, 0xb0, 0x4f, 0x1d, 0x1d, 0xdc, 0xe8, 0x5b, 0xfb, 0xa9, 0x02, 0xeb, 0x29, 0xc1,
+	0x62, 0x61, 0x57, 0xb1, 0x0e, 0x89, 0x9e, 0xb8, 0x10, 0xe5, 0x28, 0xb1, 0xbe, 0x52, 0x6a, 0x7d,
+	0x1f, 0x83, 0x36, 0xc8, 0x8c, 0x31, 0x57, 0xf9, 0x4b, 0x05, 0x6e, 0xc6, 0x11, 0x74, 0xdc, 0xb5,
+	0x8d, 0x10, 0x67, 0x8a, 0x96, 0xad, 0x43, 0x33, 0x6b, 0xd7, 0x98, 0x8b, 0xfd, 0x83, 0x02, 0xcb,
+	0x91, 0xd0, 0x2b, 0xda, 0xce, 0xd1, 0x96, 0x2c, 0x3d, 0xa0, 0x54, 0xe0, 0x01, 0x93, 0x29, 0x28,
+	0x3e, 0x84, 0x95, 0x22, 0xab, 0xc7, 0x04, 0xe4, 0x77, 0x4a, 0xe2, 0xd5, 0x24, 0x84, 0x9a, 0xf1,
+	0xcd, 0xbf, 0x0b, 0x15, 0xb1, 0xc8, 0xf0, 0xae, 0x2e, 0x46, 0x23, 0x64, 0x7c, 0x1d, 0x70, 0xfc,
+	0x5b, 0x01, 0x35, 0xcf, 0x68, 0x89, 0xc5, 0x87, 0x30, 0xc3, 0x9f, 0xa6, 0x7e, 0x38, 0x23, 0x8d,
+	0x7f, 0x37, 0xef, 0xc1, 0x97, 0xf9, 0x9d, 0xbf, 0x7f, 0x43, 0x78, 0xa7, 0xed, 0x68, 0x60, 0x12,
+	0x5f, 0x75, 0xa0, 0x9e, 0x98, 0x1d, 0x15, 0x74, 0xb4, 0x0d, 0xb3, 0x81, 0x8d, 0x03, 0x7a, 0x46,
+	0x6c, 0x6a, 0x8a, 0x6c, 0x5f, 0x44, 0xb4, 0x7e, 0xb2, 0xb6, 0x07, 0xd3, 0x2c, 0x06, 0x13, 0x63,
+	0xf4, 0xb7, 0xd8, 0xaf, 0x15, 0x98, 0x09, 0x65, 0x48, 0x80, 0xae, 0xc3, 0x14, 0x65, 0x77, 0x90,
+	0xbc, 0x65, 0xc4, 0x60, 0x98, 0x9b, 0x65, 0x0e, 0x4a, 0x36, 0xa1, 0xf2, 0xee, 0x60, 0x9f, 0xe8,
+	0x9b, 0x00, 0x6d, 0xa7, 0xeb, 0x3a, 0x36, 0xb1, 0xa9, 0x2f, 0x5f, 0xa6, 0x8b, 0x09, 0x11, 0xc2,
+	0x82, 0x07, 0x11, 0x8b, 0x9e, 0x60, 0xd7, 0x7e, 0xa5, 0xc0, 0x5c, 0x3f, 0x03, 0x5a, 0x83, 0x06,
+	0x63, 0x69, 0x61, 0xda, 0xf2, 0x88, 0x4f, 0xa5, 0xad, 0xfc, 0xb7, 0x3d, 0xaa, 0x33, 0x2c, 0x16,
+	0xa0, 0xca, 0x39, 0x3a, 0x84, 0xca, 0xec, 0xbf, 0xc2, 0xc6, 0x8f, 0x09, 0x45, 0x6f, 0xc2, 0x6c,
+	0x38, 0xd5, 0xf2, 0x88, 0x8b, 0x4d, 0x4f, 0x1a, 0x3b, 0x2d, 0x39, 0x74, 0x4e, 0x44, 0x9b, 0x30,
+	0x13, 0xf1, 0x89, 0x3c, 0x52, 0x54, 0x47, 0x1a, 0x92, 0x8d, 0x27, 0x80, 0x9a, 0x05, 0x0b, 0xc2,
+	0xbc, 0x23, 0xe2, 0x5d, 0xc1, 0x5b, 0x6c, 0x19, 0xa0, 0x6b, 0xda, 0x2d, 0xcc, 0x51, 0x95, 0x96,
+	0xd7, 0xba, 0xa6, 0x2d, 0x60, 0xd6, 0x3e, 0x53, 0x40, 0xcd, 0x53, 0x27, 0x37, 0xef, 0x21, 0xcc,
+	0x11, 0x3e, 0x1b, 0xe7, 0x77, 0xd2, 0xbf, 0xd5, 0x0c, 0xde, 0xf1, 0xdf, 0xb3, 0x24, 0x4d, 0x18,
+	0x66, 0xb7, 0x97, 0xa0, 0x46, 0xbd, 0xc0, 0x16, 0x8e, 0x2a, 0x73, 0x92, 0x88, 0xa0, 0xfd, 0x43,
+	0x81, 0xd9, 0x3e, 0x6d, 0x05, 0x0e, 0x36, 0x42, 0x64, 0x08, 0xad, 0x2c, 0x5d, 0xda, 0x27, 0x27,
+	0x8b, 0x7c, 0x72, 0x6a, 0x38, 0x9f, 0x7c, 0x0e, 0x6b, 0x2f, 0x6c, 0xc3, 0xf4, 0xa9, 0x67, 0x9e,
+	0x04, 0xf4, 0x8a, 0xb6, 0x5e, 0xfb, 0xad, 0x02, 0xeb, 0x03, 0xc4, 0xca, 0x2d, 0xfe, 0x08, 0x6e,
+	0x06, 0x49, 0xa6, 0xcc, 0x4e, 0xaf, 0x27, 0x14, 0xa5, 0xc4, 0xc5, 0xb2, 0x6e, 0x04, 0xb9, 0xf4,
+	0x61, 0xde, 0xd1, 0x18, 0x6e, 0xe4, 0x0b, 0xbf, 0xb2, 0xfd, 0x65, 0x59, 0xe7, 0x5e, 0x58, 0x6e,
+	0x14, 0x17, 0xdb, 0x18, 0x59, 0xe7, 0x2e, 0x34, 0xb3, 0xc2, 0x24, 0xa4, 0xf1, 0x15, 0xc3, 0x10,
+	0x8c, 0xae, 0x18, 0xed, 0x63, 0x98, 0x7b, 0x42, 0x2c, 0x43, 0xc7, 0xe3, 0x94, 0x64, 0x8a, 0x5e,
+	0x74, 0xda, 0xef, 0x27, 0x60, 0x3e, 0x21, 0x5e, 0xda, 0xb2, 0x0f, 0x70, 0x46, 0x2c, 0xa3, 0xe5,
+	0xe1, 0xb8, 0x34, 0x73, 0x2b, 0xa1, 0x23, 0xf3, 0x47, 0x44, 0xd1, 0x6b, 0x67, 0xe1, 0xdc, 0x10,
+	0x1b, 0xa9, 0x7e, 0xa6, 0x40, 0x35, 0x14, 0x31, 0x4a, 0x76, 0xbc, 0x07, 0xb5, 0x97, 0x8e, 0x69,
+	0x8b, 0xaa, 0xc3, 0x30, 0x29, 0x50, 0x55, 0xfc, 0xb6, 0x47, 0x59, 0xed, 0x96, 0x99, 0x2e, 0xa3,
+	0x30, 0xff, 0x66, 0xa8, 0x89, 0xa8, 0x24, 0x0f, 0xad, 0x1c, 0x69, 0x8f, 0xe1, 0x9a, 0xb8, 0x38,
+	0x1f, 0x38, 0xf6, 0xa9, 0xd9, 0x19, 0xdd, 0x21, 0xbe, 0x0f, 0xd7, 0xd3, 0x82, 0x62, 0x67, 0xf8,
+	0x14, 0x5b, 0x16, 0xa1, 0xb2, 0x88, 0x2b, 0x47, 0x68, 0x0b, 0x66, 0xc5, 0x57, 0xeb, 0x94, 0x60,
+	0x1a, 0x78, 0xbc, 0xca, 0xce, 0xbc, 0x65, 0x46, 0x90, 0x1f, 0x49, 0xaa, 0xf6, 0x13, 0x05, 0x16,
+	0x1e, 0x99, 0x9e, 0x4f, 0x8f, 0x70, 0xcf, 0xa7, 0xc1, 0x89, 0xf0, 0xb6, 0xd7, 0x5a, 0x4d, 0xfd,
+	0x1a, 0xa8, 0x79, 0x16, 0xe4, 0xb8, 0x7b, 0xd2, 0x21, 0x9f, 0xc1, 0xe2, 0x11, 0xee, 0x75, 0x89,
+	0x4d, 0xfd, 0xab, 0x09, 0x68, 0x9f, 0x4f, 0xc0, 0x52, 0xbe, 0x44, 0x69, 0xc9, 0x19, 0xa0, 0x78,
+	0x69, 0xae, 0xe4, 0x94, 0x4e, 0xff, 0x5e, 0xfa, 0xa9, 0x54, 0x28, 0x24, 0x7e, 0xad, 0x85, 0x5c,
+	0xfa, 0xbc, 0xdf, 0x4f, 0x1a, 0xe6, 0x40, 0xfc, 0x42, 0x81, 0xf9, 0x8c, 0xcc, 0x51, 0x4e, 0x06,
+	0x82, 0x49, 0x17, 0xcb, 0x0d, 0x2b, 0xe9, 0xfc, 0x5b, 0xd4, 0x92, 0xda, 0xc4, 0x3c, 0x27, 0xa1,
+	0xbb, 0x47, 0x63, 0x36, 0x17, 0x61, 0xc0, 0x9c, 0x7e, 0x4a, 0x8f, 0xc6, 0x2c, 0x16, 0x1e, 0xe1,
+	0x9e, 0x69, 0x77, 0xae, 0xa2, 0x48, 0xfc, 0x0c, 0x9a, 0x59, 0x61, 0x72, 0x4b, 0xee, 0xc1, 0x74,
+	0x72, 0x9d, 0x62, 0x37, 0xb2, 0x0b, 0x6d, 0x24, 0x16, 0xea, 0xb3, 0xfe, 0x06, 0x0b, 0x21, 0x4f,
+	0x4c, 0x9f, 0x3a, 0xe3, 0x74, 0x79, 0xfe, 0xa8, 0xc0, 0x5c, 0x42, 0xd0, 0x43, 0x9b, 0x7a, 0xbd,
+	0x51, 0x90, 0x2f, 0x4a, 0xa2, 0xf3, 0x02, 0x8d, 0x0a, 0x55, 0xc3, 0xf4, 0x5d, 0xc7, 0x8f, 0x42,
+	0x4d, 0x34, 0x8e, 0xbc, 0x66, 0xea, 0xe2, 0xfb, 0xf0, 0x10, 0xae, 0xa5, 0x20, 0x90, 0x70, 0x7e,
+	0x1d, 0x2a, 0xc4, 0xa6, 0x89, 0x3c, 0x63, 0xb1, 0x2f, 0x96, 0x27, 0x97, 0xaa, 0x87, 0xbc, 0xda,
+	0x6f, 0x14, 0x80, 0x38, 0x3f, 0x88, 0xec, 0x56, 0x12, 0x76, 0xe7, 0x79, 0xd7, 0x10, 0x2f, 0xa2,
+	0x75, 0x68, 0xf0, 0x7b, 0x86, 0xad, 0xd5, 0xc2, 0x3d, 0x59, 0xab, 0xa9, 0x33, 0xda, 0xbe, 0x20,
+	0x31, 0x16, 0x26, 0x35, 0x62, 0x11, 0x25, 0xe1, 0x3a, 0xa3, 0x49, 0x16, 0x6d, 0x1f, 0x20, 0x96,
+	0xcc, 0xa0, 0x6c, 0x07, 0x9e, 0x47, 0xec, 0x76, 0x4f, 0x86, 0x96, 0x68, 0xcc, 0x61, 0x26, 0x6d,
+	0xb3, 0x8b, 0x2d, 0x51, 0xea, 0x9f, 0xd2, 0xa3, 0xf1, 0xee, 0xfb, 0x50, 0x39, 0xa6, 0x8e, 0x87,
+	0x3b, 0x04, 0x3d, 0x82, 0x5a, 0xd4, 0xfa, 0x44, 0x49, 0xac, 0xfa, 0xfb, 0xaa, 0xea, 0x52, 0xfe,
+	0xa4, 0xc0, 0x7d, 0xd7, 0x86, 0x5a, 0xd4, 0x2f, 0x44, 0x18, 0x1a, 0xc9, 0x9e, 0x21, 0xda, 0x4a,
+	0xfc, 0x3a, 0xa8, 0x4f, 0xa9, 0x6e, 0x5f, 0xcc, 0x28, 0xf5, 0xfd, 0xa7, 0x04, 0x93, 0xcc, 0x13,
+	0xd1, 0x77, 0xa0, 0x12, 0x35, 0x8a, 0x13, 0x7f, 0xa7, 0xfb, 0x8d, 0xaa, 0x9a, 0x37, 0x25, 0x5d,
+	0xe6, 0x10, 0xea, 0x89, 0x26, 0x1f, 0x5a, 0x4e, 0xb0, 0x66, 0x9b, 0x88, 0xea, 0x4a, 0xd1, 0x74,
+	0xd4, 0xdb, 0x80, 0xb8, 0x6e, 0x8e, 0x96, 0x0a, 0x5a, 0x60, 0x42, 0xd6, 0xf2, 0xc0, 0x06, 0x19,
+	0xfa, 0x04, 0xe6, 0x33, 0x8d, 0x21, 0xb4, 0x31, 0xb8, 0x6d, 0x24, 0x04, 0x6f, 0x5e, 0xa6, 0xb7,
+	0xc4, 0xe4, 0x67, 0x5a, 0x2d, 0x29, 0xf9, 0x45, 0x0d, 0x21, 0x75, 0x73, 0x30, 0x53, 0xd4, 0xfd,
+	0x9a, 0xeb, 0x6f, 0x21, 0x20, 0x6d, 0x60, 0x7f, 0x41, 0x48, 0xdf, 0xb8, 0x44, 0x0f, 0x62, 0xf7,
+	0x8b, 0x69, 0x28, 0x8b, 0x13, 0x8b, 0x3a, 0x70, 0x3d, 0xaf, 0xe4, 0x88, 0xde, 0x4c, 0x9e, 0xc7,
+	0xe2, 0xe2, 0xa7, 0xba, 0x75, 0x21, 0x9f, 0x5c, 0x50, 0x0f, 0xd4, 0xe2, 0xda, 0x1f, 0xba, 0x53,
+	0x24, 0x26, 0xaf, 0xb4, 0xa5, 0xbe, 0x7d, 0x49, 0xee, 0x18, 0xcb, 0xfe, 0xfa, 0x5b, 0x0a, 0xcb,
+	0x82, 0xa2, 0xa1, 0xba, 0x31, 0x90, 0x47, 0x0a, 0xef, 0xc2, 0x8d, 0xfc, 0x8a, 0x16, 0xda, 0xce,
+	0xfb, 0x3d, 0x77, 0x3d, 0x6f, 0x5d, 0x82, 0x53, 0xaa, 0xc3, 0x80, 0xb2, 0x15, 0x1f, 0xb4, 0x79,
+	0x41, 0x41, 0x48, 0xa8, 0xb9, 0x75, 0xa9, 0xb2, 0x11, 0xfa, 0x36, 0x94, 0x45, 0x42, 0x89, 0x9a,
+	0x99, 0x1c, 0x33, 0x14, 0xb5, 0x90, 0x33, 0x13, 0x5b, 0x98, 0x4d, 0xfa, 0x53, 0x16, 0x16, 0x96,
+	0x20, 0xd4, 0x5b, 0x17, 0x70, 0x49, 0x15, 0xe7, 0xb0, 0x50, 0x98, 0x7b, 0xa2, 0xdb, 0x45, 0x29,
+	0x65, 0x9e, 0xc2, 0x3b, 0x97, 0x63, 0x8e, 0x1d, 0xa9, 0x3f, 0x2f, 0x4b, 0x39, 0x52, 0x41, 0x06,
+	0xa8, 0x6e, 0x0c, 0xe4, 0x91, 0xc2, 0x1f, 0x41, 0x2d, 0xca, 0x97, 0xd0, 0x62, 0x7e, 0x16, 0x95,
+	0xbd, 0x4d, 0xb2, 0x49, 0x99, 0x0f, 0xcd, 0xa2, 0x96, 0x15, 0xfa, 0xff, 0x24, 0xbe, 0x83, 0x5b,
+	0x73, 0xea, 0xed, 0x4b, 0xf1, 0x4a, 0xa5, 0x1d, 0xb8, 0x9e, 0xd7, 0x1b, 0x4a, 0x85, 0x91, 0x01,
+	0x9d, 0x2c, 0x75, 0xeb, 0x42, 0x3e, 0xa9, 0xe8, 0x19, 0x34, 0x92, 0x99, 0x10, 0x5a, 0xc9, 0x14,
+	0x29, 0x53, 0xb9, 0x96, 0xba, 0x5a, 0x38, 0x1f, 0xbb, 0x6b, 0x36, 0xfd, 0x48, 0xb9, 0x6b, 0x61,
+	0x7e, 0xa4, 0xde, 0xba, 0x80, 0x2b, 0x06, 0x27, 0x2f, 0x29, 0x48, 0x81, 0x33, 0x20, 0x99, 0x51,
+	0xb7, 0x2e, 0xe4, 0x8b, 0xfd, 0xb3, 0xff, 0xad, 0x9c, 0xf2, 0xcf, 0x82, 0x57, 0xb9, 0xba, 0x31,
+	0x90, 0x27, 0xbe, 0xea, 0x13, 0x6f, 0xc0, 0xd4, 0x55, 0x9f, 0x7d, 0x4f, 0xab, 0x2b, 0x45, 0xd3,
+	0x52, 0xda, 0xfb, 0x30, 0x9f, 0x20, 0x1f, 0x53, 0x8f, 0xe0, 0xee, 0x45, 0x32, 0x07, 0x3d, 0x47,
+	0xdf, 0x51, 0xee, 0x6f, 0x7e, 0xa4, 0xb1, 0xf1, 0xcb, 0x1d, 0xd3, 0xb9, 0xcb, 0x3f, 0xee, 0xba,
+	0x9e, 0x79, 0x8e, 0x29, 0xb9, 0x1b, 0xfd, 0xe6, 0x9e, 0x9c, 0x94, 0x79, 0x9e, 0x7f, 0xef, 0xbf,
+	0x03, 0x00, 0x2e, 0x9c, 0x79, 0x9d, 0xb4, 0x27, 0x00, 0x00,
 }
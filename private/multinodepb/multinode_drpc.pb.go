@@ -196,6 +196,8 @@ type DRPCNodeClient interface {
 	LastContact(ctx context.Context, in *LastContactRequest) (*LastContactResponse, error)
 	Reputation(ctx context.Context, in *ReputationRequest) (*ReputationResponse, error)
 	TrustedSatellites(ctx context.Context, in *TrustedSatellitesRequest) (*TrustedSatellitesResponse, error)
+	SatelliteCheckIns(ctx context.Context, in *SatelliteCheckInsRequest) (*SatelliteCheckInsResponse, error)
+	ReputationScores(ctx context.Context, in *ReputationScoresRequest) (*ReputationScoresResponse, error)
 }
 
 type drpcNodeClient struct {
@@ -244,11 +246,31 @@ func (c *drpcNodeClient) TrustedSatellites(ctx context.Context, in *TrustedSatel
 	return out, nil
 }
 
+func (c *drpcNodeClient) SatelliteCheckIns(ctx context.Context, in *SatelliteCheckInsRequest) (*SatelliteCheckInsResponse, error) {
+	out := new(SatelliteCheckInsResponse)
+	err := c.cc.Invoke(ctx, "/multinode.Node/SatelliteCheckIns", drpcEncoding_File_multinode_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcNodeClient) ReputationScores(ctx context.Context, in *ReputationScoresRequest) (*ReputationScoresResponse, error) {
+	out := new(ReputationScoresResponse)
+	err := c.cc.Invoke(ctx, "/multinode.Node/ReputationScores", drpcEncoding_File_multinode_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 type DRPCNodeServer interface {
 	Version(context.Context, *VersionRequest) (*VersionResponse, error)
 	LastContact(context.Context, *LastContactRequest) (*LastContactResponse, error)
 	Reputation(context.Context, *ReputationRequest) (*ReputationResponse, error)
 	TrustedSatellites(context.Context, *TrustedSatellitesRequest) (*TrustedSatellitesResponse, error)
+	SatelliteCheckIns(context.Context, *SatelliteCheckInsRequest) (*SatelliteCheckInsResponse, error)
+	ReputationScores(context.Context, *ReputationScoresRequest) (*ReputationScoresResponse, error)
 }
 
 type DRPCNodeUnimplementedServer struct{}
@@ -269,9 +291,17 @@ func (s *DRPCNodeUnimplementedServer) TrustedSatellites(context.Context, *Truste
 	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
 }
 
+func (s *DRPCNodeUnimplementedServer) SatelliteCheckIns(context.Context, *SatelliteCheckInsRequest) (*SatelliteCheckInsResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
+func (s *DRPCNodeUnimplementedServer) ReputationScores(context.Context, *ReputationScoresRequest) (*ReputationScoresResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
 type DRPCNodeDescription struct{}
 
-func (DRPCNodeDescription) NumMethods() int { return 4 }
+func (DRPCNodeDescription) NumMethods() int { return 6 }
 
 func (DRPCNodeDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver, interface{}, bool) {
 	switch n {
@@ -311,6 +341,24 @@ func (DRPCNodeDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver,
 						in1.(*TrustedSatellitesRequest),
 					)
 			}, DRPCNodeServer.TrustedSatellites, true
+	case 4:
+		return "/multinode.Node/SatelliteCheckIns", drpcEncoding_File_multinode_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCNodeServer).
+					SatelliteCheckIns(
+						ctx,
+						in1.(*SatelliteCheckInsRequest),
+					)
+			}, DRPCNodeServer.SatelliteCheckIns, true
+	case 5:
+		return "/multinode.Node/ReputationScores", drpcEncoding_File_multinode_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCNodeServer).
+					ReputationScores(
+						ctx,
+						in1.(*ReputationScoresRequest),
+					)
+			}, DRPCNodeServer.ReputationScores, true
 	default:
 		return "", nil, nil, nil, false
 	}
@@ -384,6 +432,38 @@ func (x *drpcNode_TrustedSatellitesStream) SendAndClose(m *TrustedSatellitesResp
 	return x.CloseSend()
 }
 
+type DRPCNode_SatelliteCheckInsStream interface {
+	drpc.Stream
+	SendAndClose(*SatelliteCheckInsResponse) error
+}
+
+type drpcNode_SatelliteCheckInsStream struct {
+	drpc.Stream
+}
+
+func (x *drpcNode_SatelliteCheckInsStream) SendAndClose(m *SatelliteCheckInsResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_multinode_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCNode_ReputationScoresStream interface {
+	drpc.Stream
+	SendAndClose(*ReputationScoresResponse) error
+}
+
+type drpcNode_ReputationScoresStream struct {
+	drpc.Stream
+}
+
+func (x *drpcNode_ReputationScoresStream) SendAndClose(m *ReputationScoresResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_multinode_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
 type DRPCPayoutClient interface {
 	DRPCConn() drpc.Conn
 
@@ -391,10 +471,20 @@ type DRPCPayoutClient interface {
 	AllSatellitesPeriodSummary(ctx context.Context, in *AllSatellitesPeriodSummaryRequest) (*AllSatellitesPeriodSummaryResponse, error)
 	SatelliteSummary(ctx context.Context, in *SatelliteSummaryRequest) (*SatelliteSummaryResponse, error)
 	SatellitePeriodSummary(ctx context.Context, in *SatellitePeriodSummaryRequest) (*SatellitePeriodSummaryResponse, error)
+	SatelliteSummaries(ctx context.Context, in *SatelliteSummariesRequest) (*SatelliteSummariesResponse, error)
 	Earned(ctx context.Context, in *EarnedRequest) (*EarnedResponse, error)
 	EarnedPerSatellite(ctx context.Context, in *EarnedPerSatelliteRequest) (*EarnedPerSatelliteResponse, error)
+	UndistributedPerSatellite(ctx context.Context, in *UndistributedPerSatelliteRequest) (*UndistributedPerSatelliteResponse, error)
+	AvailablePeriods(ctx context.Context, in *AvailablePeriodsRequest) (*AvailablePeriodsResponse, error)
+	HeldRates(ctx context.Context, in *HeldRatesRequest) (*HeldRatesResponse, error)
 	EstimatedPayoutSatellite(ctx context.Context, in *EstimatedPayoutSatelliteRequest) (*EstimatedPayoutSatelliteResponse, error)
 	EstimatedPayoutTotal(ctx context.Context, in *EstimatedPayoutTotalRequest) (*EstimatedPayoutTotalResponse, error)
+	PayoutConfig(ctx context.Context, in *PayoutConfigRequest) (*PayoutConfigResponse, error)
+	FirstPaystubPeriod(ctx context.Context, in *FirstPaystubPeriodRequest) (*FirstPaystubPeriodResponse, error)
+	PaymentsPerSatellite(ctx context.Context, in *PaymentsPerSatelliteRequest) (*PaymentsPerSatelliteResponse, error)
+	PayingSatellites(ctx context.Context, in *PayingSatellitesRequest) (*PayingSatellitesResponse, error)
+	HeldHistory(ctx context.Context, in *HeldHistoryRequest) (*HeldHistoryResponse, error)
+	HeldHistoryStream(ctx context.Context, in *HeldHistoryRequest) (DRPCPayout_HeldHistoryStreamClient, error)
 }
 
 type drpcPayoutClient struct {
@@ -443,6 +533,15 @@ func (c *drpcPayoutClient) SatellitePeriodSummary(ctx context.Context, in *Satel
 	return out, nil
 }
 
+func (c *drpcPayoutClient) SatelliteSummaries(ctx context.Context, in *SatelliteSummariesRequest) (*SatelliteSummariesResponse, error) {
+	out := new(SatelliteSummariesResponse)
+	err := c.cc.Invoke(ctx, "/multinode.Payout/SatelliteSummaries", drpcEncoding_File_multinode_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *drpcPayoutClient) Earned(ctx context.Context, in *EarnedRequest) (*EarnedResponse, error) {
 	out := new(EarnedResponse)
 	err := c.cc.Invoke(ctx, "/multinode.Payout/Earned", drpcEncoding_File_multinode_proto{}, in, out)
@@ -461,6 +560,33 @@ func (c *drpcPayoutClient) EarnedPerSatellite(ctx context.Context, in *EarnedPer
 	return out, nil
 }
 
+func (c *drpcPayoutClient) UndistributedPerSatellite(ctx context.Context, in *UndistributedPerSatelliteRequest) (*UndistributedPerSatelliteResponse, error) {
+	out := new(UndistributedPerSatelliteResponse)
+	err := c.cc.Invoke(ctx, "/multinode.Payout/UndistributedPerSatellite", drpcEncoding_File_multinode_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcPayoutClient) AvailablePeriods(ctx context.Context, in *AvailablePeriodsRequest) (*AvailablePeriodsResponse, error) {
+	out := new(AvailablePeriodsResponse)
+	err := c.cc.Invoke(ctx, "/multinode.Payout/AvailablePeriods", drpcEncoding_File_multinode_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcPayoutClient) HeldRates(ctx context.Context, in *HeldRatesRequest) (*HeldRatesResponse, error) {
+	out := new(HeldRatesResponse)
+	err := c.cc.Invoke(ctx, "/multinode.Payout/HeldRates", drpcEncoding_File_multinode_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *drpcPayoutClient) EstimatedPayoutSatellite(ctx context.Context, in *EstimatedPayoutSatelliteRequest) (*EstimatedPayoutSatelliteResponse, error) {
 	out := new(EstimatedPayoutSatelliteResponse)
 	err := c.cc.Invoke(ctx, "/multinode.Payout/EstimatedPayoutSatellite", drpcEncoding_File_multinode_proto{}, in, out)
@@ -479,15 +605,102 @@ func (c *drpcPayoutClient) EstimatedPayoutTotal(ctx context.Context, in *Estimat
 	return out, nil
 }
 
+func (c *drpcPayoutClient) PayoutConfig(ctx context.Context, in *PayoutConfigRequest) (*PayoutConfigResponse, error) {
+	out := new(PayoutConfigResponse)
+	err := c.cc.Invoke(ctx, "/multinode.Payout/PayoutConfig", drpcEncoding_File_multinode_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcPayoutClient) FirstPaystubPeriod(ctx context.Context, in *FirstPaystubPeriodRequest) (*FirstPaystubPeriodResponse, error) {
+	out := new(FirstPaystubPeriodResponse)
+	err := c.cc.Invoke(ctx, "/multinode.Payout/FirstPaystubPeriod", drpcEncoding_File_multinode_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcPayoutClient) PaymentsPerSatellite(ctx context.Context, in *PaymentsPerSatelliteRequest) (*PaymentsPerSatelliteResponse, error) {
+	out := new(PaymentsPerSatelliteResponse)
+	err := c.cc.Invoke(ctx, "/multinode.Payout/PaymentsPerSatellite", drpcEncoding_File_multinode_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcPayoutClient) PayingSatellites(ctx context.Context, in *PayingSatellitesRequest) (*PayingSatellitesResponse, error) {
+	out := new(PayingSatellitesResponse)
+	err := c.cc.Invoke(ctx, "/multinode.Payout/PayingSatellites", drpcEncoding_File_multinode_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcPayoutClient) HeldHistory(ctx context.Context, in *HeldHistoryRequest) (*HeldHistoryResponse, error) {
+	out := new(HeldHistoryResponse)
+	err := c.cc.Invoke(ctx, "/multinode.Payout/HeldHistory", drpcEncoding_File_multinode_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcPayoutClient) HeldHistoryStream(ctx context.Context, in *HeldHistoryRequest) (DRPCPayout_HeldHistoryStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, "/multinode.Payout/HeldHistoryStream", drpcEncoding_File_multinode_proto{})
+	if err != nil {
+		return nil, err
+	}
+	x := &drpcPayout_HeldHistoryStreamClient{stream}
+	if err := x.MsgSend(in, drpcEncoding_File_multinode_proto{}); err != nil {
+		return nil, err
+	}
+	if err := x.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DRPCPayout_HeldHistoryStreamClient interface {
+	drpc.Stream
+	Recv() (*HeldHistoryEntry, error)
+}
+
+type drpcPayout_HeldHistoryStreamClient struct {
+	drpc.Stream
+}
+
+func (x *drpcPayout_HeldHistoryStreamClient) Recv() (*HeldHistoryEntry, error) {
+	m := new(HeldHistoryEntry)
+	if err := x.MsgRecv(m, drpcEncoding_File_multinode_proto{}); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 type DRPCPayoutServer interface {
 	AllSatellitesSummary(context.Context, *AllSatellitesSummaryRequest) (*AllSatellitesSummaryResponse, error)
 	AllSatellitesPeriodSummary(context.Context, *AllSatellitesPeriodSummaryRequest) (*AllSatellitesPeriodSummaryResponse, error)
 	SatelliteSummary(context.Context, *SatelliteSummaryRequest) (*SatelliteSummaryResponse, error)
 	SatellitePeriodSummary(context.Context, *SatellitePeriodSummaryRequest) (*SatellitePeriodSummaryResponse, error)
+	SatelliteSummaries(context.Context, *SatelliteSummariesRequest) (*SatelliteSummariesResponse, error)
 	Earned(context.Context, *EarnedRequest) (*EarnedResponse, error)
 	EarnedPerSatellite(context.Context, *EarnedPerSatelliteRequest) (*EarnedPerSatelliteResponse, error)
+	UndistributedPerSatellite(context.Context, *UndistributedPerSatelliteRequest) (*UndistributedPerSatelliteResponse, error)
+	AvailablePeriods(context.Context, *AvailablePeriodsRequest) (*AvailablePeriodsResponse, error)
+	HeldRates(context.Context, *HeldRatesRequest) (*HeldRatesResponse, error)
 	EstimatedPayoutSatellite(context.Context, *EstimatedPayoutSatelliteRequest) (*EstimatedPayoutSatelliteResponse, error)
 	EstimatedPayoutTotal(context.Context, *EstimatedPayoutTotalRequest) (*EstimatedPayoutTotalResponse, error)
+	PayoutConfig(context.Context, *PayoutConfigRequest) (*PayoutConfigResponse, error)
+	FirstPaystubPeriod(context.Context, *FirstPaystubPeriodRequest) (*FirstPaystubPeriodResponse, error)
+	PaymentsPerSatellite(context.Context, *PaymentsPerSatelliteRequest) (*PaymentsPerSatelliteResponse, error)
+	PayingSatellites(context.Context, *PayingSatellitesRequest) (*PayingSatellitesResponse, error)
+	HeldHistory(context.Context, *HeldHistoryRequest) (*HeldHistoryResponse, error)
+	HeldHistoryStream(*HeldHistoryRequest, DRPCPayout_HeldHistoryStreamStream) error
 }
 
 type DRPCPayoutUnimplementedServer struct{}
@@ -508,6 +721,10 @@ func (s *DRPCPayoutUnimplementedServer) SatellitePeriodSummary(context.Context,
 	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
 }
 
+func (s *DRPCPayoutUnimplementedServer) SatelliteSummaries(context.Context, *SatelliteSummariesRequest) (*SatelliteSummariesResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
 func (s *DRPCPayoutUnimplementedServer) Earned(context.Context, *EarnedRequest) (*EarnedResponse, error) {
 	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
 }
@@ -516,6 +733,18 @@ func (s *DRPCPayoutUnimplementedServer) EarnedPerSatellite(context.Context, *Ear
 	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
 }
 
+func (s *DRPCPayoutUnimplementedServer) UndistributedPerSatellite(context.Context, *UndistributedPerSatelliteRequest) (*UndistributedPerSatelliteResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
+func (s *DRPCPayoutUnimplementedServer) AvailablePeriods(context.Context, *AvailablePeriodsRequest) (*AvailablePeriodsResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
+func (s *DRPCPayoutUnimplementedServer) HeldRates(context.Context, *HeldRatesRequest) (*HeldRatesResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
 func (s *DRPCPayoutUnimplementedServer) EstimatedPayoutSatellite(context.Context, *EstimatedPayoutSatelliteRequest) (*EstimatedPayoutSatelliteResponse, error) {
 	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
 }
@@ -524,9 +753,33 @@ func (s *DRPCPayoutUnimplementedServer) EstimatedPayoutTotal(context.Context, *E
 	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
 }
 
+func (s *DRPCPayoutUnimplementedServer) PayoutConfig(context.Context, *PayoutConfigRequest) (*PayoutConfigResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
+func (s *DRPCPayoutUnimplementedServer) FirstPaystubPeriod(context.Context, *FirstPaystubPeriodRequest) (*FirstPaystubPeriodResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
+func (s *DRPCPayoutUnimplementedServer) PaymentsPerSatellite(context.Context, *PaymentsPerSatelliteRequest) (*PaymentsPerSatelliteResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
+func (s *DRPCPayoutUnimplementedServer) PayingSatellites(context.Context, *PayingSatellitesRequest) (*PayingSatellitesResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
+func (s *DRPCPayoutUnimplementedServer) HeldHistory(context.Context, *HeldHistoryRequest) (*HeldHistoryResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
+func (s *DRPCPayoutUnimplementedServer) HeldHistoryStream(*HeldHistoryRequest, DRPCPayout_HeldHistoryStreamStream) error {
+	return drpcerr.WithCode(errors.New("Unimplemented"), 12)
+}
+
 type DRPCPayoutDescription struct{}
 
-func (DRPCPayoutDescription) NumMethods() int { return 8 }
+func (DRPCPayoutDescription) NumMethods() int { return 18 }
 
 func (DRPCPayoutDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver, interface{}, bool) {
 	switch n {
@@ -567,6 +820,15 @@ func (DRPCPayoutDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver
 					)
 			}, DRPCPayoutServer.SatellitePeriodSummary, true
 	case 4:
+		return "/multinode.Payout/SatelliteSummaries", drpcEncoding_File_multinode_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCPayoutServer).
+					SatelliteSummaries(
+						ctx,
+						in1.(*SatelliteSummariesRequest),
+					)
+			}, DRPCPayoutServer.SatelliteSummaries, true
+	case 5:
 		return "/multinode.Payout/Earned", drpcEncoding_File_multinode_proto{},
 			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
 				return srv.(DRPCPayoutServer).
@@ -575,7 +837,7 @@ func (DRPCPayoutDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver
 						in1.(*EarnedRequest),
 					)
 			}, DRPCPayoutServer.Earned, true
-	case 5:
+	case 6:
 		return "/multinode.Payout/EarnedPerSatellite", drpcEncoding_File_multinode_proto{},
 			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
 				return srv.(DRPCPayoutServer).
@@ -584,7 +846,34 @@ func (DRPCPayoutDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver
 						in1.(*EarnedPerSatelliteRequest),
 					)
 			}, DRPCPayoutServer.EarnedPerSatellite, true
-	case 6:
+	case 7:
+		return "/multinode.Payout/UndistributedPerSatellite", drpcEncoding_File_multinode_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCPayoutServer).
+					UndistributedPerSatellite(
+						ctx,
+						in1.(*UndistributedPerSatelliteRequest),
+					)
+			}, DRPCPayoutServer.UndistributedPerSatellite, true
+	case 8:
+		return "/multinode.Payout/AvailablePeriods", drpcEncoding_File_multinode_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCPayoutServer).
+					AvailablePeriods(
+						ctx,
+						in1.(*AvailablePeriodsRequest),
+					)
+			}, DRPCPayoutServer.AvailablePeriods, true
+	case 9:
+		return "/multinode.Payout/HeldRates", drpcEncoding_File_multinode_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCPayoutServer).
+					HeldRates(
+						ctx,
+						in1.(*HeldRatesRequest),
+					)
+			}, DRPCPayoutServer.HeldRates, true
+	case 10:
 		return "/multinode.Payout/EstimatedPayoutSatellite", drpcEncoding_File_multinode_proto{},
 			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
 				return srv.(DRPCPayoutServer).
@@ -593,7 +882,7 @@ func (DRPCPayoutDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver
 						in1.(*EstimatedPayoutSatelliteRequest),
 					)
 			}, DRPCPayoutServer.EstimatedPayoutSatellite, true
-	case 7:
+	case 11:
 		return "/multinode.Payout/EstimatedPayoutTotal", drpcEncoding_File_multinode_proto{},
 			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
 				return srv.(DRPCPayoutServer).
@@ -602,6 +891,60 @@ func (DRPCPayoutDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver
 						in1.(*EstimatedPayoutTotalRequest),
 					)
 			}, DRPCPayoutServer.EstimatedPayoutTotal, true
+	case 12:
+		return "/multinode.Payout/PayoutConfig", drpcEncoding_File_multinode_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCPayoutServer).
+					PayoutConfig(
+						ctx,
+						in1.(*PayoutConfigRequest),
+					)
+			}, DRPCPayoutServer.PayoutConfig, true
+	case 13:
+		return "/multinode.Payout/FirstPaystubPeriod", drpcEncoding_File_multinode_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCPayoutServer).
+					FirstPaystubPeriod(
+						ctx,
+						in1.(*FirstPaystubPeriodRequest),
+					)
+			}, DRPCPayoutServer.FirstPaystubPeriod, true
+	case 14:
+		return "/multinode.Payout/PaymentsPerSatellite", drpcEncoding_File_multinode_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCPayoutServer).
+					PaymentsPerSatellite(
+						ctx,
+						in1.(*PaymentsPerSatelliteRequest),
+					)
+			}, DRPCPayoutServer.PaymentsPerSatellite, true
+	case 15:
+		return "/multinode.Payout/PayingSatellites", drpcEncoding_File_multinode_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCPayoutServer).
+					PayingSatellites(
+						ctx,
+						in1.(*PayingSatellitesRequest),
+					)
+			}, DRPCPayoutServer.PayingSatellites, true
+	case 16:
+		return "/multinode.Payout/HeldHistory", drpcEncoding_File_multinode_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCPayoutServer).
+					HeldHistory(
+						ctx,
+						in1.(*HeldHistoryRequest),
+					)
+			}, DRPCPayoutServer.HeldHistory, true
+	case 17:
+		return "/multinode.Payout/HeldHistoryStream", drpcEncoding_File_multinode_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return nil, srv.(DRPCPayoutServer).
+					HeldHistoryStream(
+						in1.(*HeldHistoryRequest),
+						&drpcPayout_HeldHistoryStreamStream{in2.(drpc.Stream)},
+					)
+			}, DRPCPayoutServer.HeldHistoryStream, true
 	default:
 		return "", nil, nil, nil, false
 	}
@@ -675,6 +1018,22 @@ func (x *drpcPayout_SatellitePeriodSummaryStream) SendAndClose(m *SatellitePerio
 	return x.CloseSend()
 }
 
+type DRPCPayout_SatelliteSummariesStream interface {
+	drpc.Stream
+	SendAndClose(*SatelliteSummariesResponse) error
+}
+
+type drpcPayout_SatelliteSummariesStream struct {
+	drpc.Stream
+}
+
+func (x *drpcPayout_SatelliteSummariesStream) SendAndClose(m *SatelliteSummariesResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_multinode_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
 type DRPCPayout_EarnedStream interface {
 	drpc.Stream
 	SendAndClose(*EarnedResponse) error
@@ -707,6 +1066,54 @@ func (x *drpcPayout_EarnedPerSatelliteStream) SendAndClose(m *EarnedPerSatellite
 	return x.CloseSend()
 }
 
+type DRPCPayout_UndistributedPerSatelliteStream interface {
+	drpc.Stream
+	SendAndClose(*UndistributedPerSatelliteResponse) error
+}
+
+type drpcPayout_UndistributedPerSatelliteStream struct {
+	drpc.Stream
+}
+
+func (x *drpcPayout_UndistributedPerSatelliteStream) SendAndClose(m *UndistributedPerSatelliteResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_multinode_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCPayout_AvailablePeriodsStream interface {
+	drpc.Stream
+	SendAndClose(*AvailablePeriodsResponse) error
+}
+
+type drpcPayout_AvailablePeriodsStream struct {
+	drpc.Stream
+}
+
+func (x *drpcPayout_AvailablePeriodsStream) SendAndClose(m *AvailablePeriodsResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_multinode_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCPayout_HeldRatesStream interface {
+	drpc.Stream
+	SendAndClose(*HeldRatesResponse) error
+}
+
+type drpcPayout_HeldRatesStream struct {
+	drpc.Stream
+}
+
+func (x *drpcPayout_HeldRatesStream) SendAndClose(m *HeldRatesResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_multinode_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
 type DRPCPayout_EstimatedPayoutSatelliteStream interface {
 	drpc.Stream
 	SendAndClose(*EstimatedPayoutSatelliteResponse) error
@@ -738,3 +1145,96 @@ func (x *drpcPayout_EstimatedPayoutTotalStream) SendAndClose(m *EstimatedPayoutT
 	}
 	return x.CloseSend()
 }
+
+type DRPCPayout_PayoutConfigStream interface {
+	drpc.Stream
+	SendAndClose(*PayoutConfigResponse) error
+}
+
+type drpcPayout_PayoutConfigStream struct {
+	drpc.Stream
+}
+
+func (x *drpcPayout_PayoutConfigStream) SendAndClose(m *PayoutConfigResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_multinode_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCPayout_FirstPaystubPeriodStream interface {
+	drpc.Stream
+	SendAndClose(*FirstPaystubPeriodResponse) error
+}
+
+type drpcPayout_FirstPaystubPeriodStream struct {
+	drpc.Stream
+}
+
+func (x *drpcPayout_FirstPaystubPeriodStream) SendAndClose(m *FirstPaystubPeriodResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_multinode_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCPayout_PaymentsPerSatelliteStream interface {
+	drpc.Stream
+	SendAndClose(*PaymentsPerSatelliteResponse) error
+}
+
+type drpcPayout_PaymentsPerSatelliteStream struct {
+	drpc.Stream
+}
+
+func (x *drpcPayout_PaymentsPerSatelliteStream) SendAndClose(m *PaymentsPerSatelliteResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_multinode_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCPayout_PayingSatellitesStream interface {
+	drpc.Stream
+	SendAndClose(*PayingSatellitesResponse) error
+}
+
+type drpcPayout_PayingSatellitesStream struct {
+	drpc.Stream
+}
+
+func (x *drpcPayout_PayingSatellitesStream) SendAndClose(m *PayingSatellitesResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_multinode_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCPayout_HeldHistoryStream interface {
+	drpc.Stream
+	SendAndClose(*HeldHistoryResponse) error
+}
+
+type drpcPayout_HeldHistoryStream struct {
+	drpc.Stream
+}
+
+func (x *drpcPayout_HeldHistoryStream) SendAndClose(m *HeldHistoryResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_multinode_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCPayout_HeldHistoryStreamStream interface {
+	drpc.Stream
+	Send(*HeldHistoryEntry) error
+}
+
+type drpcPayout_HeldHistoryStreamStream struct {
+	drpc.Stream
+}
+
+func (x *drpcPayout_HeldHistoryStreamStream) Send(m *HeldHistoryEntry) error {
+	return x.MsgSend(m, drpcEncoding_File_multinode_proto{})
+}
@@ -48,13 +48,21 @@ type NodeInfo struct {
 	Operator pb.NodeOperator
 }
 
+// CheckIn contains result of the last node check-in on a satellite.
+type CheckIn struct {
+	Succeeded bool
+	At        time.Time
+	Error     string
+}
+
 // Service is the contact service between storage nodes and satellites.
 type Service struct {
 	log    *zap.Logger
 	dialer rpc.Dialer
 
-	mu   sync.Mutex
-	self NodeInfo
+	mu       sync.Mutex
+	self     NodeInfo
+	checkIns map[storj.NodeID]CheckIn
 
 	trust *trust.Pool
 
@@ -64,10 +72,11 @@ type Service struct {
 // NewService creates a new contact service.
 func NewService(log *zap.Logger, dialer rpc.Dialer, self NodeInfo, trust *trust.Pool) *Service {
 	return &Service{
-		log:    log,
-		dialer: dialer,
-		trust:  trust,
-		self:   self,
+		log:      log,
+		dialer:   dialer,
+		trust:    trust,
+		self:     self,
+		checkIns: make(map[storj.NodeID]CheckIn),
 	}
 }
 
@@ -93,6 +102,7 @@ func (service *Service) pingSatellite(ctx context.Context, satellite storj.NodeI
 		mon.Meter("satellite_contact_request").Mark(1) //mon:locked
 
 		err := service.pingSatelliteOnce(ctx, satellite)
+		service.recordCheckIn(satellite, err)
 		attempts++
 		if err == nil {
 			return nil
@@ -146,6 +156,31 @@ func (service *Service) pingSatelliteOnce(ctx context.Context, id storj.NodeID)
 	return nil
 }
 
+// recordCheckIn stores result of the check-in on the satellite.
+func (service *Service) recordCheckIn(satellite storj.NodeID, err error) {
+	checkIn := CheckIn{
+		Succeeded: err == nil,
+		At:        time.Now().UTC(),
+	}
+	if err != nil {
+		checkIn.Error = err.Error()
+	}
+
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	service.checkIns[satellite] = checkIn
+}
+
+// LastCheckIn returns result of the last check-in on the satellite.
+// False is returned when node hasn't tried to check-in on the satellite yet.
+func (service *Service) LastCheckIn(satellite storj.NodeID) (CheckIn, bool) {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	checkIn, ok := service.checkIns[satellite]
+	return checkIn, ok
+}
+
 // Local returns the storagenode info.
 func (service *Service) Local() NodeInfo {
 	service.mu.Lock()
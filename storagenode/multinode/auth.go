@@ -24,3 +24,14 @@ func authenticate(ctx context.Context, apiKeys *apikeys.Service, header *multino
 
 	return nil
 }
+
+// clientInfo returns optional client version and trace id from request header.
+// Older clients don't send them, so defaults are returned instead.
+func clientInfo(header *multinodepb.RequestHeader) (clientVersion, traceID string) {
+	clientVersion, traceID = header.GetClientVersion(), header.GetTraceId()
+	if clientVersion == "" {
+		clientVersion = "unknown"
+	}
+
+	return clientVersion, traceID
+}
@@ -10,6 +10,7 @@ import (
 	"go.uber.org/zap"
 
 	"storj.io/common/rpc/rpcstatus"
+	"storj.io/storj/private/date"
 	"storj.io/storj/private/multinodepb"
 	"storj.io/storj/storagenode/apikeys"
 	"storj.io/storj/storagenode/bandwidth"
@@ -45,12 +46,21 @@ func (bandwidth *BandwidthEndpoint) MonthSummary(ctx context.Context, req *multi
 		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
 	}
 
-	used, err := bandwidth.db.MonthSummary(ctx, time.Now())
+	now := time.Now()
+
+	used, err := bandwidth.db.MonthSummary(ctx, now)
+	if err != nil {
+		return nil, rpcstatus.Wrap(rpcstatus.Internal, err)
+	}
+
+	from, _ := date.MonthBoundary(now.UTC())
+	egress, err := bandwidth.db.EgressSummary(ctx, from, now)
 	if err != nil {
 		return nil, rpcstatus.Wrap(rpcstatus.Internal, err)
 	}
 
 	return &multinodepb.BandwidthMonthSummaryResponse{
-		Used: used,
+		Used:   used,
+		Egress: egress.Total(),
 	}, nil
 }
@@ -0,0 +1,11 @@
+// Copyright (C) 2021 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package multinode
+
+import "time"
+
+// SetEarnedPerSatelliteBudget sets the time in which earned per satellite is gathered.
+func (payout *PayoutEndpoint) SetEarnedPerSatelliteBudget(budget time.Duration) {
+	payout.earnedPerSatelliteBudget = budget
+}
@@ -9,6 +9,7 @@ import (
 	"go.uber.org/zap"
 
 	"storj.io/common/rpc/rpcstatus"
+	"storj.io/common/storj"
 	"storj.io/private/version"
 	"storj.io/storj/private/multinodepb"
 	"storj.io/storj/storagenode/apikeys"
@@ -29,17 +30,19 @@ type NodeEndpoint struct {
 	apiKeys    *apikeys.Service
 	version    version.Info
 	contact    *contact.PingStats
+	checkIns   *contact.Service
 	reputation reputation.DB
 	trust      *trust.Pool
 }
 
 // NewNodeEndpoint creates new multinode node endpoint.
-func NewNodeEndpoint(log *zap.Logger, apiKeys *apikeys.Service, version version.Info, contact *contact.PingStats, reputation reputation.DB, trust *trust.Pool) *NodeEndpoint {
+func NewNodeEndpoint(log *zap.Logger, apiKeys *apikeys.Service, version version.Info, contact *contact.PingStats, checkIns *contact.Service, reputation reputation.DB, trust *trust.Pool) *NodeEndpoint {
 	return &NodeEndpoint{
 		log:        log,
 		apiKeys:    apiKeys,
 		version:    version,
 		contact:    contact,
+		checkIns:   checkIns,
 		reputation: reputation,
 		trust:      trust,
 	}
@@ -95,6 +98,43 @@ func (node *NodeEndpoint) Reputation(ctx context.Context, req *multinodepb.Reput
 	}, nil
 }
 
+// ReputationScores returns audit, suspension and online scores of every trusted satellite.
+// Satellites which haven't sent reputation stats yet are returned as not reported.
+func (node *NodeEndpoint) ReputationScores(ctx context.Context, req *multinodepb.ReputationScoresRequest) (_ *multinodepb.ReputationScoresResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = authenticate(ctx, node.apiKeys, req.GetHeader()); err != nil {
+		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	}
+
+	stats, err := node.reputation.All(ctx)
+	if err != nil {
+		return nil, rpcstatus.Wrap(rpcstatus.Internal, err)
+	}
+
+	reported := make(map[storj.NodeID]reputation.Stats, len(stats))
+	for _, satelliteStats := range stats {
+		reported[satelliteStats.SatelliteID] = satelliteStats
+	}
+
+	response := new(multinodepb.ReputationScoresResponse)
+	for _, satelliteID := range node.trust.GetSatellites(ctx) {
+		scores := &multinodepb.ReputationScoresResponse_SatelliteScores{
+			SatelliteId: satelliteID,
+		}
+		if satelliteStats, ok := reported[satelliteID]; ok {
+			scores.Reported = true
+			scores.AuditScore = satelliteStats.Audit.Score
+			scores.SuspensionScore = satelliteStats.Audit.UnknownScore
+			scores.OnlineScore = satelliteStats.OnlineScore
+		}
+
+		response.Scores = append(response.Scores, scores)
+	}
+
+	return response, nil
+}
+
 // TrustedSatellites returns list of trusted satellites node urls.
 func (node *NodeEndpoint) TrustedSatellites(ctx context.Context, req *multinodepb.TrustedSatellitesRequest) (_ *multinodepb.TrustedSatellitesResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -120,3 +160,29 @@ func (node *NodeEndpoint) TrustedSatellites(ctx context.Context, req *multinodep
 
 	return response, nil
 }
+
+// SatelliteCheckIns returns result of the last node check-in on each trusted satellite.
+func (node *NodeEndpoint) SatelliteCheckIns(ctx context.Context, req *multinodepb.SatelliteCheckInsRequest) (_ *multinodepb.SatelliteCheckInsResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = authenticate(ctx, node.apiKeys, req.GetHeader()); err != nil {
+		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	}
+
+	response := new(multinodepb.SatelliteCheckInsResponse)
+
+	satellites := node.trust.GetSatellites(ctx)
+	for _, satellite := range satellites {
+		checkIn, attempted := node.checkIns.LastCheckIn(satellite)
+
+		response.CheckIns = append(response.CheckIns, &multinodepb.SatelliteCheckInsResponse_CheckIn{
+			SatelliteId: satellite,
+			Attempted:   attempted,
+			Succeeded:   checkIn.Succeeded,
+			CheckedAt:   checkIn.At,
+			Error:       checkIn.Error,
+		})
+	}
+
+	return response, nil
+}
@@ -5,19 +5,47 @@ package multinode
 
 import (
 	"context"
+	"math"
+	"sort"
 	"time"
 
+	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 
 	"storj.io/common/rpc/rpcstatus"
+	"storj.io/common/storj"
+	"storj.io/common/sync2"
 	"storj.io/storj/private/multinodepb"
 	"storj.io/storj/storagenode/apikeys"
+	"storj.io/storj/storagenode/operator"
 	"storj.io/storj/storagenode/payouts"
 	"storj.io/storj/storagenode/payouts/estimatedpayouts"
+	"storj.io/storj/storagenode/reputation"
+	"storj.io/storj/storagenode/storagenodedb"
 )
 
 var _ multinodepb.DRPCPayoutServer = (*PayoutEndpoint)(nil)
 
+const (
+	// estimationAttempts is the maximum number of attempts to estimate payouts.
+	estimationAttempts = 3
+	// estimationBackoff is the delay before the first retry, doubled for every next one.
+	estimationBackoff = 50 * time.Millisecond
+	// periodSummaryConcurrency is the maximum number of satellite period summaries queried at once.
+	periodSummaryConcurrency = 8
+	// earnedPerSatelliteBudget is the time in which earned per satellite is gathered, satellites
+	// gathered afterwards are left out of truncated response. It must be shorter than the timeout
+	// of multinode dashboard, otherwise it stops waiting before the truncated response is sent.
+	earnedPerSatelliteBudget = 10 * time.Second
+)
+
+var (
+	// paystubUnit describes amounts received from satellite paystubs.
+	paystubUnit = &multinodepb.AmountUnit{Currency: "USD", Decimals: 6}
+	// estimationUnit describes estimated amounts, which are calculated in cents.
+	estimationUnit = &multinodepb.AmountUnit{Currency: "USD", Decimals: 2}
+)
+
 // PayoutEndpoint implements multinode payouts endpoint.
 //
 // architecture: Endpoint
@@ -28,33 +56,79 @@ type PayoutEndpoint struct {
 	apiKeys          *apikeys.Service
 	estimatedPayouts *estimatedpayouts.Service
 	db               payouts.DB
+	reputationDB     reputation.DB
+	operator         operator.Config
+
+	earnedPerSatelliteBudget time.Duration
 }
 
 // NewPayoutEndpoint creates new multinode payouts endpoint.
-func NewPayoutEndpoint(log *zap.Logger, apiKeys *apikeys.Service, estimatedPayouts *estimatedpayouts.Service, db payouts.DB) *PayoutEndpoint {
+func NewPayoutEndpoint(log *zap.Logger, apiKeys *apikeys.Service, estimatedPayouts *estimatedpayouts.Service, db payouts.DB, reputationDB reputation.DB, operator operator.Config) *PayoutEndpoint {
 	return &PayoutEndpoint{
 		log:              log,
 		apiKeys:          apiKeys,
 		estimatedPayouts: estimatedPayouts,
 		db:               db,
+		reputationDB:     reputationDB,
+		operator:         operator,
+
+		earnedPerSatelliteBudget: earnedPerSatelliteBudget,
 	}
 }
 
+// PayoutConfig returns wallet configured by the node operator to receive payouts.
+func (payout *PayoutEndpoint) PayoutConfig(ctx context.Context, req *multinodepb.PayoutConfigRequest) (_ *multinodepb.PayoutConfigResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = payout.authenticate(ctx, req.GetHeader()); err != nil {
+		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	}
+
+	return &multinodepb.PayoutConfigResponse{
+		Wallet:         payout.operator.Wallet,
+		WalletFeatures: payout.operator.WalletFeatures,
+	}, nil
+}
+
 // Earned returns total earned amount.
 func (payout *PayoutEndpoint) Earned(ctx context.Context, req *multinodepb.EarnedRequest) (_ *multinodepb.EarnedResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, payout.apiKeys, req.GetHeader()); err != nil {
+	if err = payout.authenticate(ctx, req.GetHeader()); err != nil {
 		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
 	}
 
 	earned, err := payout.db.GetTotalEarned(ctx)
 	if err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Internal, err)
+		return nil, payout.internalError(err, "failed to get total earned", multinodepb.ErrorDetails{Code: multinodepb.ErrorCodeDatabase})
+	}
+
+	satelliteIDs, err := payout.payingSatellites(ctx)
+	if err != nil {
+		return nil, payout.internalError(err, "failed to get paying satellites", multinodepb.ErrorDetails{Code: multinodepb.ErrorCodeDatabase})
+	}
+
+	var held int64
+	var components payouts.EarnedComponents
+	for _, satelliteID := range satelliteIDs {
+		satelliteHeld, err := payout.currentlyHeld(ctx, satelliteID)
+		if err != nil {
+			return nil, payout.internalError(err, "failed to get held at satellite", multinodepb.ErrorDetails{Code: multinodepb.ErrorCodeDatabase, SatelliteID: satelliteID})
+		}
+		held += satelliteHeld
+
+		satelliteComponents, err := payout.db.GetEarnedComponentsAtSatellite(ctx, satelliteID)
+		if err != nil {
+			return nil, payout.internalError(err, "failed to get earned components at satellite", multinodepb.ErrorDetails{Code: multinodepb.ErrorCodeDatabase, SatelliteID: satelliteID})
+		}
+		components.Add(satelliteComponents)
 	}
 
 	return &multinodepb.EarnedResponse{
-		Total: earned,
+		Total:      earned,
+		Unit:       paystubUnit,
+		Net:        earned - held,
+		Components: earnedComponents(components),
 	}, nil
 }
 
@@ -62,25 +136,219 @@ func (payout *PayoutEndpoint) Earned(ctx context.Context, req *multinodepb.Earne
 func (payout *PayoutEndpoint) EarnedPerSatellite(ctx context.Context, req *multinodepb.EarnedPerSatelliteRequest) (_ *multinodepb.EarnedPerSatelliteResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, payout.apiKeys, req.GetHeader()); err != nil {
+	if err = payout.authenticate(ctx, req.GetHeader()); err != nil {
 		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
 	}
 
-	var resp multinodepb.EarnedPerSatelliteResponse
-	satelliteIDs, err := payout.db.GetPayingSatellitesIDs(ctx)
+	resp := multinodepb.EarnedPerSatelliteResponse{Unit: paystubUnit}
+	satelliteIDs, err := payout.payingSatellites(ctx)
 	if err != nil {
-		return nil, rpcstatus.Wrap(rpcstatus.Internal, err)
+		return nil, payout.internalError(err, "failed to get paying satellites", multinodepb.ErrorDetails{Code: multinodepb.ErrorCodeDatabase})
+	}
+
+	// DRPC drops the response of canceled request, so the node stops gathering satellites
+	// on its own, while the client is still waiting for the truncated response.
+	ctx, cancel := context.WithTimeout(ctx, payout.earnedPerSatelliteBudget)
+	defer cancel()
+
+	// satellites gathered before the budget is exceeded are returned as truncated response.
+	truncated := func() (*multinodepb.EarnedPerSatelliteResponse, error) {
+		payout.log.Warn("earned per satellite budget exceeded, returning truncated response",
+			zap.Int("gathered", len(resp.EarnedSatellite)), zap.Int("satellites", len(satelliteIDs)), zap.Error(ctx.Err()))
+		resp.Truncated = true
+		return &resp, nil
 	}
 
 	for i := 0; i < len(satelliteIDs); i++ {
+		if ctx.Err() != nil {
+			return truncated()
+		}
+
 		earned, err := payout.db.GetEarnedAtSatellite(ctx, satelliteIDs[i])
 		if err != nil {
-			return nil, rpcstatus.Wrap(rpcstatus.Internal, err)
+			if ctx.Err() != nil {
+				return truncated()
+			}
+			return nil, payout.internalError(err, "failed to get earned at satellite", multinodepb.ErrorDetails{Code: multinodepb.ErrorCodeDatabase, SatelliteID: satelliteIDs[i]})
+		}
+		if earned < req.MinAmount {
+			continue
+		}
+
+		held, err := payout.currentlyHeld(ctx, satelliteIDs[i])
+		if err != nil {
+			if ctx.Err() != nil {
+				return truncated()
+			}
+			return nil, payout.internalError(err, "failed to get held at satellite", multinodepb.ErrorDetails{Code: multinodepb.ErrorCodeDatabase, SatelliteID: satelliteIDs[i]})
 		}
 
+		components, err := payout.db.GetEarnedComponentsAtSatellite(ctx, satelliteIDs[i])
+		if err != nil {
+			if ctx.Err() != nil {
+				return truncated()
+			}
+			return nil, payout.internalError(err, "failed to get earned components at satellite", multinodepb.ErrorDetails{Code: multinodepb.ErrorCodeDatabase, SatelliteID: satelliteIDs[i]})
+		}
+
+		// satellite paystubs are always denominated in USD, regardless of the token used for payment.
 		resp.EarnedSatellite = append(resp.EarnedSatellite, &multinodepb.EarnedSatellite{
 			Total:       earned,
 			SatelliteId: satelliteIDs[i],
+			Unit:        paystubUnit,
+			Net:         earned - held,
+			Components:  earnedComponents(components),
+		})
+	}
+
+	return &resp, nil
+}
+
+// UndistributedPerSatellite returns amount paid by every satellite, which was not yet distributed.
+func (payout *PayoutEndpoint) UndistributedPerSatellite(ctx context.Context, req *multinodepb.UndistributedPerSatelliteRequest) (_ *multinodepb.UndistributedPerSatelliteResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = payout.authenticate(ctx, req.GetHeader()); err != nil {
+		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	}
+
+	resp := multinodepb.UndistributedPerSatelliteResponse{Unit: paystubUnit}
+	satelliteIDs, err := payout.payingSatellites(ctx)
+	if err != nil {
+		return nil, payout.internalError(err, "failed to get paying satellites", multinodepb.ErrorDetails{Code: multinodepb.ErrorCodeDatabase})
+	}
+
+	for _, satelliteID := range satelliteIDs {
+		undistributed, err := payout.db.GetUndistributedAtSatellite(ctx, satelliteID)
+		if err != nil {
+			return nil, payout.internalError(err, "failed to get undistributed at satellite", multinodepb.ErrorDetails{Code: multinodepb.ErrorCodeDatabase, SatelliteID: satelliteID})
+		}
+
+		resp.UndistributedSatellite = append(resp.UndistributedSatellite, &multinodepb.UndistributedSatellite{
+			Total:       undistributed,
+			SatelliteId: satelliteID,
+		})
+	}
+
+	return &resp, nil
+}
+
+// AvailablePeriods returns all periods in which node has some payouts data.
+func (payout *PayoutEndpoint) AvailablePeriods(ctx context.Context, req *multinodepb.AvailablePeriodsRequest) (_ *multinodepb.AvailablePeriodsResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = payout.authenticate(ctx, req.GetHeader()); err != nil {
+		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	}
+
+	periods, err := payout.db.AllPeriods(ctx)
+	if err != nil {
+		return nil, payout.internalError(err, "failed to get available periods", multinodepb.ErrorDetails{Code: multinodepb.ErrorCodeDatabase})
+	}
+
+	return &multinodepb.AvailablePeriodsResponse{Period: periods}, nil
+}
+
+// FirstPaystubPeriod returns the first period the node has a paystub from the satellite for.
+func (payout *PayoutEndpoint) FirstPaystubPeriod(ctx context.Context, req *multinodepb.FirstPaystubPeriodRequest) (_ *multinodepb.FirstPaystubPeriodResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = payout.authenticate(ctx, req.GetHeader()); err != nil {
+		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	}
+
+	periods, err := payout.db.SatellitePeriods(ctx, req.SatelliteId)
+	if err != nil {
+		return nil, payout.internalError(err, "failed to get satellite periods", multinodepb.ErrorDetails{Code: multinodepb.ErrorCodeDatabase, SatelliteID: req.SatelliteId})
+	}
+
+	// periods are in YYYY-MM format, so they sort chronologically.
+	var first string
+	for _, period := range periods {
+		if first == "" || period < first {
+			first = period
+		}
+	}
+
+	return &multinodepb.FirstPaystubPeriodResponse{Period: first}, nil
+}
+
+// PaymentsPerSatellite returns amount paid according to paystubs and sum of payments recorded by the node
+// for every paying satellite, so they can be reconciled.
+func (payout *PayoutEndpoint) PaymentsPerSatellite(ctx context.Context, req *multinodepb.PaymentsPerSatelliteRequest) (_ *multinodepb.PaymentsPerSatelliteResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = payout.authenticate(ctx, req.GetHeader()); err != nil {
+		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	}
+
+	resp := multinodepb.PaymentsPerSatelliteResponse{Unit: paystubUnit}
+	satelliteIDs, err := payout.payingSatellites(ctx)
+	if err != nil {
+		return nil, payout.internalError(err, "failed to get paying satellites", multinodepb.ErrorDetails{Code: multinodepb.ErrorCodeDatabase})
+	}
+
+	for _, satelliteID := range satelliteIDs {
+		paid, _, err := payout.db.GetSatelliteSummary(ctx, satelliteID)
+		if err != nil {
+			return nil, payout.internalError(err, "failed to get satellite summary", multinodepb.ErrorDetails{Code: multinodepb.ErrorCodeDatabase, SatelliteID: satelliteID})
+		}
+
+		received, count, err := payout.db.GetSatellitePayments(ctx, satelliteID)
+		if err != nil {
+			return nil, payout.internalError(err, "failed to get satellite payments", multinodepb.ErrorDetails{Code: multinodepb.ErrorCodeDatabase, SatelliteID: satelliteID})
+		}
+
+		resp.SatellitePayments = append(resp.SatellitePayments, &multinodepb.PaymentsPerSatelliteResponse_SatellitePayments{
+			SatelliteId: satelliteID,
+			Paid:        paid,
+			Received:    received,
+			Payments:    int32(count),
+		})
+	}
+
+	return &resp, nil
+}
+
+// HeldRates returns amount held from the node earnings in the period by every paying satellite,
+// with the node join date on the satellite, which determines the held percentage.
+func (payout *PayoutEndpoint) HeldRates(ctx context.Context, req *multinodepb.HeldRatesRequest) (_ *multinodepb.HeldRatesResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = payout.authenticate(ctx, req.GetHeader()); err != nil {
+		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	}
+
+	resp := multinodepb.HeldRatesResponse{Unit: paystubUnit}
+	satelliteIDs, err := payout.payingSatellites(ctx)
+	if err != nil {
+		return nil, payout.internalError(err, "failed to get paying satellites", multinodepb.ErrorDetails{Code: multinodepb.ErrorCodeDatabase})
+	}
+
+	for _, satelliteID := range satelliteIDs {
+		paystub, err := payout.db.GetPayStub(ctx, satelliteID, req.Period)
+		if err != nil {
+			if payouts.ErrNoPayStubForPeriod.Has(err) {
+				continue
+			}
+			return nil, payout.internalError(err, "failed to get paystub", multinodepb.ErrorDetails{Code: multinodepb.ErrorCodeDatabase, SatelliteID: satelliteID, Period: req.Period})
+		}
+
+		stats, err := payout.reputationDB.Get(ctx, satelliteID)
+		if err != nil {
+			return nil, payout.internalError(err, "failed to get reputation", multinodepb.ErrorDetails{Code: multinodepb.ErrorCodeDatabase, SatelliteID: satelliteID})
+		}
+
+		if paystub.SurgePercent == 0 {
+			paystub.SurgePercent = 100
+		}
+		_, surge := paystub.GetEarnedWithSurge()
+
+		resp.HeldRates = append(resp.HeldRates, &multinodepb.HeldRatesResponse_HeldRate{
+			SatelliteId: satelliteID,
+			JoinedAt:    stats.JoinedAt,
+			Held:        paystub.Held,
+			Earned:      surge,
 		})
 	}
 
@@ -91,93 +359,127 @@ func (payout *PayoutEndpoint) EarnedPerSatellite(ctx context.Context, req *multi
 func (payout *PayoutEndpoint) EstimatedPayoutTotal(ctx context.Context, req *multinodepb.EstimatedPayoutTotalRequest) (_ *multinodepb.EstimatedPayoutTotalResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, payout.apiKeys, req.GetHeader()); err != nil {
+	if err = payout.authenticate(ctx, req.GetHeader()); err != nil {
 		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
 	}
 
-	estimated, err := payout.estimatedPayouts.GetAllSatellitesEstimatedPayout(ctx, time.Now())
+	now := payout.estimationTime(time.Now(), req.AsOf)
+
+	var estimated estimatedpayouts.EstimatedPayout
+	err = retryTransient(ctx, func() (err error) {
+		estimated, err = payout.estimatedPayouts.GetAllSatellitesEstimatedPayout(ctx, now)
+		return err
+	})
 	if err != nil {
-		return &multinodepb.EstimatedPayoutTotalResponse{}, rpcstatus.Wrap(rpcstatus.Internal, err)
+		return &multinodepb.EstimatedPayoutTotalResponse{}, payout.internalError(err, "failed to estimate payout", multinodepb.ErrorDetails{Code: multinodepb.ErrorCodeEstimation})
 	}
 
-	return &multinodepb.EstimatedPayoutTotalResponse{EstimatedEarnings: estimated.CurrentMonthExpectations}, nil
+	previousMonth := estimated.PreviousMonth
+
+	return &multinodepb.EstimatedPayoutTotalResponse{
+		EstimatedEarnings:              estimated.CurrentMonthExpectations,
+		Unit:                           estimationUnit,
+		PreviousMonthEstimatedEarnings: int64(math.Round(previousMonth.Payout + previousMonth.Held)),
+		PreviousMonthPeriod:            time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, time.UTC).Format("2006-01"),
+	}, nil
 }
 
 // EstimatedPayoutSatellite returns estimated earnings for current month from specific satellite.
 func (payout *PayoutEndpoint) EstimatedPayoutSatellite(ctx context.Context, req *multinodepb.EstimatedPayoutSatelliteRequest) (_ *multinodepb.EstimatedPayoutSatelliteResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, payout.apiKeys, req.GetHeader()); err != nil {
+	if err = payout.authenticate(ctx, req.GetHeader()); err != nil {
 		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
 	}
 
-	estimated, err := payout.estimatedPayouts.GetSatelliteEstimatedPayout(ctx, req.SatelliteId, time.Now())
+	now := payout.estimationTime(time.Now(), req.AsOf)
+
+	var estimated estimatedpayouts.EstimatedPayout
+	err = retryTransient(ctx, func() (err error) {
+		estimated, err = payout.estimatedPayouts.GetSatelliteEstimatedPayout(ctx, req.SatelliteId, now)
+		return err
+	})
 	if err != nil {
-		return &multinodepb.EstimatedPayoutSatelliteResponse{}, rpcstatus.Wrap(rpcstatus.Internal, err)
+		return &multinodepb.EstimatedPayoutSatelliteResponse{}, payout.internalError(err, "failed to estimate satellite payout", multinodepb.ErrorDetails{Code: multinodepb.ErrorCodeEstimation, SatelliteID: req.SatelliteId})
 	}
 
-	return &multinodepb.EstimatedPayoutSatelliteResponse{EstimatedEarnings: estimated.CurrentMonthExpectations}, nil
+	return &multinodepb.EstimatedPayoutSatelliteResponse{EstimatedEarnings: estimated.CurrentMonthExpectations, Unit: estimationUnit}, nil
 }
 
 // AllSatellitesSummary returns all satellites all time payout summary.
 func (payout *PayoutEndpoint) AllSatellitesSummary(ctx context.Context, req *multinodepb.AllSatellitesSummaryRequest) (_ *multinodepb.AllSatellitesSummaryResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, payout.apiKeys, req.GetHeader()); err != nil {
+	if err = payout.authenticate(ctx, req.GetHeader()); err != nil {
 		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
 	}
 
 	var totalPaid, totalHeld int64
-	satelliteIDs, err := payout.db.GetPayingSatellitesIDs(ctx)
+	satelliteIDs, err := payout.payingSatellites(ctx)
 	if err != nil {
-		return &multinodepb.AllSatellitesSummaryResponse{}, rpcstatus.Wrap(rpcstatus.Internal, err)
+		return &multinodepb.AllSatellitesSummaryResponse{}, payout.internalError(err, "failed to get paying satellites", multinodepb.ErrorDetails{Code: multinodepb.ErrorCodeDatabase})
 	}
 
 	for _, id := range satelliteIDs {
 		paid, held, err := payout.db.GetSatelliteSummary(ctx, id)
 		if err != nil {
-			return &multinodepb.AllSatellitesSummaryResponse{}, rpcstatus.Wrap(rpcstatus.Internal, err)
+			return &multinodepb.AllSatellitesSummaryResponse{}, payout.internalError(err, "failed to get satellite summary", multinodepb.ErrorDetails{Code: multinodepb.ErrorCodeDatabase, SatelliteID: id})
 		}
 
 		totalHeld += held
 		totalPaid += paid
 	}
 
-	return &multinodepb.AllSatellitesSummaryResponse{PayoutInfo: &multinodepb.PayoutInfo{Paid: totalPaid, Held: totalHeld}}, nil
+	return &multinodepb.AllSatellitesSummaryResponse{PayoutInfo: payoutInfo(totalHeld, totalPaid, req.Format)}, nil
 }
 
 // AllSatellitesPeriodSummary returns all satellites period payout summary.
 func (payout *PayoutEndpoint) AllSatellitesPeriodSummary(ctx context.Context, req *multinodepb.AllSatellitesPeriodSummaryRequest) (_ *multinodepb.AllSatellitesPeriodSummaryResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, payout.apiKeys, req.GetHeader()); err != nil {
+	if err = payout.authenticate(ctx, req.GetHeader()); err != nil {
 		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
 	}
 
 	var totalPaid, totalHeld int64
-	satelliteIDs, err := payout.db.GetPayingSatellitesIDs(ctx)
+	satelliteIDs, err := payout.payingSatellites(ctx)
 	if err != nil {
-		return &multinodepb.AllSatellitesPeriodSummaryResponse{}, rpcstatus.Wrap(rpcstatus.Internal, err)
+		return &multinodepb.AllSatellitesPeriodSummaryResponse{}, payout.internalError(err, "failed to get paying satellites", multinodepb.ErrorDetails{Code: multinodepb.ErrorCodeDatabase, Period: req.Period})
 	}
 
-	for _, id := range satelliteIDs {
-		paid, held, err := payout.db.GetSatellitePeriodSummary(ctx, id, req.Period)
-		if err != nil {
-			return &multinodepb.AllSatellitesPeriodSummaryResponse{}, rpcstatus.Wrap(rpcstatus.Internal, err)
+	// every worker writes only its own result, so no locking is needed.
+	results := make([]satellitePeriodSummary, len(satelliteIDs))
+
+	limiter := sync2.NewLimiter(periodSummaryConcurrency)
+	for i, id := range satelliteIDs {
+		i, id := i, id
+		started := limiter.Go(ctx, func() {
+			results[i].paid, results[i].held, results[i].err = payout.db.GetSatellitePeriodSummary(ctx, id, req.Period)
+		})
+		if !started {
+			results[i].err = ctx.Err()
 		}
+	}
+	limiter.Wait()
 
-		totalHeld += held
-		totalPaid += paid
+	// the whole request fails on any satellite error, the first satellite in order is reported.
+	for i, result := range results {
+		if result.err != nil {
+			return &multinodepb.AllSatellitesPeriodSummaryResponse{}, payout.internalError(result.err, "failed to get satellite period summary", multinodepb.ErrorDetails{Code: multinodepb.ErrorCodeDatabase, SatelliteID: satelliteIDs[i], Period: req.Period})
+		}
+
+		totalHeld += result.held
+		totalPaid += result.paid
 	}
 
-	return &multinodepb.AllSatellitesPeriodSummaryResponse{PayoutInfo: &multinodepb.PayoutInfo{Held: totalHeld, Paid: totalPaid}}, nil
+	return &multinodepb.AllSatellitesPeriodSummaryResponse{PayoutInfo: payoutInfo(totalHeld, totalPaid, req.Format)}, nil
 }
 
 // SatelliteSummary returns satellite all time payout summary.
 func (payout *PayoutEndpoint) SatelliteSummary(ctx context.Context, req *multinodepb.SatelliteSummaryRequest) (_ *multinodepb.SatelliteSummaryResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, payout.apiKeys, req.GetHeader()); err != nil {
+	if err = payout.authenticate(ctx, req.GetHeader()); err != nil {
 		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
 	}
 
@@ -185,17 +487,17 @@ func (payout *PayoutEndpoint) SatelliteSummary(ctx context.Context, req *multino
 
 	totalPaid, totalHeld, err = payout.db.GetSatelliteSummary(ctx, req.SatelliteId)
 	if err != nil {
-		return &multinodepb.SatelliteSummaryResponse{}, rpcstatus.Wrap(rpcstatus.Internal, err)
+		return &multinodepb.SatelliteSummaryResponse{}, payout.internalError(err, "failed to get satellite summary", multinodepb.ErrorDetails{Code: multinodepb.ErrorCodeDatabase, SatelliteID: req.SatelliteId})
 	}
 
-	return &multinodepb.SatelliteSummaryResponse{PayoutInfo: &multinodepb.PayoutInfo{Held: totalHeld, Paid: totalPaid}}, nil
+	return &multinodepb.SatelliteSummaryResponse{PayoutInfo: payoutInfo(totalHeld, totalPaid, req.Format)}, nil
 }
 
 // SatellitePeriodSummary returns satellite period payout summary.
 func (payout *PayoutEndpoint) SatellitePeriodSummary(ctx context.Context, req *multinodepb.SatellitePeriodSummaryRequest) (_ *multinodepb.SatellitePeriodSummaryResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = authenticate(ctx, payout.apiKeys, req.GetHeader()); err != nil {
+	if err = payout.authenticate(ctx, req.GetHeader()); err != nil {
 		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
 	}
 
@@ -203,8 +505,254 @@ func (payout *PayoutEndpoint) SatellitePeriodSummary(ctx context.Context, req *m
 
 	totalPaid, totalHeld, err = payout.db.GetSatellitePeriodSummary(ctx, req.SatelliteId, req.Period)
 	if err != nil {
-		return &multinodepb.SatellitePeriodSummaryResponse{}, rpcstatus.Wrap(rpcstatus.Internal, err)
+		return &multinodepb.SatellitePeriodSummaryResponse{}, payout.internalError(err, "failed to get satellite period summary", multinodepb.ErrorDetails{Code: multinodepb.ErrorCodeDatabase, SatelliteID: req.SatelliteId, Period: req.Period})
+	}
+
+	return &multinodepb.SatellitePeriodSummaryResponse{PayoutInfo: payoutInfo(totalHeld, totalPaid, req.Format)}, nil
+}
+
+// SatelliteSummaries returns satellite summary for every node authenticated by the request headers,
+// so that nodes served by the same process are queried with a single request. This process serves
+// a single node, so every accepted header receives the same summary. At least one header has to be accepted.
+func (payout *PayoutEndpoint) SatelliteSummaries(ctx context.Context, req *multinodepb.SatelliteSummariesRequest) (_ *multinodepb.SatelliteSummariesResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var resp multinodepb.SatelliteSummariesResponse
+	var info *multinodepb.PayoutInfo
+	var authErr error
+	for _, header := range req.Headers {
+		if err := payout.authenticate(ctx, header); err != nil {
+			authErr = err
+			resp.NodeSummaries = append(resp.NodeSummaries, &multinodepb.SatelliteSummariesResponse_NodeSummary{Unauthenticated: true})
+			continue
+		}
+
+		if info == nil {
+			info, err = payout.satelliteSummary(ctx, req.SatelliteId, req.Period, req.Format)
+			if err != nil {
+				return nil, err
+			}
+		}
+		resp.NodeSummaries = append(resp.NodeSummaries, &multinodepb.SatelliteSummariesResponse_NodeSummary{PayoutInfo: info})
+	}
+
+	if info == nil {
+		if authErr == nil {
+			authErr = errs.New("no request header")
+		}
+		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, authErr)
+	}
+
+	return &resp, nil
+}
+
+// satelliteSummary returns satellite payout info for the period, or for all time when period is empty.
+func (payout *PayoutEndpoint) satelliteSummary(ctx context.Context, satelliteID storj.NodeID, period string, format bool) (_ *multinodepb.PayoutInfo, err error) {
+	if period == "" {
+		paid, held, err := payout.db.GetSatelliteSummary(ctx, satelliteID)
+		if err != nil {
+			return nil, payout.internalError(err, "failed to get satellite summary", multinodepb.ErrorDetails{Code: multinodepb.ErrorCodeDatabase, SatelliteID: satelliteID})
+		}
+		return payoutInfo(held, paid, format), nil
+	}
+
+	paid, held, err := payout.db.GetSatellitePeriodSummary(ctx, satelliteID, period)
+	if err != nil {
+		return nil, payout.internalError(err, "failed to get satellite period summary", multinodepb.ErrorDetails{Code: multinodepb.ErrorCodeDatabase, SatelliteID: satelliteID, Period: period})
 	}
+	return payoutInfo(held, paid, format), nil
+}
 
-	return &multinodepb.SatellitePeriodSummaryResponse{PayoutInfo: &multinodepb.PayoutInfo{Held: totalHeld, Paid: totalPaid}}, nil
+// earnedComponents converts earned components into their protobuf representation.
+func earnedComponents(components payouts.EarnedComponents) *multinodepb.EarnedComponents {
+	return &multinodepb.EarnedComponents{
+		CompAtRest:    components.AtRest,
+		CompGet:       components.Get,
+		CompGetRepair: components.GetRepair,
+		CompGetAudit:  components.GetAudit,
+	}
+}
+
+// payoutInfo creates paystub payout info, adding formatted amounts when format is requested.
+func payoutInfo(held, paid int64, format bool) *multinodepb.PayoutInfo {
+	info := &multinodepb.PayoutInfo{Held: held, Paid: paid, Unit: paystubUnit}
+	if format {
+		info.HeldDisplay = multinodepb.FormatAmount(held, paystubUnit)
+		info.PaidDisplay = multinodepb.FormatAmount(paid, paystubUnit)
+	}
+	return info
+}
+
+// authenticate checks if request header contains valid api key, logging optional client info on failure.
+func (payout *PayoutEndpoint) authenticate(ctx context.Context, header *multinodepb.RequestHeader) error {
+	if err := authenticate(ctx, payout.apiKeys, header); err != nil {
+		clientVersion, traceID := clientInfo(header)
+		payout.log.Debug("unauthenticated request",
+			zap.String("Client Version", clientVersion),
+			zap.String("Trace ID", traceID),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	return nil
+}
+
+// satellitePeriodSummary is the result of a single satellite period summary query.
+type satellitePeriodSummary struct {
+	paid, held int64
+	err        error
+}
+
+// PayingSatellites returns ids of all satellites that ever paid to the node.
+func (payout *PayoutEndpoint) PayingSatellites(ctx context.Context, req *multinodepb.PayingSatellitesRequest) (_ *multinodepb.PayingSatellitesResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = payout.authenticate(ctx, req.GetHeader()); err != nil {
+		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	}
+
+	satelliteIDs, err := payout.payingSatellites(ctx)
+	if err != nil {
+		return nil, payout.internalError(err, "failed to get paying satellites", multinodepb.ErrorDetails{Code: multinodepb.ErrorCodeDatabase})
+	}
+
+	return &multinodepb.PayingSatellitesResponse{SatelliteIds: satelliteIDs}, nil
+}
+
+// HeldHistory returns amounts held and disposed by every satellite in every period.
+func (payout *PayoutEndpoint) HeldHistory(ctx context.Context, req *multinodepb.HeldHistoryRequest) (_ *multinodepb.HeldHistoryResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = payout.authenticate(ctx, req.GetHeader()); err != nil {
+		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	}
+
+	var resp multinodepb.HeldHistoryResponse
+	err = payout.heldHistory(ctx, func(entry *multinodepb.HeldHistoryEntry) error {
+		resp.Entries = append(resp.Entries, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// HeldHistoryStream sends amounts held and disposed by every satellite in every period one entry at a time,
+// so that long history is never held in memory as a whole.
+func (payout *PayoutEndpoint) HeldHistoryStream(req *multinodepb.HeldHistoryRequest, stream multinodepb.DRPCPayout_HeldHistoryStreamStream) (err error) {
+	ctx := stream.Context()
+	defer mon.Task()(&ctx)(&err)
+
+	if err = payout.authenticate(ctx, req.GetHeader()); err != nil {
+		return rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	}
+
+	return payout.heldHistory(ctx, stream.Send)
+}
+
+// heldHistory passes held history entries sorted by satellite id and period to send.
+// Sending stops at the first send error, which is returned.
+func (payout *PayoutEndpoint) heldHistory(ctx context.Context, send func(*multinodepb.HeldHistoryEntry) error) (err error) {
+	satelliteIDs, err := payout.payingSatellites(ctx)
+	if err != nil {
+		return payout.internalError(err, "failed to get paying satellites", multinodepb.ErrorDetails{Code: multinodepb.ErrorCodeDatabase})
+	}
+
+	for _, satelliteID := range satelliteIDs {
+		periods, err := payout.db.SatellitePeriods(ctx, satelliteID)
+		if err != nil {
+			return payout.internalError(err, "failed to get satellite periods", multinodepb.ErrorDetails{Code: multinodepb.ErrorCodeDatabase, SatelliteID: satelliteID})
+		}
+		sort.Strings(periods)
+
+		for _, period := range periods {
+			paystub, err := payout.db.GetPayStub(ctx, satelliteID, period)
+			if err != nil {
+				return payout.internalError(err, "failed to get paystub", multinodepb.ErrorDetails{Code: multinodepb.ErrorCodeDatabase, SatelliteID: satelliteID, Period: period})
+			}
+
+			err = send(&multinodepb.HeldHistoryEntry{
+				SatelliteId: satelliteID,
+				Period:      period,
+				Held:        paystub.Held,
+				Disposed:    paystub.Disposed,
+				Unit:        paystubUnit,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// payingSatellites returns satellites that ever paid to the node, sorted by id,
+// so errors and partial results of per-satellite loops are deterministic.
+func (payout *PayoutEndpoint) payingSatellites(ctx context.Context) (_ []storj.NodeID, err error) {
+	satelliteIDs, err := payout.db.GetPayingSatellitesIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Sort(storj.NodeIDList(satelliteIDs))
+	return satelliteIDs, nil
+}
+
+// currentlyHeld returns amount held by the satellite, which was not yet returned to the node.
+func (payout *PayoutEndpoint) currentlyHeld(ctx context.Context, satelliteID storj.NodeID) (_ int64, err error) {
+	_, held, err := payout.db.GetSatelliteSummary(ctx, satelliteID)
+	if err != nil {
+		return 0, err
+	}
+
+	disposed, err := payout.db.SatellitesDisposedHistory(ctx, satelliteID)
+	if err != nil {
+		return 0, err
+	}
+
+	return held - disposed, nil
+}
+
+// internalError logs the error and converts it into rpc error with structured error details.
+// The original error is not sent to the client, since it may contain sensitive information.
+func (payout *PayoutEndpoint) internalError(err error, message string, details multinodepb.ErrorDetails) error {
+	payout.log.Error(message, zap.String("Details", details.String()), zap.Error(err))
+	return details.Error(rpcstatus.Internal, message)
+}
+
+// estimationTime returns the time estimations are calculated for. Time provided by the caller overrides
+// the node clock, since skewed clock may attribute usage to the wrong month near the month boundary.
+func (payout *PayoutEndpoint) estimationTime(local, asOf time.Time) time.Time {
+	local = local.UTC()
+	if asOf.IsZero() {
+		return local
+	}
+
+	asOf = asOf.UTC()
+	if local.Year() != asOf.Year() || local.Month() != asOf.Month() {
+		payout.log.Warn("node clock is in a different month than the caller, possible clock skew",
+			zap.Time("local", local), zap.Time("as of", asOf))
+	}
+
+	return asOf
+}
+
+// retryTransient calls fn until it succeeds, fails with non transient error or runs out of attempts.
+func retryTransient(ctx context.Context, fn func() error) (err error) {
+	backoff := estimationBackoff
+	for attempt := 1; ; attempt++ {
+		err = fn()
+		if err == nil || attempt >= estimationAttempts || !storagenodedb.IsTransient(err) {
+			return err
+		}
+
+		if !sync2.Sleep(ctx, backoff) {
+			return errs.Combine(err, ctx.Err())
+		}
+		backoff *= 2
+	}
 }
@@ -4,21 +4,32 @@
 package multinode_test
 
 import (
+	"context"
+	"sort"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
+	"github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/require"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
+	"go.uber.org/zap/zaptest/observer"
 
 	"storj.io/common/pb"
 	"storj.io/common/rpc"
+	"storj.io/common/rpc/rpcstatus"
 	"storj.io/common/storj"
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
+	"storj.io/storj/private/multinodeauth"
 	"storj.io/storj/private/multinodepb"
 	"storj.io/storj/storagenode"
 	"storj.io/storj/storagenode/apikeys"
 	"storj.io/storj/storagenode/multinode"
+	"storj.io/storj/storagenode/operator"
 	"storj.io/storj/storagenode/payouts"
 	"storj.io/storj/storagenode/payouts/estimatedpayouts"
 	"storj.io/storj/storagenode/pricing"
@@ -67,7 +78,7 @@ func TestPayoutsEndpointSummary(t *testing.T) {
 		require.NoError(t, trustPool.Refresh(ctx))
 
 		estimatedPayoutsService := estimatedpayouts.NewService(db.Bandwidth(), db.Reputation(), db.StorageUsage(), db.Pricing(), db.Satellites(), trustPool)
-		endpoint := multinode.NewPayoutEndpoint(log, service, estimatedPayoutsService, db.Payout())
+		endpoint := multinode.NewPayoutEndpoint(log, service, estimatedPayoutsService, db.Payout(), db.Reputation(), operator.Config{})
 
 		id := testrand.NodeID()
 		id2 := testrand.NodeID()
@@ -132,6 +143,545 @@ func TestPayoutsEndpointSummary(t *testing.T) {
 	})
 }
 
+func TestPayoutsEndpointRequestHeader(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		log := zaptest.NewLogger(t)
+		service := apikeys.NewService(db.APIKeys())
+		endpoint := multinode.NewPayoutEndpoint(log, service, nil, db.Payout(), db.Reputation(), operator.Config{})
+
+		key, err := service.Issue(ctx)
+		require.NoError(t, err)
+
+		t.Run("without optional fields", func(t *testing.T) {
+			_, err := endpoint.Earned(ctx, &multinodepb.EarnedRequest{Header: &multinodepb.RequestHeader{
+				ApiKey: key.Secret[:],
+			}})
+			require.NoError(t, err)
+		})
+
+		t.Run("with optional fields", func(t *testing.T) {
+			_, err := endpoint.Earned(ctx, &multinodepb.EarnedRequest{Header: &multinodepb.RequestHeader{
+				ApiKey:        key.Secret[:],
+				ClientVersion: "v1.30.0",
+				TraceId:       "1f2e3d",
+			}})
+			require.NoError(t, err)
+		})
+
+		t.Run("invalid api key", func(t *testing.T) {
+			_, err := endpoint.Earned(ctx, &multinodepb.EarnedRequest{Header: &multinodepb.RequestHeader{
+				ApiKey:        testrand.BytesInt(32),
+				ClientVersion: "v1.30.0",
+			}})
+			require.Error(t, err)
+			require.Equal(t, rpcstatus.Unauthenticated, rpcstatus.Code(err))
+		})
+	})
+}
+
+func TestPayoutsEndpointAmountUnit(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		log := zaptest.NewLogger(t)
+		service := apikeys.NewService(db.APIKeys())
+		endpoint := multinode.NewPayoutEndpoint(log, service, nil, db.Payout(), db.Reputation(), operator.Config{})
+
+		satelliteID := testrand.NodeID()
+		err := db.Payout().StorePayStub(ctx, payouts.PayStub{
+			SatelliteID: satelliteID,
+			Held:        100,
+			Paid:        200,
+			Period:      "2021-04",
+		})
+		require.NoError(t, err)
+
+		key, err := service.Issue(ctx)
+		require.NoError(t, err)
+		header := &multinodepb.RequestHeader{ApiKey: key.Secret[:]}
+
+		earned, err := endpoint.Earned(ctx, &multinodepb.EarnedRequest{Header: header})
+		require.NoError(t, err)
+
+		earnedPerSatellite, err := endpoint.EarnedPerSatellite(ctx, &multinodepb.EarnedPerSatelliteRequest{Header: header})
+		require.NoError(t, err)
+
+		allSatellites, err := endpoint.AllSatellitesSummary(ctx, &multinodepb.AllSatellitesSummaryRequest{Header: header})
+		require.NoError(t, err)
+
+		satellitePeriod, err := endpoint.SatellitePeriodSummary(ctx, &multinodepb.SatellitePeriodSummaryRequest{
+			Header: header, SatelliteId: satelliteID, Period: "2021-04",
+		})
+		require.NoError(t, err)
+
+		units := []*multinodepb.AmountUnit{
+			earned.Unit,
+			earnedPerSatellite.Unit,
+			allSatellites.PayoutInfo.Unit,
+			satellitePeriod.PayoutInfo.Unit,
+		}
+		for _, unit := range units {
+			require.NotNil(t, unit)
+			require.Equal(t, "USD", unit.Currency)
+			require.EqualValues(t, 6, unit.Decimals)
+		}
+
+		// unit must survive the rpc encoding.
+		encoded, err := proto.Marshal(allSatellites)
+		require.NoError(t, err)
+
+		var decoded multinodepb.AllSatellitesSummaryResponse
+		require.NoError(t, proto.Unmarshal(encoded, &decoded))
+		require.Equal(t, allSatellites.PayoutInfo.Unit.Currency, decoded.PayoutInfo.Unit.Currency)
+		require.Equal(t, allSatellites.PayoutInfo.Unit.Decimals, decoded.PayoutInfo.Unit.Decimals)
+	})
+}
+
+func TestPayoutsEndpointEarnedNet(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		log := zaptest.NewLogger(t)
+		service := apikeys.NewService(db.APIKeys())
+		endpoint := multinode.NewPayoutEndpoint(log, service, nil, db.Payout(), db.Reputation(), operator.Config{})
+
+		withHeld, withoutHeld := testrand.NodeID(), testrand.NodeID()
+		for _, paystub := range []payouts.PayStub{
+			{SatelliteID: withHeld, Period: "2021-03", CompAtRest: 1000, CompGet: 200, Held: 900, Paid: 300},
+			// part of the held amount was returned.
+			{SatelliteID: withHeld, Period: "2021-04", CompAtRest: 1000, CompGet: 200, Held: 600, Paid: 900, Disposed: 300},
+			{SatelliteID: withoutHeld, Period: "2021-04", CompAtRest: 500, Paid: 500},
+		} {
+			require.NoError(t, db.Payout().StorePayStub(ctx, paystub))
+		}
+
+		key, err := service.Issue(ctx)
+		require.NoError(t, err)
+		header := &multinodepb.RequestHeader{ApiKey: key.Secret[:]}
+
+		const held = 900 + 600 - 300
+
+		earned, err := endpoint.Earned(ctx, &multinodepb.EarnedRequest{Header: header})
+		require.NoError(t, err)
+		require.EqualValues(t, 2900, earned.Total)
+		require.Equal(t, earned.Total, earned.Net+held)
+
+		perSatellite, err := endpoint.EarnedPerSatellite(ctx, &multinodepb.EarnedPerSatelliteRequest{Header: header})
+		require.NoError(t, err)
+		require.Len(t, perSatellite.EarnedSatellite, 2)
+		for _, satellite := range perSatellite.EarnedSatellite {
+			switch satellite.SatelliteId {
+			case withHeld:
+				require.EqualValues(t, 2400, satellite.Total)
+				require.Equal(t, satellite.Total, satellite.Net+held)
+			case withoutHeld:
+				require.EqualValues(t, 500, satellite.Total)
+				require.Equal(t, satellite.Total, satellite.Net)
+			}
+		}
+	})
+}
+
+func TestPayoutsEndpointEarnedComponents(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		log := zaptest.NewLogger(t)
+		service := apikeys.NewService(db.APIKeys())
+		endpoint := multinode.NewPayoutEndpoint(log, service, nil, db.Payout(), db.Reputation(), operator.Config{})
+
+		first, second := testrand.NodeID(), testrand.NodeID()
+		for _, paystub := range []payouts.PayStub{
+			{SatelliteID: first, Period: "2021-03", CompAtRest: 1000, CompGet: 200, CompGetRepair: 30, CompGetAudit: 4},
+			{SatelliteID: first, Period: "2021-04", CompAtRest: 2000, CompGet: 400, CompGetRepair: 60, CompGetAudit: 8},
+			{SatelliteID: second, Period: "2021-04", CompAtRest: 10000, CompGet: 5000},
+		} {
+			require.NoError(t, db.Payout().StorePayStub(ctx, paystub))
+		}
+
+		key, err := service.Issue(ctx)
+		require.NoError(t, err)
+		header := &multinodepb.RequestHeader{ApiKey: key.Secret[:]}
+
+		sum := func(components *multinodepb.EarnedComponents) int64 {
+			return components.CompAtRest + components.CompGet + components.CompGetRepair + components.CompGetAudit
+		}
+
+		earned, err := endpoint.Earned(ctx, &multinodepb.EarnedRequest{Header: header})
+		require.NoError(t, err)
+		require.Equal(t, &multinodepb.EarnedComponents{CompAtRest: 13000, CompGet: 5600, CompGetRepair: 90, CompGetAudit: 12}, earned.Components)
+		require.Equal(t, earned.Total, sum(earned.Components))
+
+		perSatellite, err := endpoint.EarnedPerSatellite(ctx, &multinodepb.EarnedPerSatelliteRequest{Header: header})
+		require.NoError(t, err)
+		require.Len(t, perSatellite.EarnedSatellite, 2)
+		for _, satellite := range perSatellite.EarnedSatellite {
+			switch satellite.SatelliteId {
+			case first:
+				require.Equal(t, &multinodepb.EarnedComponents{CompAtRest: 3000, CompGet: 600, CompGetRepair: 90, CompGetAudit: 12}, satellite.Components)
+			case second:
+				require.Equal(t, &multinodepb.EarnedComponents{CompAtRest: 10000, CompGet: 5000}, satellite.Components)
+			}
+			require.Equal(t, satellite.Total, sum(satellite.Components))
+		}
+	})
+}
+
+func TestPayoutsEndpointEarnedPerSatelliteTruncated(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		log := zaptest.NewLogger(t)
+		service := apikeys.NewService(db.APIKeys())
+
+		satelliteIDs := storj.NodeIDList{testrand.NodeID(), testrand.NodeID(), testrand.NodeID()}
+		sort.Sort(satelliteIDs)
+		for _, satelliteID := range satelliteIDs {
+			require.NoError(t, db.Payout().StorePayStub(ctx, payouts.PayStub{SatelliteID: satelliteID, Period: "2021-04", CompAtRest: 100}))
+		}
+
+		key, err := service.Issue(ctx)
+		require.NoError(t, err)
+		header := &multinodepb.RequestHeader{ApiKey: key.Secret[:]}
+
+		// the node stalls after the first satellite, until its budget is exceeded.
+		payoutsDB := &stallingPayoutsDB{DB: db.Payout()}
+		endpoint := multinode.NewPayoutEndpoint(log, service, nil, payoutsDB, db.Reputation(), operator.Config{})
+		endpoint.SetEarnedPerSatelliteBudget(100 * time.Millisecond)
+
+		response, err := endpoint.EarnedPerSatellite(ctx, &multinodepb.EarnedPerSatelliteRequest{Header: header})
+		require.NoError(t, err)
+		require.NoError(t, ctx.Err())
+		require.True(t, response.Truncated)
+		require.Len(t, response.EarnedSatellite, 1)
+		require.Equal(t, satelliteIDs[0], response.EarnedSatellite[0].SatelliteId)
+		require.EqualValues(t, 100, response.EarnedSatellite[0].Total)
+
+		// complete response is not truncated.
+		endpoint = multinode.NewPayoutEndpoint(log, service, nil, db.Payout(), db.Reputation(), operator.Config{})
+		response, err = endpoint.EarnedPerSatellite(ctx, &multinodepb.EarnedPerSatelliteRequest{Header: header})
+		require.NoError(t, err)
+		require.False(t, response.Truncated)
+		require.Len(t, response.EarnedSatellite, 3)
+	})
+}
+
+// stallingPayoutsDB is a payouts.DB which blocks reading earned amount of any but the first satellite until ctx is done.
+type stallingPayoutsDB struct {
+	payouts.DB
+	calls int32
+}
+
+// GetEarnedAtSatellite returns earned amount of the first satellite and stalls for others.
+func (db *stallingPayoutsDB) GetEarnedAtSatellite(ctx context.Context, id storj.NodeID) (int64, error) {
+	if atomic.AddInt32(&db.calls, 1) > 1 {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}
+	return db.DB.GetEarnedAtSatellite(ctx, id)
+}
+
+func TestPayoutsEndpointEarnedPerSatelliteMinAmount(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		log := zaptest.NewLogger(t)
+		service := apikeys.NewService(db.APIKeys())
+		endpoint := multinode.NewPayoutEndpoint(log, service, nil, db.Payout(), db.Reputation(), operator.Config{})
+
+		above, exact, dust := testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+		for _, paystub := range []payouts.PayStub{
+			{SatelliteID: above, Period: "2021-04", CompAtRest: 5000, Paid: 5000},
+			{SatelliteID: exact, Period: "2021-04", CompAtRest: 1000, Paid: 1000},
+			{SatelliteID: dust, Period: "2021-04", CompAtRest: 3, Paid: 3},
+		} {
+			require.NoError(t, db.Payout().StorePayStub(ctx, paystub))
+		}
+
+		key, err := service.Issue(ctx)
+		require.NoError(t, err)
+		header := &multinodepb.RequestHeader{ApiKey: key.Secret[:]}
+
+		for _, tt := range []struct {
+			minAmount int64
+			expected  []storj.NodeID
+		}{
+			{0, []storj.NodeID{above, exact, dust}},
+			{1000, []storj.NodeID{above, exact}},
+			{1001, []storj.NodeID{above}},
+			{10000, nil},
+		} {
+			perSatellite, err := endpoint.EarnedPerSatellite(ctx, &multinodepb.EarnedPerSatelliteRequest{Header: header, MinAmount: tt.minAmount})
+			require.NoError(t, err)
+
+			var satellites []storj.NodeID
+			for _, satellite := range perSatellite.EarnedSatellite {
+				satellites = append(satellites, satellite.SatelliteId)
+			}
+			require.ElementsMatch(t, tt.expected, satellites, tt.minAmount)
+		}
+	})
+}
+
+func TestPayoutsEndpointFirstPaystubPeriod(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		log := zaptest.NewLogger(t)
+		service := apikeys.NewService(db.APIKeys())
+		endpoint := multinode.NewPayoutEndpoint(log, service, nil, db.Payout(), db.Reputation(), operator.Config{})
+
+		veteran, newcomer, unknown := testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+		for _, paystub := range []payouts.PayStub{
+			// stored out of order, e.g. after a late paystub sync.
+			{SatelliteID: veteran, Period: "2021-03", Paid: 300},
+			{SatelliteID: veteran, Period: "2020-11", Paid: 100},
+			{SatelliteID: veteran, Period: "2021-01", Paid: 200},
+			{SatelliteID: newcomer, Period: "2021-04", Paid: 50},
+		} {
+			require.NoError(t, db.Payout().StorePayStub(ctx, paystub))
+		}
+
+		key, err := service.Issue(ctx)
+		require.NoError(t, err)
+		header := &multinodepb.RequestHeader{ApiKey: key.Secret[:]}
+
+		for satelliteID, expected := range map[storj.NodeID]string{
+			veteran:  "2020-11",
+			newcomer: "2021-04",
+			unknown:  "",
+		} {
+			response, err := endpoint.FirstPaystubPeriod(ctx, &multinodepb.FirstPaystubPeriodRequest{Header: header, SatelliteId: satelliteID})
+			require.NoError(t, err)
+			require.Equal(t, expected, response.Period)
+		}
+	})
+}
+
+func TestPayoutsEndpointPayingSatellites(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		log := zaptest.NewLogger(t)
+		service := apikeys.NewService(db.APIKeys())
+		endpoint := multinode.NewPayoutEndpoint(log, service, nil, db.Payout(), db.Reputation(), operator.Config{})
+
+		key, err := service.Issue(ctx)
+		require.NoError(t, err)
+		header := &multinodepb.RequestHeader{ApiKey: key.Secret[:]}
+
+		response, err := endpoint.PayingSatellites(ctx, &multinodepb.PayingSatellitesRequest{Header: header})
+		require.NoError(t, err)
+		require.Empty(t, response.SatelliteIds)
+
+		satelliteIDs := storj.NodeIDList{testrand.NodeID(), testrand.NodeID()}
+		sort.Sort(satelliteIDs)
+		for _, satelliteID := range satelliteIDs {
+			require.NoError(t, db.Payout().StorePayStub(ctx, payouts.PayStub{SatelliteID: satelliteID, Period: "2021-04", Paid: 100}))
+		}
+
+		response, err = endpoint.PayingSatellites(ctx, &multinodepb.PayingSatellitesRequest{Header: header})
+		require.NoError(t, err)
+		require.Equal(t, []storj.NodeID(satelliteIDs), response.SatelliteIds)
+	})
+}
+
+func TestPayoutsEndpointHeldHistory(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		log := zaptest.NewLogger(t)
+		service := apikeys.NewService(db.APIKeys())
+		endpoint := multinode.NewPayoutEndpoint(log, service, nil, db.Payout(), db.Reputation(), operator.Config{})
+
+		satelliteIDs := storj.NodeIDList{testrand.NodeID(), testrand.NodeID()}
+		sort.Sort(satelliteIDs)
+
+		var expected []*multinodepb.HeldHistoryEntry
+		for _, satelliteID := range satelliteIDs {
+			for month := 1; month <= 12; month++ {
+				paystub := payouts.PayStub{
+					SatelliteID: satelliteID,
+					Period:      time.Date(2020, time.Month(month), 1, 0, 0, 0, 0, time.UTC).Format("2006-01"),
+					Held:        int64(month * 100),
+					Disposed:    int64(month),
+				}
+				require.NoError(t, db.Payout().StorePayStub(ctx, paystub))
+
+				expected = append(expected, &multinodepb.HeldHistoryEntry{
+					SatelliteId: paystub.SatelliteID,
+					Period:      paystub.Period,
+					Held:        paystub.Held,
+					Disposed:    paystub.Disposed,
+					Unit:        &multinodepb.AmountUnit{Currency: "USD", Decimals: 6},
+				})
+			}
+		}
+
+		key, err := service.Issue(ctx)
+		require.NoError(t, err)
+		header := &multinodepb.RequestHeader{ApiKey: key.Secret[:]}
+
+		response, err := endpoint.HeldHistory(ctx, &multinodepb.HeldHistoryRequest{Header: header})
+		require.NoError(t, err)
+		require.Equal(t, expected, response.Entries)
+
+		// stream sends every entry of the unary response in the same order.
+		stream := &heldHistoryStream{ctx: ctx}
+		require.NoError(t, endpoint.HeldHistoryStream(&multinodepb.HeldHistoryRequest{Header: header}, stream))
+		require.Equal(t, expected, stream.entries)
+
+		// failing send stops the stream.
+		stream = &heldHistoryStream{ctx: ctx, failAfter: 3}
+		require.Error(t, endpoint.HeldHistoryStream(&multinodepb.HeldHistoryRequest{Header: header}, stream))
+		require.Len(t, stream.entries, 3)
+
+		err = endpoint.HeldHistoryStream(&multinodepb.HeldHistoryRequest{}, &heldHistoryStream{ctx: ctx})
+		require.True(t, rpcstatus.Code(err) == rpcstatus.Unauthenticated)
+	})
+}
+
+// heldHistoryStream collects entries sent by the held history stream.
+type heldHistoryStream struct {
+	multinodepb.DRPCPayout_HeldHistoryStreamStream
+
+	ctx       context.Context
+	entries   []*multinodepb.HeldHistoryEntry
+	failAfter int
+}
+
+// Context returns the stream context.
+func (stream *heldHistoryStream) Context() context.Context { return stream.ctx }
+
+// Send collects the entry, failing once failAfter entries were collected.
+func (stream *heldHistoryStream) Send(entry *multinodepb.HeldHistoryEntry) error {
+	if stream.failAfter > 0 && len(stream.entries) >= stream.failAfter {
+		return errs.New("stream closed")
+	}
+	stream.entries = append(stream.entries, entry)
+	return nil
+}
+
+func TestPayoutsEndpointSatelliteSummaries(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		log := zaptest.NewLogger(t)
+		service := apikeys.NewService(db.APIKeys())
+		endpoint := multinode.NewPayoutEndpoint(log, service, nil, db.Payout(), db.Reputation(), operator.Config{})
+
+		satelliteID := testrand.NodeID()
+		require.NoError(t, db.Payout().StorePayStub(ctx, payouts.PayStub{SatelliteID: satelliteID, Period: "2021-04", Held: 10, Paid: 100}))
+		require.NoError(t, db.Payout().StorePayStub(ctx, payouts.PayStub{SatelliteID: satelliteID, Period: "2021-05", Held: 20, Paid: 200}))
+
+		first, err := service.Issue(ctx)
+		require.NoError(t, err)
+		second, err := service.Issue(ctx)
+		require.NoError(t, err)
+
+		headers := []*multinodepb.RequestHeader{
+			{ApiKey: first.Secret[:]},
+			{ApiKey: testrand.Bytes(32)},
+			{ApiKey: second.Secret[:]},
+		}
+
+		// all time summary of every accepted header, in the order of headers.
+		response, err := endpoint.SatelliteSummaries(ctx, &multinodepb.SatelliteSummariesRequest{Headers: headers, SatelliteId: satelliteID})
+		require.NoError(t, err)
+		require.Len(t, response.NodeSummaries, 3)
+		for _, i := range []int{0, 2} {
+			require.False(t, response.NodeSummaries[i].Unauthenticated)
+			require.EqualValues(t, 30, response.NodeSummaries[i].PayoutInfo.Held)
+			require.EqualValues(t, 300, response.NodeSummaries[i].PayoutInfo.Paid)
+		}
+		require.True(t, response.NodeSummaries[1].Unauthenticated)
+		require.Nil(t, response.NodeSummaries[1].PayoutInfo)
+
+		// period summary matches the single node rpc.
+		response, err = endpoint.SatelliteSummaries(ctx, &multinodepb.SatelliteSummariesRequest{Headers: headers[:1], SatelliteId: satelliteID, Period: "2021-05"})
+		require.NoError(t, err)
+		single, err := endpoint.SatellitePeriodSummary(ctx, &multinodepb.SatellitePeriodSummaryRequest{Header: headers[0], SatelliteId: satelliteID, Period: "2021-05"})
+		require.NoError(t, err)
+		require.Len(t, response.NodeSummaries, 1)
+		require.Equal(t, single.PayoutInfo, response.NodeSummaries[0].PayoutInfo)
+
+		// request without any accepted header is refused as a whole.
+		_, err = endpoint.SatelliteSummaries(ctx, &multinodepb.SatelliteSummariesRequest{Headers: headers[1:2], SatelliteId: satelliteID})
+		require.True(t, rpcstatus.Code(err) == rpcstatus.Unauthenticated)
+		_, err = endpoint.SatelliteSummaries(ctx, &multinodepb.SatelliteSummariesRequest{SatelliteId: satelliteID})
+		require.True(t, rpcstatus.Code(err) == rpcstatus.Unauthenticated)
+	})
+}
+
+func TestPayoutsEndpointPaymentsPerSatellite(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		log := zaptest.NewLogger(t)
+		service := apikeys.NewService(db.APIKeys())
+		endpoint := multinode.NewPayoutEndpoint(log, service, nil, db.Payout(), db.Reputation(), operator.Config{})
+
+		recorded, unrecorded := testrand.NodeID(), testrand.NodeID()
+		for _, paystub := range []payouts.PayStub{
+			{SatelliteID: recorded, Period: "2021-03", Paid: 300},
+			{SatelliteID: recorded, Period: "2021-04", Paid: 200},
+			{SatelliteID: unrecorded, Period: "2021-04", Paid: 50},
+		} {
+			require.NoError(t, db.Payout().StorePayStub(ctx, paystub))
+		}
+		require.NoError(t, db.Payout().StorePayment(ctx, payouts.Payment{ID: 1, SatelliteID: recorded, Period: "2021-03", Amount: 300}))
+		require.NoError(t, db.Payout().StorePayment(ctx, payouts.Payment{ID: 2, SatelliteID: recorded, Period: "2021-04", Amount: 190}))
+
+		key, err := service.Issue(ctx)
+		require.NoError(t, err)
+
+		response, err := endpoint.PaymentsPerSatellite(ctx, &multinodepb.PaymentsPerSatelliteRequest{
+			Header: &multinodepb.RequestHeader{ApiKey: key.Secret[:]},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "USD", response.Unit.Currency)
+		require.Len(t, response.SatellitePayments, 2)
+
+		for _, payments := range response.SatellitePayments {
+			switch payments.SatelliteId {
+			case recorded:
+				require.EqualValues(t, 500, payments.Paid)
+				require.EqualValues(t, 490, payments.Received)
+				require.EqualValues(t, 2, payments.Payments)
+			case unrecorded:
+				require.EqualValues(t, 50, payments.Paid)
+				require.Zero(t, payments.Received)
+				require.Zero(t, payments.Payments)
+			default:
+				t.Fatalf("unexpected satellite %s", payments.SatelliteId)
+			}
+		}
+	})
+}
+
+func TestPayoutsEndpointHeldRates(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		log := zaptest.NewLogger(t)
+		service := apikeys.NewService(db.APIKeys())
+		endpoint := multinode.NewPayoutEndpoint(log, service, nil, db.Payout(), db.Reputation(), operator.Config{})
+
+		satelliteID := testrand.NodeID()
+		joinedAt := time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC)
+		require.NoError(t, db.Reputation().Store(ctx, reputation.Stats{
+			SatelliteID: satelliteID,
+			JoinedAt:    joinedAt,
+		}))
+		require.NoError(t, db.Payout().StorePayStub(ctx, payouts.PayStub{
+			SatelliteID:  satelliteID,
+			Period:       "2021-04",
+			CompAtRest:   400,
+			CompGet:      600,
+			SurgePercent: 200,
+			Held:         1000,
+		}))
+		// satellite without paystub for the period is skipped.
+		require.NoError(t, db.Payout().StorePayStub(ctx, payouts.PayStub{
+			SatelliteID: testrand.NodeID(),
+			Period:      "2021-03",
+			Held:        100,
+		}))
+
+		key, err := service.Issue(ctx)
+		require.NoError(t, err)
+
+		response, err := endpoint.HeldRates(ctx, &multinodepb.HeldRatesRequest{
+			Header: &multinodepb.RequestHeader{ApiKey: key.Secret[:]},
+			Period: "2021-04",
+		})
+		require.NoError(t, err)
+		require.Len(t, response.HeldRates, 1)
+		require.Equal(t, satelliteID, response.HeldRates[0].SatelliteId)
+		require.True(t, joinedAt.Equal(response.HeldRates[0].JoinedAt))
+		require.EqualValues(t, 1000, response.HeldRates[0].Held)
+		require.EqualValues(t, 2000, response.HeldRates[0].Earned)
+	})
+}
+
 func TestPayoutsEndpointEstimations(t *testing.T) {
 	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
 		satelliteID := testrand.NodeID()
@@ -157,7 +707,7 @@ func TestPayoutsEndpointEstimations(t *testing.T) {
 		require.NoError(t, trustPool.Refresh(ctx))
 
 		estimatedPayoutsService := estimatedpayouts.NewService(db.Bandwidth(), db.Reputation(), db.StorageUsage(), db.Pricing(), db.Satellites(), trustPool)
-		endpoint := multinode.NewPayoutEndpoint(log, service, estimatedPayoutsService, db.Payout())
+		endpoint := multinode.NewPayoutEndpoint(log, service, estimatedPayoutsService, db.Payout(), db.Reputation(), operator.Config{})
 
 		now := time.Now().UTC().Add(-2 * time.Hour)
 
@@ -165,6 +715,11 @@ func TestPayoutsEndpointEstimations(t *testing.T) {
 			err := bandwidthdb.Add(ctx, satelliteID, action, 2300000000000, now)
 			require.NoError(t, err)
 		}
+		// 1 TB of egress in the previous month earns 20 USD at the egress price below.
+		year, month, _ := time.Now().UTC().Date()
+		err = bandwidthdb.Add(ctx, satelliteID, pb.PieceAction_GET, 1000000000000, time.Date(year, month-1, 15, 12, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+
 		var satellites []storj.NodeID
 
 		satellites = append(satellites, satelliteID)
@@ -200,10 +755,367 @@ func TestPayoutsEndpointEstimations(t *testing.T) {
 			require.NoError(t, err)
 
 			require.EqualValues(t, estimation.CurrentMonthExpectations, resp.EstimatedEarnings)
+			require.Equal(t, "USD", resp.Unit.Currency)
+			require.EqualValues(t, 2, resp.Unit.Decimals)
+
+			// previous month estimate is in cents, the same as the current month one.
+			require.EqualValues(t, 2000, resp.PreviousMonthEstimatedEarnings)
+			require.Equal(t, time.Date(year, month-1, 1, 0, 0, 0, 0, time.UTC).Format("2006-01"), resp.PreviousMonthPeriod)
 		})
 	})
 }
 
+func TestPayoutsEndpointEstimationsRetry(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		satelliteID := testrand.NodeID()
+
+		log := zaptest.NewLogger(t)
+		service := apikeys.NewService(db.APIKeys())
+
+		key, err := service.Issue(ctx)
+		require.NoError(t, err)
+
+		poolConfig := trust.Config{
+			CachePath: ctx.File("trust-cache.json"),
+		}
+		poolConfig.Sources = append(poolConfig.Sources, &trust.StaticURLSource{URL: trust.SatelliteURL{ID: satelliteID}})
+
+		trustPool, err := trust.NewPool(zaptest.NewLogger(t), trust.Dialer(rpc.Dialer{}), poolConfig)
+		require.NoError(t, err)
+		require.NoError(t, trustPool.Refresh(ctx))
+
+		err = db.Reputation().Store(ctx, reputation.Stats{
+			SatelliteID: satelliteID,
+			JoinedAt:    time.Now().UTC().AddDate(0, -2, 0),
+		})
+		require.NoError(t, err)
+
+		header := &multinodepb.RequestHeader{
+			ApiKey: key.Secret[:],
+		}
+
+		t.Run("transient error is retried", func(t *testing.T) {
+			reputationDB := &failingReputationDB{
+				DB:       db.Reputation(),
+				failures: 1,
+				err:      sqlite3.Error{Code: sqlite3.ErrBusy},
+			}
+
+			estimatedPayoutsService := estimatedpayouts.NewService(db.Bandwidth(), reputationDB, db.StorageUsage(), db.Pricing(), db.Satellites(), trustPool)
+			endpoint := multinode.NewPayoutEndpoint(log, service, estimatedPayoutsService, db.Payout(), db.Reputation(), operator.Config{})
+
+			_, err := endpoint.EstimatedPayoutTotal(ctx, &multinodepb.EstimatedPayoutTotalRequest{Header: header})
+			require.NoError(t, err)
+
+			reputationDB.failures = 1
+			_, err = endpoint.EstimatedPayoutSatellite(ctx, &multinodepb.EstimatedPayoutSatelliteRequest{Header: header, SatelliteId: satelliteID})
+			require.NoError(t, err)
+		})
+
+		t.Run("logic error is not retried", func(t *testing.T) {
+			reputationDB := &failingReputationDB{
+				DB:       db.Reputation(),
+				failures: 2,
+				err:      errs.New("invalid stats"),
+			}
+
+			estimatedPayoutsService := estimatedpayouts.NewService(db.Bandwidth(), reputationDB, db.StorageUsage(), db.Pricing(), db.Satellites(), trustPool)
+			endpoint := multinode.NewPayoutEndpoint(log, service, estimatedPayoutsService, db.Payout(), db.Reputation(), operator.Config{})
+
+			_, err := endpoint.EstimatedPayoutTotal(ctx, &multinodepb.EstimatedPayoutTotalRequest{Header: header})
+			require.Error(t, err)
+			require.Equal(t, rpcstatus.Internal, rpcstatus.Code(err))
+			require.Equal(t, 1, reputationDB.failures)
+		})
+	})
+}
+
+func TestPayoutsEndpointEstimationsClockSkew(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		satelliteID := testrand.NodeID()
+
+		core, logs := observer.New(zap.WarnLevel)
+		log := zap.New(core)
+		service := apikeys.NewService(db.APIKeys())
+
+		key, err := service.Issue(ctx)
+		require.NoError(t, err)
+
+		poolConfig := trust.Config{
+			CachePath: ctx.File("trust-cache.json"),
+		}
+		poolConfig.Sources = append(poolConfig.Sources, &trust.StaticURLSource{URL: trust.SatelliteURL{ID: satelliteID}})
+
+		trustPool, err := trust.NewPool(zaptest.NewLogger(t), trust.Dialer(rpc.Dialer{}), poolConfig)
+		require.NoError(t, err)
+		require.NoError(t, trustPool.Refresh(ctx))
+
+		err = db.Reputation().Store(ctx, reputation.Stats{
+			SatelliteID: satelliteID,
+			JoinedAt:    time.Now().UTC().AddDate(0, -3, 0),
+		})
+		require.NoError(t, err)
+
+		estimatedPayoutsService := estimatedpayouts.NewService(db.Bandwidth(), db.Reputation(), db.StorageUsage(), db.Pricing(), db.Satellites(), trustPool)
+		endpoint := multinode.NewPayoutEndpoint(log, service, estimatedPayoutsService, db.Payout(), db.Reputation(), operator.Config{})
+
+		header := &multinodepb.RequestHeader{
+			ApiKey: key.Secret[:],
+		}
+
+		year, month, _ := time.Now().UTC().Date()
+		monthStart := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+
+		// caller clock is still in the previous month.
+		asOf := monthStart.Add(-time.Nanosecond)
+		resp, err := endpoint.EstimatedPayoutTotal(ctx, &multinodepb.EstimatedPayoutTotalRequest{Header: header, AsOf: asOf})
+		require.NoError(t, err)
+		require.Equal(t, monthStart.AddDate(0, -2, 0).Format("2006-01"), resp.PreviousMonthPeriod)
+		require.Equal(t, 1, logs.FilterMessageSnippet("clock skew").Len())
+
+		_, err = endpoint.EstimatedPayoutSatellite(ctx, &multinodepb.EstimatedPayoutSatelliteRequest{Header: header, SatelliteId: satelliteID, AsOf: asOf})
+		require.NoError(t, err)
+		require.Equal(t, 2, logs.FilterMessageSnippet("clock skew").Len())
+
+		// caller clock is in the same month.
+		resp, err = endpoint.EstimatedPayoutTotal(ctx, &multinodepb.EstimatedPayoutTotalRequest{Header: header, AsOf: time.Now()})
+		require.NoError(t, err)
+		require.Equal(t, monthStart.AddDate(0, -1, 0).Format("2006-01"), resp.PreviousMonthPeriod)
+		require.Equal(t, 2, logs.FilterMessageSnippet("clock skew").Len())
+
+		// node clock is used when caller doesn't provide time.
+		resp, err = endpoint.EstimatedPayoutTotal(ctx, &multinodepb.EstimatedPayoutTotalRequest{Header: header})
+		require.NoError(t, err)
+		require.Equal(t, monthStart.AddDate(0, -1, 0).Format("2006-01"), resp.PreviousMonthPeriod)
+	})
+}
+
+func TestPayoutsEndpointSatelliteOrder(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		log := zaptest.NewLogger(t)
+		service := apikeys.NewService(db.APIKeys())
+
+		key, err := service.Issue(ctx)
+		require.NoError(t, err)
+
+		header := &multinodepb.RequestHeader{
+			ApiKey: key.Secret[:],
+		}
+
+		satelliteIDs := storj.NodeIDList{testrand.NodeID(), testrand.NodeID(), testrand.NodeID(), testrand.NodeID()}
+		sorted := append(storj.NodeIDList{}, satelliteIDs...)
+		sort.Sort(sorted)
+		// make sure the database returns satellites out of order.
+		satelliteIDs[0], satelliteIDs[len(satelliteIDs)-1] = sorted[len(sorted)-1], sorted[0]
+
+		payoutsDB := &unorderedPayoutsDB{DB: db.Payout(), satelliteIDs: satelliteIDs}
+		endpoint := multinode.NewPayoutEndpoint(log, service, nil, payoutsDB, nil, operator.Config{})
+
+		for i := 0; i < 3; i++ {
+			response, err := endpoint.EarnedPerSatellite(ctx, &multinodepb.EarnedPerSatelliteRequest{Header: header})
+			require.NoError(t, err)
+
+			var listed storj.NodeIDList
+			for _, earned := range response.EarnedSatellite {
+				listed = append(listed, earned.SatelliteId)
+			}
+			require.Equal(t, sorted, listed)
+		}
+
+		// error is reported for the first satellite in order.
+		payoutsDB.summaryErr = errs.New("database is closed")
+		_, err = endpoint.AllSatellitesSummary(ctx, &multinodepb.AllSatellitesSummaryRequest{Header: header})
+		require.Error(t, err)
+
+		details, ok := multinodepb.ParseErrorDetails(err)
+		require.True(t, ok)
+		require.Equal(t, sorted[0], details.SatelliteID)
+	})
+}
+
+// unorderedPayoutsDB is a payouts.DB which returns predefined paying satellites in the provided order.
+type unorderedPayoutsDB struct {
+	payouts.DB
+
+	satelliteIDs storj.NodeIDList
+	summaryErr   error
+}
+
+// GetPayingSatellitesIDs returns predefined satellites.
+func (db *unorderedPayoutsDB) GetPayingSatellitesIDs(ctx context.Context) ([]storj.NodeID, error) {
+	return append([]storj.NodeID{}, db.satelliteIDs...), nil
+}
+
+// GetSatelliteSummary returns configured error or calls underlying db.
+func (db *unorderedPayoutsDB) GetSatelliteSummary(ctx context.Context, satelliteID storj.NodeID) (paid, held int64, err error) {
+	if db.summaryErr != nil {
+		return 0, 0, db.summaryErr
+	}
+	return db.DB.GetSatelliteSummary(ctx, satelliteID)
+}
+
+func TestPayoutsEndpointAllSatellitesPeriodSummaryConcurrent(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	payoutsDB := newManySatellitesPayoutsDB(100, 0)
+	endpoint := multinode.NewPayoutEndpoint(zaptest.NewLogger(t), apikeys.NewService(acceptingAPIKeysDB{}), nil, payoutsDB, nil, operator.Config{})
+	header := &multinodepb.RequestHeader{ApiKey: testrand.Bytes(32)}
+
+	response, err := endpoint.AllSatellitesPeriodSummary(ctx, &multinodepb.AllSatellitesPeriodSummaryRequest{Header: header, Period: "2021-04"})
+	require.NoError(t, err)
+	require.Equal(t, payoutsDB.totalPaid, response.PayoutInfo.Paid)
+	require.Equal(t, payoutsDB.totalHeld, response.PayoutInfo.Held)
+	require.EqualValues(t, 100, payoutsDB.queried)
+
+	// several failing satellites, the first one in order is reported.
+	sorted := append(storj.NodeIDList{}, payoutsDB.satelliteIDs...)
+	sort.Sort(sorted)
+	payoutsDB.failing = map[storj.NodeID]bool{sorted[10]: true, sorted[3]: true, sorted[50]: true}
+
+	_, err = endpoint.AllSatellitesPeriodSummary(ctx, &multinodepb.AllSatellitesPeriodSummaryRequest{Header: header, Period: "2021-04"})
+	require.Error(t, err)
+
+	details, ok := multinodepb.ParseErrorDetails(err)
+	require.True(t, ok)
+	require.Equal(t, sorted[3], details.SatelliteID)
+	require.Equal(t, "2021-04", details.Period)
+}
+
+func TestPayoutsEndpointSummaryFormat(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	payoutsDB := newManySatellitesPayoutsDB(3, 0)
+	endpoint := multinode.NewPayoutEndpoint(zaptest.NewLogger(t), apikeys.NewService(acceptingAPIKeysDB{}), nil, payoutsDB, nil, operator.Config{})
+	header := &multinodepb.RequestHeader{ApiKey: testrand.Bytes(32)}
+
+	// display fields are empty unless requested.
+	response, err := endpoint.AllSatellitesPeriodSummary(ctx, &multinodepb.AllSatellitesPeriodSummaryRequest{Header: header, Period: "2021-04"})
+	require.NoError(t, err)
+	require.Empty(t, response.PayoutInfo.PaidDisplay)
+	require.Empty(t, response.PayoutInfo.HeldDisplay)
+
+	response, err = endpoint.AllSatellitesPeriodSummary(ctx, &multinodepb.AllSatellitesPeriodSummaryRequest{Header: header, Period: "2021-04", Format: true})
+	require.NoError(t, err)
+	require.Equal(t, payoutsDB.totalPaid, response.PayoutInfo.Paid)
+	require.Equal(t, payoutsDB.totalHeld, response.PayoutInfo.Held)
+	require.Equal(t, "0.000300 USD", response.PayoutInfo.PaidDisplay)
+	require.Equal(t, "0.000003 USD", response.PayoutInfo.HeldDisplay)
+	require.Equal(t, multinodepb.FormatAmount(response.PayoutInfo.Paid, response.PayoutInfo.Unit), response.PayoutInfo.PaidDisplay)
+}
+
+func TestPayoutsEndpointPayoutConfig(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	config := operator.Config{
+		Wallet:         "0x0123456789012345678901234567890123456789",
+		WalletFeatures: operator.WalletFeatures{"zksync"},
+	}
+	endpoint := multinode.NewPayoutEndpoint(zaptest.NewLogger(t), apikeys.NewService(acceptingAPIKeysDB{}), nil, nil, nil, config)
+
+	response, err := endpoint.PayoutConfig(ctx, &multinodepb.PayoutConfigRequest{
+		Header: &multinodepb.RequestHeader{ApiKey: testrand.Bytes(32)},
+	})
+	require.NoError(t, err)
+	require.Equal(t, config.Wallet, response.Wallet)
+	require.Equal(t, []string{"zksync"}, response.WalletFeatures)
+}
+
+func BenchmarkPayoutsEndpointAllSatellitesPeriodSummary(b *testing.B) {
+	ctx := testcontext.New(b)
+	defer ctx.Cleanup()
+
+	// latency simulates database query.
+	payoutsDB := newManySatellitesPayoutsDB(50, time.Millisecond)
+	endpoint := multinode.NewPayoutEndpoint(zap.NewNop(), apikeys.NewService(acceptingAPIKeysDB{}), nil, payoutsDB, nil, operator.Config{})
+	request := &multinodepb.AllSatellitesPeriodSummaryRequest{
+		Header: &multinodepb.RequestHeader{ApiKey: testrand.Bytes(32)},
+		Period: "2021-04",
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := endpoint.AllSatellitesPeriodSummary(ctx, request)
+		require.NoError(b, err)
+	}
+}
+
+// acceptingAPIKeysDB is an apikeys.DB which accepts every api key.
+type acceptingAPIKeysDB struct {
+	apikeys.DB
+}
+
+// Check accepts every secret.
+func (acceptingAPIKeysDB) Check(ctx context.Context, secret multinodeauth.Secret) error {
+	return nil
+}
+
+// manySatellitesPayoutsDB is a payouts.DB with many paying satellites and predefined period summaries.
+type manySatellitesPayoutsDB struct {
+	payouts.DB
+
+	latency      time.Duration
+	satelliteIDs storj.NodeIDList
+	summaries    map[storj.NodeID][2]int64
+	failing      map[storj.NodeID]bool
+
+	totalPaid, totalHeld int64
+	queried              int64
+}
+
+func newManySatellitesPayoutsDB(count int, latency time.Duration) *manySatellitesPayoutsDB {
+	db := &manySatellitesPayoutsDB{
+		latency:   latency,
+		summaries: make(map[storj.NodeID][2]int64),
+	}
+	for i := 0; i < count; i++ {
+		satelliteID := testrand.NodeID()
+		paid, held := int64(i*100), int64(i)
+
+		db.satelliteIDs = append(db.satelliteIDs, satelliteID)
+		db.summaries[satelliteID] = [2]int64{paid, held}
+		db.totalPaid += paid
+		db.totalHeld += held
+	}
+	return db
+}
+
+// GetPayingSatellitesIDs returns all satellites.
+func (db *manySatellitesPayoutsDB) GetPayingSatellitesIDs(ctx context.Context) ([]storj.NodeID, error) {
+	return append([]storj.NodeID{}, db.satelliteIDs...), nil
+}
+
+// GetSatellitePeriodSummary returns predefined summary or error for failing satellites.
+func (db *manySatellitesPayoutsDB) GetSatellitePeriodSummary(ctx context.Context, satelliteID storj.NodeID, period string) (paid, held int64, err error) {
+	atomic.AddInt64(&db.queried, 1)
+	time.Sleep(db.latency)
+
+	if db.failing[satelliteID] {
+		return 0, 0, errs.New("database is locked")
+	}
+	summary := db.summaries[satelliteID]
+	return summary[0], summary[1], nil
+}
+
+// failingReputationDB fails first Get calls with provided error.
+type failingReputationDB struct {
+	reputation.DB
+
+	failures int
+	err      error
+}
+
+// Get returns configured error while failures are left, otherwise calls underlying db.
+func (db *failingReputationDB) Get(ctx context.Context, satelliteID storj.NodeID) (*reputation.Stats, error) {
+	if db.failures > 0 {
+		db.failures--
+		return nil, db.err
+	}
+	return db.DB.Get(ctx, satelliteID)
+}
+
 // makeStorageUsageStamps creates storage usage stamps and expected summaries for provided satellites.
 // Creates one entry per day for 30 days with last date as beginning of provided endDate.
 func makeStorageUsageStamps(satellites []storj.NodeID) ([]storageusage.Stamp, map[storj.NodeID]float64) {
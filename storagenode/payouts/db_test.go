@@ -405,6 +405,73 @@ func TestPayouts(t *testing.T) {
 			satellite3Earned, err := payout.GetEarnedAtSatellite(ctx, id3)
 			require.Equal(t, int(satellite3Earned), 198)
 			require.NoError(t, err)
+
+			components, err := payout.GetEarnedComponentsAtSatellite(ctx, id2)
+			require.NoError(t, err)
+			require.Equal(t, payouts.EarnedComponents{AtRest: 99, Get: 99}, components)
+			require.Equal(t, satellite2Earned, components.Total())
+
+			components, err = payout.GetEarnedComponentsAtSatellite(ctx, storj.NodeID{9, 9, 9})
+			require.NoError(t, err)
+			require.Zero(t, components.Total())
+		})
+
+		t.Run("Test GetUndistributedAtSatellite", func(t *testing.T) {
+			id1 := storj.NodeID{5, 6, 7}
+			id2 := storj.NodeID{6, 7, 8}
+
+			err := payout.StorePayStub(ctx, payouts.PayStub{
+				Period:      "2020-11",
+				SatelliteID: id1,
+				Paid:        100,
+				Distributed: 100,
+			})
+			require.NoError(t, err)
+			err = payout.StorePayStub(ctx, payouts.PayStub{
+				Period:      "2020-12",
+				SatelliteID: id1,
+				Paid:        70,
+				Distributed: 20,
+			})
+			require.NoError(t, err)
+
+			undistributed, err := payout.GetUndistributedAtSatellite(ctx, id1)
+			require.NoError(t, err)
+			require.EqualValues(t, 50, undistributed)
+
+			undistributed, err = payout.GetUndistributedAtSatellite(ctx, id2)
+			require.NoError(t, err)
+			require.Zero(t, undistributed)
+		})
+
+		t.Run("Test GetSatellitePayments", func(t *testing.T) {
+			id1 := storj.NodeID{7, 8, 9}
+			id2 := storj.NodeID{8, 9, 10}
+
+			err := payout.StorePayment(ctx, payouts.Payment{
+				ID:          1001,
+				SatelliteID: id1,
+				Period:      "2020-11",
+				Amount:      100,
+			})
+			require.NoError(t, err)
+			err = payout.StorePayment(ctx, payouts.Payment{
+				ID:          1002,
+				SatelliteID: id1,
+				Period:      "2020-12",
+				Amount:      70,
+			})
+			require.NoError(t, err)
+
+			received, count, err := payout.GetSatellitePayments(ctx, id1)
+			require.NoError(t, err)
+			require.EqualValues(t, 170, received)
+			require.Equal(t, 2, count)
+
+			received, count, err = payout.GetSatellitePayments(ctx, id2)
+			require.NoError(t, err)
+			require.Zero(t, received)
+			require.Zero(t, count)
 		})
 
 		t.Run("Test GetSatelliteSummary", func(t *testing.T) {
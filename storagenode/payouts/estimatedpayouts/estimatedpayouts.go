@@ -78,7 +78,10 @@ func RoundFloat(value float64) float64 {
 }
 
 // Set set's estimated payout with current/previous PayoutMonthly's data and current month expectations.
+// Billing months are always computed in UTC, regardless of the location of now.
 func (estimatedPayout *EstimatedPayout) Set(current, previous PayoutMonthly, now, joinedAt time.Time) {
+	now = now.UTC()
+
 	estimatedPayout.CurrentMonth = current
 	estimatedPayout.PreviousMonth = previous
 
@@ -108,3 +111,9 @@ func (estimatedPayout *EstimatedPayout) Add(other EstimatedPayout) {
 	estimatedPayout.PreviousMonth.Add(other.PreviousMonth)
 	estimatedPayout.CurrentMonthExpectations += other.CurrentMonthExpectations
 }
+
+// BillingMonth returns the edges of the billing month which contains t.
+// Billing months are always computed in UTC to match satellite accounting.
+func BillingMonth(t time.Time) (from, to time.Time) {
+	return date.MonthBoundary(t.UTC())
+}
@@ -314,3 +314,58 @@ func TestAddEstimationPayout(t *testing.T) {
 		require.Equal(t, test.basic, test.result)
 	}
 }
+
+func TestBillingMonth(t *testing.T) {
+	utcPlus3 := time.FixedZone("UTC+3", 3*60*60)
+	utcMinus5 := time.FixedZone("UTC-5", -5*60*60)
+
+	tests := []struct {
+		name string
+		time time.Time
+		from time.Time
+	}{
+		{
+			name: "start of month ahead of UTC",
+			time: time.Date(2021, 3, 1, 1, 0, 0, 0, utcPlus3),
+			from: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "end of month behind UTC",
+			time: time.Date(2021, 1, 31, 21, 0, 0, 0, utcMinus5),
+			from: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "end of year behind UTC",
+			time: time.Date(2020, 12, 31, 20, 0, 0, 0, utcMinus5),
+			from: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			from, to := estimatedpayouts.BillingMonth(test.time)
+			require.True(t, test.from.Equal(from), from)
+			require.True(t, test.from.AddDate(0, 1, 0).Add(-time.Nanosecond).Equal(to), to)
+			require.Equal(t, time.UTC, from.Location())
+			require.Equal(t, time.UTC, to.Location())
+		})
+	}
+}
+
+func TestCurrentMonthExpectationsTimezone(t *testing.T) {
+	utcPlus3 := time.FixedZone("UTC+3", 3*60*60)
+
+	current := estimatedpayouts.PayoutMonthly{Payout: 100}
+	joinedAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// 2021-03-01 01:00 UTC+3 is still 2021-02-28 in UTC.
+	var local estimatedpayouts.EstimatedPayout
+	local.Set(current, estimatedpayouts.PayoutMonthly{}, time.Date(2021, 3, 1, 1, 0, 0, 0, utcPlus3), joinedAt)
+
+	var utc estimatedpayouts.EstimatedPayout
+	utc.Set(current, estimatedpayouts.PayoutMonthly{}, time.Date(2021, 2, 28, 22, 0, 0, 0, time.UTC), joinedAt)
+
+	require.Equal(t, utc.CurrentMonthExpectations, local.CurrentMonthExpectations)
+	require.EqualValues(t, 103, local.CurrentMonthExpectations)
+}
@@ -11,7 +11,6 @@ import (
 	"github.com/zeebo/errs"
 
 	"storj.io/common/storj"
-	"storj.io/storj/private/date"
 	"storj.io/storj/storagenode/bandwidth"
 	"storj.io/storj/storagenode/payouts"
 	"storj.io/storj/storagenode/pricing"
@@ -115,8 +114,10 @@ func (s *Service) estimatedPayout(ctx context.Context, satelliteID storj.NodeID,
 	if err != nil {
 		return PayoutMonthly{}, PayoutMonthly{}, EstimationServiceErr.Wrap(err)
 	}
-	currentMonthPayout, err = s.estimationUsagePeriod(ctx, now.UTC(), stats.JoinedAt, priceModel)
-	previousMonthPayout, err = s.estimationUsagePeriod(ctx, now.UTC().AddDate(0, -1, 0), stats.JoinedAt, priceModel)
+
+	currentMonth, _ := BillingMonth(now)
+	currentMonthPayout, err = s.estimationUsagePeriod(ctx, currentMonth, stats.JoinedAt, priceModel)
+	previousMonthPayout, err = s.estimationUsagePeriod(ctx, currentMonth.AddDate(0, -1, 0), stats.JoinedAt, priceModel)
 
 	return currentMonthPayout, previousMonthPayout, nil
 }
@@ -128,7 +129,7 @@ func (s *Service) estimationUsagePeriod(ctx context.Context, period time.Time, j
 	heldRate := payouts.GetHeldRate(joinedAt, period)
 	payout.HeldRate = heldRate
 
-	from, to = date.MonthBoundary(period)
+	from, to = BillingMonth(period)
 
 	bandwidthDaily, err := s.bandwidthDB.GetDailySatelliteRollups(ctx, priceModel.SatelliteID, from, to)
 	if err != nil {
@@ -38,12 +38,18 @@ type DB interface {
 	GetTotalEarned(ctx context.Context) (_ int64, err error)
 	// GetEarnedAtSatellite returns total earned value for node from specific satellite.
 	GetEarnedAtSatellite(ctx context.Context, id storj.NodeID) (int64, error)
+	// GetEarnedComponentsAtSatellite returns earned value of every payout component for node from specific satellite.
+	GetEarnedComponentsAtSatellite(ctx context.Context, id storj.NodeID) (EarnedComponents, error)
+	// GetUndistributedAtSatellite returns amount paid by specific satellite, which was not yet distributed.
+	GetUndistributedAtSatellite(ctx context.Context, id storj.NodeID) (int64, error)
 	// GetPayingSatellitesIDs returns list of satellite ID's that ever paid to storagenode.
 	GetPayingSatellitesIDs(ctx context.Context) ([]storj.NodeID, error)
 	// GetSatelliteSummary returns satellite all time paid and held amounts.
 	GetSatelliteSummary(ctx context.Context, satelliteID storj.NodeID) (paid, held int64, err error)
 	// GetSatellitePeriodSummary returns satellite paid and held amounts for specific period.
 	GetSatellitePeriodSummary(ctx context.Context, satelliteID storj.NodeID, period string) (paid, held int64, err error)
+	// GetSatellitePayments returns sum of amounts and number of payments received from specific satellite.
+	GetSatellitePayments(ctx context.Context, satelliteID storj.NodeID) (received int64, count int, err error)
 }
 
 // ErrNoPayStubForPeriod represents errors from the payouts database.
@@ -81,6 +87,27 @@ type HoldForPeriod struct {
 	Amount int64  `json:"amount"`
 }
 
+// EarnedComponents contains earned amount of every payout component.
+type EarnedComponents struct {
+	AtRest    int64 `json:"atRest"`
+	Get       int64 `json:"get"`
+	GetRepair int64 `json:"getRepair"`
+	GetAudit  int64 `json:"getAudit"`
+}
+
+// Add adds components of other to components.
+func (components *EarnedComponents) Add(other EarnedComponents) {
+	components.AtRest += other.AtRest
+	components.Get += other.Get
+	components.GetRepair += other.GetRepair
+	components.GetAudit += other.GetAudit
+}
+
+// Total returns sum of all components.
+func (components EarnedComponents) Total() int64 {
+	return components.AtRest + components.Get + components.GetRepair + components.GetAudit
+}
+
 // Payment is node payment data for specific period.
 type Payment struct {
 	ID          int64        `json:"id"`
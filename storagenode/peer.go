@@ -798,6 +798,7 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			apiKeys,
 			peer.Version.Service.Info,
 			peer.Contact.PingStats,
+			peer.Contact.Service,
 			peer.DB.Reputation(),
 			peer.Storage2.Trust)
 
@@ -805,7 +806,9 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			peer.Log.Named("multinode:payout-endpoint"),
 			apiKeys,
 			peer.Estimation.Service,
-			peer.DB.Payout())
+			peer.DB.Payout(),
+			peer.DB.Reputation(),
+			config.Operator)
 
 		if err = multinodepb.DRPCRegisterStorage(peer.Server.DRPC(), peer.Multinode.Storage); err != nil {
 			return nil, errs.Combine(err, peer.Close())
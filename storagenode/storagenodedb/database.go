@@ -7,13 +7,14 @@ package storagenodedb
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
-	_ "github.com/mattn/go-sqlite3" // used indirectly.
+	"github.com/mattn/go-sqlite3"
 	"github.com/spacemonkeygo/monkit/v3"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
@@ -51,6 +52,17 @@ var (
 	ErrPreflight = errs.Class("preflight")
 )
 
+// IsTransient returns true if err is a temporary database error, e.g. when database is busy or locked,
+// so the operation may succeed on retry.
+func IsTransient(err error) bool {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code == sqlite3.ErrBusy || sqliteErr.Code == sqlite3.ErrLocked
+	}
+
+	return false
+}
+
 // DBContainer defines an interface to allow accessing and setting a SQLDB.
 type DBContainer interface {
 	Configure(sqlDB tagsql.DB)
@@ -463,6 +463,41 @@ func (db *payoutDB) GetEarnedAtSatellite(ctx context.Context, id storj.NodeID) (
 	return totalEarned, nil
 }
 
+// GetEarnedComponentsAtSatellite returns earned value of every payout component for node from specific satellite.
+func (db *payoutDB) GetEarnedComponentsAtSatellite(ctx context.Context, id storj.NodeID) (_ payouts.EarnedComponents, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	query := `SELECT
+			COALESCE(SUM(comp_at_rest), 0),
+			COALESCE(SUM(comp_get), 0),
+			COALESCE(SUM(comp_get_repair), 0),
+			COALESCE(SUM(comp_get_audit), 0)
+		FROM paystubs WHERE satellite_id = ?`
+
+	var components payouts.EarnedComponents
+	err = db.QueryRowContext(ctx, query, id).Scan(&components.AtRest, &components.Get, &components.GetRepair, &components.GetAudit)
+	if err != nil {
+		return payouts.EarnedComponents{}, ErrPayout.Wrap(err)
+	}
+
+	return components, nil
+}
+
+// GetUndistributedAtSatellite returns amount paid by specific satellite, which was not yet distributed.
+func (db *payoutDB) GetUndistributedAtSatellite(ctx context.Context, id storj.NodeID) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	query := `SELECT COALESCE(SUM(paid - distributed), 0) FROM paystubs WHERE satellite_id = ?`
+
+	var undistributed int64
+	err = db.QueryRowContext(ctx, query, id).Scan(&undistributed)
+	if err != nil {
+		return 0, ErrPayout.Wrap(err)
+	}
+
+	return undistributed, nil
+}
+
 // GetPayingSatellitesIDs returns list of satellite ID's that ever paid to storagenode.
 func (db *payoutDB) GetPayingSatellitesIDs(ctx context.Context) (_ []storj.NodeID, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -564,3 +599,17 @@ func (db *payoutDB) GetSatellitePeriodSummary(ctx context.Context, satelliteID s
 
 	return paid, held, nil
 }
+
+// GetSatellitePayments returns sum of amounts and number of payments received from specific satellite.
+func (db *payoutDB) GetSatellitePayments(ctx context.Context, satelliteID storj.NodeID) (received int64, count int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	query := `SELECT COALESCE(SUM(amount), 0), COUNT(*) FROM payments WHERE satellite_id = ?`
+
+	err = db.QueryRowContext(ctx, query, satelliteID).Scan(&received, &count)
+	if err != nil {
+		return 0, 0, ErrPayout.Wrap(err)
+	}
+
+	return received, count, nil
+}